@@ -4,6 +4,7 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: [0-9a-fA-F]* ':' [0-9a-fA-F:.]* '/' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -16,6 +17,12 @@ TOS: 'TOS' | 'tos';
 PROTOCOL: 'PROTOCOL' | 'protocol';
 SRCPORT: 'SRCPORT' | 'srcport';
 DSTPORT: 'DSTPORT' | 'dstport';
+TC: 'TC' | 'tc';
+FLOWLABEL: 'FLOWLABEL' | 'flowlabel';
+NEXTHDR: 'NEXTHDR' | 'nexthdr';
+TCPFLAGS: 'TCPFLAGS' | 'tcpflags';
+ICMPTYPE: 'ICMPTYPE' | 'icmptype';
+ICMP6TYPE: 'ICMP6TYPE' | 'icmp6type';
 
 STRING: [a-zA-Z]+;
 
@@ -25,11 +32,21 @@ matchDSCP: DSCP '=0x' (HEX_DIGITS | DIGITS);
 matchTOS: TOS '=0x' (HEX_DIGITS | DIGITS);
 matchProtocol: PROTOCOL '=' STRING;
 
+matchSrc6: SRC '=' NET6;
+matchDst6: DST '=' NET6;
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOWLABEL '=' DIGITS;
+matchNextHdr: NEXTHDR '=' (STRING | DIGITS);
+
 matchSrcPort: SRCPORT '=' DIGITS;
 matchSrcPortRange: SRCPORT '=' DIGITS '-' DIGITS;
 matchDstPort: DSTPORT '=' DIGITS;
 matchDstPortRange: DSTPORT '=' DIGITS '-' DIGITS;
 
+matchTCPFlags: TCPFLAGS '=0x' (HEX_DIGITS | DIGITS);
+matchICMPType: ICMPTYPE '=' DIGITS;
+matchICMP6Type: ICMP6TYPE '=' DIGITS;
+
 condCls: 'cls=' DIGITS;
 condAny: ANY '(' cond (',' cond)* ')';
 condAll: ALL '(' cond (',' cond)* ')';
@@ -37,7 +54,18 @@ condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
 condIPv4: matchSrc | matchDst | matchDSCP | matchTOS | matchProtocol;
+condIPv6: matchSrc6 | matchDst6 | matchTC | matchFlowLabel | matchNextHdr;
 condPort: matchSrcPort | matchSrcPortRange | matchDstPort | matchDstPortRange;
-cond: condAll | condAny | condNot | condIPv4 | condPort | condCls | condBool;
+condL4: matchTCPFlags | matchICMPType | matchICMP6Type;
+cond:
+    condAll
+    | condAny
+    | condNot
+    | condIPv4
+    | condIPv6
+    | condPort
+    | condL4
+    | condCls
+    | condBool;
 
 trafficClass: cond EOF;
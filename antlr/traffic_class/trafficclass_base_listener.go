@@ -50,6 +50,36 @@ func (s *BaseTrafficClassListener) EnterMatchProtocol(ctx *MatchProtocolContext)
 // ExitMatchProtocol is called when production matchProtocol is exited.
 func (s *BaseTrafficClassListener) ExitMatchProtocol(ctx *MatchProtocolContext) {}
 
+// EnterMatchSrc6 is called when production matchSrc6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchSrc6(ctx *MatchSrc6Context) {}
+
+// ExitMatchSrc6 is called when production matchSrc6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchSrc6(ctx *MatchSrc6Context) {}
+
+// EnterMatchDst6 is called when production matchDst6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchDst6(ctx *MatchDst6Context) {}
+
+// ExitMatchDst6 is called when production matchDst6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchDst6(ctx *MatchDst6Context) {}
+
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchNextHdr is called when production matchNextHdr is entered.
+func (s *BaseTrafficClassListener) EnterMatchNextHdr(ctx *MatchNextHdrContext) {}
+
+// ExitMatchNextHdr is called when production matchNextHdr is exited.
+func (s *BaseTrafficClassListener) ExitMatchNextHdr(ctx *MatchNextHdrContext) {}
+
 // EnterMatchSrcPort is called when production matchSrcPort is entered.
 func (s *BaseTrafficClassListener) EnterMatchSrcPort(ctx *MatchSrcPortContext) {}
 
@@ -74,6 +104,24 @@ func (s *BaseTrafficClassListener) EnterMatchDstPortRange(ctx *MatchDstPortRange
 // ExitMatchDstPortRange is called when production matchDstPortRange is exited.
 func (s *BaseTrafficClassListener) ExitMatchDstPortRange(ctx *MatchDstPortRangeContext) {}
 
+// EnterMatchTCPFlags is called when production matchTCPFlags is entered.
+func (s *BaseTrafficClassListener) EnterMatchTCPFlags(ctx *MatchTCPFlagsContext) {}
+
+// ExitMatchTCPFlags is called when production matchTCPFlags is exited.
+func (s *BaseTrafficClassListener) ExitMatchTCPFlags(ctx *MatchTCPFlagsContext) {}
+
+// EnterMatchICMPType is called when production matchICMPType is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// ExitMatchICMPType is called when production matchICMPType is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// EnterMatchICMP6Type is called when production matchICMP6Type is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMP6Type(ctx *MatchICMP6TypeContext) {}
+
+// ExitMatchICMP6Type is called when production matchICMP6Type is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMP6Type(ctx *MatchICMP6TypeContext) {}
+
 // EnterCondCls is called when production condCls is entered.
 func (s *BaseTrafficClassListener) EnterCondCls(ctx *CondClsContext) {}
 
@@ -110,12 +158,24 @@ func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
 // ExitCondIPv4 is called when production condIPv4 is exited.
 func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
 
+// EnterCondIPv6 is called when production condIPv6 is entered.
+func (s *BaseTrafficClassListener) EnterCondIPv6(ctx *CondIPv6Context) {}
+
+// ExitCondIPv6 is called when production condIPv6 is exited.
+func (s *BaseTrafficClassListener) ExitCondIPv6(ctx *CondIPv6Context) {}
+
 // EnterCondPort is called when production condPort is entered.
 func (s *BaseTrafficClassListener) EnterCondPort(ctx *CondPortContext) {}
 
 // ExitCondPort is called when production condPort is exited.
 func (s *BaseTrafficClassListener) ExitCondPort(ctx *CondPortContext) {}
 
+// EnterCondL4 is called when production condL4 is entered.
+func (s *BaseTrafficClassListener) EnterCondL4(ctx *CondL4Context) {}
+
+// ExitCondL4 is called when production condL4 is exited.
+func (s *BaseTrafficClassListener) ExitCondL4(ctx *CondL4Context) {}
+
 // EnterCond is called when production cond is entered.
 func (s *BaseTrafficClassListener) EnterCond(ctx *CondContext) {}
 
@@ -22,6 +22,21 @@ type TrafficClassListener interface {
 	// EnterMatchProtocol is called when entering the matchProtocol production.
 	EnterMatchProtocol(c *MatchProtocolContext)
 
+	// EnterMatchSrc6 is called when entering the matchSrc6 production.
+	EnterMatchSrc6(c *MatchSrc6Context)
+
+	// EnterMatchDst6 is called when entering the matchDst6 production.
+	EnterMatchDst6(c *MatchDst6Context)
+
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchNextHdr is called when entering the matchNextHdr production.
+	EnterMatchNextHdr(c *MatchNextHdrContext)
+
 	// EnterMatchSrcPort is called when entering the matchSrcPort production.
 	EnterMatchSrcPort(c *MatchSrcPortContext)
 
@@ -34,6 +49,15 @@ type TrafficClassListener interface {
 	// EnterMatchDstPortRange is called when entering the matchDstPortRange production.
 	EnterMatchDstPortRange(c *MatchDstPortRangeContext)
 
+	// EnterMatchTCPFlags is called when entering the matchTCPFlags production.
+	EnterMatchTCPFlags(c *MatchTCPFlagsContext)
+
+	// EnterMatchICMPType is called when entering the matchICMPType production.
+	EnterMatchICMPType(c *MatchICMPTypeContext)
+
+	// EnterMatchICMP6Type is called when entering the matchICMP6Type production.
+	EnterMatchICMP6Type(c *MatchICMP6TypeContext)
+
 	// EnterCondCls is called when entering the condCls production.
 	EnterCondCls(c *CondClsContext)
 
@@ -52,9 +76,15 @@ type TrafficClassListener interface {
 	// EnterCondIPv4 is called when entering the condIPv4 production.
 	EnterCondIPv4(c *CondIPv4Context)
 
+	// EnterCondIPv6 is called when entering the condIPv6 production.
+	EnterCondIPv6(c *CondIPv6Context)
+
 	// EnterCondPort is called when entering the condPort production.
 	EnterCondPort(c *CondPortContext)
 
+	// EnterCondL4 is called when entering the condL4 production.
+	EnterCondL4(c *CondL4Context)
+
 	// EnterCond is called when entering the cond production.
 	EnterCond(c *CondContext)
 
@@ -76,6 +106,21 @@ type TrafficClassListener interface {
 	// ExitMatchProtocol is called when exiting the matchProtocol production.
 	ExitMatchProtocol(c *MatchProtocolContext)
 
+	// ExitMatchSrc6 is called when exiting the matchSrc6 production.
+	ExitMatchSrc6(c *MatchSrc6Context)
+
+	// ExitMatchDst6 is called when exiting the matchDst6 production.
+	ExitMatchDst6(c *MatchDst6Context)
+
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchNextHdr is called when exiting the matchNextHdr production.
+	ExitMatchNextHdr(c *MatchNextHdrContext)
+
 	// ExitMatchSrcPort is called when exiting the matchSrcPort production.
 	ExitMatchSrcPort(c *MatchSrcPortContext)
 
@@ -88,6 +133,15 @@ type TrafficClassListener interface {
 	// ExitMatchDstPortRange is called when exiting the matchDstPortRange production.
 	ExitMatchDstPortRange(c *MatchDstPortRangeContext)
 
+	// ExitMatchTCPFlags is called when exiting the matchTCPFlags production.
+	ExitMatchTCPFlags(c *MatchTCPFlagsContext)
+
+	// ExitMatchICMPType is called when exiting the matchICMPType production.
+	ExitMatchICMPType(c *MatchICMPTypeContext)
+
+	// ExitMatchICMP6Type is called when exiting the matchICMP6Type production.
+	ExitMatchICMP6Type(c *MatchICMP6TypeContext)
+
 	// ExitCondCls is called when exiting the condCls production.
 	ExitCondCls(c *CondClsContext)
 
@@ -106,9 +160,15 @@ type TrafficClassListener interface {
 	// ExitCondIPv4 is called when exiting the condIPv4 production.
 	ExitCondIPv4(c *CondIPv4Context)
 
+	// ExitCondIPv6 is called when exiting the condIPv6 production.
+	ExitCondIPv6(c *CondIPv6Context)
+
 	// ExitCondPort is called when exiting the condPort production.
 	ExitCondPort(c *CondPortContext)
 
+	// ExitCondL4 is called when exiting the condL4 production.
+	ExitCondL4(c *CondL4Context)
+
 	// ExitCond is called when exiting the cond production.
 	ExitCond(c *CondContext)
 
@@ -15,62 +15,90 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 27, 140,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 34, 206,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
-	18, 4, 19, 9, 19, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4,
-	3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3,
-	12, 3, 12, 3, 12, 3, 12, 3, 12, 7, 12, 87, 10, 12, 12, 12, 14, 12, 90,
-	11, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 7, 13, 99, 10,
-	13, 12, 13, 14, 13, 102, 11, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3,
-	14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16,
-	5, 16, 120, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 126, 10, 17, 3,
-	18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 135, 10, 18, 3, 19,
-	3, 19, 3, 19, 3, 19, 2, 2, 20, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
-	24, 26, 28, 30, 32, 34, 36, 2, 4, 3, 2, 13, 14, 3, 2, 10, 11, 2, 136, 2,
-	38, 3, 2, 2, 2, 4, 42, 3, 2, 2, 2, 6, 46, 3, 2, 2, 2, 8, 50, 3, 2, 2, 2,
-	10, 54, 3, 2, 2, 2, 12, 58, 3, 2, 2, 2, 14, 62, 3, 2, 2, 2, 16, 68, 3,
-	2, 2, 2, 18, 72, 3, 2, 2, 2, 20, 78, 3, 2, 2, 2, 22, 81, 3, 2, 2, 2, 24,
-	93, 3, 2, 2, 2, 26, 105, 3, 2, 2, 2, 28, 110, 3, 2, 2, 2, 30, 119, 3, 2,
-	2, 2, 32, 125, 3, 2, 2, 2, 34, 134, 3, 2, 2, 2, 36, 136, 3, 2, 2, 2, 38,
-	39, 7, 20, 2, 2, 39, 40, 7, 3, 2, 2, 40, 41, 7, 15, 2, 2, 41, 3, 3, 2,
-	2, 2, 42, 43, 7, 21, 2, 2, 43, 44, 7, 3, 2, 2, 44, 45, 7, 15, 2, 2, 45,
-	5, 3, 2, 2, 2, 46, 47, 7, 22, 2, 2, 47, 48, 7, 4, 2, 2, 48, 49, 9, 2, 2,
-	2, 49, 7, 3, 2, 2, 2, 50, 51, 7, 23, 2, 2, 51, 52, 7, 4, 2, 2, 52, 53,
-	9, 2, 2, 2, 53, 9, 3, 2, 2, 2, 54, 55, 7, 24, 2, 2, 55, 56, 7, 3, 2, 2,
-	56, 57, 7, 27, 2, 2, 57, 11, 3, 2, 2, 2, 58, 59, 7, 25, 2, 2, 59, 60, 7,
-	3, 2, 2, 60, 61, 7, 13, 2, 2, 61, 13, 3, 2, 2, 2, 62, 63, 7, 25, 2, 2,
-	63, 64, 7, 3, 2, 2, 64, 65, 7, 13, 2, 2, 65, 66, 7, 5, 2, 2, 66, 67, 7,
-	13, 2, 2, 67, 15, 3, 2, 2, 2, 68, 69, 7, 26, 2, 2, 69, 70, 7, 3, 2, 2,
-	70, 71, 7, 13, 2, 2, 71, 17, 3, 2, 2, 2, 72, 73, 7, 26, 2, 2, 73, 74, 7,
-	3, 2, 2, 74, 75, 7, 13, 2, 2, 75, 76, 7, 5, 2, 2, 76, 77, 7, 13, 2, 2,
-	77, 19, 3, 2, 2, 2, 78, 79, 7, 6, 2, 2, 79, 80, 7, 13, 2, 2, 80, 21, 3,
-	2, 2, 2, 81, 82, 7, 16, 2, 2, 82, 83, 7, 7, 2, 2, 83, 88, 5, 34, 18, 2,
-	84, 85, 7, 8, 2, 2, 85, 87, 5, 34, 18, 2, 86, 84, 3, 2, 2, 2, 87, 90, 3,
-	2, 2, 2, 88, 86, 3, 2, 2, 2, 88, 89, 3, 2, 2, 2, 89, 91, 3, 2, 2, 2, 90,
-	88, 3, 2, 2, 2, 91, 92, 7, 9, 2, 2, 92, 23, 3, 2, 2, 2, 93, 94, 7, 17,
-	2, 2, 94, 95, 7, 7, 2, 2, 95, 100, 5, 34, 18, 2, 96, 97, 7, 8, 2, 2, 97,
-	99, 5, 34, 18, 2, 98, 96, 3, 2, 2, 2, 99, 102, 3, 2, 2, 2, 100, 98, 3,
-	2, 2, 2, 100, 101, 3, 2, 2, 2, 101, 103, 3, 2, 2, 2, 102, 100, 3, 2, 2,
-	2, 103, 104, 7, 9, 2, 2, 104, 25, 3, 2, 2, 2, 105, 106, 7, 18, 2, 2, 106,
-	107, 7, 7, 2, 2, 107, 108, 5, 34, 18, 2, 108, 109, 7, 9, 2, 2, 109, 27,
-	3, 2, 2, 2, 110, 111, 7, 19, 2, 2, 111, 112, 7, 3, 2, 2, 112, 113, 9, 3,
-	2, 2, 113, 29, 3, 2, 2, 2, 114, 120, 5, 2, 2, 2, 115, 120, 5, 4, 3, 2,
-	116, 120, 5, 6, 4, 2, 117, 120, 5, 8, 5, 2, 118, 120, 5, 10, 6, 2, 119,
-	114, 3, 2, 2, 2, 119, 115, 3, 2, 2, 2, 119, 116, 3, 2, 2, 2, 119, 117,
-	3, 2, 2, 2, 119, 118, 3, 2, 2, 2, 120, 31, 3, 2, 2, 2, 121, 126, 5, 12,
-	7, 2, 122, 126, 5, 14, 8, 2, 123, 126, 5, 16, 9, 2, 124, 126, 5, 18, 10,
-	2, 125, 121, 3, 2, 2, 2, 125, 122, 3, 2, 2, 2, 125, 123, 3, 2, 2, 2, 125,
-	124, 3, 2, 2, 2, 126, 33, 3, 2, 2, 2, 127, 135, 5, 24, 13, 2, 128, 135,
-	5, 22, 12, 2, 129, 135, 5, 26, 14, 2, 130, 135, 5, 30, 16, 2, 131, 135,
-	5, 32, 17, 2, 132, 135, 5, 20, 11, 2, 133, 135, 5, 28, 15, 2, 134, 127,
-	3, 2, 2, 2, 134, 128, 3, 2, 2, 2, 134, 129, 3, 2, 2, 2, 134, 130, 3, 2,
-	2, 2, 134, 131, 3, 2, 2, 2, 134, 132, 3, 2, 2, 2, 134, 133, 3, 2, 2, 2,
-	135, 35, 3, 2, 2, 2, 136, 137, 5, 34, 18, 2, 137, 138, 7, 2, 2, 3, 138,
-	37, 3, 2, 2, 2, 7, 88, 100, 119, 125, 134,
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 28, 4,
+	29, 9, 29, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4,
+	3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7,
+	3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10,
+	3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3,
+	13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3, 14, 3, 15,
+	3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 17, 3,
+	17, 3, 17, 3, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 20,
+	3, 20, 3, 20, 3, 20, 3, 20, 7, 20, 139, 10, 20, 12, 20, 14, 20, 142, 11,
+	20, 3, 20, 3, 20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 7, 21, 151, 10, 21,
+	12, 21, 14, 21, 154, 11, 21, 3, 21, 3, 21, 3, 22, 3, 22, 3, 22, 3, 22,
+	3, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 5,
+	24, 172, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5, 25, 179, 10, 25,
+	3, 26, 3, 26, 3, 26, 3, 26, 5, 26, 185, 10, 26, 3, 27, 3, 27, 3, 27, 5,
+	27, 190, 10, 27, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28,
+	3, 28, 5, 28, 201, 10, 28, 3, 29, 3, 29, 3, 29, 3, 29, 2, 2, 30, 2, 4,
+	6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42,
+	44, 46, 48, 50, 52, 54, 56, 2, 5, 3, 2, 13, 14, 4, 2, 13, 13, 34, 34, 3,
+	2, 10, 11, 2, 200, 2, 58, 3, 2, 2, 2, 4, 62, 3, 2, 2, 2, 6, 66, 3, 2, 2,
+	2, 8, 70, 3, 2, 2, 2, 10, 74, 3, 2, 2, 2, 12, 78, 3, 2, 2, 2, 14, 82, 3,
+	2, 2, 2, 16, 86, 3, 2, 2, 2, 18, 90, 3, 2, 2, 2, 20, 94, 3, 2, 2, 2, 22,
+	98, 3, 2, 2, 2, 24, 102, 3, 2, 2, 2, 26, 108, 3, 2, 2, 2, 28, 112, 3, 2,
+	2, 2, 30, 118, 3, 2, 2, 2, 32, 122, 3, 2, 2, 2, 34, 126, 3, 2, 2, 2, 36,
+	130, 3, 2, 2, 2, 38, 133, 3, 2, 2, 2, 40, 145, 3, 2, 2, 2, 42, 157, 3,
+	2, 2, 2, 44, 162, 3, 2, 2, 2, 46, 171, 3, 2, 2, 2, 48, 178, 3, 2, 2, 2,
+	50, 184, 3, 2, 2, 2, 52, 189, 3, 2, 2, 2, 54, 200, 3, 2, 2, 2, 56, 202,
+	3, 2, 2, 2, 58, 59, 7, 21, 2, 2, 59, 60, 7, 3, 2, 2, 60, 61, 7, 15, 2,
+	2, 61, 3, 3, 2, 2, 2, 62, 63, 7, 22, 2, 2, 63, 64, 7, 3, 2, 2, 64, 65,
+	7, 15, 2, 2, 65, 5, 3, 2, 2, 2, 66, 67, 7, 23, 2, 2, 67, 68, 7, 4, 2, 2,
+	68, 69, 9, 2, 2, 2, 69, 7, 3, 2, 2, 2, 70, 71, 7, 24, 2, 2, 71, 72, 7,
+	4, 2, 2, 72, 73, 9, 2, 2, 2, 73, 9, 3, 2, 2, 2, 74, 75, 7, 25, 2, 2, 75,
+	76, 7, 3, 2, 2, 76, 77, 7, 34, 2, 2, 77, 11, 3, 2, 2, 2, 78, 79, 7, 21,
+	2, 2, 79, 80, 7, 3, 2, 2, 80, 81, 7, 16, 2, 2, 81, 13, 3, 2, 2, 2, 82,
+	83, 7, 22, 2, 2, 83, 84, 7, 3, 2, 2, 84, 85, 7, 16, 2, 2, 85, 15, 3, 2,
+	2, 2, 86, 87, 7, 28, 2, 2, 87, 88, 7, 4, 2, 2, 88, 89, 9, 2, 2, 2, 89,
+	17, 3, 2, 2, 2, 90, 91, 7, 29, 2, 2, 91, 92, 7, 3, 2, 2, 92, 93, 7, 13,
+	2, 2, 93, 19, 3, 2, 2, 2, 94, 95, 7, 30, 2, 2, 95, 96, 7, 3, 2, 2, 96,
+	97, 9, 3, 2, 2, 97, 21, 3, 2, 2, 2, 98, 99, 7, 26, 2, 2, 99, 100, 7, 3,
+	2, 2, 100, 101, 7, 13, 2, 2, 101, 23, 3, 2, 2, 2, 102, 103, 7, 26, 2, 2,
+	103, 104, 7, 3, 2, 2, 104, 105, 7, 13, 2, 2, 105, 106, 7, 5, 2, 2, 106,
+	107, 7, 13, 2, 2, 107, 25, 3, 2, 2, 2, 108, 109, 7, 27, 2, 2, 109, 110,
+	7, 3, 2, 2, 110, 111, 7, 13, 2, 2, 111, 27, 3, 2, 2, 2, 112, 113, 7, 27,
+	2, 2, 113, 114, 7, 3, 2, 2, 114, 115, 7, 13, 2, 2, 115, 116, 7, 5, 2, 2,
+	116, 117, 7, 13, 2, 2, 117, 29, 3, 2, 2, 2, 118, 119, 7, 31, 2, 2, 119,
+	120, 7, 4, 2, 2, 120, 121, 9, 2, 2, 2, 121, 31, 3, 2, 2, 2, 122, 123, 7,
+	32, 2, 2, 123, 124, 7, 3, 2, 2, 124, 125, 7, 13, 2, 2, 125, 33, 3, 2, 2,
+	2, 126, 127, 7, 33, 2, 2, 127, 128, 7, 3, 2, 2, 128, 129, 7, 13, 2, 2,
+	129, 35, 3, 2, 2, 2, 130, 131, 7, 6, 2, 2, 131, 132, 7, 13, 2, 2, 132,
+	37, 3, 2, 2, 2, 133, 134, 7, 17, 2, 2, 134, 135, 7, 7, 2, 2, 135, 140,
+	5, 54, 28, 2, 136, 137, 7, 8, 2, 2, 137, 139, 5, 54, 28, 2, 138, 136, 3,
+	2, 2, 2, 139, 142, 3, 2, 2, 2, 140, 138, 3, 2, 2, 2, 140, 141, 3, 2, 2,
+	2, 141, 143, 3, 2, 2, 2, 142, 140, 3, 2, 2, 2, 143, 144, 7, 9, 2, 2, 144,
+	39, 3, 2, 2, 2, 145, 146, 7, 18, 2, 2, 146, 147, 7, 7, 2, 2, 147, 152,
+	5, 54, 28, 2, 148, 149, 7, 8, 2, 2, 149, 151, 5, 54, 28, 2, 150, 148, 3,
+	2, 2, 2, 151, 154, 3, 2, 2, 2, 152, 150, 3, 2, 2, 2, 152, 153, 3, 2, 2,
+	2, 153, 155, 3, 2, 2, 2, 154, 152, 3, 2, 2, 2, 155, 156, 7, 9, 2, 2, 156,
+	41, 3, 2, 2, 2, 157, 158, 7, 19, 2, 2, 158, 159, 7, 7, 2, 2, 159, 160,
+	5, 54, 28, 2, 160, 161, 7, 9, 2, 2, 161, 43, 3, 2, 2, 2, 162, 163, 7, 20,
+	2, 2, 163, 164, 7, 3, 2, 2, 164, 165, 9, 4, 2, 2, 165, 45, 3, 2, 2, 2,
+	166, 172, 5, 2, 2, 2, 167, 172, 5, 4, 3, 2, 168, 172, 5, 6, 4, 2, 169,
+	172, 5, 8, 5, 2, 170, 172, 5, 10, 6, 2, 171, 166, 3, 2, 2, 2, 171, 167,
+	3, 2, 2, 2, 171, 168, 3, 2, 2, 2, 171, 169, 3, 2, 2, 2, 171, 170, 3, 2,
+	2, 2, 172, 47, 3, 2, 2, 2, 173, 179, 5, 12, 7, 2, 174, 179, 5, 14, 8, 2,
+	175, 179, 5, 16, 9, 2, 176, 179, 5, 18, 10, 2, 177, 179, 5, 20, 11, 2,
+	178, 173, 3, 2, 2, 2, 178, 174, 3, 2, 2, 2, 178, 175, 3, 2, 2, 2, 178,
+	176, 3, 2, 2, 2, 178, 177, 3, 2, 2, 2, 179, 49, 3, 2, 2, 2, 180, 185, 5,
+	22, 12, 2, 181, 185, 5, 24, 13, 2, 182, 185, 5, 26, 14, 2, 183, 185, 5,
+	28, 15, 2, 184, 180, 3, 2, 2, 2, 184, 181, 3, 2, 2, 2, 184, 182, 3, 2,
+	2, 2, 184, 183, 3, 2, 2, 2, 185, 51, 3, 2, 2, 2, 186, 190, 5, 30, 16, 2,
+	187, 190, 5, 32, 17, 2, 188, 190, 5, 34, 18, 2, 189, 186, 3, 2, 2, 2, 189,
+	187, 3, 2, 2, 2, 189, 188, 3, 2, 2, 2, 190, 53, 3, 2, 2, 2, 191, 201, 5,
+	40, 21, 2, 192, 201, 5, 38, 20, 2, 193, 201, 5, 42, 22, 2, 194, 201, 5,
+	46, 24, 2, 195, 201, 5, 48, 25, 2, 196, 201, 5, 50, 26, 2, 197, 201, 5,
+	52, 27, 2, 198, 201, 5, 36, 19, 2, 199, 201, 5, 44, 23, 2, 200, 191, 3,
+	2, 2, 2, 200, 192, 3, 2, 2, 2, 200, 193, 3, 2, 2, 2, 200, 194, 3, 2, 2,
+	2, 200, 195, 3, 2, 2, 2, 200, 196, 3, 2, 2, 2, 200, 197, 3, 2, 2, 2, 200,
+	198, 3, 2, 2, 2, 200, 199, 3, 2, 2, 2, 201, 55, 3, 2, 2, 2, 202, 203, 5,
+	54, 28, 2, 203, 204, 7, 2, 2, 3, 204, 57, 3, 2, 2, 2, 9, 140, 152, 171,
+	178, 184, 189, 200,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -80,14 +108,17 @@ var literalNames = []string{
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-	"SRCPORT", "DSTPORT", "STRING",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"PROTOCOL", "SRCPORT", "DSTPORT", "TC", "FLOWLABEL", "NEXTHDR", "TCPFLAGS",
+	"ICMPTYPE", "ICMP6TYPE", "STRING",
 }
 
 var ruleNames = []string{
-	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrcPort",
-	"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "condCls", "condAny",
-	"condAll", "condNot", "condBool", "condIPv4", "condPort", "cond", "trafficClass",
+	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrc6",
+	"matchDst6", "matchTC", "matchFlowLabel", "matchNextHdr", "matchSrcPort",
+	"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "matchTCPFlags",
+	"matchICMPType", "matchICMP6Type", "condCls", "condAny", "condAll", "condNot",
+	"condBool", "condIPv4", "condIPv6", "condPort", "condL4", "cond", "trafficClass",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -131,18 +162,25 @@ const (
 	TrafficClassParserDIGITS     = 11
 	TrafficClassParserHEX_DIGITS = 12
 	TrafficClassParserNET        = 13
-	TrafficClassParserANY        = 14
-	TrafficClassParserALL        = 15
-	TrafficClassParserNOT        = 16
-	TrafficClassParserBOOL       = 17
-	TrafficClassParserSRC        = 18
-	TrafficClassParserDST        = 19
-	TrafficClassParserDSCP       = 20
-	TrafficClassParserTOS        = 21
-	TrafficClassParserPROTOCOL   = 22
-	TrafficClassParserSRCPORT    = 23
-	TrafficClassParserDSTPORT    = 24
-	TrafficClassParserSTRING     = 25
+	TrafficClassParserNET6       = 14
+	TrafficClassParserANY        = 15
+	TrafficClassParserALL        = 16
+	TrafficClassParserNOT        = 17
+	TrafficClassParserBOOL       = 18
+	TrafficClassParserSRC        = 19
+	TrafficClassParserDST        = 20
+	TrafficClassParserDSCP       = 21
+	TrafficClassParserTOS        = 22
+	TrafficClassParserPROTOCOL   = 23
+	TrafficClassParserSRCPORT    = 24
+	TrafficClassParserDSTPORT    = 25
+	TrafficClassParserTC         = 26
+	TrafficClassParserFLOWLABEL  = 27
+	TrafficClassParserNEXTHDR    = 28
+	TrafficClassParserTCPFLAGS   = 29
+	TrafficClassParserICMPTYPE   = 30
+	TrafficClassParserICMP6TYPE  = 31
+	TrafficClassParserSTRING     = 32
 )
 
 // TrafficClassParser rules.
@@ -152,19 +190,29 @@ const (
 	TrafficClassParserRULE_matchDSCP         = 2
 	TrafficClassParserRULE_matchTOS          = 3
 	TrafficClassParserRULE_matchProtocol     = 4
-	TrafficClassParserRULE_matchSrcPort      = 5
-	TrafficClassParserRULE_matchSrcPortRange = 6
-	TrafficClassParserRULE_matchDstPort      = 7
-	TrafficClassParserRULE_matchDstPortRange = 8
-	TrafficClassParserRULE_condCls           = 9
-	TrafficClassParserRULE_condAny           = 10
-	TrafficClassParserRULE_condAll           = 11
-	TrafficClassParserRULE_condNot           = 12
-	TrafficClassParserRULE_condBool          = 13
-	TrafficClassParserRULE_condIPv4          = 14
-	TrafficClassParserRULE_condPort          = 15
-	TrafficClassParserRULE_cond              = 16
-	TrafficClassParserRULE_trafficClass      = 17
+	TrafficClassParserRULE_matchSrc6         = 5
+	TrafficClassParserRULE_matchDst6         = 6
+	TrafficClassParserRULE_matchTC           = 7
+	TrafficClassParserRULE_matchFlowLabel    = 8
+	TrafficClassParserRULE_matchNextHdr      = 9
+	TrafficClassParserRULE_matchSrcPort      = 10
+	TrafficClassParserRULE_matchSrcPortRange = 11
+	TrafficClassParserRULE_matchDstPort      = 12
+	TrafficClassParserRULE_matchDstPortRange = 13
+	TrafficClassParserRULE_matchTCPFlags     = 14
+	TrafficClassParserRULE_matchICMPType     = 15
+	TrafficClassParserRULE_matchICMP6Type    = 16
+	TrafficClassParserRULE_condCls           = 17
+	TrafficClassParserRULE_condAny           = 18
+	TrafficClassParserRULE_condAll           = 19
+	TrafficClassParserRULE_condNot           = 20
+	TrafficClassParserRULE_condBool          = 21
+	TrafficClassParserRULE_condIPv4          = 22
+	TrafficClassParserRULE_condIPv6          = 23
+	TrafficClassParserRULE_condPort          = 24
+	TrafficClassParserRULE_condL4            = 25
+	TrafficClassParserRULE_cond              = 26
+	TrafficClassParserRULE_trafficClass      = 27
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -255,15 +303,15 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
+		p.SetState(56)
 		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(37)
+		p.SetState(57)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(38)
+		p.SetState(58)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -358,15 +406,15 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(60)
 		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(41)
+		p.SetState(61)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(42)
+		p.SetState(62)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -466,15 +514,15 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(44)
+		p.SetState(64)
 		p.Match(TrafficClassParserDSCP)
 	}
 	{
-		p.SetState(45)
+		p.SetState(65)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(46)
+		p.SetState(66)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -581,15 +629,15 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(48)
+		p.SetState(68)
 		p.Match(TrafficClassParserTOS)
 	}
 	{
-		p.SetState(49)
+		p.SetState(69)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(50)
+		p.SetState(70)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -691,90 +739,90 @@ func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(52)
+		p.SetState(72)
 		p.Match(TrafficClassParserPROTOCOL)
 	}
 	{
-		p.SetState(53)
+		p.SetState(73)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(54)
+		p.SetState(74)
 		p.Match(TrafficClassParserSTRING)
 	}
 
 	return localctx
 }
 
-// IMatchSrcPortContext is an interface to support dynamic dispatch.
-type IMatchSrcPortContext interface {
+// IMatchSrc6Context is an interface to support dynamic dispatch.
+type IMatchSrc6Context interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchSrcPortContext differentiates from other interfaces.
-	IsMatchSrcPortContext()
+	// IsMatchSrc6Context differentiates from other interfaces.
+	IsMatchSrc6Context()
 }
 
-type MatchSrcPortContext struct {
+type MatchSrc6Context struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchSrcPortContext() *MatchSrcPortContext {
-	var p = new(MatchSrcPortContext)
+func NewEmptyMatchSrc6Context() *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
 	return p
 }
 
-func (*MatchSrcPortContext) IsMatchSrcPortContext() {}
+func (*MatchSrc6Context) IsMatchSrc6Context() {}
 
-func NewMatchSrcPortContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrcPortContext {
-	var p = new(MatchSrcPortContext)
+func NewMatchSrc6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
 
 	return p
 }
 
-func (s *MatchSrcPortContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchSrc6Context) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchSrcPortContext) SRCPORT() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserSRCPORT, 0)
+func (s *MatchSrc6Context) SRC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSRC, 0)
 }
 
-func (s *MatchSrcPortContext) DIGITS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, 0)
+func (s *MatchSrc6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
 }
 
-func (s *MatchSrcPortContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchSrc6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchSrcPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchSrc6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchSrcPortContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrc6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchSrcPort(s)
+		listenerT.EnterMatchSrc6(s)
 	}
 }
 
-func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchSrc6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchSrcPort(s)
+		listenerT.ExitMatchSrc6(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
-	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchSrcPort)
+func (p *TrafficClassParser) MatchSrc6() (localctx IMatchSrc6Context) {
+	localctx = NewMatchSrc6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchSrc6)
 
 	defer func() {
 		p.ExitRule()
@@ -794,94 +842,90 @@ func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(56)
-		p.Match(TrafficClassParserSRCPORT)
+		p.SetState(76)
+		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(57)
+		p.SetState(77)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(58)
-		p.Match(TrafficClassParserDIGITS)
+		p.SetState(78)
+		p.Match(TrafficClassParserNET6)
 	}
 
 	return localctx
 }
 
-// IMatchSrcPortRangeContext is an interface to support dynamic dispatch.
-type IMatchSrcPortRangeContext interface {
+// IMatchDst6Context is an interface to support dynamic dispatch.
+type IMatchDst6Context interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchSrcPortRangeContext differentiates from other interfaces.
-	IsMatchSrcPortRangeContext()
+	// IsMatchDst6Context differentiates from other interfaces.
+	IsMatchDst6Context()
 }
 
-type MatchSrcPortRangeContext struct {
+type MatchDst6Context struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchSrcPortRangeContext() *MatchSrcPortRangeContext {
-	var p = new(MatchSrcPortRangeContext)
+func NewEmptyMatchDst6Context() *MatchDst6Context {
+	var p = new(MatchDst6Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchSrcPortRange
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
 	return p
 }
 
-func (*MatchSrcPortRangeContext) IsMatchSrcPortRangeContext() {}
+func (*MatchDst6Context) IsMatchDst6Context() {}
 
-func NewMatchSrcPortRangeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrcPortRangeContext {
-	var p = new(MatchSrcPortRangeContext)
+func NewMatchDst6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDst6Context {
+	var p = new(MatchDst6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchSrcPortRange
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
 
 	return p
 }
 
-func (s *MatchSrcPortRangeContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *MatchSrcPortRangeContext) SRCPORT() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserSRCPORT, 0)
-}
+func (s *MatchDst6Context) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchSrcPortRangeContext) AllDIGITS() []antlr.TerminalNode {
-	return s.GetTokens(TrafficClassParserDIGITS)
+func (s *MatchDst6Context) DST() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDST, 0)
 }
 
-func (s *MatchSrcPortRangeContext) DIGITS(i int) antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, i)
+func (s *MatchDst6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
 }
 
-func (s *MatchSrcPortRangeContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchDst6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchSrcPortRangeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchDst6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchSrcPortRangeContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchDst6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchSrcPortRange(s)
+		listenerT.EnterMatchDst6(s)
 	}
 }
 
-func (s *MatchSrcPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchDst6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchSrcPortRange(s)
+		listenerT.ExitMatchDst6(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeContext) {
-	localctx = NewMatchSrcPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchSrcPortRange)
+func (p *TrafficClassParser) MatchDst6() (localctx IMatchDst6Context) {
+	localctx = NewMatchDst6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchDst6)
 
 	defer func() {
 		p.ExitRule()
@@ -901,98 +945,95 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(60)
-		p.Match(TrafficClassParserSRCPORT)
+		p.SetState(80)
+		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(61)
+		p.SetState(81)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(62)
-		p.Match(TrafficClassParserDIGITS)
-	}
-	{
-		p.SetState(63)
-		p.Match(TrafficClassParserT__2)
-	}
-	{
-		p.SetState(64)
-		p.Match(TrafficClassParserDIGITS)
+		p.SetState(82)
+		p.Match(TrafficClassParserNET6)
 	}
 
 	return localctx
 }
 
-// IMatchDstPortContext is an interface to support dynamic dispatch.
-type IMatchDstPortContext interface {
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchDstPortContext differentiates from other interfaces.
-	IsMatchDstPortContext()
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
 }
 
-type MatchDstPortContext struct {
+type MatchTCContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchDstPortContext() *MatchDstPortContext {
-	var p = new(MatchDstPortContext)
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+	p.RuleIndex = TrafficClassParserRULE_matchTC
 	return p
 }
 
-func (*MatchDstPortContext) IsMatchDstPortContext() {}
+func (*MatchTCContext) IsMatchTCContext() {}
 
-func NewMatchDstPortContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDstPortContext {
-	var p = new(MatchDstPortContext)
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCContext {
+	var p = new(MatchTCContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+	p.RuleIndex = TrafficClassParserRULE_matchTC
 
 	return p
 }
 
-func (s *MatchDstPortContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchDstPortContext) DSTPORT() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDSTPORT, 0)
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
 }
 
-func (s *MatchDstPortContext) DIGITS() antlr.TerminalNode {
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *MatchDstPortContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchDstPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchDstPortContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchDstPort(s)
+		listenerT.EnterMatchTC(s)
 	}
 }
 
-func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchDstPort(s)
+		listenerT.ExitMatchTC(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
-	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchDstPort)
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchTC)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -1012,94 +1053,97 @@ func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(66)
-		p.Match(TrafficClassParserDSTPORT)
+		p.SetState(84)
+		p.Match(TrafficClassParserTC)
 	}
 	{
-		p.SetState(67)
-		p.Match(TrafficClassParserT__0)
+		p.SetState(85)
+		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(68)
-		p.Match(TrafficClassParserDIGITS)
+		p.SetState(86)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
 }
 
-// IMatchDstPortRangeContext is an interface to support dynamic dispatch.
-type IMatchDstPortRangeContext interface {
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchDstPortRangeContext differentiates from other interfaces.
-	IsMatchDstPortRangeContext()
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
 }
 
-type MatchDstPortRangeContext struct {
+type MatchFlowLabelContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchDstPortRangeContext() *MatchDstPortRangeContext {
-	var p = new(MatchDstPortRangeContext)
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchDstPortRange
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
 	return p
 }
 
-func (*MatchDstPortRangeContext) IsMatchDstPortRangeContext() {}
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
 
-func NewMatchDstPortRangeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDstPortRangeContext {
-	var p = new(MatchDstPortRangeContext)
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchDstPortRange
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
 
 	return p
 }
 
-func (s *MatchDstPortRangeContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *MatchDstPortRangeContext) DSTPORT() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDSTPORT, 0)
-}
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchDstPortRangeContext) AllDIGITS() []antlr.TerminalNode {
-	return s.GetTokens(TrafficClassParserDIGITS)
+func (s *MatchFlowLabelContext) FLOWLABEL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOWLABEL, 0)
 }
 
-func (s *MatchDstPortRangeContext) DIGITS(i int) antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserDIGITS, i)
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *MatchDstPortRangeContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchDstPortRangeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchDstPortRangeContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchDstPortRange(s)
+		listenerT.EnterMatchFlowLabel(s)
 	}
 }
 
-func (s *MatchDstPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchDstPortRange(s)
+		listenerT.ExitMatchFlowLabel(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeContext) {
-	localctx = NewMatchDstPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchDstPortRange)
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchFlowLabel)
 
 	defer func() {
 		p.ExitRule()
@@ -1119,94 +1163,95 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(70)
-		p.Match(TrafficClassParserDSTPORT)
+		p.SetState(88)
+		p.Match(TrafficClassParserFLOWLABEL)
 	}
 	{
-		p.SetState(71)
+		p.SetState(89)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(72)
-		p.Match(TrafficClassParserDIGITS)
-	}
-	{
-		p.SetState(73)
-		p.Match(TrafficClassParserT__2)
-	}
-	{
-		p.SetState(74)
+		p.SetState(90)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
 	return localctx
 }
 
-// ICondClsContext is an interface to support dynamic dispatch.
-type ICondClsContext interface {
+// IMatchNextHdrContext is an interface to support dynamic dispatch.
+type IMatchNextHdrContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondClsContext differentiates from other interfaces.
-	IsCondClsContext()
+	// IsMatchNextHdrContext differentiates from other interfaces.
+	IsMatchNextHdrContext()
 }
 
-type CondClsContext struct {
+type MatchNextHdrContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondClsContext() *CondClsContext {
-	var p = new(CondClsContext)
+func NewEmptyMatchNextHdrContext() *MatchNextHdrContext {
+	var p = new(MatchNextHdrContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condCls
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
 	return p
 }
 
-func (*CondClsContext) IsCondClsContext() {}
+func (*MatchNextHdrContext) IsMatchNextHdrContext() {}
 
-func NewCondClsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondClsContext {
-	var p = new(CondClsContext)
+func NewMatchNextHdrContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchNextHdrContext {
+	var p = new(MatchNextHdrContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condCls
+	p.RuleIndex = TrafficClassParserRULE_matchNextHdr
 
 	return p
 }
 
-func (s *CondClsContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchNextHdrContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondClsContext) DIGITS() antlr.TerminalNode {
+func (s *MatchNextHdrContext) NEXTHDR() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNEXTHDR, 0)
+}
+
+func (s *MatchNextHdrContext) STRING() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSTRING, 0)
+}
+
+func (s *MatchNextHdrContext) DIGITS() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *CondClsContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchNextHdrContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CondClsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchNextHdrContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *CondClsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchNextHdrContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterCondCls(s)
+		listenerT.EnterMatchNextHdr(s)
 	}
 }
 
-func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchNextHdrContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitCondCls(s)
+		listenerT.ExitMatchNextHdr(s)
 	}
 }
 
-func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
-	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condCls)
+func (p *TrafficClassParser) MatchNextHdr() (localctx IMatchNextHdrContext) {
+	localctx = NewMatchNextHdrContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_matchNextHdr)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -1226,64 +1271,927 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(76)
-		p.Match(TrafficClassParserT__3)
+		p.SetState(92)
+		p.Match(TrafficClassParserNEXTHDR)
 	}
 	{
-		p.SetState(77)
-		p.Match(TrafficClassParserDIGITS)
+		p.SetState(93)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(94)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserSTRING) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
 }
 
-// ICondAnyContext is an interface to support dynamic dispatch.
-type ICondAnyContext interface {
+// IMatchSrcPortContext is an interface to support dynamic dispatch.
+type IMatchSrcPortContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsCondAnyContext differentiates from other interfaces.
-	IsCondAnyContext()
+	// IsMatchSrcPortContext differentiates from other interfaces.
+	IsMatchSrcPortContext()
 }
 
-type CondAnyContext struct {
+type MatchSrcPortContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyCondAnyContext() *CondAnyContext {
-	var p = new(CondAnyContext)
+func NewEmptyMatchSrcPortContext() *MatchSrcPortContext {
+	var p = new(MatchSrcPortContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_condAny
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
 	return p
 }
 
-func (*CondAnyContext) IsCondAnyContext() {}
+func (*MatchSrcPortContext) IsMatchSrcPortContext() {}
 
-func NewCondAnyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAnyContext {
-	var p = new(CondAnyContext)
+func NewMatchSrcPortContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrcPortContext {
+	var p = new(MatchSrcPortContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_condAny
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPort
 
 	return p
 }
 
-func (s *CondAnyContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchSrcPortContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *CondAnyContext) ANY() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserANY, 0)
+func (s *MatchSrcPortContext) SRCPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSRCPORT, 0)
 }
 
-func (s *CondAnyContext) AllCond() []ICondContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
-	var tst = make([]ICondContext, len(ts))
-
-	for i, t := range ts {
+func (s *MatchSrcPortContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchSrcPortContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchSrcPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchSrcPortContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchSrcPort(s)
+	}
+}
+
+func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchSrcPort(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
+	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_matchSrcPort)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(96)
+		p.Match(TrafficClassParserSRCPORT)
+	}
+	{
+		p.SetState(97)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(98)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchSrcPortRangeContext is an interface to support dynamic dispatch.
+type IMatchSrcPortRangeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchSrcPortRangeContext differentiates from other interfaces.
+	IsMatchSrcPortRangeContext()
+}
+
+type MatchSrcPortRangeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchSrcPortRangeContext() *MatchSrcPortRangeContext {
+	var p = new(MatchSrcPortRangeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPortRange
+	return p
+}
+
+func (*MatchSrcPortRangeContext) IsMatchSrcPortRangeContext() {}
+
+func NewMatchSrcPortRangeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrcPortRangeContext {
+	var p = new(MatchSrcPortRangeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchSrcPortRange
+
+	return p
+}
+
+func (s *MatchSrcPortRangeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchSrcPortRangeContext) SRCPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSRCPORT, 0)
+}
+
+func (s *MatchSrcPortRangeContext) AllDIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserDIGITS)
+}
+
+func (s *MatchSrcPortRangeContext) DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, i)
+}
+
+func (s *MatchSrcPortRangeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchSrcPortRangeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchSrcPortRangeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchSrcPortRange(s)
+	}
+}
+
+func (s *MatchSrcPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchSrcPortRange(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeContext) {
+	localctx = NewMatchSrcPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_matchSrcPortRange)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(100)
+		p.Match(TrafficClassParserSRCPORT)
+	}
+	{
+		p.SetState(101)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(102)
+		p.Match(TrafficClassParserDIGITS)
+	}
+	{
+		p.SetState(103)
+		p.Match(TrafficClassParserT__2)
+	}
+	{
+		p.SetState(104)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchDstPortContext is an interface to support dynamic dispatch.
+type IMatchDstPortContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchDstPortContext differentiates from other interfaces.
+	IsMatchDstPortContext()
+}
+
+type MatchDstPortContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDstPortContext() *MatchDstPortContext {
+	var p = new(MatchDstPortContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+	return p
+}
+
+func (*MatchDstPortContext) IsMatchDstPortContext() {}
+
+func NewMatchDstPortContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDstPortContext {
+	var p = new(MatchDstPortContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDstPort
+
+	return p
+}
+
+func (s *MatchDstPortContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDstPortContext) DSTPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDSTPORT, 0)
+}
+
+func (s *MatchDstPortContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchDstPortContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDstPortContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchDstPortContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchDstPort(s)
+	}
+}
+
+func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchDstPort(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
+	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_matchDstPort)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(106)
+		p.Match(TrafficClassParserDSTPORT)
+	}
+	{
+		p.SetState(107)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(108)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchDstPortRangeContext is an interface to support dynamic dispatch.
+type IMatchDstPortRangeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchDstPortRangeContext differentiates from other interfaces.
+	IsMatchDstPortRangeContext()
+}
+
+type MatchDstPortRangeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDstPortRangeContext() *MatchDstPortRangeContext {
+	var p = new(MatchDstPortRangeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDstPortRange
+	return p
+}
+
+func (*MatchDstPortRangeContext) IsMatchDstPortRangeContext() {}
+
+func NewMatchDstPortRangeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDstPortRangeContext {
+	var p = new(MatchDstPortRangeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDstPortRange
+
+	return p
+}
+
+func (s *MatchDstPortRangeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDstPortRangeContext) DSTPORT() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDSTPORT, 0)
+}
+
+func (s *MatchDstPortRangeContext) AllDIGITS() []antlr.TerminalNode {
+	return s.GetTokens(TrafficClassParserDIGITS)
+}
+
+func (s *MatchDstPortRangeContext) DIGITS(i int) antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, i)
+}
+
+func (s *MatchDstPortRangeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDstPortRangeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchDstPortRangeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchDstPortRange(s)
+	}
+}
+
+func (s *MatchDstPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchDstPortRange(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeContext) {
+	localctx = NewMatchDstPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_matchDstPortRange)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(110)
+		p.Match(TrafficClassParserDSTPORT)
+	}
+	{
+		p.SetState(111)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(112)
+		p.Match(TrafficClassParserDIGITS)
+	}
+	{
+		p.SetState(113)
+		p.Match(TrafficClassParserT__2)
+	}
+	{
+		p.SetState(114)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchTCPFlagsContext is an interface to support dynamic dispatch.
+type IMatchTCPFlagsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCPFlagsContext differentiates from other interfaces.
+	IsMatchTCPFlagsContext()
+}
+
+type MatchTCPFlagsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCPFlagsContext() *MatchTCPFlagsContext {
+	var p = new(MatchTCPFlagsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlags
+	return p
+}
+
+func (*MatchTCPFlagsContext) IsMatchTCPFlagsContext() {}
+
+func NewMatchTCPFlagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCPFlagsContext {
+	var p = new(MatchTCPFlagsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTCPFlags
+
+	return p
+}
+
+func (s *MatchTCPFlagsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCPFlagsContext) TCPFLAGS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTCPFLAGS, 0)
+}
+
+func (s *MatchTCPFlagsContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCPFlagsContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCPFlagsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCPFlagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCPFlagsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTCPFlags(s)
+	}
+}
+
+func (s *MatchTCPFlagsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTCPFlags(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTCPFlags() (localctx IMatchTCPFlagsContext) {
+	localctx = NewMatchTCPFlagsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_matchTCPFlags)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(116)
+		p.Match(TrafficClassParserTCPFLAGS)
+	}
+	{
+		p.SetState(117)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(118)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchICMPTypeContext is an interface to support dynamic dispatch.
+type IMatchICMPTypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchICMPTypeContext differentiates from other interfaces.
+	IsMatchICMPTypeContext()
+}
+
+type MatchICMPTypeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchICMPTypeContext() *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+	return p
+}
+
+func (*MatchICMPTypeContext) IsMatchICMPTypeContext() {}
+
+func NewMatchICMPTypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+
+	return p
+}
+
+func (s *MatchICMPTypeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchICMPTypeContext) ICMPTYPE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMPTYPE, 0)
+}
+
+func (s *MatchICMPTypeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchICMPTypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchICMPTypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchICMPTypeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchICMPType(s)
+	}
+}
+
+func (s *MatchICMPTypeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchICMPType(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchICMPType() (localctx IMatchICMPTypeContext) {
+	localctx = NewMatchICMPTypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_matchICMPType)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(120)
+		p.Match(TrafficClassParserICMPTYPE)
+	}
+	{
+		p.SetState(121)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(122)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// IMatchICMP6TypeContext is an interface to support dynamic dispatch.
+type IMatchICMP6TypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchICMP6TypeContext differentiates from other interfaces.
+	IsMatchICMP6TypeContext()
+}
+
+type MatchICMP6TypeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchICMP6TypeContext() *MatchICMP6TypeContext {
+	var p = new(MatchICMP6TypeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMP6Type
+	return p
+}
+
+func (*MatchICMP6TypeContext) IsMatchICMP6TypeContext() {}
+
+func NewMatchICMP6TypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMP6TypeContext {
+	var p = new(MatchICMP6TypeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchICMP6Type
+
+	return p
+}
+
+func (s *MatchICMP6TypeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchICMP6TypeContext) ICMP6TYPE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMP6TYPE, 0)
+}
+
+func (s *MatchICMP6TypeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchICMP6TypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchICMP6TypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchICMP6TypeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchICMP6Type(s)
+	}
+}
+
+func (s *MatchICMP6TypeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchICMP6Type(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchICMP6Type() (localctx IMatchICMP6TypeContext) {
+	localctx = NewMatchICMP6TypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_matchICMP6Type)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(124)
+		p.Match(TrafficClassParserICMP6TYPE)
+	}
+	{
+		p.SetState(125)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(126)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// ICondClsContext is an interface to support dynamic dispatch.
+type ICondClsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondClsContext differentiates from other interfaces.
+	IsCondClsContext()
+}
+
+type CondClsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondClsContext() *CondClsContext {
+	var p = new(CondClsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condCls
+	return p
+}
+
+func (*CondClsContext) IsCondClsContext() {}
+
+func NewCondClsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondClsContext {
+	var p = new(CondClsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condCls
+
+	return p
+}
+
+func (s *CondClsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondClsContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *CondClsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondClsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondClsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondCls(s)
+	}
+}
+
+func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondCls(s)
+	}
+}
+
+func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
+	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_condCls)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(128)
+		p.Match(TrafficClassParserT__3)
+	}
+	{
+		p.SetState(129)
+		p.Match(TrafficClassParserDIGITS)
+	}
+
+	return localctx
+}
+
+// ICondAnyContext is an interface to support dynamic dispatch.
+type ICondAnyContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondAnyContext differentiates from other interfaces.
+	IsCondAnyContext()
+}
+
+type CondAnyContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondAnyContext() *CondAnyContext {
+	var p = new(CondAnyContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condAny
+	return p
+}
+
+func (*CondAnyContext) IsCondAnyContext() {}
+
+func NewCondAnyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondAnyContext {
+	var p = new(CondAnyContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condAny
+
+	return p
+}
+
+func (s *CondAnyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondAnyContext) ANY() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserANY, 0)
+}
+
+func (s *CondAnyContext) AllCond() []ICondContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICondContext)(nil)).Elem())
+	var tst = make([]ICondContext, len(ts))
+
+	for i, t := range ts {
 		if t != nil {
 			tst[i] = t.(ICondContext)
 		}
@@ -1324,7 +2232,7 @@ func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_condAny)
+	p.EnterRule(localctx, 36, TrafficClassParserRULE_condAny)
 	var _la int
 
 	defer func() {
@@ -1345,37 +2253,37 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(79)
+		p.SetState(131)
 		p.Match(TrafficClassParserANY)
 	}
 	{
-		p.SetState(80)
+		p.SetState(132)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(81)
+		p.SetState(133)
 		p.Cond()
 	}
-	p.SetState(86)
+	p.SetState(138)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(82)
+			p.SetState(134)
 			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(83)
+			p.SetState(135)
 			p.Cond()
 		}
 
-		p.SetState(88)
+		p.SetState(140)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(89)
+		p.SetState(141)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1469,7 +2377,7 @@ func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAll)
+	p.EnterRule(localctx, 38, TrafficClassParserRULE_condAll)
 	var _la int
 
 	defer func() {
@@ -1490,37 +2398,37 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
+		p.SetState(143)
 		p.Match(TrafficClassParserALL)
 	}
 	{
-		p.SetState(92)
+		p.SetState(144)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(93)
+		p.SetState(145)
 		p.Cond()
 	}
-	p.SetState(98)
+	p.SetState(150)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(94)
+			p.SetState(146)
 			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(95)
+			p.SetState(147)
 			p.Cond()
 		}
 
-		p.SetState(100)
+		p.SetState(152)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(101)
+		p.SetState(153)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1601,7 +2509,7 @@ func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, TrafficClassParserRULE_condNot)
+	p.EnterRule(localctx, 40, TrafficClassParserRULE_condNot)
 
 	defer func() {
 		p.ExitRule()
@@ -1621,19 +2529,19 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(103)
+		p.SetState(155)
 		p.Match(TrafficClassParserNOT)
 	}
 	{
-		p.SetState(104)
+		p.SetState(156)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(105)
+		p.SetState(157)
 		p.Cond()
 	}
 	{
-		p.SetState(106)
+		p.SetState(158)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1704,7 +2612,7 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 42, TrafficClassParserRULE_condBool)
 	var _la int
 
 	defer func() {
@@ -1725,15 +2633,15 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(108)
+		p.SetState(160)
 		p.Match(TrafficClassParserBOOL)
 	}
 	{
-		p.SetState(109)
+		p.SetState(161)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(110)
+		p.SetState(162)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserT__7 || _la == TrafficClassParserT__8) {
@@ -1857,7 +2765,7 @@ func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, TrafficClassParserRULE_condIPv4)
+	p.EnterRule(localctx, 44, TrafficClassParserRULE_condIPv4)
 
 	defer func() {
 		p.ExitRule()
@@ -1875,42 +2783,42 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 		}
 	}()
 
-	p.SetState(117)
+	p.SetState(169)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(112)
+			p.SetState(164)
 			p.MatchSrc()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(113)
+			p.SetState(165)
 			p.MatchDst()
 		}
 
 	case TrafficClassParserDSCP:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(114)
+			p.SetState(166)
 			p.MatchDSCP()
 		}
 
 	case TrafficClassParserTOS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(115)
+			p.SetState(167)
 			p.MatchTOS()
 		}
 
 	case TrafficClassParserPROTOCOL:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(116)
+			p.SetState(168)
 			p.MatchProtocol()
 		}
 
@@ -1921,6 +2829,180 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	return localctx
 }
 
+// ICondIPv6Context is an interface to support dynamic dispatch.
+type ICondIPv6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondIPv6Context differentiates from other interfaces.
+	IsCondIPv6Context()
+}
+
+type CondIPv6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondIPv6Context() *CondIPv6Context {
+	var p = new(CondIPv6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+	return p
+}
+
+func (*CondIPv6Context) IsCondIPv6Context() {}
+
+func NewCondIPv6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv6Context {
+	var p = new(CondIPv6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+
+	return p
+}
+
+func (s *CondIPv6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondIPv6Context) MatchSrc6() IMatchSrc6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrc6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchSrc6Context)
+}
+
+func (s *CondIPv6Context) MatchDst6() IMatchDst6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDst6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDst6Context)
+}
+
+func (s *CondIPv6Context) MatchTC() IMatchTCContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCContext)
+}
+
+func (s *CondIPv6Context) MatchFlowLabel() IMatchFlowLabelContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchFlowLabelContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchFlowLabelContext)
+}
+
+func (s *CondIPv6Context) MatchNextHdr() IMatchNextHdrContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchNextHdrContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchNextHdrContext)
+}
+
+func (s *CondIPv6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondIPv6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondIPv6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondIPv6(s)
+	}
+}
+
+func (s *CondIPv6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondIPv6(s)
+	}
+}
+
+func (p *TrafficClassParser) CondIPv6() (localctx ICondIPv6Context) {
+	localctx = NewCondIPv6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 46, TrafficClassParserRULE_condIPv6)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(176)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserSRC:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(171)
+			p.MatchSrc6()
+		}
+
+	case TrafficClassParserDST:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(172)
+			p.MatchDst6()
+		}
+
+	case TrafficClassParserTC:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(173)
+			p.MatchTC()
+		}
+
+	case TrafficClassParserFLOWLABEL:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(174)
+			p.MatchFlowLabel()
+		}
+
+	case TrafficClassParserNEXTHDR:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(175)
+			p.MatchNextHdr()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // ICondPortContext is an interface to support dynamic dispatch.
 type ICondPortContext interface {
 	antlr.ParserRuleContext
@@ -2021,7 +3103,7 @@ func (s *CondPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	localctx = NewCondPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, TrafficClassParserRULE_condPort)
+	p.EnterRule(localctx, 48, TrafficClassParserRULE_condPort)
 
 	defer func() {
 		p.ExitRule()
@@ -2039,34 +3121,34 @@ func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 		}
 	}()
 
-	p.SetState(123)
+	p.SetState(182)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(119)
+			p.SetState(178)
 			p.MatchSrcPort()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(120)
+			p.SetState(179)
 			p.MatchSrcPortRange()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(121)
+			p.SetState(180)
 			p.MatchDstPort()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(122)
+			p.SetState(181)
 			p.MatchDstPortRange()
 		}
 
@@ -2075,6 +3157,146 @@ func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	return localctx
 }
 
+// ICondL4Context is an interface to support dynamic dispatch.
+type ICondL4Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondL4Context differentiates from other interfaces.
+	IsCondL4Context()
+}
+
+type CondL4Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondL4Context() *CondL4Context {
+	var p = new(CondL4Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condL4
+	return p
+}
+
+func (*CondL4Context) IsCondL4Context() {}
+
+func NewCondL4Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondL4Context {
+	var p = new(CondL4Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condL4
+
+	return p
+}
+
+func (s *CondL4Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondL4Context) MatchTCPFlags() IMatchTCPFlagsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCPFlagsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCPFlagsContext)
+}
+
+func (s *CondL4Context) MatchICMPType() IMatchICMPTypeContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchICMPTypeContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMPTypeContext)
+}
+
+func (s *CondL4Context) MatchICMP6Type() IMatchICMP6TypeContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchICMP6TypeContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMP6TypeContext)
+}
+
+func (s *CondL4Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondL4Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondL4Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondL4(s)
+	}
+}
+
+func (s *CondL4Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondL4(s)
+	}
+}
+
+func (p *TrafficClassParser) CondL4() (localctx ICondL4Context) {
+	localctx = NewCondL4Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 50, TrafficClassParserRULE_condL4)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(187)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserTCPFLAGS:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(184)
+			p.MatchTCPFlags()
+		}
+
+	case TrafficClassParserICMPTYPE:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(185)
+			p.MatchICMPType()
+		}
+
+	case TrafficClassParserICMP6TYPE:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(186)
+			p.MatchICMP6Type()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // ICondContext is an interface to support dynamic dispatch.
 type ICondContext interface {
 	antlr.ParserRuleContext
@@ -2153,6 +3375,16 @@ func (s *CondContext) CondIPv4() ICondIPv4Context {
 	return t.(ICondIPv4Context)
 }
 
+func (s *CondContext) CondIPv6() ICondIPv6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPv6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPv6Context)
+}
+
 func (s *CondContext) CondPort() ICondPortContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondPortContext)(nil)).Elem(), 0)
 
@@ -2163,6 +3395,16 @@ func (s *CondContext) CondPort() ICondPortContext {
 	return t.(ICondPortContext)
 }
 
+func (s *CondContext) CondL4() ICondL4Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondL4Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondL4Context)
+}
+
 func (s *CondContext) CondCls() ICondClsContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondClsContext)(nil)).Elem(), 0)
 
@@ -2205,7 +3447,7 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, TrafficClassParserRULE_cond)
+	p.EnterRule(localctx, 52, TrafficClassParserRULE_cond)
 
 	defer func() {
 		p.ExitRule()
@@ -2223,61 +3465,72 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 		}
 	}()
 
-	p.SetState(132)
+	p.SetState(198)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case TrafficClassParserALL:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 6, p.GetParserRuleContext()) {
+	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(125)
+			p.SetState(189)
 			p.CondAll()
 		}
 
-	case TrafficClassParserANY:
+	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(126)
+			p.SetState(190)
 			p.CondAny()
 		}
 
-	case TrafficClassParserNOT:
+	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(127)
+			p.SetState(191)
 			p.CondNot()
 		}
 
-	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP, TrafficClassParserTOS, TrafficClassParserPROTOCOL:
+	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(128)
+			p.SetState(192)
 			p.CondIPv4()
 		}
 
-	case TrafficClassParserSRCPORT, TrafficClassParserDSTPORT:
+	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(129)
-			p.CondPort()
+			p.SetState(193)
+			p.CondIPv6()
 		}
 
-	case TrafficClassParserT__3:
+	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(130)
-			p.CondCls()
+			p.SetState(194)
+			p.CondPort()
 		}
 
-	case TrafficClassParserBOOL:
+	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(131)
+			p.SetState(195)
+			p.CondL4()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(196)
+			p.CondCls()
+		}
+
+	case 9:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(197)
 			p.CondBool()
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -2357,7 +3610,7 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 54, TrafficClassParserRULE_trafficClass)
 
 	defer func() {
 		p.ExitRule()
@@ -2377,11 +3630,11 @@ func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(134)
+		p.SetState(200)
 		p.Cond()
 	}
 	{
-		p.SetState(135)
+		p.SetState(201)
 		p.Match(TrafficClassParserEOF)
 	}
 
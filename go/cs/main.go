@@ -165,6 +165,7 @@ func realMain(ctx context.Context) error {
 
 	nc := infraenv.NetworkConfig{
 		IA:                    topo.IA(),
+		EndhostPorts:          topo.Get().EndhostPorts(),
 		Public:                topo.ControlServiceAddress(globalCfg.General.ID),
 		ReconnectToDispatcher: globalCfg.General.ReconnectToDispatcher,
 		QUIC: infraenv.QUIC{
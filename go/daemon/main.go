@@ -40,6 +40,7 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/periodic"
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
@@ -217,6 +218,12 @@ func realMain(ctx context.Context) error {
 	if err != nil {
 		return serrors.WrapStr("loading hidden path groups", err)
 	}
+	var pathPolicy *pathpol.Policy
+	if globalCfg.SD.PathPolicy != "" {
+		if pathPolicy, err = pathpol.LoadPolicyFromYaml(globalCfg.SD.PathPolicy); err != nil {
+			return serrors.WrapStr("loading path policy", err)
+		}
+	}
 	var requester segfetcher.RPC = &segfetchergrpc.Requester{
 		Dialer: dialer,
 	}
@@ -265,6 +272,7 @@ func realMain(ctx context.Context) error {
 			DRKeyClient: drkeyClientEngine,
 			ColFetcher:  colibri.NewFetcher(dialer),
 			ColClient:   &colibri.DaemonClient{Dialer: dialer},
+			PathPolicy:  pathPolicy,
 		},
 	))
 
@@ -36,13 +36,25 @@ const ReceiveBufferSize = 1 << 20
 type Server struct {
 	// routingTable is used to register new connections.
 	routingTable *IATable
-	ipv4Conn     conn.ExtendedPacketConn
-	ipv6Conn     conn.ExtendedPacketConn
+	// ipv4Conn and ipv6Conn are the same connection if it is a dual-stack
+	// socket.
+	ipv4Conn conn.ExtendedPacketConn
+	ipv6Conn conn.ExtendedPacketConn
 }
 
 // NewServer creates new instance of Server. Internally, it opens the dispatcher ports
-// for both IPv4 and IPv6. Returns error if the ports can't be opened.
+// for both IPv4 and IPv6. If the address has no IP and the host supports it, a
+// single dual-stack socket is used for both. Returns error if the ports can't be
+// opened.
 func NewServer(address string, ipv4Conn, ipv6Conn conn.ExtendedPacketConn) (*Server, error) {
+	if ipv4Conn == nil && ipv6Conn == nil {
+		dualConn, err := openDualStackConn(address)
+		if err == nil {
+			ipv4Conn, ipv6Conn = dualConn, dualConn
+		} else {
+			log.Info("Opening separate IPv4 and IPv6 sockets", "reason", err)
+		}
+	}
 	if ipv4Conn == nil {
 		var err error
 		ipv4Conn, err = openConn("udp4", address)
@@ -69,22 +81,16 @@ func NewServer(address string, ipv4Conn, ipv6Conn conn.ExtendedPacketConn) (*Ser
 // The function blocks and returns if there's an error or when Close has been called.
 func (as *Server) Serve() error {
 	errChan := make(chan error)
-	go func() {
-		defer log.HandlePanic()
-		netToRingDataplane := &NetToRingDataplane{
-			UnderlayConn: as.ipv4Conn,
-			RoutingTable: as.routingTable,
-		}
-		errChan <- netToRingDataplane.Run()
-	}()
-	go func() {
-		defer log.HandlePanic()
-		netToRingDataplane := &NetToRingDataplane{
-			UnderlayConn: as.ipv6Conn,
-			RoutingTable: as.routingTable,
-		}
-		errChan <- netToRingDataplane.Run()
-	}()
+	for _, c := range as.conns() {
+		go func(c conn.ExtendedPacketConn) {
+			defer log.HandlePanic()
+			netToRingDataplane := &NetToRingDataplane{
+				UnderlayConn: c,
+				RoutingTable: as.routingTable,
+			}
+			errChan <- netToRingDataplane.Run()
+		}(c)
+	}
 	return <-errChan
 }
 
@@ -112,8 +118,17 @@ func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr
 }
 
 func (as *Server) Close() {
-	as.ipv4Conn.Close()
-	as.ipv6Conn.Close()
+	for _, c := range as.conns() {
+		c.Close()
+	}
+}
+
+// conns returns the underlay connections, each only once.
+func (as *Server) conns() []conn.ExtendedPacketConn {
+	if as.ipv4Conn == as.ipv6Conn {
+		return []conn.ExtendedPacketConn{as.ipv4Conn}
+	}
+	return []conn.ExtendedPacketConn{as.ipv4Conn, as.ipv6Conn}
 }
 
 // Conn represents a connection bound to a specific SCION port/SVC.
@@ -221,6 +236,25 @@ func openConn(network, address string) (conn.ExtendedPacketConn, error) {
 	return conn.PacketConn(c), nil
 }
 
+// openDualStackConn opens an underlay socket for both IPv4 and IPv6, see
+// openConn. It fails if the address has an IP, or if the host does not support
+// dual-stack sockets.
+func openDualStackConn(address string) (conn.ExtendedPacketConn, error) {
+	listeningAddress, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to construct UDP addr", err)
+	}
+	if listeningAddress.IP != nil {
+		return nil, serrors.New("dual-stack socket requires an address without IP",
+			"address", address)
+	}
+	c, err := conn.New(listeningAddress, nil, &conn.Config{ReceiveBufferSize: ReceiveBufferSize})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to open conn", err)
+	}
+	return conn.PacketConn(c), nil
+}
+
 // registerIfSCMPInfo registers the ID of the SCMP request if it is an echo or
 // traceroute message.
 func registerIfSCMPInfo(ref registration.RegReference, pkt *respool.Packet) error {
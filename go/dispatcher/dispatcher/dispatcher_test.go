@@ -0,0 +1,39 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer(t *testing.T) {
+	t.Run("address without IP uses a dual-stack socket", func(t *testing.T) {
+		if _, err := openDualStackConn(":0"); err != nil {
+			t.Skipf("dual-stack sockets not supported: %v", err)
+		}
+		s, err := NewServer(":0", nil, nil)
+		require.NoError(t, err)
+		defer s.Close()
+		assert.Same(t, s.ipv4Conn, s.ipv6Conn)
+		assert.Len(t, s.conns(), 1)
+	})
+	t.Run("address with IP can't use a dual-stack socket", func(t *testing.T) {
+		_, err := openDualStackConn("127.0.0.1:0")
+		assert.Error(t, err)
+	})
+}
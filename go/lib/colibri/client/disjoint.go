@@ -0,0 +1,45 @@
+// Copyright 2021 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/scionproto/scion/go/lib/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// TripsAvoiding returns the trips that do not use any of the interfaces, in
+// the order they are passed.
+func TripsAvoiding(trips []*colibri.FullTrip,
+	avoid ...snet.PathInterface) []*colibri.FullTrip {
+
+	taken := make(map[snet.PathInterface]struct{}, len(avoid))
+	for _, intf := range avoid {
+		taken[intf] = struct{}{}
+	}
+	var avoiding []*colibri.FullTrip
+	for _, trip := range trips {
+		shared := false
+		for _, intf := range trip.PathSteps().Interfaces() {
+			if _, ok := taken[intf]; ok {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			avoiding = append(avoiding, trip)
+		}
+	}
+	return avoiding
+}
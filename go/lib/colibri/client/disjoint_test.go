@@ -0,0 +1,76 @@
+// Copyright 2021 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/lib/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestTripsAvoiding(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:111")
+	core := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	// trip returns a trip from src via core to dst, leaving src on srcEgress
+	// and entering dst on dstIngress.
+	trip := func(srcEgress, dstIngress uint16) *colibri.FullTrip {
+		return &colibri.FullTrip{
+			{Steps: []base.PathStep{
+				{IA: src, Egress: srcEgress},
+				{IA: core, Ingress: srcEgress},
+			}},
+			{Steps: []base.PathStep{
+				{IA: core, Egress: dstIngress},
+				{IA: dst, Ingress: dstIngress},
+			}},
+		}
+	}
+	a, b, c, d := trip(1, 1), trip(1, 2), trip(2, 2), trip(3, 3)
+	trips := []*colibri.FullTrip{a, b, c, d}
+
+	cases := map[string]struct {
+		avoid    []snet.PathInterface
+		expected []*colibri.FullTrip
+	}{
+		"none": {
+			expected: trips,
+		},
+		"source interface": {
+			avoid:    []snet.PathInterface{{IA: src, ID: 1}},
+			expected: []*colibri.FullTrip{c, d},
+		},
+		"destination interface": {
+			avoid:    []snet.PathInterface{{IA: dst, ID: 2}},
+			expected: []*colibri.FullTrip{a, d},
+		},
+		"all": {
+			avoid: []snet.PathInterface{{IA: core, ID: 1}, {IA: core, ID: 2},
+				{IA: core, ID: 3}},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, TripsAvoiding(trips, tc.avoid...))
+		})
+	}
+}
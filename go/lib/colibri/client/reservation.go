@@ -88,8 +88,22 @@ func NewReservation(ctx context.Context,
 			return lessFcn(*trips[a], *trips[b])
 		})
 	}
-	trip := trips[0]
+	return NewReservationOnTrip(ctx, daemon, localIA, srcHost, dstIA, dstHost, trips[0],
+		bw, index)
+}
+
+// NewReservationOnTrip creates a reservation on the given trip, instead of
+// choosing one among all trips to the destination. This allows callers to
+// select the trips themselves, e.g. to obtain several disjoint reservations.
+func NewReservationOnTrip(ctx context.Context,
+	daemon daemon.Connector,
+	localIA addr.IA, srcHost net.IP, dstIA addr.IA, dstHost net.IP,
+	trip *colibri.FullTrip, bw reservation.BWCls, index reservation.IndexNumber) (
+	*Reservation, error) {
 
+	if err := trip.Validate(); err != nil {
+		return nil, err
+	}
 	// 3. create reservation setup request
 	setupReq := &colibri.E2EReservationSetup{
 		BaseRequest: colibri.BaseRequest{
@@ -108,7 +122,7 @@ func NewReservation(ctx context.Context,
 	rand.Read(setupReq.Id.Suffix) // random suffix
 
 	// 4. compute authenticators
-	err = setupReq.CreateAuthenticators(ctx, daemon)
+	err := setupReq.CreateAuthenticators(ctx, daemon)
 	return &Reservation{
 		daemon:      daemon,
 		dstIA:       dstIA,
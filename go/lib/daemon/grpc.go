@@ -444,6 +444,10 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 			LinkType:        linkType,
 			InternalHops:    p.InternalHops,
 			Notes:           p.Notes,
+			EpicAuths: snet.EpicAuths{
+				AuthPHVF: p.EpicAuths.GetAuthPhvf(),
+				AuthLHVF: p.EpicAuths.GetAuthLhvf(),
+			},
 		},
 	}, nil
 }
@@ -72,6 +72,7 @@ const (
 	SCMP    = Protocol(pb.Protocol_PROTOCOL_SCMP)
 	DNS     = Protocol(pb.Protocol_PROTOCOL_DNS)
 	COLIBRI = Protocol(pb.Protocol_PROTOCOL_COLIBRI)
+	FourSP  = Protocol(pb.Protocol_PROTOCOL_4SP)
 )
 
 func (p Protocol) String() string {
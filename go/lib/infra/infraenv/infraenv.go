@@ -42,6 +42,7 @@ import (
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 // QUIC contains the QUIC configuration for control-plane speakers.
@@ -55,6 +56,8 @@ type QUIC struct {
 type NetworkConfig struct {
 	// IA is the local AS number.
 	IA addr.IA
+	// EndhostPorts is the end host port range of the local AS.
+	EndhostPorts underlay.PortRange
 	// Public is the Internet-reachable address in the case where the service
 	// is behind NAT.
 	Public *net.UDPAddr
@@ -256,9 +259,10 @@ func (nc *NetworkConfig) initSvcRedirect(quicAddress string, tlsQUICAdress strin
 		},
 	)
 	network := &snet.SCIONNetwork{
-		LocalIA:    nc.IA,
-		Dispatcher: packetDispatcher,
-		Metrics:    nc.SCIONNetworkMetrics,
+		LocalIA:      nc.IA,
+		Dispatcher:   packetDispatcher,
+		Metrics:      nc.SCIONNetworkMetrics,
+		EndhostPorts: nc.EndhostPorts,
 	}
 	conn, err := network.Listen(context.Background(), "udp", nc.Public, addr.SvcWildcard)
 	if err != nil {
@@ -298,7 +302,8 @@ func (nc *NetworkConfig) initQUICSockets(ignorePort bool) (net.PacketConn, net.P
 			SCMPHandler:            ignoreSCMP{},
 			SCIONPacketConnMetrics: nc.SCIONPacketConnMetrics,
 		},
-		Metrics: nc.SCIONNetworkMetrics,
+		Metrics:      nc.SCIONNetworkMetrics,
+		EndhostPorts: nc.EndhostPorts,
 	}
 	serverAddr, err := net.ResolveUDPAddr("udp", nc.QUIC.Address)
 	if err != nil {
@@ -319,7 +324,8 @@ func (nc *NetworkConfig) initQUICSockets(ignorePort bool) (net.PacketConn, net.P
 			SCMPHandler:            nc.SCMPHandler,
 			SCIONPacketConnMetrics: nc.SCIONPacketConnMetrics,
 		},
-		Metrics: nc.SCIONNetworkMetrics,
+		Metrics:      nc.SCIONNetworkMetrics,
+		EndhostPorts: nc.EndhostPorts,
 	}
 	// Let the dispatcher decide on the port for the client connection.
 	clientAddr := &net.UDPAddr{
@@ -25,10 +25,12 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/ctrl/seg/extensions/epic"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
@@ -729,3 +731,45 @@ func flagPrint(name string, b bool) string {
 	}
 	return name
 }
+
+func TestEpicAuths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	src := xtest.MustParseIA("1-ff00:0:112")
+	dst := xtest.MustParseIA("1-ff00:0:130")
+
+	// newUp returns the up segment from 112 to 130. The AS entries with the
+	// indices in withEpic carry the detached EPIC extension.
+	newUp := func(withEpic ...int) *seg.PathSegment {
+		up := g.Beacon([]uint16{graph.If_130_B_111_A, graph.If_111_A_112_X})
+		for _, i := range withEpic {
+			up.ASEntries[i].UnsignedExtensions.EpicDetached = &epic.Detached{
+				AuthHopEntry: bytes.Repeat([]byte{byte(i + 1)}, epic.AuthLen),
+			}
+		}
+		return up
+	}
+	fullMAC := func(up *seg.PathSegment, i int) []byte {
+		mac := up.ASEntries[i].HopEntry.HopField.MAC
+		return append(mac[:], up.ASEntries[i].UnsignedExtensions.EpicDetached.AuthHopEntry...)
+	}
+
+	t.Run("all ASes", func(t *testing.T) {
+		up := newUp(0, 1, 2)
+		paths := combinator.Combine(src, dst, []*seg.PathSegment{up}, nil, nil, false)
+		require.Len(t, paths, 1)
+		// The up segment is traversed against construction direction, so the
+		// last hop is the first AS entry.
+		auths := paths[0].Metadata.EpicAuths
+		assert.True(t, auths.SupportsEpic())
+		assert.Equal(t, fullMAC(up, 1), auths.AuthPHVF)
+		assert.Equal(t, fullMAC(up, 0), auths.AuthLHVF)
+	})
+	t.Run("missing last hop", func(t *testing.T) {
+		up := newUp(1, 2)
+		paths := combinator.Combine(src, dst, []*seg.PathSegment{up}, nil, nil, false)
+		require.Len(t, paths, 1)
+		assert.False(t, paths[0].Metadata.EpicAuths.SupportsEpic())
+	})
+}
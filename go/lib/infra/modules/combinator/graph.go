@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/ctrl/seg/extensions/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -311,6 +312,7 @@ func (solution *pathSolution) Path() Path {
 	var segments segmentList
 	for _, solEdge := range solution.edges {
 		var hops []path.HopField
+		var epicAuths [][]byte // EPIC authenticators of the hops, nil if not available.
 		var intfs []snet.PathInterface
 		var pathASEntries []seg.ASEntry // ASEntries that on the path, eventually in path order.
 
@@ -323,7 +325,9 @@ func (solution *pathSolution) Path() Path {
 			asEntry := asEntries[asEntryIdx]
 
 			var hopField path.HopField
+			var epicAuth []byte
 			var forwardingLinkMtu int
+			epicDetached := asEntry.UnsignedExtensions.EpicDetached
 			if !isPeer {
 				// Regular hop field.
 				entry := asEntry.HopEntry
@@ -333,6 +337,9 @@ func (solution *pathSolution) Path() Path {
 					ConsEgress:  entry.HopField.ConsEgress,
 					Mac:         entry.HopField.MAC,
 				}
+				if epicDetached != nil {
+					epicAuth = fullMAC(hopField.Mac, epicDetached.AuthHopEntry)
+				}
 				forwardingLinkMtu = entry.IngressMTU
 			} else {
 				// We've reached the ASEntry where we want to switch
@@ -344,6 +351,10 @@ func (solution *pathSolution) Path() Path {
 					ConsEgress:  peer.HopField.ConsEgress,
 					Mac:         peer.HopField.MAC,
 				}
+				if epicDetached != nil && solEdge.edge.Peer <= len(epicDetached.AuthPeerEntries) {
+					epicAuth = fullMAC(hopField.Mac,
+						epicDetached.AuthPeerEntries[solEdge.edge.Peer-1])
+				}
 				forwardingLinkMtu = peer.PeerMTU
 			}
 
@@ -363,6 +374,7 @@ func (solution *pathSolution) Path() Path {
 				})
 			}
 			hops = append(hops, hopField)
+			epicAuths = append(epicAuths, epicAuth)
 			pathASEntries = append(pathASEntries, asEntry)
 
 			mtu = minUint16(mtu, uint16(asEntry.MTU))
@@ -374,6 +386,7 @@ func (solution *pathSolution) Path() Path {
 
 		if solEdge.segment.Type == proto.PathSegType_down {
 			reverseHops(hops)
+			reverseEpicAuths(epicAuths)
 			reverseIntfs(intfs)
 			reverseASEntries(pathASEntries)
 		}
@@ -386,6 +399,7 @@ func (solution *pathSolution) Path() Path {
 				Peer:      solEdge.edge.Peer != 0,
 			},
 			HopFields:  hops,
+			EpicAuths:  epicAuths,
 			Interfaces: intfs,
 			ASEntries:  pathASEntries,
 		})
@@ -408,6 +422,7 @@ func (solution *pathSolution) Path() Path {
 			LinkType:        staticInfo.LinkType,
 			InternalHops:    staticInfo.InternalHops,
 			Notes:           staticInfo.Notes,
+			EpicAuths:       segments.EpicAuths(),
 		},
 		Weight: solution.cost,
 	}
@@ -419,6 +434,23 @@ func reverseHops(s []path.HopField) {
 	}
 }
 
+func reverseEpicAuths(s [][]byte) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// fullMAC returns the full 16-byte MAC of a hop field from the MAC in the hop
+// field and the remaining bytes in the detached EPIC extension. It returns
+// nil if the detached bytes are missing.
+func fullMAC(mac [path.MacLen]byte, detached []byte) []byte {
+	if len(detached) != epic.AuthLen {
+		return nil
+	}
+	return append(append(make([]byte, 0, path.MacLen+epic.AuthLen), mac[:]...),
+		detached...)
+}
+
 func reverseIntfs(s []snet.PathInterface) {
 	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
 		s[i], s[j] = s[j], s[i]
@@ -533,8 +565,11 @@ func validNextSeg(currSeg, nextSeg *inputSegment) bool {
 // segment is a helper that represents a path segment during the conversion
 // from the graph solution to the raw forwarding information.
 type segment struct {
-	InfoField  path.InfoField
-	HopFields  []path.HopField
+	InfoField path.InfoField
+	HopFields []path.HopField
+	// EpicAuths are the EPIC authenticators of the hop fields. Entries are
+	// nil if the AS did not add the detached EPIC extension.
+	EpicAuths  [][]byte
 	Interfaces []snet.PathInterface
 	ASEntries  []seg.ASEntry
 }
@@ -580,6 +615,22 @@ func (s segmentList) ASEntries() []seg.ASEntry {
 	return asEntries
 }
 
+// EpicAuths returns the EPIC authenticators of the penultimate and the last
+// hop field of the path. They are empty unless both are known.
+func (s segmentList) EpicAuths() snet.EpicAuths {
+	var auths [][]byte
+	for _, seg := range s {
+		auths = append(auths, seg.EpicAuths...)
+	}
+	if len(auths) < 2 || auths[len(auths)-2] == nil || auths[len(auths)-1] == nil {
+		return snet.EpicAuths{}
+	}
+	return snet.EpicAuths{
+		AuthPHVF: auths[len(auths)-2],
+		AuthLHVF: auths[len(auths)-1],
+	}
+}
+
 func (s segmentList) ComputeExpTime() time.Time {
 	minTimestamp := maxExpirationTime
 	for _, segment := range s {
@@ -35,7 +35,7 @@ type ACL struct {
 
 // NewACL creates a new entry and checks for the presence of a default action
 func NewACL(entries ...*ACLEntry) (*ACL, error) {
-	if len(entries) == 0 || !entries[len(entries)-1].Rule.matchesAll() {
+	if len(entries) == 0 || !entries[len(entries)-1].matchesAll() {
 		return nil, ErrNoDefault
 	}
 	return &ACL{Entries: entries}, nil
@@ -65,43 +65,48 @@ func (a *ACL) UnmarshalJSON(b []byte) error {
 }
 
 func (a *ACL) evalPath(pm *snet.PathMetadata) ACLAction {
-	for i, iface := range pm.Interfaces {
-		if a.evalInterface(iface, i%2 != 0) == Deny {
+	for i := range pm.Interfaces {
+		if a.evalInterface(pm, i) == Deny {
 			return Deny
 		}
 	}
 	return Allow
 }
 
-func (a *ACL) evalInterface(iface snet.PathInterface, ingress bool) ACLAction {
+func (a *ACL) evalInterface(pm *snet.PathMetadata, i int) ACLAction {
 	for _, aclEntry := range a.Entries {
-		if aclEntry.Rule == nil || aclEntry.Rule.pathIFMatch(iface, ingress) {
+		if aclEntry.match(pm, i) {
 			return aclEntry.Action
 		}
 	}
 	panic("Default ACL action missing")
 }
 
+// ACLEntry is an entry of the ACL. At most one of Rule and Geo is set, if
+// none is set the entry matches all interfaces.
 type ACLEntry struct {
 	Action ACLAction
 	Rule   *HopPredicate
+	Geo    *GeoPredicate
 }
 
 func (ae *ACLEntry) LoadFromString(str string) error {
 	var err error
-	parts := strings.Split(str, " ")
-	if len(parts) == 1 {
-		ae.Action, err = getAction(parts[0])
+	parts := strings.SplitN(str, " ", 2)
+	// Geo predicates may contain spaces, e.g., in addresses.
+	if len(parts) == 2 && !IsGeoPredicate(parts[1]) && strings.Contains(parts[1], " ") {
+		return serrors.New("ACLEntry has too many parts", "str", str)
+	}
+	ae.Action, err = getAction(parts[0])
+	if err != nil || len(parts) == 1 {
 		return err
-	} else if len(parts) == 2 {
-		ae.Action, err = getAction(parts[0])
-		if err != nil {
-			return err
-		}
-		ae.Rule, err = HopPredicateFromString(parts[1])
+	}
+	if IsGeoPredicate(parts[1]) {
+		ae.Geo, err = GeoPredicateFromString(parts[1])
 		return err
 	}
-	return serrors.New("ACLEntry has too many parts", "str", str)
+	ae.Rule, err = HopPredicateFromString(parts[1])
+	return err
 }
 
 func (ae *ACLEntry) String() string {
@@ -109,12 +114,26 @@ func (ae *ACLEntry) String() string {
 	if ae.Action == Allow {
 		str = allowSymbol
 	}
-	if ae.Rule != nil {
+	if ae.Geo != nil {
+		str = str + " " + ae.Geo.String()
+	} else if ae.Rule != nil {
 		str = str + " " + ae.Rule.String()
 	}
 	return str
 }
 
+// match returns true if the entry matches interface i of the path.
+func (ae *ACLEntry) match(pm *snet.PathMetadata, i int) bool {
+	if ae.Geo != nil {
+		return ae.Geo.match(pm, i)
+	}
+	return ae.Rule == nil || ae.Rule.pathIFMatch(pm.Interfaces[i], i%2 != 0)
+}
+
+func (ae *ACLEntry) matchesAll() bool {
+	return ae.Geo == nil && ae.Rule.matchesAll()
+}
+
 func (ae *ACLEntry) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ae.String())
 }
@@ -122,8 +122,8 @@ func TestACLEntryString(t *testing.T) {
 	assert.Equal(t, aclEntryString, aclEntry.String())
 }
 
-var allowEntry = &ACLEntry{ACLAction(true), NewHopPredicate()}
-var denyEntry = &ACLEntry{ACLAction(false), NewHopPredicate()}
+var allowEntry = &ACLEntry{Action: Allow, Rule: NewHopPredicate()}
+var denyEntry = &ACLEntry{Action: Deny, Rule: NewHopPredicate()}
 
 func TestACLEval(t *testing.T) {
 	tests := map[string]struct {
@@ -0,0 +1,234 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	geoAddressPrefix = "addr="
+	geoNotePrefix    = "note="
+	geoAreaPrefix    = "geo="
+	geoUnknown       = "unknown"
+
+	// earthRadius is the mean radius of the earth in km.
+	earthRadius = 6371.0
+)
+
+// A GeoPredicate specifies a hop in the ACL of the path policy based on the
+// static information that the ASes disseminate in the beacons, see
+// docs/PathPolicy.md. Exactly one of the matching criteria is set.
+type GeoPredicate struct {
+	// Address matches hops whose router announces a civic address that
+	// contains the comma or space separated words of the value as whole
+	// words, ignoring case.
+	Address string
+	// Note matches hops in ASes that announce a note that contains the value,
+	// ignoring case.
+	Note string
+	// Area matches hops whose router is located in the area.
+	Area *GeoArea
+	// Unknown matches hops whose router does not announce a location.
+	Unknown bool
+}
+
+// GeoArea is a circular area on the surface of the earth.
+type GeoArea struct {
+	Latitude  float64
+	Longitude float64
+	// Radius is the radius of the area in km.
+	Radius float64
+}
+
+// IsGeoPredicate returns true if str is the string representation of a geo
+// predicate, as opposed to a hop predicate.
+func IsGeoPredicate(str string) bool {
+	return strings.HasPrefix(str, geoAddressPrefix) || strings.HasPrefix(str, geoNotePrefix) ||
+		strings.HasPrefix(str, geoAreaPrefix)
+}
+
+func GeoPredicateFromString(str string) (*GeoPredicate, error) {
+	switch {
+	case strings.HasPrefix(str, geoAddressPrefix):
+		value := strings.TrimPrefix(str, geoAddressPrefix)
+		if value == "" {
+			return nil, serrors.New("Failed to parse geo predicate, empty address", "value", str)
+		}
+		return &GeoPredicate{Address: value}, nil
+	case strings.HasPrefix(str, geoNotePrefix):
+		value := strings.TrimPrefix(str, geoNotePrefix)
+		if value == "" {
+			return nil, serrors.New("Failed to parse geo predicate, empty note", "value", str)
+		}
+		return &GeoPredicate{Note: value}, nil
+	case strings.HasPrefix(str, geoAreaPrefix):
+		value := strings.TrimPrefix(str, geoAreaPrefix)
+		if value == geoUnknown {
+			return &GeoPredicate{Unknown: true}, nil
+		}
+		area, err := parseGeoArea(value)
+		if err != nil {
+			return nil, serrors.WrapStr("Failed to parse geo predicate", err, "value", str)
+		}
+		return &GeoPredicate{Area: area}, nil
+	}
+	return nil, serrors.New("Failed to parse geo predicate, unknown type", "value", str)
+}
+
+// match returns true if the GeoPredicate matches interface i of the path.
+func (gp *GeoPredicate) match(pm *snet.PathMetadata, i int) bool {
+	if gp.Note != "" {
+		// Interface 0 belongs to AS 0, interfaces 2*k-1 and 2*k belong to AS k.
+		as := (i + 1) / 2
+		if as >= len(pm.Notes) {
+			return false
+		}
+		return containsFold(pm.Notes[as], gp.Note)
+	}
+	var geo snet.GeoCoordinates
+	if i < len(pm.Geo) {
+		geo = pm.Geo[i]
+	}
+	if geo == (snet.GeoCoordinates{}) {
+		return gp.Unknown
+	}
+	switch {
+	case gp.Address != "":
+		return containsTokens(geo.Address, gp.Address)
+	case gp.Area != nil:
+		return gp.Area.contains(geo)
+	}
+	return false
+}
+
+func (gp GeoPredicate) String() string {
+	switch {
+	case gp.Address != "":
+		return geoAddressPrefix + gp.Address
+	case gp.Note != "":
+		return geoNotePrefix + gp.Note
+	case gp.Area != nil:
+		return geoAreaPrefix + gp.Area.String()
+	}
+	return geoAreaPrefix + geoUnknown
+}
+
+func (gp *GeoPredicate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gp.String())
+}
+
+func (gp *GeoPredicate) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	ngp, err := GeoPredicateFromString(str)
+	if err != nil {
+		return err
+	}
+	*gp = *ngp
+	return nil
+}
+
+func (a GeoArea) String() string {
+	return fmt.Sprintf("%s,%s,%s", formatFloat(a.Latitude), formatFloat(a.Longitude),
+		formatFloat(a.Radius))
+}
+
+// contains returns true if the coordinates are within the area.
+func (a GeoArea) contains(geo snet.GeoCoordinates) bool {
+	return greatCircleDistance(a.Latitude, a.Longitude,
+		float64(geo.Latitude), float64(geo.Longitude)) <= a.Radius
+}
+
+func parseGeoArea(str string) (*GeoArea, error) {
+	parts := strings.Split(str, ",")
+	if len(parts) != 3 {
+		return nil, serrors.New("area must be of the form lat,long,radius")
+	}
+	var values [3]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	area := &GeoArea{Latitude: values[0], Longitude: values[1], Radius: values[2]}
+	if math.Abs(area.Latitude) > 90 || math.Abs(area.Longitude) > 180 {
+		return nil, serrors.New("coordinates out of range",
+			"latitude", area.Latitude, "longitude", area.Longitude)
+	}
+	if area.Radius < 0 {
+		return nil, serrors.New("radius must not be negative", "radius", area.Radius)
+	}
+	return area, nil
+}
+
+// greatCircleDistance returns the distance in km between two coordinates,
+// using the haversine formula.
+func greatCircleDistance(lat1, long1, lat2, long2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLong := toRad(lat2-lat1), toRad(long2-long1)
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Pow(math.Sin(dLong/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// containsTokens reports whether the comma or space separated tokens of substr
+// appear as consecutive whole tokens in s, ignoring case. Unlike containsFold,
+// "DE" does not match "Sweden" or "Dresden".
+func containsTokens(s, substr string) bool {
+	have, want := addressTokens(s), addressTokens(substr)
+	if len(want) == 0 {
+		return false
+	}
+	for i := 0; i+len(want) <= len(have); i++ {
+		match := true
+		for j := range want {
+			if have[i+j] != want[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func addressTokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestGeoPredicateFromString(t *testing.T) {
+	tests := map[string]struct {
+		String         string
+		Expected       *GeoPredicate
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"address": {
+			String:         "addr=Zürich, Switzerland",
+			Expected:       &GeoPredicate{Address: "Zürich, Switzerland"},
+			ErrorAssertion: assert.NoError,
+		},
+		"note": {
+			String:         "note=Anapaya",
+			Expected:       &GeoPredicate{Note: "Anapaya"},
+			ErrorAssertion: assert.NoError,
+		},
+		"area": {
+			String:         "geo=47.37,8.54,50",
+			Expected:       &GeoPredicate{Area: &GeoArea{47.37, 8.54, 50}},
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown": {
+			String:         "geo=unknown",
+			Expected:       &GeoPredicate{Unknown: true},
+			ErrorAssertion: assert.NoError,
+		},
+		"empty address": {
+			String:         "addr=",
+			ErrorAssertion: assert.Error,
+		},
+		"area missing radius": {
+			String:         "geo=47.37,8.54",
+			ErrorAssertion: assert.Error,
+		},
+		"area out of range": {
+			String:         "geo=91,8.54,50",
+			ErrorAssertion: assert.Error,
+		},
+		"area negative radius": {
+			String:         "geo=47.37,8.54,-1",
+			ErrorAssertion: assert.Error,
+		},
+		"hop predicate": {
+			String:         "1-ff00:0:110",
+			ErrorAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gp, err := GeoPredicateFromString(test.String)
+			test.ErrorAssertion(t, err)
+			assert.Equal(t, test.Expected, gp)
+			if err == nil {
+				assert.Equal(t, test.String, gp.String())
+			}
+		})
+	}
+}
+
+func TestACLEvalGeo(t *testing.T) {
+	zurich := snet.GeoCoordinates{Latitude: 47.37, Longitude: 8.54, Address: "Zürich, CH"}
+	bern := snet.GeoCoordinates{Latitude: 46.95, Longitude: 7.45, Address: "Bern, CH"}
+	paris := snet.GeoCoordinates{Latitude: 48.86, Longitude: 2.35, Address: "Paris, FR"}
+	dresden := snet.GeoCoordinates{Latitude: 51.05, Longitude: 13.74, Address: "Dresden, DE"}
+	stockholm := snet.GeoCoordinates{Latitude: 59.33, Longitude: 18.07, Address: "Stockholm,Sweden"}
+	moscow := snet.GeoCoordinates{Latitude: 55.76, Longitude: 37.62, Address: "Moscow Russia"}
+
+	viaParis := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, paris, paris, bern},
+		[]string{"", "transit operator", ""},
+	)
+	viaBern := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, bern, bern, bern},
+		[]string{"", "", ""},
+	)
+	unannounced := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, {}, {}, bern},
+		nil,
+	)
+	viaDresden := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, dresden, dresden, bern},
+		nil,
+	)
+	viaStockholm := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, stockholm, stockholm, bern},
+		nil,
+	)
+	viaMoscow := newGeoTestPath(
+		[]snet.GeoCoordinates{zurich, moscow, moscow, bern},
+		nil,
+	)
+	paths := []snet.Path{viaParis, viaBern, unannounced}
+	countries := []snet.Path{viaDresden, viaStockholm, viaMoscow}
+
+	tests := map[string]struct {
+		Entries  []string
+		Paths    []snet.Path
+		Expected []snet.Path
+	}{
+		"deny country": {
+			Entries:  []string{"- addr=fr", "+"},
+			Expected: []snet.Path{viaBern, unannounced},
+		},
+		"country code does not match inside words": {
+			Entries:  []string{"- addr=DE", "+"},
+			Paths:    countries,
+			Expected: []snet.Path{viaStockholm, viaMoscow},
+		},
+		"country code does not match inside country name": {
+			Entries:  []string{"- addr=US", "+"},
+			Paths:    countries,
+			Expected: countries,
+		},
+		"country name matches comma separated word": {
+			Entries:  []string{"- addr=sweden", "+"},
+			Paths:    countries,
+			Expected: []snet.Path{viaDresden, viaMoscow},
+		},
+		"multiple words match consecutive words": {
+			Entries:  []string{"- addr=moscow, russia", "+"},
+			Paths:    countries,
+			Expected: []snet.Path{viaDresden, viaStockholm},
+		},
+		"multiple words do not match partial words": {
+			Entries:  []string{"- addr=Dresden D", "+"},
+			Paths:    countries,
+			Expected: countries,
+		},
+		"deny country and unknown location": {
+			Entries:  []string{"- addr=FR", "- geo=unknown", "+"},
+			Expected: []snet.Path{viaBern},
+		},
+		"allow area only": {
+			Entries:  []string{"+ geo=47.37,8.54,150", "-"},
+			Expected: []snet.Path{viaBern},
+		},
+		"deny note": {
+			Entries:  []string{"- note=Operator", "+"},
+			Expected: []snet.Path{viaBern, unannounced},
+		},
+		"combined with hop predicates": {
+			Entries:  []string{"- geo=unknown", "+ 1", "-"},
+			Expected: []snet.Path{viaParis, viaBern},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var entries []*ACLEntry
+			for _, str := range test.Entries {
+				entry := &ACLEntry{}
+				require.NoError(t, entry.LoadFromString(str))
+				entries = append(entries, entry)
+			}
+			acl, err := NewACL(entries...)
+			require.NoError(t, err)
+			input := paths
+			if test.Paths != nil {
+				input = test.Paths
+			}
+			assert.Equal(t, test.Expected, acl.Eval(input))
+		})
+	}
+}
+
+func TestACLGeoJsonConversion(t *testing.T) {
+	acl := &ACL{
+		Entries: []*ACLEntry{
+			{Action: Deny, Geo: &GeoPredicate{Address: "Paris, FR"}},
+			{Action: Deny, Geo: &GeoPredicate{Area: &GeoArea{48.86, 2.35, 100}}},
+			{Action: Allow, Rule: mustHopPredicate(t, "1")},
+			denyEntry,
+		},
+	}
+	raw, err := json.Marshal(acl)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`["- addr=Paris, FR", "- geo=48.86,2.35,100", "+ 1-0#0", "- 0-0#0"]`, string(raw))
+	var parsed ACL
+	require.NoError(t, json.Unmarshal(raw, &parsed))
+	assert.Equal(t, acl, &parsed)
+}
+
+// newGeoTestPath creates a path 1-ff00:0:110 -> 1-ff00:0:111 -> 1-ff00:0:112
+// with the given geo information.
+func newGeoTestPath(geo []snet.GeoCoordinates, notes []string) snet.Path {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	transit := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	return snetpath.Path{
+		Dst: dst,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: src, ID: 1},
+				{IA: transit, ID: 2},
+				{IA: transit, ID: 3},
+				{IA: dst, ID: 4},
+			},
+			Geo:   geo,
+			Notes: notes,
+		},
+	}
+}
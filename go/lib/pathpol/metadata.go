@@ -0,0 +1,116 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// hasMetadataConstraints returns true if the policy has any quantitative
+// constraint on the path metadata.
+func (p *Policy) hasMetadataConstraints() bool {
+	return p.MaxLatency != nil || p.MinBandwidth != 0 || p.MaxHops != 0 ||
+		p.MinExpiry != nil || p.MinMTU != 0
+}
+
+// evalMetadata returns the paths that satisfy the quantitative constraints of
+// the policy. Paths for which a constrained value is not known, because the
+// ASes on the path did not announce it, are rejected.
+func (p *Policy) evalMetadata(paths []snet.Path, now time.Time) []snet.Path {
+	if !p.hasMetadataConstraints() {
+		return paths
+	}
+	result := []snet.Path{}
+	for _, path := range paths {
+		if pm := path.Metadata(); pm != nil && p.metadataAllowed(pm, now) {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func (p *Policy) metadataAllowed(pm *snet.PathMetadata, now time.Time) bool {
+	if p.MaxLatency != nil {
+		latency, ok := pathLatency(pm)
+		if !ok || latency > p.MaxLatency.Duration {
+			return false
+		}
+	}
+	if p.MinBandwidth != 0 {
+		bw, ok := pathBandwidth(pm)
+		if !ok || bw < p.MinBandwidth {
+			return false
+		}
+	}
+	if p.MaxHops != 0 && pathHops(pm) > p.MaxHops {
+		return false
+	}
+	if p.MinExpiry != nil && pm.Expiry.Sub(now) < p.MinExpiry.Duration {
+		return false
+	}
+	if p.MinMTU != 0 && pm.MTU < p.MinMTU {
+		return false
+	}
+	return true
+}
+
+// pathLatency returns the aggregate latency of the path. It returns false if
+// the latency of any hop is unknown.
+func pathLatency(pm *snet.PathMetadata) (time.Duration, bool) {
+	if len(pm.Interfaces) == 0 {
+		return 0, true
+	}
+	if len(pm.Latency) != len(pm.Interfaces)-1 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, l := range pm.Latency {
+		if l < 0 {
+			return 0, false
+		}
+		total += l
+	}
+	return total, true
+}
+
+// pathBandwidth returns the bottleneck bandwidth of the path in Kbit/s. It
+// returns false if the bandwidth of any hop is unknown. Paths without
+// interfaces are not constrained by any link, for them the bandwidth is
+// reported as the maximum value.
+func pathBandwidth(pm *snet.PathMetadata) (uint64, bool) {
+	if len(pm.Interfaces) == 0 {
+		return ^uint64(0), true
+	}
+	if len(pm.Bandwidth) != len(pm.Interfaces)-1 {
+		return 0, false
+	}
+	min := ^uint64(0)
+	for _, bw := range pm.Bandwidth {
+		if bw == 0 {
+			return 0, false
+		}
+		if bw < min {
+			min = bw
+		}
+	}
+	return min, true
+}
+
+// pathHops returns the number of ASes on the path.
+func pathHops(pm *snet.PathMetadata) int {
+	return len(pm.Interfaces)/2 + 1
+}
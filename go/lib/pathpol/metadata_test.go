@@ -0,0 +1,232 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPolicyMetadata(t *testing.T) {
+	fast := newMetadataTestPath(1, snet.PathMetadata{
+		MTU:       1400,
+		Expiry:    time.Now().Add(6 * time.Hour),
+		Latency:   []time.Duration{5 * time.Millisecond, 0, 10 * time.Millisecond},
+		Bandwidth: []uint64{1000000, 10000000, 1000000},
+	})
+	slow := newMetadataTestPath(2, snet.PathMetadata{
+		MTU:       1280,
+		Expiry:    time.Now().Add(10 * time.Minute),
+		Latency:   []time.Duration{50 * time.Millisecond, 0, 100 * time.Millisecond},
+		Bandwidth: []uint64{1000, 10000000, 1000000},
+	})
+	unannounced := newMetadataTestPath(3, snet.PathMetadata{
+		MTU:       1400,
+		Expiry:    time.Now().Add(6 * time.Hour),
+		Latency:   []time.Duration{5 * time.Millisecond, snet.LatencyUnset, 0},
+		Bandwidth: []uint64{1000000, 0, 1000000},
+	})
+	paths := []snet.Path{fast, slow, unannounced}
+
+	tests := map[string]struct {
+		Policy   *Policy
+		Expected []snet.Path
+	}{
+		"no constraints": {
+			Policy:   &Policy{},
+			Expected: paths,
+		},
+		"max latency": {
+			Policy:   &Policy{MaxLatency: &util.DurWrap{Duration: 20 * time.Millisecond}},
+			Expected: []snet.Path{fast},
+		},
+		"min bandwidth": {
+			Policy:   &Policy{MinBandwidth: 10000},
+			Expected: []snet.Path{fast},
+		},
+		"max hops": {
+			Policy:   &Policy{MaxHops: 3},
+			Expected: paths,
+		},
+		"max hops too low": {
+			Policy:   &Policy{MaxHops: 2},
+			Expected: []snet.Path{},
+		},
+		"min expiry": {
+			Policy:   &Policy{MinExpiry: &util.DurWrap{Duration: time.Hour}},
+			Expected: []snet.Path{fast, unannounced},
+		},
+		"min mtu": {
+			Policy:   &Policy{MinMTU: 1300},
+			Expected: []snet.Path{fast, unannounced},
+		},
+		"fall back to option without latency constraint": {
+			Policy: NewPolicy("", nil, nil, []Option{
+				{
+					Weight: 1,
+					Policy: &ExtPolicy{Policy: &Policy{
+						MaxLatency: &util.DurWrap{Duration: time.Millisecond},
+					}},
+				},
+				{
+					Policy: &ExtPolicy{Policy: &Policy{MinMTU: 1300}},
+				},
+			}),
+			Expected: []snet.Path{fast, unannounced},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Policy.Filter(paths))
+		})
+	}
+}
+
+func TestPolicyMetadataExtends(t *testing.T) {
+	extended := []*ExtPolicy{
+		{
+			Policy: &Policy{
+				Name:         "base",
+				MaxLatency:   &util.DurWrap{Duration: 100 * time.Millisecond},
+				MinBandwidth: 1000,
+				MaxHops:      5,
+			},
+		},
+	}
+	pol, err := PolicyFromExtPolicy(&ExtPolicy{
+		Extends: []string{"base"},
+		Policy:  &Policy{MaxHops: 3, MinMTU: 1280},
+	}, extended)
+	require.NoError(t, err)
+	assert.Equal(t, &util.DurWrap{Duration: 100 * time.Millisecond}, pol.MaxLatency)
+	assert.Equal(t, uint64(1000), pol.MinBandwidth)
+	assert.Equal(t, 3, pol.MaxHops)
+	assert.Nil(t, pol.MinExpiry)
+	assert.Equal(t, uint16(1280), pol.MinMTU)
+}
+
+func TestPolicyMetadataJsonConversion(t *testing.T) {
+	policy := &Policy{
+		MaxLatency:   &util.DurWrap{Duration: 100 * time.Millisecond},
+		MinBandwidth: 1000,
+		MaxHops:      4,
+		MinExpiry:    &util.DurWrap{Duration: time.Hour},
+		MinMTU:       1280,
+	}
+	raw, err := json.Marshal(policy)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"max_latency": "100ms", "min_bandwidth": 1000, "max_hops": 4,
+		"min_expiry": "1h", "min_mtu": 1280}`, string(raw))
+	var parsed Policy
+	require.NoError(t, json.Unmarshal(raw, &parsed))
+	assert.Equal(t, policy, &parsed)
+}
+
+func TestParsePolicyYaml(t *testing.T) {
+	tests := map[string]struct {
+		Input          string
+		Expected       *Policy
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"yaml": {
+			Input: `
+acl:
+- "- addr=Germany"
+- "+"
+max_latency: 150ms
+min_mtu: 1280
+options:
+- weight: 1
+  policy:
+    min_bandwidth: 100000
+- weight: 2
+  policy:
+    max_hops: 3
+`,
+			Expected: &Policy{
+				ACL: &ACL{Entries: []*ACLEntry{
+					{Action: Deny, Geo: &GeoPredicate{Address: "Germany"}},
+					{Action: Allow},
+				}},
+				MaxLatency: &util.DurWrap{Duration: 150 * time.Millisecond},
+				MinMTU:     1280,
+				Options: []Option{
+					{Weight: 2, Policy: &ExtPolicy{Policy: &Policy{MaxHops: 3}}},
+					{Weight: 1, Policy: &ExtPolicy{Policy: &Policy{MinBandwidth: 100000}}},
+				},
+			},
+			ErrorAssertion: assert.NoError,
+		},
+		"json": {
+			Input:          `{"max_hops": 4, "min_expiry": "2h"}`,
+			Expected:       &Policy{MaxHops: 4, MinExpiry: &util.DurWrap{Duration: 2 * time.Hour}},
+			ErrorAssertion: assert.NoError,
+		},
+		"unknown attribute": {
+			Input:          `max_latncy: 150ms`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid duration": {
+			Input:          `max_latency: fast`,
+			ErrorAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pol, err := ParsePolicyYaml([]byte(test.Input))
+			test.ErrorAssertion(t, err)
+			assert.Equal(t, test.Expected, pol)
+		})
+	}
+}
+
+func TestLoadPolicyFromYaml(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "policy.yml")
+	require.NoError(t, os.WriteFile(file, []byte("max_hops: 4\n"), 0644))
+	pol, err := LoadPolicyFromYaml(file)
+	require.NoError(t, err)
+	assert.Equal(t, &Policy{MaxHops: 4}, pol)
+
+	_, err = LoadPolicyFromYaml(filepath.Join(dir, "missing.yml"))
+	assert.Error(t, err)
+}
+
+// newMetadataTestPath creates a path 1-ff00:0:110 -> 1-ff00:0:111 -> 1-ff00:0:112
+// over the link with the given ID to the transit AS, with the given metadata.
+func newMetadataTestPath(id common.IFIDType, meta snet.PathMetadata) snet.Path {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	transit := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	meta.Interfaces = []snet.PathInterface{
+		{IA: src, ID: id},
+		{IA: transit, ID: id},
+		{IA: transit, ID: 3},
+		{IA: dst, ID: 4},
+	}
+	return snetpath.Path{Dst: dst, Meta: meta}
+}
@@ -21,10 +21,17 @@
 package pathpol
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
 	"sort"
+	"time"
+
+	"github.com/ghodss/yaml"
 
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 // ExtPolicy is an extending policy, it may have a list of policies it extends
@@ -52,6 +59,16 @@ type Policy struct {
 	LocalISDAS  *LocalISDAS  `json:"local_isd_ases,omitempty"`
 	RemoteISDAS *RemoteISDAS `json:"remote_isd_ases,omitempty"`
 	Options     []Option     `json:"options,omitempty"`
+	// MaxLatency is the maximum aggregate latency of the path.
+	MaxLatency *util.DurWrap `json:"max_latency,omitempty"`
+	// MinBandwidth is the minimum bottleneck bandwidth of the path, in Kbit/s.
+	MinBandwidth uint64 `json:"min_bandwidth,omitempty"`
+	// MaxHops is the maximum number of ASes on the path.
+	MaxHops int `json:"max_hops,omitempty"`
+	// MinExpiry is the minimum remaining lifetime of the path.
+	MinExpiry *util.DurWrap `json:"min_expiry,omitempty"`
+	// MinMTU is the minimum MTU of the path, in bytes.
+	MinMTU uint16 `json:"min_mtu,omitempty"`
 }
 
 // NewPolicy creates a Policy and sorts its Options
@@ -81,6 +98,7 @@ func (p *Policy) FilterOpt(paths []snet.Path, opts FilterOptions) []snet.Path {
 	if p.RemoteISDAS != nil {
 		paths = p.RemoteISDAS.Eval(paths)
 	}
+	paths = p.evalMetadata(paths, time.Now())
 	paths = p.ACL.Eval(paths)
 	if p.Sequence != nil && !opts.IgnoreSequence {
 		paths = p.Sequence.Eval(paths)
@@ -105,6 +123,34 @@ func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy,
 	return policy, nil
 }
 
+// ParsePolicyYaml parses a single policy in YAML or JSON format.
+func ParsePolicyYaml(b []byte) (*Policy, error) {
+	raw, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, serrors.WrapStr("Unable to parse policy", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	p := &Policy{}
+	if err := dec.Decode(p); err != nil {
+		return nil, serrors.WrapStr("Unable to parse policy", err)
+	}
+	// Sort Options by weight, descending
+	sort.Slice(p.Options, func(i, j int) bool {
+		return p.Options[i].Weight > p.Options[j].Weight
+	})
+	return p, nil
+}
+
+// LoadPolicyFromYaml loads a single policy from a file in YAML or JSON format.
+func LoadPolicyFromYaml(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, serrors.WrapStr("Unable to read policy file", err, "path", path)
+	}
+	return ParsePolicyYaml(b)
+}
+
 // applyExtended adds attributes of extended policies to the extending policy if they are not
 // already set
 func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error {
@@ -145,6 +191,22 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		if p.RemoteISDAS == nil {
 			p.RemoteISDAS = policy.RemoteISDAS
 		}
+		// Replace quantitative constraints.
+		if p.MaxLatency == nil {
+			p.MaxLatency = policy.MaxLatency
+		}
+		if p.MinBandwidth == 0 {
+			p.MinBandwidth = policy.MinBandwidth
+		}
+		if p.MaxHops == 0 {
+			p.MaxHops = policy.MaxHops
+		}
+		if p.MinExpiry == nil {
+			p.MinExpiry = policy.MinExpiry
+		}
+		if p.MinMTU == 0 {
+			p.MinMTU = policy.MinMTU
+		}
 	}
 	return nil
 }
@@ -0,0 +1,173 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// maxSetCandidates is the maximum number of complete candidate sets that
+// SetPolicy.Select evaluates before giving up.
+const maxSetCandidates = 10000
+
+// SetPolicy is a policy over a set of paths that are used together, e.g., to
+// send the shares of a secret over disjoint paths. In contrast to a Policy,
+// which judges every path on its own, a SetPolicy judges the set as a whole.
+//
+// Transit ASes are the ASes of a path except the first and the last one. Paths
+// without interface metadata have no transit ASes.
+type SetPolicy struct {
+	// MaxASOverlap is the maximum number of paths in the set that any transit
+	// AS may appear on. If zero, the overlap is not limited.
+	MaxASOverlap int `json:"max_as_overlap,omitempty"`
+	// MinTransitISDs is the minimum number of distinct ISDs the transit ASes
+	// of the paths in the set belong to. If zero, the number is not limited.
+	MinTransitISDs int `json:"min_transit_isds,omitempty"`
+}
+
+// Eval returns whether the set of paths satisfies the policy.
+func (p *SetPolicy) Eval(paths []snet.Path) bool {
+	if p == nil {
+		return true
+	}
+	s := newSetState(p)
+	for _, path := range paths {
+		if !s.add(transitIAs(path)) {
+			return false
+		}
+	}
+	return s.complete()
+}
+
+// Select returns the first set of n paths that satisfies the policy. The sets
+// are considered in lexicographic order of the indices of their paths, i.e.,
+// paths that come first are preferred. Select returns nil if no set satisfies
+// the policy, or if none was found after evaluating a bounded number of sets.
+func (p *SetPolicy) Select(paths []snet.Path, n int) []snet.Path {
+	if n <= 0 || len(paths) < n {
+		return nil
+	}
+	if p == nil {
+		return paths[:n]
+	}
+	transits := make([][]addr.IA, 0, len(paths))
+	for _, path := range paths {
+		transits = append(transits, transitIAs(path))
+	}
+	s := newSetState(p)
+	selected := make([]int, 0, n)
+	budget := maxSetCandidates
+	var search func(start int) bool
+	search = func(start int) bool {
+		if len(selected) == n {
+			budget--
+			return s.complete()
+		}
+		for i := start; i <= len(paths)-(n-len(selected)) && budget > 0; i++ {
+			// The overlap can only grow, so there is no need to extend a set
+			// that already violates the policy.
+			if s.add(transits[i]) {
+				selected = append(selected, i)
+				if search(i + 1) {
+					return true
+				}
+				selected = selected[:len(selected)-1]
+			}
+			s.remove(transits[i])
+		}
+		return false
+	}
+	if !search(0) {
+		return nil
+	}
+	result := make([]snet.Path, 0, n)
+	for _, i := range selected {
+		result = append(result, paths[i])
+	}
+	return result
+}
+
+// setState tracks the transit ASes of a set of paths.
+type setState struct {
+	policy *SetPolicy
+	// ases counts the paths each transit AS appears on.
+	ases map[addr.IA]int
+	// isds counts the transit ASes of each ISD, over all paths.
+	isds map[addr.ISD]int
+}
+
+func newSetState(policy *SetPolicy) *setState {
+	return &setState{
+		policy: policy,
+		ases:   make(map[addr.IA]int),
+		isds:   make(map[addr.ISD]int),
+	}
+}
+
+// add adds the transit ASes of a path to the set. It returns false if the
+// AS overlap of the set exceeds the limit.
+func (s *setState) add(transit []addr.IA) bool {
+	ok := true
+	for _, ia := range transit {
+		s.ases[ia]++
+		s.isds[ia.ISD()]++
+		if s.policy.MaxASOverlap > 0 && s.ases[ia] > s.policy.MaxASOverlap {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// remove removes the transit ASes of a path from the set.
+func (s *setState) remove(transit []addr.IA) {
+	for _, ia := range transit {
+		if s.ases[ia]--; s.ases[ia] == 0 {
+			delete(s.ases, ia)
+		}
+		if s.isds[ia.ISD()]--; s.isds[ia.ISD()] == 0 {
+			delete(s.isds, ia.ISD())
+		}
+	}
+}
+
+// complete returns whether the set satisfies the constraints that can only be
+// checked for the complete set.
+func (s *setState) complete() bool {
+	return len(s.isds) >= s.policy.MinTransitISDs
+}
+
+// transitIAs returns the distinct transit ASes of the path.
+func transitIAs(path snet.Path) []addr.IA {
+	meta := path.Metadata()
+	if meta == nil || len(meta.Interfaces) < 2 {
+		return nil
+	}
+	first, last := meta.Interfaces[0].IA, meta.Interfaces[len(meta.Interfaces)-1].IA
+	var result []addr.IA
+	seen := make(map[addr.IA]struct{})
+	for _, intf := range meta.Interfaces {
+		ia := intf.IA
+		if ia.Equal(first) || ia.Equal(last) {
+			continue
+		}
+		if _, ok := seen[ia]; ok {
+			continue
+		}
+		seen[ia] = struct{}{}
+		result = append(result, ia)
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSetPolicyEval(t *testing.T) {
+	viaA := newSetTestPath("1-ff00:0:110", "1-ff00:0:120", "1-ff00:0:130")
+	viaB := newSetTestPath("1-ff00:0:110", "1-ff00:0:121", "1-ff00:0:130")
+	viaAB := newSetTestPath("1-ff00:0:110", "1-ff00:0:120", "1-ff00:0:121",
+		"1-ff00:0:130")
+	viaC := newSetTestPath("1-ff00:0:110", "2-ff00:0:210", "1-ff00:0:130")
+	direct := newSetTestPath("1-ff00:0:110", "1-ff00:0:130")
+
+	tests := map[string]struct {
+		Policy *SetPolicy
+		Paths  []snet.Path
+		Exp    bool
+	}{
+		"nil policy": {
+			Paths: []snet.Path{viaA, viaA},
+			Exp:   true,
+		},
+		"empty policy": {
+			Policy: &SetPolicy{},
+			Paths:  []snet.Path{viaA, viaA},
+			Exp:    true,
+		},
+		"disjoint paths": {
+			Policy: &SetPolicy{MaxASOverlap: 1},
+			Paths:  []snet.Path{viaA, viaB, direct},
+			Exp:    true,
+		},
+		"overlapping paths": {
+			Policy: &SetPolicy{MaxASOverlap: 1},
+			Paths:  []snet.Path{viaA, viaAB},
+			Exp:    false,
+		},
+		"overlap within limit": {
+			Policy: &SetPolicy{MaxASOverlap: 2},
+			Paths:  []snet.Path{viaA, viaB, viaAB},
+			Exp:    true,
+		},
+		"transit ISDs": {
+			Policy: &SetPolicy{MinTransitISDs: 2},
+			Paths:  []snet.Path{viaA, viaC},
+			Exp:    true,
+		},
+		"too few transit ISDs": {
+			Policy: &SetPolicy{MinTransitISDs: 2},
+			Paths:  []snet.Path{viaA, viaB, direct},
+			Exp:    false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Exp, test.Policy.Eval(test.Paths))
+		})
+	}
+}
+
+func TestSetPolicySelect(t *testing.T) {
+	viaA := newSetTestPath("1-ff00:0:110", "1-ff00:0:120", "1-ff00:0:130")
+	viaA2 := newSetTestPath("1-ff00:0:110", "1-ff00:0:120", "1-ff00:0:122",
+		"1-ff00:0:130")
+	viaB := newSetTestPath("1-ff00:0:110", "1-ff00:0:121", "1-ff00:0:130")
+	viaC := newSetTestPath("1-ff00:0:110", "2-ff00:0:210", "1-ff00:0:130")
+
+	tests := map[string]struct {
+		Policy *SetPolicy
+		Paths  []snet.Path
+		N      int
+		Exp    []snet.Path
+	}{
+		"nil policy takes the first paths": {
+			Paths: []snet.Path{viaA, viaA2, viaB},
+			N:     2,
+			Exp:   []snet.Path{viaA, viaA2},
+		},
+		"skips overlapping path": {
+			Policy: &SetPolicy{MaxASOverlap: 1},
+			Paths:  []snet.Path{viaA, viaA2, viaB},
+			N:      2,
+			Exp:    []snet.Path{viaA, viaB},
+		},
+		"prefers earlier paths": {
+			Policy: &SetPolicy{MinTransitISDs: 2},
+			Paths:  []snet.Path{viaA, viaA2, viaB, viaC},
+			N:      3,
+			Exp:    []snet.Path{viaA, viaA2, viaC},
+		},
+		"combined constraints": {
+			Policy: &SetPolicy{MaxASOverlap: 1, MinTransitISDs: 2},
+			Paths:  []snet.Path{viaA, viaA2, viaB, viaC},
+			N:      3,
+			Exp:    []snet.Path{viaA, viaB, viaC},
+		},
+		"unsatisfiable": {
+			Policy: &SetPolicy{MaxASOverlap: 1},
+			Paths:  []snet.Path{viaA, viaA2, viaB},
+			N:      3,
+		},
+		"not enough paths": {
+			Paths: []snet.Path{viaA},
+			N:     2,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Exp, test.Policy.Select(test.Paths, test.N))
+		})
+	}
+}
+
+func TestSetPolicyJsonConversion(t *testing.T) {
+	raw := []byte(`{"max_as_overlap": 1, "min_transit_isds": 2}`)
+	var pol SetPolicy
+	require.NoError(t, json.Unmarshal(raw, &pol))
+	assert.Equal(t, SetPolicy{MaxASOverlap: 1, MinTransitISDs: 2}, pol)
+	out, err := json.Marshal(pol)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(raw), string(out))
+}
+
+func newSetTestPath(ias ...string) snet.Path {
+	var intfs []snet.PathInterface
+	for i, ia := range ias {
+		parsed := xtest.MustParseIA(ia)
+		if i != 0 {
+			intfs = append(intfs, snet.PathInterface{IA: parsed})
+		}
+		if i != len(ias)-1 {
+			intfs = append(intfs, snet.PathInterface{IA: parsed})
+		}
+	}
+	return snetpath.Path{
+		Dst:  xtest.MustParseIA(ias[len(ias)-1]),
+		Meta: snet.PathMetadata{Interfaces: intfs},
+	}
+}
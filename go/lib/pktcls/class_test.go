@@ -73,6 +73,29 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 					"classC",
 					pktcls.NewCondAllOf(),
 				),
+				"dual-stack": pktcls.NewClass(
+					"dual-stack",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("fd00::"),
+								Mask: net.CIDRMask(8, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0xfffff}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+						pktcls.NewCondL4(&pktcls.TCPMatchFlags{Flags: pktcls.TCPFlagSYN}),
+						pktcls.NewCondL4(&pktcls.ICMPMatchType{ICMPType: 8}),
+						pktcls.NewCondL4(&pktcls.ICMPv6MatchType{ICMPType: 128}),
+					),
+				),
 			},
 		},
 		{
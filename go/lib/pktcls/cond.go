@@ -21,6 +21,8 @@ import (
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/slayers"
 )
 
 // Cond is used to decide which objects match a logical predicate. Types implementing Cond
@@ -226,6 +228,52 @@ func (c *CondIPv4) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondIPv6)(nil)
+
+// CondIPv6 conditions return true if the embedded IPv6 predicate returns true.
+type CondIPv6 struct {
+	Predicate IPv6Predicate
+}
+
+func NewCondIPv6(p IPv6Predicate) *CondIPv6 {
+	return &CondIPv6{Predicate: p}
+}
+
+func (c *CondIPv6) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	if v.LayerType() != layers.LayerTypeIPv6 {
+		return false
+	}
+	p, ok := v.(*layers.IPv6)
+	if !ok {
+		return false
+	}
+	return c.Predicate.Eval(p)
+}
+
+func (c *CondIPv6) Type() string {
+	return TypeCondIPv6
+}
+
+func (c *CondIPv6) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondIPv6) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondIPv6) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalIPv6Predicate(b)
+	return err
+}
+
 var _ Cond = (*CondPorts)(nil)
 
 // CondPorts conditions return true if the embedded port predicate returns true.
@@ -243,35 +291,21 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 	}
 	// Port predicates are independent on particular L3 or L4 protocol.
 	// Here we extract the ports and pass them to the embedded predicate.
-	l3 := v.LayerType()
-	if l3 != layers.LayerTypeIPv4 {
-		return false
-	}
-	ipv4, ok := v.(*layers.IPv4)
-	if !ok {
-		return false
-	}
-
-	switch ipv4.NextLayerType() {
-	case layers.LayerTypeUDP:
-		udp := &layers.UDP{}
-		err := udp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
-		if err != nil {
-			return false
-		}
+	switch l4 := decodeL4(v).(type) {
+	case *layers.UDP:
 		return c.Predicate.Eval(&Ports{
-			Src: uint16(udp.SrcPort),
-			Dst: uint16(udp.DstPort),
+			Src: uint16(l4.SrcPort),
+			Dst: uint16(l4.DstPort),
 		})
-	case layers.LayerTypeTCP:
-		tcp := &layers.TCP{}
-		err := tcp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
-		if err != nil {
-			return false
-		}
+	case *layers.TCP:
 		return c.Predicate.Eval(&Ports{
-			Src: uint16(tcp.SrcPort),
-			Dst: uint16(tcp.DstPort),
+			Src: uint16(l4.SrcPort),
+			Dst: uint16(l4.DstPort),
+		})
+	case *slayers.UDP:
+		return c.Predicate.Eval(&Ports{
+			Src: l4.SrcPort,
+			Dst: l4.DstPort,
 		})
 	default:
 		return false
@@ -299,6 +333,93 @@ func (c *CondPorts) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondL4)(nil)
+
+// CondL4 conditions return true if the embedded L4 predicate returns true.
+type CondL4 struct {
+	Predicate L4Predicate
+}
+
+func NewCondL4(p L4Predicate) *CondL4 {
+	return &CondL4{Predicate: p}
+}
+
+func (c *CondL4) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	l4 := decodeL4(v)
+	if l4 == nil {
+		return false
+	}
+	return c.Predicate.Eval(l4)
+}
+
+func (c *CondL4) Type() string {
+	return TypeCondL4
+}
+
+func (c *CondL4) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondL4) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondL4) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalL4Predicate(b)
+	return err
+}
+
+// decodeL4 decodes the UDP, TCP, ICMPv4 or ICMPv6 layer directly following the
+// IPv4 or IPv6 layer v. For IPv6, only the hop-by-hop extension header is
+// skipped. If v is a SCION layer or a SCION extension, the SCION/UDP or SCMP
+// layer following it is decoded. It returns nil if v is not an IP or SCION
+// layer, if the next layer is of a different type, or if decoding fails.
+func decodeL4(v gopacket.Layer) gopacket.Layer {
+	var next gopacket.LayerType
+	switch l3 := v.(type) {
+	case *layers.IPv4:
+		next = l3.NextLayerType()
+	case *layers.IPv6:
+		next = l3.NextLayerType()
+	case *slayers.SCION:
+		next = l3.NextLayerType()
+	case *slayers.HopByHopExtn:
+		next = l3.NextLayerType()
+	case *slayers.EndToEndExtn:
+		next = l3.NextLayerType()
+	default:
+		return nil
+	}
+	var l4 gopacket.DecodingLayer
+	switch next {
+	case layers.LayerTypeUDP:
+		l4 = &layers.UDP{}
+	case layers.LayerTypeTCP:
+		l4 = &layers.TCP{}
+	case layers.LayerTypeICMPv4:
+		l4 = &layers.ICMPv4{}
+	case layers.LayerTypeICMPv6:
+		l4 = &layers.ICMPv6{}
+	case slayers.LayerTypeSCIONUDP:
+		l4 = &slayers.UDP{}
+	case slayers.LayerTypeSCMP:
+		l4 = &slayers.SCMP{}
+	default:
+		return nil
+	}
+	if err := l4.DecodeFromBytes(v.LayerPayload(), gopacket.NilDecodeFeedback); err != nil {
+		return nil
+	}
+	return l4.(gopacket.Layer)
+}
+
 const typeCondClass = "CondClass"
 
 // CondClass conditions return true if the embedded traffic class returns true
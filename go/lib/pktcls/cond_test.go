@@ -22,8 +22,13 @@ import (
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestBasicCond(t *testing.T) {
@@ -155,6 +160,60 @@ func TestIPCond(t *testing.T) {
 			},
 			ExpEval: false,
 		},
+		{
+			Name: "Match IPv6 destination",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDestination{
+					Net: &net.IPNet{
+						IP:   net.ParseIP("2001:db8::"),
+						Mask: net.CIDRMask(32, 128),
+					},
+				},
+			),
+			Packet: &layers.IPv6{
+				SrcIP: net.ParseIP("2001:db9::1"),
+				DstIP: net.ParseIP("2001:db8::1"),
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "IPv6 cond does not match IPv4 packet",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{
+					Net: &net.IPNet{
+						IP:   net.IPv6zero,
+						Mask: net.CIDRMask(0, 128),
+					},
+				},
+			),
+			Packet: &layers.IPv4{
+				SrcIP: net.IP{192, 168, 1, 1},
+				DstIP: net.IP{10, 0, 0, 2},
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 traffic class and flow label",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0xbeef}),
+			),
+			Packet: &layers.IPv6{
+				TrafficClass: 0xb8,
+				FlowLabel:    0xbeef,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match IPv6 next header",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchNextHeader{NextHeader: uint8(layers.IPProtocolTCP)},
+			),
+			Packet: &layers.IPv6{
+				NextHeader: layers.IPProtocolUDP,
+			},
+			ExpEval: false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -204,6 +263,101 @@ func TestPortCond(t *testing.T) {
 			pkt := createUDPPacket(tc.SrcPort, tc.DstPort)
 			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
 		})
+		t.Run(name+" IPv6", func(t *testing.T) {
+			t.Parallel()
+			pkt := decodeIP(t, newIPv6(layers.IPProtocolUDP), &layers.UDP{
+				SrcPort: layers.UDPPort(tc.SrcPort),
+				DstPort: layers.UDPPort(tc.DstPort),
+			})
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
+		})
+		t.Run(name+" SCION", func(t *testing.T) {
+			t.Parallel()
+			pkt := decodeSCION(t, common.L4UDP, &slayers.UDP{
+				SrcPort: tc.SrcPort,
+				DstPort: tc.DstPort,
+			})
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
+		})
+	}
+}
+
+func TestL4Cond(t *testing.T) {
+	synAck := &layers.TCP{SrcPort: 443, DstPort: 10000, SYN: true, ACK: true, Window: 1024}
+	testCases := map[string]struct {
+		Cond    pktcls.Cond
+		Packet  func(t *testing.T) gopacket.Layer
+		ExpEval bool
+	}{
+		"Match TCP SYN in SYN-ACK": {
+			Cond: pktcls.NewCondL4(&pktcls.TCPMatchFlags{Flags: pktcls.TCPFlagSYN}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeIP(t, newIPv4(layers.IPProtocolTCP), synAck)
+			},
+			ExpEval: true,
+		},
+		"Do not match TCP SYN-RST in SYN-ACK over IPv6": {
+			Cond: pktcls.NewCondL4(&pktcls.TCPMatchFlags{
+				Flags: pktcls.TCPFlagSYN | pktcls.TCPFlagRST,
+			}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeIP(t, newIPv6(layers.IPProtocolTCP), synAck)
+			},
+			ExpEval: false,
+		},
+		"Do not match TCP flags on UDP": {
+			Cond: pktcls.NewCondL4(&pktcls.TCPMatchFlags{}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return createUDPPacket(1, 2)
+			},
+			ExpEval: false,
+		},
+		"Do not match TCP flags on SCMP": {
+			Cond: pktcls.NewCondL4(&pktcls.TCPMatchFlags{}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeSCION(t, common.L4SCMP, &slayers.SCMP{
+					TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeEchoRequest, 0),
+				})
+			},
+			ExpEval: false,
+		},
+		"Match ICMP echo request": {
+			Cond: pktcls.NewCondL4(&pktcls.ICMPMatchType{ICMPType: layers.ICMPv4TypeEchoRequest}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeIP(t, newIPv4(layers.IPProtocolICMPv4), &layers.ICMPv4{
+					TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0),
+				})
+			},
+			ExpEval: true,
+		},
+		"Match ICMPv6 echo request": {
+			Cond: pktcls.NewCondL4(
+				&pktcls.ICMPv6MatchType{ICMPType: layers.ICMPv6TypeEchoRequest},
+			),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeIP(t, newIPv6(layers.IPProtocolICMPv6), &layers.ICMPv6{
+					TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeEchoRequest, 0),
+				})
+			},
+			ExpEval: true,
+		},
+		"Do not match ICMPv4 type on ICMPv6": {
+			Cond: pktcls.NewCondL4(&pktcls.ICMPMatchType{ICMPType: layers.ICMPv6TypeEchoRequest}),
+			Packet: func(t *testing.T) gopacket.Layer {
+				return decodeIP(t, newIPv6(layers.IPProtocolICMPv6), &layers.ICMPv6{
+					TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeEchoRequest, 0),
+				})
+			},
+			ExpEval: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(tc.Packet(t)))
+		})
 	}
 }
 
@@ -237,7 +391,85 @@ func createUDPPacket(src, dst uint16) gopacket.Layer {
 	return pkt
 }
 
+func newIPv4(proto layers.IPProtocol) *layers.IPv4 {
+	return &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		SrcIP:    net.IP{192, 168, 14, 3},
+		DstIP:    net.IP{192, 168, 14, 2},
+		Protocol: proto,
+	}
+}
+
+func newIPv6(nextHdr layers.IPProtocol) *layers.IPv6 {
+	return &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		SrcIP:      net.ParseIP("2001:db8::3"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+		NextHeader: nextHdr,
+	}
+}
+
+// decodeIP serializes the IP layer followed by l4 and a short payload, and
+// returns the decoded IP layer.
+func decodeIP(t *testing.T, ip gopacket.NetworkLayer,
+	l4 gopacket.SerializableLayer) gopacket.Layer {
+
+	t.Helper()
+	if c, ok := l4.(interface {
+		SetNetworkLayerForChecksum(gopacket.NetworkLayer) error
+	}); ok {
+		require.NoError(t, c.SetNetworkLayerForChecksum(ip))
+	}
+	buf := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, options, ip.(gopacket.SerializableLayer), l4,
+		gopacket.Payload("payload"))
+	require.NoError(t, err)
+	pkt := gopacket.NewPacket(buf.Bytes(), ip.LayerType(), gopacket.Default)
+	require.NotNil(t, pkt.NetworkLayer())
+	return pkt.NetworkLayer()
+}
+
+// decodeSCION serializes a SCION header with an empty path followed by l4 and
+// a short payload, and returns the decoded SCION layer.
+func decodeSCION(t *testing.T, nextHdr common.L4ProtocolType,
+	l4 gopacket.SerializableLayer) gopacket.Layer {
+
+	t.Helper()
+	scn := &slayers.SCION{
+		PathType: empty.PathType,
+		Path:     empty.Path{},
+	}
+	scn.NextHdr = nextHdr
+	scn.DstIA = xtest.MustParseIA("1-ff00:0:110")
+	scn.SrcIA = xtest.MustParseIA("1-ff00:0:111")
+	require.NoError(t, scn.SetDstAddr(&net.IPAddr{IP: net.IP{192, 168, 14, 2}}))
+	require.NoError(t, scn.SetSrcAddr(&net.IPAddr{IP: net.IP{192, 168, 14, 3}}))
+	if c, ok := l4.(interface {
+		SetNetworkLayerForChecksum(gopacket.NetworkLayer) error
+	}); ok {
+		require.NoError(t, c.SetNetworkLayerForChecksum(scn))
+	}
+	buf := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, options, scn, l4, gopacket.Payload("payload"))
+	require.NoError(t, err)
+	var pkt slayers.SCION
+	require.NoError(t, pkt.DecodeFromBytes(buf.Bytes(), gopacket.NilDecodeFeedback))
+	return &pkt
+}
+
 func TestStringer(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	tests := map[string]struct {
 		Cond pktcls.Cond
@@ -251,6 +483,22 @@ func TestStringer(t *testing.T) {
 				pktcls.NewCondNot(pktcls.NewCondNot(pktcls.CondTrue)),
 			),
 		},
+		"IPv6 and L4": {
+			Str: "any(dst=2001:db8::/32,tc=0xb8,flowlabel=42,nexthdr=TCP,nexthdr=58," +
+				"all(tcpflags=0x2,icmptype=8,icmp6type=128))",
+			Cond: pktcls.CondAnyOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 42}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+				pktcls.CondAllOf{
+					pktcls.NewCondL4(&pktcls.TCPMatchFlags{Flags: pktcls.TCPFlagSYN}),
+					pktcls.NewCondL4(&pktcls.ICMPMatchType{ICMPType: 8}),
+					pktcls.NewCondL4(&pktcls.ICMPv6MatchType{ICMPType: 128}),
+				},
+			},
+		},
 		"ANY ALL NOT src dst dscp tos": {
 			Str: "any(dscp=0x2,all(dst=12.12.12.0/26,tos=0x2,not(src=12.12.12.0/26)))",
 			Cond: pktcls.CondAnyOf{
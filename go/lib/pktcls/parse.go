@@ -125,6 +125,63 @@ func (l *classListener) EnterMatchProtocol(ctx *traffic_class.MatchProtocolConte
 	l.pushCond(NewCondIPv4(prot))
 }
 
+func (l *classListener) EnterMatchSrc6(ctx *traffic_class.MatchSrc6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	msrc := &IPv6MatchSource{}
+	msrc.Net, err = parseIPv6Net(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(msrc))
+}
+
+func (l *classListener) EnterMatchDst6(ctx *traffic_class.MatchDst6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	mdst := &IPv6MatchDestination{}
+	mdst.Net, err = parseIPv6Net(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(mdst))
+}
+
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtc := &IPv6MatchTrafficClass{}
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	mtc.TrafficClass = uint8(tc)
+	l.pushCond(NewCondIPv6(mtc))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mfl := &IPv6MatchFlowLabel{}
+	fl, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 20)
+	if err != nil {
+		l.err = serrors.WrapStr("FLOWLABEL parsing failed!", err,
+			"flowlabel", ctx.GetStop().GetText())
+	}
+	mfl.FlowLabel = uint32(fl)
+	l.pushCond(NewCondIPv6(mfl))
+}
+
+func (l *classListener) EnterMatchNextHdr(ctx *traffic_class.MatchNextHdrContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mnh := &IPv6MatchNextHeader{}
+	number, err := nextHeaderToNumber(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("NEXTHDR parsing failed!", err,
+			"nexthdr", ctx.GetStop().GetText())
+	}
+	mnh.NextHeader = number
+	l.pushCond(NewCondIPv6(mnh))
+}
+
 func (l *classListener) EnterMatchSrcPort(ctx *traffic_class.MatchSrcPortContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
 	src := &PortMatchSource{}
@@ -187,6 +244,42 @@ func (l *classListener) EnterMatchDstPortRange(ctx *traffic_class.MatchDstPortRa
 	l.pushCond(NewCondPorts(dst))
 }
 
+func (l *classListener) EnterMatchTCPFlags(ctx *traffic_class.MatchTCPFlagsContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mflags := &TCPMatchFlags{}
+	flags, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TCPFLAGS parsing failed!", err,
+			"tcpflags", ctx.GetStop().GetText())
+	}
+	mflags.Flags = uint8(flags)
+	l.pushCond(NewCondL4(mflags))
+}
+
+func (l *classListener) EnterMatchICMPType(ctx *traffic_class.MatchICMPTypeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtype := &ICMPMatchType{}
+	t, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("ICMPTYPE parsing failed!", err,
+			"icmptype", ctx.GetStop().GetText())
+	}
+	mtype.ICMPType = uint8(t)
+	l.pushCond(NewCondL4(mtype))
+}
+
+func (l *classListener) EnterMatchICMP6Type(ctx *traffic_class.MatchICMP6TypeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtype := &ICMPv6MatchType{}
+	t, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("ICMP6TYPE parsing failed!", err,
+			"icmp6type", ctx.GetStop().GetText())
+	}
+	mtype.ICMPType = uint8(t)
+	l.pushCond(NewCondL4(mtype))
+}
+
 func (l *classListener) EnterCondCls(ctx *traffic_class.CondClsContext) {
 	l.pushCond(CondClass{TrafficClass: ctx.GetStop().GetText()})
 }
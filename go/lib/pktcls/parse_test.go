@@ -119,6 +119,81 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "ANY(dscp=0x2,ALL(dst=12.12.12.0/24,dscp=0x2, NOT(src=2.2.2.0/28)))",
 			Valid: true,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=::/0",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8::",
+			Valid: false,
+		},
+		{
+			Name:  "IPv4-mapped IPv6Cond",
+			Class: "dst=::ffff:12.12.12.0/120",
+			Valid: false,
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Valid: true,
+		},
+		{
+			Name:  "bad tc IPv6Cond",
+			Class: "tc=184",
+			Valid: false,
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=12345",
+			Valid: true,
+		},
+		{
+			Name:  "flowlabel IPv6Cond too large",
+			Class: "flowlabel=1048576",
+			Valid: false,
+		},
+		{
+			Name:  "nexthdr IPv6Cond name",
+			Class: "nexthdr=udp",
+			Valid: true,
+		},
+		{
+			Name:  "nexthdr IPv6Cond number",
+			Class: "nexthdr=58",
+			Valid: true,
+		},
+		{
+			Name:  "tcpflags L4Cond",
+			Class: "tcpflags=0x12",
+			Valid: true,
+		},
+		{
+			Name:  "icmptype L4Cond",
+			Class: "icmptype=8",
+			Valid: true,
+		},
+		{
+			Name:  "bad icmptype L4Cond",
+			Class: "icmptype=256",
+			Valid: false,
+		},
+		{
+			Name:  "icmp6type L4Cond",
+			Class: "icmp6type=128",
+			Valid: true,
+		},
+		{
+			Name:  "dual-stack ANY",
+			Class: "ANY(dst=12.12.12.0/24,dst=2001:db8::/32)",
+			Valid: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -134,6 +209,7 @@ func TestTrafficClassValidation(t *testing.T) {
 }
 
 func TestTrafficClassTree(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	testCases := []struct {
 		Name  string
@@ -257,6 +333,61 @@ func TestTrafficClassTree(t *testing.T) {
 			Class: "protocol=udp",
 			Tree:  pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: uint8(17)}),
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:db8::/32",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=12345",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 12345}),
+		},
+		{
+			Name:  "nexthdr tcp",
+			Class: "nexthdr=tcp",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 6}),
+		},
+		{
+			Name:  "nexthdr 58",
+			Class: "nexthdr=58",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+		},
+		{
+			Name:  "tcpflags",
+			Class: "tcpflags=0x12",
+			Tree: pktcls.NewCondL4(&pktcls.TCPMatchFlags{
+				Flags: pktcls.TCPFlagSYN | pktcls.TCPFlagACK,
+			}),
+		},
+		{
+			Name:  "icmptype",
+			Class: "icmptype=8",
+			Tree:  pktcls.NewCondL4(&pktcls.ICMPMatchType{ICMPType: 8}),
+		},
+		{
+			Name:  "icmp6type",
+			Class: "icmp6type=128",
+			Tree:  pktcls.NewCondL4(&pktcls.ICMPv6MatchType{ICMPType: 128}),
+		},
+		{
+			Name:  "dual-stack ANY",
+			Class: "ANY(dst=12.12.12.0/26,dst=2001:db8::/32)",
+			Tree: pktcls.CondAnyOf{
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchDestination{Net: net}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,293 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// IPv6Predicate describes a single test on various IPv6 packet fields.
+type IPv6Predicate interface {
+	// Eval returns true if the IPv6 packet matched the predicate
+	Eval(*layers.IPv6) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ IPv6Predicate = (*IPv6MatchSource)(nil)
+
+// IPv6MatchSource checks whether the source IPv6 address is contained in Net.
+type IPv6MatchSource struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchSource) Type() string {
+	return TypeIPv6MatchSource
+}
+
+func (m *IPv6MatchSource) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.SrcIP)
+}
+
+func (m *IPv6MatchSource) String() string {
+	if m.Net == nil {
+		return "src="
+	}
+	return fmt.Sprintf("src=%s", m.Net)
+}
+
+func (m *IPv6MatchSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchSource) UnmarshalJSON(b []byte) error {
+	network, err := unmarshalIPv6NetField(b, TypeIPv6MatchSource)
+	if err != nil {
+		return err
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchDestination)(nil)
+
+// IPv6MatchDestination checks whether the destination IPv6 address is contained
+// in Net.
+type IPv6MatchDestination struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchDestination) Type() string {
+	return TypeIPv6MatchDestination
+}
+
+func (m *IPv6MatchDestination) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.DstIP)
+}
+
+func (m *IPv6MatchDestination) String() string {
+	if m.Net == nil {
+		return "dst="
+	}
+	return fmt.Sprintf("dst=%s", m.Net)
+}
+
+func (m *IPv6MatchDestination) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchDestination) UnmarshalJSON(b []byte) error {
+	network, err := unmarshalIPv6NetField(b, TypeIPv6MatchDestination)
+	if err != nil {
+		return err
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchTrafficClass)(nil)
+
+// IPv6MatchTrafficClass checks whether the traffic class field matches.
+type IPv6MatchTrafficClass struct {
+	TrafficClass uint8
+}
+
+func (m *IPv6MatchTrafficClass) Type() string {
+	return TypeIPv6MatchTrafficClass
+}
+
+func (m *IPv6MatchTrafficClass) Eval(p *layers.IPv6) bool {
+	return m.TrafficClass == p.TrafficClass
+}
+
+func (m *IPv6MatchTrafficClass) String() string {
+	return fmt.Sprintf("tc=%s", m.toHex())
+}
+
+func (m *IPv6MatchTrafficClass) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"TrafficClass": m.toHex(),
+		},
+	)
+}
+
+func (m *IPv6MatchTrafficClass) toHex() string {
+	return fmt.Sprintf("%#x", m.TrafficClass)
+}
+
+func (m *IPv6MatchTrafficClass) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, TypeIPv6MatchTrafficClass, "TrafficClass", 8)
+	if err != nil {
+		return err
+	}
+	m.TrafficClass = uint8(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchFlowLabel)(nil)
+
+// IPv6MatchFlowLabel checks whether the 20-bit flow label matches.
+type IPv6MatchFlowLabel struct {
+	FlowLabel uint32
+}
+
+func (m *IPv6MatchFlowLabel) Type() string {
+	return TypeIPv6MatchFlowLabel
+}
+
+func (m *IPv6MatchFlowLabel) Eval(p *layers.IPv6) bool {
+	return m.FlowLabel == p.FlowLabel
+}
+
+func (m *IPv6MatchFlowLabel) String() string {
+	return fmt.Sprintf("flowlabel=%d", m.FlowLabel)
+}
+
+func (m *IPv6MatchFlowLabel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"FlowLabel": fmt.Sprintf("%d", m.FlowLabel),
+		},
+	)
+}
+
+func (m *IPv6MatchFlowLabel) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, TypeIPv6MatchFlowLabel, "FlowLabel", 20)
+	if err != nil {
+		return err
+	}
+	m.FlowLabel = uint32(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchNextHeader)(nil)
+
+// IPv6MatchNextHeader checks whether the L4 protocol matches. Only the hop-by-hop
+// extension header is skipped, other extension headers are matched as is.
+type IPv6MatchNextHeader struct {
+	NextHeader uint8
+}
+
+func (m *IPv6MatchNextHeader) Type() string {
+	return TypeIPv6MatchNextHeader
+}
+
+func (m *IPv6MatchNextHeader) Eval(p *layers.IPv6) bool {
+	if p.HopByHop != nil {
+		return m.NextHeader == uint8(p.HopByHop.NextHeader)
+	}
+	return m.NextHeader == uint8(p.NextHeader)
+}
+
+func (m *IPv6MatchNextHeader) String() string {
+	return fmt.Sprintf("nexthdr=%s", m.name())
+}
+
+func (m *IPv6MatchNextHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"NextHeader": m.name(),
+		},
+	)
+}
+
+// name returns the protocol name if it is unambiguous and can be parsed back by
+// the traffic class grammar, and the decimal protocol number otherwise.
+func (m *IPv6MatchNextHeader) name() string {
+	name := layers.IPProtocolMetadata[m.NextHeader].Name
+	if !isLetters(name) {
+		return strconv.Itoa(int(m.NextHeader))
+	}
+	if n, err := protocolNameToNumber(name); err != nil || n != m.NextHeader {
+		return strconv.Itoa(int(m.NextHeader))
+	}
+	return name
+}
+
+func (m *IPv6MatchNextHeader) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, TypeIPv6MatchNextHeader, "NextHeader")
+	if err != nil {
+		return err
+	}
+	n, err := nextHeaderToNumber(s)
+	if err != nil {
+		return err
+	}
+	m.NextHeader = n
+	return nil
+}
+
+// nextHeaderToNumber converts either a protocol name or a decimal protocol
+// number to the IP protocol number.
+func nextHeaderToNumber(s string) (uint8, error) {
+	if n, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return uint8(n), nil
+	}
+	return protocolNameToNumber(s)
+}
+
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func unmarshalIPv6NetField(b []byte, name string) (*net.IPNet, error) {
+	s, err := unmarshalStringField(b, name, "Net")
+	if err != nil {
+		return nil, err
+	}
+	network, err := parseIPv6Net(s)
+	if err != nil {
+		return nil, serrors.WrapStr("Unable to parse operand", err, "name", name)
+	}
+	return network, nil
+}
+
+// parseIPv6Net parses an IPv6 network in CIDR notation. IPv4 and IPv4-mapped
+// networks are rejected, they are matched by the IPv4 predicates.
+func parseIPv6Net(s string) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+	if ip.To4() != nil {
+		return nil, serrors.New("not an IPv6 network", "net", s)
+	}
+	return network, nil
+}
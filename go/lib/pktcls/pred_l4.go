@@ -0,0 +1,193 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// L4Predicate describes a single test on the transport or ICMP header of a
+// packet. The predicate is evaluated on the decoded layer following the IP
+// header, irrespective of whether that header was IPv4 or IPv6.
+type L4Predicate interface {
+	// Eval returns true if the L4 layer matched the predicate
+	Eval(gopacket.Layer) bool
+	Typer
+	fmt.Stringer
+}
+
+// TCP flag bits as they appear in byte 13 of the TCP header.
+const (
+	TCPFlagFIN uint8 = 1 << iota
+	TCPFlagSYN
+	TCPFlagRST
+	TCPFlagPSH
+	TCPFlagACK
+	TCPFlagURG
+	TCPFlagECE
+	TCPFlagCWR
+)
+
+var _ L4Predicate = (*TCPMatchFlags)(nil)
+
+// TCPMatchFlags checks whether all the flags in Flags are set in the TCP
+// header. Flags not in Flags are ignored.
+type TCPMatchFlags struct {
+	Flags uint8
+}
+
+func (m *TCPMatchFlags) Type() string {
+	return TypeTCPMatchFlags
+}
+
+func (m *TCPMatchFlags) Eval(l gopacket.Layer) bool {
+	tcp, ok := l.(*layers.TCP)
+	if !ok {
+		return false
+	}
+	return tcpFlags(tcp)&m.Flags == m.Flags
+}
+
+func (m *TCPMatchFlags) String() string {
+	return fmt.Sprintf("tcpflags=%s", m.toHex())
+}
+
+func (m *TCPMatchFlags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Flags": m.toHex(),
+		},
+	)
+}
+
+func (m *TCPMatchFlags) toHex() string {
+	return fmt.Sprintf("%#x", m.Flags)
+}
+
+func (m *TCPMatchFlags) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, TypeTCPMatchFlags, "Flags", 8)
+	if err != nil {
+		return err
+	}
+	m.Flags = uint8(i)
+	return nil
+}
+
+func tcpFlags(tcp *layers.TCP) uint8 {
+	var flags uint8
+	for _, f := range []struct {
+		set  bool
+		flag uint8
+	}{
+		{tcp.FIN, TCPFlagFIN},
+		{tcp.SYN, TCPFlagSYN},
+		{tcp.RST, TCPFlagRST},
+		{tcp.PSH, TCPFlagPSH},
+		{tcp.ACK, TCPFlagACK},
+		{tcp.URG, TCPFlagURG},
+		{tcp.ECE, TCPFlagECE},
+		{tcp.CWR, TCPFlagCWR},
+	} {
+		if f.set {
+			flags |= f.flag
+		}
+	}
+	return flags
+}
+
+var _ L4Predicate = (*ICMPMatchType)(nil)
+
+// ICMPMatchType checks whether the ICMPv4 message type matches.
+type ICMPMatchType struct {
+	ICMPType uint8
+}
+
+func (m *ICMPMatchType) Type() string {
+	return TypeICMPMatchType
+}
+
+func (m *ICMPMatchType) Eval(l gopacket.Layer) bool {
+	icmp, ok := l.(*layers.ICMPv4)
+	if !ok {
+		return false
+	}
+	return m.ICMPType == icmp.TypeCode.Type()
+}
+
+func (m *ICMPMatchType) String() string {
+	return fmt.Sprintf("icmptype=%d", m.ICMPType)
+}
+
+func (m *ICMPMatchType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Type": fmt.Sprintf("%d", m.ICMPType),
+		},
+	)
+}
+
+func (m *ICMPMatchType) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, TypeICMPMatchType, "Type", 8)
+	if err != nil {
+		return err
+	}
+	m.ICMPType = uint8(i)
+	return nil
+}
+
+var _ L4Predicate = (*ICMPv6MatchType)(nil)
+
+// ICMPv6MatchType checks whether the ICMPv6 message type matches.
+type ICMPv6MatchType struct {
+	ICMPType uint8
+}
+
+func (m *ICMPv6MatchType) Type() string {
+	return TypeICMPv6MatchType
+}
+
+func (m *ICMPv6MatchType) Eval(l gopacket.Layer) bool {
+	icmp, ok := l.(*layers.ICMPv6)
+	if !ok {
+		return false
+	}
+	return m.ICMPType == icmp.TypeCode.Type()
+}
+
+func (m *ICMPv6MatchType) String() string {
+	return fmt.Sprintf("icmp6type=%d", m.ICMPType)
+}
+
+func (m *ICMPv6MatchType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Type": fmt.Sprintf("%d", m.ICMPType),
+		},
+	)
+}
+
+func (m *ICMPv6MatchType) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, TypeICMPv6MatchType, "Type", 8)
+	if err != nil {
+		return err
+	}
+	m.ICMPType = uint8(i)
+	return nil
+}
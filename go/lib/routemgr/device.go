@@ -266,3 +266,80 @@ func FixedTunnelName(name string) func(addr.IA) string {
 		return name
 	}
 }
+
+// MappedTunnelName returns a namer that resolves the IAs in names to the mapped
+// device name, and all other IAs with the fallback namer.
+func MappedTunnelName(names map[addr.IA]string,
+	fallback func(addr.IA) string) func(addr.IA) string {
+
+	return func(ia addr.IA) string {
+		if name, ok := names[ia]; ok {
+			return name
+		}
+		return fallback(ia)
+	}
+}
+
+// NamedDeviceManager shares devices between all remote IAs that resolve to the
+// same device name. This allows multiple remote IAs to be mapped to the same
+// device, while other remote IAs use different devices.
+type NamedDeviceManager struct {
+	// Namer resolves the name of the device used for a remote IA.
+	Namer func(addr.IA) string
+	// DeviceOpener is used to create new resources for handles returned by Get.
+	// It is called with the first IA that resolves to a given device name.
+	DeviceOpener control.DeviceOpener
+
+	mtx     sync.Mutex
+	devices map[string]*deviceHandle
+}
+
+// Get returns a handle to the device that the ISD-AS resolves to. If no device
+// with that name exists, one will be created. If it already exists, a handle to
+// the existing device is returned. The caller must Close the handle to
+// guarantee that resources will be cleaned up.
+func (m *NamedDeviceManager) Get(ctx context.Context, ia addr.IA) (control.DeviceHandle, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.DeviceOpener == nil {
+		return nil, serrors.New("no DeviceOpener set")
+	}
+	if m.Namer == nil {
+		return nil, serrors.New("no Namer set")
+	}
+
+	if m.devices == nil {
+		m.devices = make(map[string]*deviceHandle)
+	}
+
+	name := m.Namer(ia)
+	if m.devices[name] == nil || m.devices[name].destroyed() {
+		device, err := m.DeviceOpener.Open(ctx, ia)
+		if err != nil {
+			return nil, err
+		}
+		m.devices[name] = newDeviceHandle(device, m.newDeletionCallback(name))
+	} else {
+		m.devices[name].incRefs()
+	}
+
+	return m.devices[name], nil
+}
+
+// Size returns the number of existing devices.
+func (m *NamedDeviceManager) Size() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.devices)
+}
+
+func (m *NamedDeviceManager) newDeletionCallback(name string) destructionCallback {
+	return func() {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+
+		delete(m.devices, name)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/routemgr"
@@ -375,6 +376,17 @@ func TestFixedTunnelName(t *testing.T) {
 	assert.Equal(t, "foo", namer(xtest.MustParseIA("1-ff00:0:1")))
 }
 
+func TestMappedTunnelName(t *testing.T) {
+	namer := routemgr.MappedTunnelName(map[addr.IA]string{
+		xtest.MustParseIA("1-ff00:0:1"): "foo",
+	}, routemgr.FixedTunnelName("bar"))
+	assert.Equal(t, "foo", namer(xtest.MustParseIA("1-ff00:0:1")))
+	assert.Equal(t, "bar", namer(xtest.MustParseIA("1-ff00:0:2")))
+
+	namer = routemgr.MappedTunnelName(nil, routemgr.Base32TunnelName)
+	assert.Equal(t, "s.AAA76AAAAAAAC", namer(xtest.MustParseIA("1-ff00:0:1")))
+}
+
 func TestBase32TunnelName(t *testing.T) {
 	testCases := map[string]*struct {
 		IA   addr.IA
@@ -670,3 +682,96 @@ func TestMultiDeviceManager(t *testing.T) {
 		assert.Nil(t, handle2.Close())
 	})
 }
+
+func TestNamedDeviceManager(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:1")
+	ia2 := xtest.MustParseIA("1-ff00:0:2")
+	ia3 := xtest.MustParseIA("1-ff00:0:3")
+	namer := routemgr.MappedTunnelName(map[addr.IA]string{ia1: "a", ia2: "a"},
+		routemgr.FixedTunnelName("b"))
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		m := routemgr.NamedDeviceManager{}
+
+		handle, err := m.Get(context.Background(), ia1)
+		assert.Nil(t, handle)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("IAs with same name share device", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeviceHandle := mock_control.NewMockDeviceHandle(ctrl)
+		mockDeviceOpener := mock_control.NewMockDeviceOpener(ctrl)
+		mockDeviceOpener.EXPECT().Open(gomock.Any(), ia1).Return(mockDeviceHandle, nil)
+
+		m := routemgr.NamedDeviceManager{
+			Namer:        namer,
+			DeviceOpener: mockDeviceOpener,
+		}
+
+		handle1, err := m.Get(context.Background(), ia1)
+		require.NoError(t, err)
+		handle2, err := m.Get(context.Background(), ia2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, m.Size())
+
+		assert.NoError(t, handle1.Close())
+		mockDeviceHandle.EXPECT().Close()
+		assert.NoError(t, handle2.Close())
+		assert.Equal(t, 0, m.Size())
+	})
+
+	t.Run("IAs with different names use different devices", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeviceOpener := mock_control.NewMockDeviceOpener(ctrl)
+		mockDeviceHandle1 := mock_control.NewMockDeviceHandle(ctrl)
+		mockDeviceOpener.EXPECT().Open(gomock.Any(), ia1).Return(mockDeviceHandle1, nil)
+		mockDeviceHandle3 := mock_control.NewMockDeviceHandle(ctrl)
+		mockDeviceOpener.EXPECT().Open(gomock.Any(), ia3).Return(mockDeviceHandle3, nil)
+
+		m := routemgr.NamedDeviceManager{
+			Namer:        namer,
+			DeviceOpener: mockDeviceOpener,
+		}
+
+		handle1, err := m.Get(context.Background(), ia1)
+		require.NoError(t, err)
+		handle3, err := m.Get(context.Background(), ia3)
+		require.NoError(t, err)
+		assert.Equal(t, 2, m.Size())
+
+		mockDeviceHandle1.EXPECT().Close()
+		assert.NoError(t, handle1.Close())
+		mockDeviceHandle3.EXPECT().Close()
+		assert.NoError(t, handle3.Close())
+	})
+
+	t.Run("failed open", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDeviceOpener := mock_control.NewMockDeviceOpener(ctrl)
+		mockDeviceOpener.EXPECT().Open(gomock.Any(), ia3).Return(nil, serrors.New("test error"))
+
+		m := routemgr.NamedDeviceManager{
+			Namer:        namer,
+			DeviceOpener: mockDeviceOpener,
+		}
+
+		handle, err := m.Get(context.Background(), ia3)
+		assert.Nil(t, handle)
+		assert.Error(t, err)
+	})
+}
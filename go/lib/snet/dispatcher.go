@@ -16,7 +16,11 @@ package snet
 
 import (
 	"context"
+	"errors"
+	"math"
+	"math/rand"
 	"net"
+	"syscall"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -27,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 	"github.com/scionproto/scion/go/lib/util"
 )
 
@@ -66,6 +71,79 @@ func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.I
 	}, port, nil
 }
 
+var _ PacketDispatcherService = (*DirectPacketDispatcherService)(nil)
+
+// DirectPacketDispatcherService binds the SCION UDP port directly on the
+// underlay, bypassing the dispatcher. The border router delivers packets whose
+// destination UDP port is in Ports directly to that port on the end host, see
+// the endhost_port_range topology attribute. Ports must match the range
+// configured for the local AS.
+//
+// SVC addresses cannot be registered without the dispatcher. SCMP errors are
+// still delivered to the dispatcher, connections created by this service do
+// not receive them.
+type DirectPacketDispatcherService struct {
+	// Ports is the range of ports the border router delivers to directly.
+	Ports underlay.PortRange
+	// SCMPHandler is invoked for packets that contain an SCMP L4. If the
+	// handler is nil, errors are returned back to applications every time an
+	// SCMP message is received.
+	SCMPHandler SCMPHandler
+	// Metrics injected into SCIONPacketConn.
+	SCIONPacketConnMetrics SCIONPacketConnMetrics
+}
+
+func (s *DirectPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
+	registration *net.UDPAddr, svc addr.HostSVC) (PacketConn, uint16, error) {
+
+	if s.Ports.Empty() {
+		return nil, 0, serrors.New("no end host port range configured")
+	}
+	if registration == nil {
+		return nil, 0, serrors.New("registration address missing")
+	}
+	if svc != addr.SvcNone {
+		return nil, 0, serrors.New("SVC addresses require the dispatcher", "svc", svc)
+	}
+	conn, err := s.listen(registration)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &SCIONPacketConn{
+		Conn:        conn,
+		SCMPHandler: s.SCMPHandler,
+		Metrics:     s.SCIONPacketConnMetrics,
+	}, uint16(conn.LocalAddr().(*net.UDPAddr).Port), nil
+}
+
+// listen binds the registration address. If the port is 0, a free port in the
+// range is chosen, starting at a random offset.
+func (s *DirectPacketDispatcherService) listen(registration *net.UDPAddr) (*net.UDPConn,
+	error) {
+
+	if registration.Port != 0 {
+		if registration.Port > math.MaxUint16 ||
+			!s.Ports.Contains(uint16(registration.Port)) {
+
+			return nil, serrors.New("port outside of the end host port range",
+				"port", registration.Port, "range", s.Ports)
+		}
+		return net.ListenUDP("udp", registration)
+	}
+	size := int(s.Ports.End) - int(s.Ports.Start) + 1
+	offset := rand.Intn(size)
+	for i := 0; i < size; i++ {
+		a := *registration
+		a.Port = int(s.Ports.Start) + (offset+i)%size
+		conn, err := net.ListenUDP("udp", &a)
+		if errors.Is(err, syscall.EADDRINUSE) {
+			continue
+		}
+		return conn, err
+	}
+	return nil, serrors.New("no free port in the end host port range", "range", s.Ports)
+}
+
 // RevocationHandler is called by the default SCMP Handler whenever revocations are encountered.
 type RevocationHandler interface {
 	// RevokeRaw handles a revocation received as raw bytes.
@@ -0,0 +1,128 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/sock/reliable/mock_reliable"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDirectPacketDispatcherService(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ports := underlay.PortRange{Start: 31000, End: 31099}
+	localhost := net.IPv4(127, 0, 0, 1)
+	ctx := context.Background()
+
+	t.Run("allocates a port in the range", func(t *testing.T) {
+		s := &snet.DirectPacketDispatcherService{Ports: ports}
+		conn, port, err := s.Register(ctx, ia, &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.True(t, ports.Contains(port), "port %d", port)
+	})
+	t.Run("port outside of the range", func(t *testing.T) {
+		s := &snet.DirectPacketDispatcherService{Ports: ports}
+		_, _, err := s.Register(ctx, ia, &net.UDPAddr{IP: localhost, Port: 40000},
+			addr.SvcNone)
+		assert.Error(t, err)
+	})
+	t.Run("SVC address", func(t *testing.T) {
+		s := &snet.DirectPacketDispatcherService{Ports: ports}
+		_, _, err := s.Register(ctx, ia, &net.UDPAddr{IP: localhost}, addr.SvcCS)
+		assert.Error(t, err)
+	})
+	t.Run("empty range", func(t *testing.T) {
+		s := &snet.DirectPacketDispatcherService{}
+		_, _, err := s.Register(ctx, ia, &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		assert.Error(t, err)
+	})
+	t.Run("sends to the port in the local AS", func(t *testing.T) {
+		network := &snet.SCIONNetwork{
+			LocalIA:      ia,
+			Dispatcher:   &snet.DirectPacketDispatcherService{Ports: ports},
+			EndhostPorts: ports,
+		}
+		server, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer server.Close()
+		client, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer client.Close()
+
+		checkSend(t, client, server, ia)
+	})
+	t.Run("dispatcher sender reaches direct listener", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server, err := (&snet.SCIONNetwork{
+			LocalIA:      ia,
+			Dispatcher:   &snet.DirectPacketDispatcherService{Ports: ports},
+			EndhostPorts: ports,
+		}).Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer server.Close()
+
+		// The connection registered with the dispatcher writes the packets to
+		// the underlay address chosen by the network.
+		underlayConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: localhost})
+		require.NoError(t, err)
+		dispatcher := mock_reliable.NewMockDispatcher(ctrl)
+		dispatcher.EXPECT().Register(gomock.Any(), ia, gomock.Any(), addr.SvcNone).Return(
+			underlayConn, uint16(underlayConn.LocalAddr().(*net.UDPAddr).Port), nil)
+		client, err := (&snet.SCIONNetwork{
+			LocalIA:      ia,
+			Dispatcher:   &snet.DefaultPacketDispatcherService{Dispatcher: dispatcher},
+			EndhostPorts: ports,
+		}).Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer client.Close()
+
+		checkSend(t, client, server, ia)
+	})
+}
+
+// checkSend checks that a packet written by the client in the local AS is read
+// by the server.
+func checkSend(t *testing.T, client, server *snet.Conn, ia addr.IA) {
+	dst := &snet.UDPAddr{
+		IA:   ia,
+		Host: server.LocalAddr().(*net.UDPAddr),
+		Path: path.Empty{},
+	}
+	_, err := client.WriteTo([]byte("hello"), dst)
+	require.NoError(t, err)
+
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 100)
+	n, from, err := server.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+	assert.Equal(t, client.LocalAddr().(*net.UDPAddr).Port,
+		from.(*snet.UDPAddr).Host.Port)
+}
@@ -150,6 +150,25 @@ type PathMetadata struct {
 	// Notes contains the notes added by ASes on the path, in the order of occurrence.
 	// Entry i is the note of AS i on the path.
 	Notes []string
+
+	// EpicAuths contains the EPIC authenticators used to calculate the PHVF and LHVF.
+	EpicAuths EpicAuths
+}
+
+// EpicAuths contains the EPIC authenticators of the penultimate and the last
+// hop of a path. The authenticators are the full 16-byte MACs of the hop
+// fields, of which the path only contains the first 6 bytes.
+type EpicAuths struct {
+	// AuthPHVF is the authenticator for the penultimate hop.
+	AuthPHVF []byte
+	// AuthLHVF is the authenticator for the last hop.
+	AuthLHVF []byte
+}
+
+// SupportsEpic returns whether the authenticators can be used to send EPIC
+// packets.
+func (ea *EpicAuths) SupportsEpic() bool {
+	return len(ea.AuthPHVF) == 16 && len(ea.AuthLHVF) == 16
 }
 
 func (pm *PathMetadata) Copy() *PathMetadata {
@@ -167,6 +186,10 @@ func (pm *PathMetadata) Copy() *PathMetadata {
 		LinkType:        append(pm.LinkType[:0:0], pm.LinkType...),
 		InternalHops:    append(pm.InternalHops[:0:0], pm.InternalHops...),
 		Notes:           append(pm.Notes[:0:0], pm.Notes...),
+		EpicAuths: EpicAuths{
+			AuthPHVF: append([]byte(nil), pm.EpicAuths.AuthPHVF...),
+			AuthLHVF: append([]byte(nil), pm.EpicAuths.AuthLHVF...),
+		},
 	}
 }
 
@@ -0,0 +1,110 @@
+// Copyright 2021 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path
+
+import (
+	"sync"
+	"time"
+
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// EPIC is an EPIC-HP dataplane path. Every packet gets a fresh packet ID and
+// the hop validation fields of the penultimate and the last hop are computed
+// from the authenticators of these hops.
+type EPIC struct {
+	AuthPHVF []byte
+	AuthLHVF []byte
+	SCION    []byte
+	// Counter, if set, returns the counter of the packet ID of a packet,
+	// instead of the counter of the path. It is called with the packet ID
+	// that has the EPIC timestamp of the packet and a zero counter, the SCION
+	// header, and the timestamp of the info field the EPIC timestamp is
+	// relative to. This allows the destination to authenticate the packets
+	// with a key it shares with the source, as the hop validation fields
+	// cover the counter.
+	Counter func(pktID epic.PktID, s *slayers.SCION, timestamp uint32) (uint32, error)
+
+	mtx     sync.Mutex
+	counter uint32
+}
+
+var _ snet.DataplanePath = (*EPIC)(nil)
+
+// NewEPICDataplanePath creates an EPIC dataplane path from the SCION path and
+// the EPIC authenticators of its metadata.
+func NewEPICDataplanePath(p SCION, auths snet.EpicAuths) (*EPIC, error) {
+	if !auths.SupportsEpic() {
+		return nil, serrors.New("EPIC not supported by path")
+	}
+	return &EPIC{
+		AuthPHVF: append([]byte(nil), auths.AuthPHVF...),
+		AuthLHVF: append([]byte(nil), auths.AuthLHVF...),
+		SCION:    p.Raw,
+	}, nil
+}
+
+// SetPath sets the EPIC path on the SCION header. The source address and the
+// payload length of the header must already be set, as they are covered by
+// the hop validation fields.
+func (e *EPIC) SetPath(s *slayers.SCION) error {
+	var sp scion.Raw
+	if err := sp.DecodeFromBytes(e.SCION); err != nil {
+		return err
+	}
+	// The routers verify the hop validation fields against the timestamp of
+	// their current info field, which is the last one for the last two hops.
+	info, err := sp.GetInfoField(int(sp.NumINF) - 1)
+	if err != nil {
+		return err
+	}
+	ts, err := libepic.CreateTimestamp(time.Unix(int64(info.Timestamp), 0), time.Now())
+	if err != nil {
+		return err
+	}
+	pktID := epic.PktID{Timestamp: ts}
+	if e.Counter != nil {
+		if pktID.Counter, err = e.Counter(pktID, s, info.Timestamp); err != nil {
+			return serrors.WrapStr("calculating packet counter", err)
+		}
+	} else {
+		e.mtx.Lock()
+		pktID.Counter = e.counter
+		e.counter++
+		e.mtx.Unlock()
+	}
+
+	phvf, err := libepic.CalcMac(e.AuthPHVF, pktID, s, info.Timestamp, nil)
+	if err != nil {
+		return serrors.WrapStr("calculating PHVF", err)
+	}
+	lhvf, err := libepic.CalcMac(e.AuthLHVF, pktID, s, info.Timestamp, nil)
+	if err != nil {
+		return serrors.WrapStr("calculating LHVF", err)
+	}
+	s.Path = &epic.Path{
+		PktID:     pktID,
+		PHVF:      phvf,
+		LHVF:      lhvf,
+		ScionPath: &sp,
+	}
+	s.PathType = epic.PathType
+	return nil
+}
@@ -30,6 +30,11 @@
 //
 // Multiple networking contexts can share the same SCIOND and/or dispatcher.
 //
+// If the AS configures an end host port range, applications can bind their
+// ports directly on the underlay with DirectPacketDispatcherService instead of
+// registering with the dispatcher. Such connections do not receive SCMP
+// errors, and cannot listen on SVC addresses.
+//
 // Write calls never return SCMP errors directly. If a write call caused an
 // SCMP message to be received by the Conn, it can be inspected by calling
 // Read. In this case, the error value is non-nil and can be type asserted to
@@ -44,12 +49,14 @@ package snet
 
 import (
 	"context"
+	"math"
 	"net"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 var _ Network = (*SCIONNetwork)(nil)
@@ -71,6 +78,12 @@ type SCIONNetwork struct {
 	ReplyPather ReplyPather
 	// Metrics holds the metrics emitted by the network.
 	Metrics SCIONNetworkMetrics
+	// EndhostPorts is the end host port range of the local AS, as configured
+	// in its topology. Packets to a host in the local AS are sent to the
+	// underlay port of the destination port if it is in the range, and to the
+	// dispatcher port otherwise, regardless of how the sending connection is
+	// bound.
+	EndhostPorts underlay.PortRange
 }
 
 // Dial returns a SCION connection to remote. Nil values for listen are not
@@ -151,3 +164,12 @@ func (n *SCIONNetwork) Listen(ctx context.Context, network string, listen *net.U
 
 	return newConn(conn, packetConn, replyPather), nil
 }
+
+// underlayPort returns the underlay port of a host in the local AS that
+// listens on the given SCION UDP port.
+func (n *SCIONNetwork) underlayPort(port int) int {
+	if port < 0 || port > math.MaxUint16 {
+		return underlay.EndhostPort
+	}
+	return n.EndhostPorts.UnderlayPort(uint16(port))
+}
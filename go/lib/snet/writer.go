@@ -24,7 +24,6 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
-	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 type scionConnWriter struct {
@@ -54,7 +53,7 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		if nextHop == nil && c.base.scionNet.LocalIA.Equal(a.IA) {
 			nextHop = &net.UDPAddr{
 				IP:   a.Host.IP,
-				Port: underlay.EndhostPort,
+				Port: c.base.scionNet.underlayPort(a.Host.Port),
 				Zone: a.Host.Zone,
 			}
 
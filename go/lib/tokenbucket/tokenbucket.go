@@ -0,0 +1,108 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokenbucket implements a token bucket rate limiter. What a token
+// stands for, e.g., a byte or a message, is up to the user.
+package tokenbucket
+
+import (
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket rate limiter. It is safe for concurrent use.
+type Bucket struct {
+	mtx sync.Mutex
+	// rate is the rate at which the bucket fills up in tokens per second.
+	rate float64
+	// burst is the capacity of the bucket.
+	burst float64
+	// tokens is the number of tokens in the bucket at the time of the last
+	// update.
+	tokens float64
+	// last is the time of the last update.
+	last time.Time
+}
+
+// New creates a full token bucket that fills up with rate tokens per second
+// and holds up to burst tokens.
+func New(rate, burst float64, now time.Time) *Bucket {
+	return &Bucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// Allow takes n tokens from the bucket. It returns false, and leaves the
+// bucket untouched, if there are fewer than n tokens.
+func (b *Bucket) Allow(n int, now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.refill(now)
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Available returns whether the bucket holds at least n tokens, without taking
+// them.
+func (b *Bucket) Available(n int, now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.refill(now)
+	return b.tokens >= float64(n)
+}
+
+// refill adds the tokens accumulated since the last update. The caller must
+// hold the lock.
+func (b *Bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// AllowBoth takes n tokens from both buckets, either of which may be nil. It
+// returns false, and leaves both buckets untouched, if either of them has
+// fewer than n tokens. The buckets are locked in order, so callers must always
+// pass the same two buckets in the same order.
+func AllowBoth(first, second *Bucket, n int, now time.Time) bool {
+	switch {
+	case first == nil && second == nil:
+		return true
+	case first == nil:
+		return second.Allow(n, now)
+	case second == nil:
+		return first.Allow(n, now)
+	}
+	first.mtx.Lock()
+	defer first.mtx.Unlock()
+	second.mtx.Lock()
+	defer second.mtx.Unlock()
+	first.refill(now)
+	second.refill(now)
+	if first.tokens < float64(n) || second.tokens < float64(n) {
+		return false
+	}
+	first.tokens -= float64(n)
+	second.tokens -= float64(n)
+	return true
+}
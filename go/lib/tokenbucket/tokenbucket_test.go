@@ -0,0 +1,101 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenbucket_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+
+	t.Run("burst", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.True(t, b.Allow(1000, now))
+		assert.True(t, b.Allow(500, now))
+		assert.False(t, b.Allow(1, now))
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.True(t, b.Allow(1500, now))
+		assert.False(t, b.Allow(200, now.Add(100*time.Millisecond)))
+		assert.True(t, b.Allow(200, now.Add(200*time.Millisecond)))
+		// The bucket does not fill up beyond the burst size.
+		assert.True(t, b.Allow(1500, now.Add(time.Hour)))
+		assert.False(t, b.Allow(1, now.Add(time.Hour)))
+	})
+
+	t.Run("request larger than the tokens is not charged", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.False(t, b.Allow(2000, now))
+		assert.True(t, b.Allow(1500, now))
+	})
+
+	t.Run("available does not take tokens", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.True(t, b.Available(1500, now))
+		assert.False(t, b.Available(1501, now))
+		assert.True(t, b.Allow(1500, now))
+		assert.False(t, b.Available(1, now))
+	})
+
+	t.Run("time going backwards", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.True(t, b.Allow(1500, now))
+		assert.False(t, b.Allow(1, now.Add(-time.Hour)))
+	})
+}
+
+func TestAllowBoth(t *testing.T) {
+	now := time.Now()
+
+	t.Run("second rejects", func(t *testing.T) {
+		first := tokenbucket.New(1000, 1500, now)
+		second := tokenbucket.New(1000, 500, now)
+		assert.False(t, tokenbucket.AllowBoth(first, second, 1000, now))
+		// Neither bucket is charged.
+		assert.True(t, first.Allow(1500, now))
+		assert.True(t, second.Allow(500, now))
+	})
+
+	t.Run("first rejects", func(t *testing.T) {
+		first := tokenbucket.New(1000, 500, now)
+		second := tokenbucket.New(1000, 1500, now)
+		assert.False(t, tokenbucket.AllowBoth(first, second, 1000, now))
+		assert.True(t, first.Allow(500, now))
+		assert.True(t, second.Allow(1500, now))
+	})
+
+	t.Run("both allow", func(t *testing.T) {
+		first := tokenbucket.New(1000, 1500, now)
+		second := tokenbucket.New(1000, 1500, now)
+		assert.True(t, tokenbucket.AllowBoth(first, second, 1000, now))
+		assert.False(t, first.Allow(1000, now))
+		assert.False(t, second.Allow(1000, now))
+	})
+
+	t.Run("nil buckets", func(t *testing.T) {
+		b := tokenbucket.New(1000, 1500, now)
+		assert.True(t, tokenbucket.AllowBoth(nil, nil, 1000, now))
+		assert.True(t, tokenbucket.AllowBoth(b, nil, 1000, now))
+		assert.False(t, tokenbucket.AllowBoth(nil, b, 1000, now))
+	})
+}
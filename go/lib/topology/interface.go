@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 	jsontopo "github.com/scionproto/scion/go/lib/topology/json"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 // Topology is the topology type for applications and libraries that only need read access to AS
@@ -43,6 +44,9 @@ type Topology interface {
 	CA() bool
 	// InterfaceIDs returns all interface IDS from the local AS.
 	InterfaceIDs() []common.IFIDType
+	// EndhostPorts returns the range of underlay ports to which the end hosts
+	// of the local AS bind directly, without the dispatcher.
+	EndhostPorts() underlay.PortRange
 
 	// PublicAddress gets the public address of a server with the requested type and name, and nil
 	// if no such server exists.
@@ -57,6 +61,12 @@ type Topology interface {
 	UnderlayAnycast(svc addr.HostSVC) (*net.UDPAddr, error)
 	// UnderlayMulticast returns all underlay addresses for the requested type.
 	UnderlayMulticast(svc addr.HostSVC) ([]*net.UDPAddr, error)
+	// UnderlayMulticastFor returns all underlay addresses for the requested
+	// type, like UnderlayMulticast. For dual-stack servers, the address in the
+	// IP family of local is returned, such that a host that only has an address
+	// in that family can reach them. If local is nil, it is the same as
+	// UnderlayMulticast.
+	UnderlayMulticastFor(svc addr.HostSVC, local net.IP) ([]*net.UDPAddr, error)
 	// UnderlayNextHop returns the internal underlay address of the router
 	// containing the interface ID.
 	UnderlayNextHop(ifID common.IFIDType) (*net.UDPAddr, bool)
@@ -159,6 +169,10 @@ func (t *topologyS) InterfaceIDs() []common.IFIDType {
 	return intfs
 }
 
+func (t *topologyS) EndhostPorts() underlay.PortRange {
+	return t.Topology.EndhostPorts
+}
+
 func (t *topologyS) UnderlayNextHop(ifid common.IFIDType) (*net.UDPAddr, bool) {
 	ifInfo, ok := t.Topology.IFInfoMap[ifid]
 	if !ok {
@@ -300,6 +314,12 @@ func supportedSVC(svc addr.HostSVC) bool {
 }
 
 func (t *topologyS) UnderlayMulticast(svc addr.HostSVC) ([]*net.UDPAddr, error) {
+	return t.UnderlayMulticastFor(svc, nil)
+}
+
+func (t *topologyS) UnderlayMulticastFor(svc addr.HostSVC,
+	local net.IP) ([]*net.UDPAddr, error) {
+
 	st, err := toServiceType(svc)
 	if err != nil {
 		return nil, err
@@ -317,7 +337,7 @@ func (t *topologyS) UnderlayMulticast(svc addr.HostSVC) ([]*net.UDPAddr, error)
 	// multiple times by the remote dispatcher.
 	uniqueUnderlayAddrs := make(map[string]*net.UDPAddr)
 	for _, topoAddr := range topoAddrs {
-		underlayAddr := topoAddr.UnderlayAddr()
+		underlayAddr := topoAddr.ForFamily(local).UnderlayAddr()
 		if underlayAddr == nil {
 			continue
 		}
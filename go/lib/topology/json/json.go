@@ -68,7 +68,12 @@ type Topology struct {
 	MTU            int    `json:"mtu"`
 	// Attributes are the primary AS attributes as described in
 	// https://github.com/scionproto/scion/blob/master/doc/ControlPlanePKI.md#primary-ases
-	Attributes          []Attribute             `json:"attributes"`
+	Attributes []Attribute `json:"attributes"`
+	// EndhostPortRange is the range of underlay ports, e.g. "31000-32767", to
+	// which the end hosts bind their SCION/UDP sockets directly. The routers
+	// deliver the packets for these ports to the same underlay port, and all
+	// other packets to the dispatcher.
+	EndhostPortRange    string                  `json:"endhost_port_range,omitempty"`
 	BorderRouters       map[string]*BRInfo      `json:"border_routers,omitempty"`
 	ControlService      map[string]*ServerInfo  `json:"control_service,omitempty"`
 	ColibriService      map[string]*ServerInfo  `json:"colibri_service,omitempty"`
@@ -81,12 +86,19 @@ type Topology struct {
 // ServerInfo contains the information for a SCION application running in the local AS.
 type ServerInfo struct {
 	Addr string `json:"addr"`
+	// AltAddr is the address of the server in the other IP family than Addr,
+	// if the server is reachable over both IPv4 and IPv6.
+	AltAddr string `json:"alt_addr,omitempty"`
 }
 
 // BRInfo contains Border Router specific information.
 type BRInfo struct {
-	InternalAddr string                           `json:"internal_addr"`
-	Interfaces   map[common.IFIDType]*BRInterface `json:"interfaces"`
+	InternalAddr string `json:"internal_addr"`
+	// AltInternalAddr is the internal address of the router in the other IP
+	// family than InternalAddr, if the router is reachable over both IPv4 and
+	// IPv6. It must have the same port as InternalAddr.
+	AltInternalAddr string                           `json:"alt_internal_addr,omitempty"`
+	Interfaces      map[common.IFIDType]*BRInterface `json:"interfaces"`
 }
 
 // GatewayInfo contains SCION gateway information.
@@ -123,12 +135,19 @@ type BFD struct {
 }
 
 func (i ServerInfo) String() string {
+	if i.AltAddr != "" {
+		return fmt.Sprintf("Addr: %s, AltAddr: %s", i.Addr, i.AltAddr)
+	}
 	return fmt.Sprintf("Addr: %s", i.Addr)
 }
 
 func (i BRInfo) String() string {
 	var s []string
-	s = append(s, fmt.Sprintf("Loc addrs:\n  %s\nInterfaces:", i.InternalAddr))
+	addrs := i.InternalAddr
+	if i.AltInternalAddr != "" {
+		addrs += "\n  " + i.AltInternalAddr
+	}
+	s = append(s, fmt.Sprintf("Loc addrs:\n  %s\nInterfaces:", addrs))
 	for ifid, intf := range i.Interfaces {
 		s = append(s, fmt.Sprintf("%d: %+v", ifid, intf))
 	}
@@ -12,6 +12,7 @@ import (
 	addr "github.com/scionproto/scion/go/lib/addr"
 	common "github.com/scionproto/scion/go/lib/common"
 	topology "github.com/scionproto/scion/go/lib/topology"
+	underlay "github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 // MockTopology is a mock of Topology interface.
@@ -109,6 +110,20 @@ func (mr *MockTopologyMockRecorder) Core() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Core", reflect.TypeOf((*MockTopology)(nil).Core))
 }
 
+// EndhostPorts mocks base method.
+func (m *MockTopology) EndhostPorts() underlay.PortRange {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndhostPorts")
+	ret0, _ := ret[0].(underlay.PortRange)
+	return ret0
+}
+
+// EndhostPorts indicates an expected call of EndhostPorts.
+func (mr *MockTopologyMockRecorder) EndhostPorts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndhostPorts", reflect.TypeOf((*MockTopology)(nil).EndhostPorts))
+}
+
 // Gateways mocks base method.
 func (m *MockTopology) Gateways() ([]topology.GatewayInfo, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +283,21 @@ func (mr *MockTopologyMockRecorder) UnderlayMulticast(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnderlayMulticast", reflect.TypeOf((*MockTopology)(nil).UnderlayMulticast), arg0)
 }
 
+// UnderlayMulticastFor mocks base method.
+func (m *MockTopology) UnderlayMulticastFor(arg0 addr.HostSVC, arg1 net.IP) ([]*net.UDPAddr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnderlayMulticastFor", arg0, arg1)
+	ret0, _ := ret[0].([]*net.UDPAddr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnderlayMulticastFor indicates an expected call of UnderlayMulticastFor.
+func (mr *MockTopologyMockRecorder) UnderlayMulticastFor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnderlayMulticastFor", reflect.TypeOf((*MockTopology)(nil).UnderlayMulticastFor), arg0, arg1)
+}
+
 // UnderlayNextHop mocks base method.
 func (m *MockTopology) UnderlayNextHop(arg0 common.IFIDType) (*net.UDPAddr, bool) {
 	m.ctrl.T.Helper()
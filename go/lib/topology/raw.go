@@ -29,15 +29,38 @@ func rawAddrToTopoAddr(rawAddr string) (*TopoAddr, error) {
 		return nil, err
 	}
 	return &TopoAddr{
-		SCIONAddress: a,
-		UnderlayAddress: &net.UDPAddr{
-			IP:   append(a.IP[:0:0], a.IP...),
-			Port: EndhostPort,
-			Zone: a.Zone,
-		},
+		SCIONAddress:    a,
+		UnderlayAddress: endhostAddr(a),
 	}, nil
 }
 
+// endhostAddr returns the underlay address of an end host with SCION address a.
+func endhostAddr(a *net.UDPAddr) *net.UDPAddr {
+	return &net.UDPAddr{
+		IP:   append(a.IP[:0:0], a.IP...),
+		Port: EndhostPort,
+		Zone: a.Zone,
+	}
+}
+
+// rawAltAddrToUDPAddr parses the alternative address of an element, which must
+// be in the other IP family than its primary address. It returns nil if rawAddr
+// is empty.
+func rawAltAddrToUDPAddr(rawAddr string, primary *net.UDPAddr) (*net.UDPAddr, error) {
+	if rawAddr == "" {
+		return nil, nil
+	}
+	a, err := rawAddrToUDPAddr(rawAddr)
+	if err != nil {
+		return nil, err
+	}
+	if sameFamily(a.IP, primary.IP) {
+		return nil, serrors.New("alternative address must be in the other IP family",
+			"address", a, "primary", primary)
+	}
+	return a, nil
+}
+
 func rawBRIntfTopoBRAddr(i *jsontopo.BRInterface) (*net.UDPAddr, error) {
 	rh, port, err := splitHostPort(i.Underlay.Public)
 	if err != nil {
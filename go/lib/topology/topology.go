@@ -62,6 +62,9 @@ type (
 		IA         addr.IA
 		Attributes []jsontopo.Attribute
 		MTU        int
+		// EndhostPorts is the range of underlay ports to which the end hosts
+		// bind directly. It is empty if all the end hosts use the dispatcher.
+		EndhostPorts underlay.PortRange
 
 		BR        map[string]BRInfo
 		BRNames   []string
@@ -91,6 +94,9 @@ type (
 		Name string
 		// InternalAddr is the local data-plane address.
 		InternalAddr *net.UDPAddr
+		// AltInternalAddr is the local data-plane address in the other IP
+		// family, if the router is dual-stack. It is nil otherwise.
+		AltInternalAddr *net.UDPAddr
 		// IFIDs is a sorted list of the interface IDs.
 		IFIDs []common.IFIDType
 		// IFs is a map of interface IDs.
@@ -109,13 +115,16 @@ type (
 		BRName       string
 		Underlay     underlay.Type
 		InternalAddr *net.UDPAddr
-		Local        *net.UDPAddr
-		Remote       *net.UDPAddr
-		RemoteIFID   common.IFIDType
-		IA           addr.IA
-		LinkType     LinkType
-		MTU          int
-		BFD          BFD
+		// AltInternalAddr is the internal address of the router in the other
+		// IP family, if the router is dual-stack. It is nil otherwise.
+		AltInternalAddr *net.UDPAddr
+		Local           *net.UDPAddr
+		Remote          *net.UDPAddr
+		RemoteIFID      common.IFIDType
+		IA              addr.IA
+		LinkType        LinkType
+		MTU             int
+		BFD             BFD
 	}
 
 	// IDAddrMap maps process IDs to their topology addresses.
@@ -126,6 +135,11 @@ type (
 	TopoAddr struct {
 		SCIONAddress    *net.UDPAddr
 		UnderlayAddress *net.UDPAddr
+		// AltSCIONAddress and AltUnderlayAddress are the addresses of the
+		// service in the other IP family, if the service is dual-stack. They
+		// are nil otherwise.
+		AltSCIONAddress    *net.UDPAddr
+		AltUnderlayAddress *net.UDPAddr
 	}
 
 	// BFD is the configuration for a BFD session
@@ -203,6 +217,13 @@ func (t *RWTopology) populateMeta(raw *jsontopo.Topology) error {
 	}
 	t.MTU = raw.MTU
 	t.Attributes = raw.Attributes
+	if t.EndhostPorts, err = underlay.ParsePortRange(raw.EndhostPortRange); err != nil {
+		return serrors.WrapStr("parsing end host port range", err)
+	}
+	if t.EndhostPorts.Contains(underlay.EndhostPort) {
+		return serrors.New("end host port range contains the dispatcher port",
+			"range", t.EndhostPorts, "port", underlay.EndhostPort)
+	}
 	return nil
 }
 
@@ -215,10 +236,20 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 		if err != nil {
 			return serrors.WrapStr("unable to extract underlay internal data-plane address", err)
 		}
+		altIntAddr, err := rawAltAddrToUDPAddr(rawBr.AltInternalAddr, intAddr)
+		if err != nil {
+			return serrors.WrapStr("unable to extract alternative underlay internal "+
+				"data-plane address", err, "br", name)
+		}
+		if altIntAddr != nil && altIntAddr.Port != intAddr.Port {
+			return serrors.New("internal addresses must have the same port", "br", name,
+				"internal_addr", intAddr, "alt_internal_addr", altIntAddr)
+		}
 		brInfo := BRInfo{
-			Name:         name,
-			InternalAddr: intAddr,
-			IFs:          make(map[common.IFIDType]*IFInfo),
+			Name:            name,
+			InternalAddr:    intAddr,
+			AltInternalAddr: altIntAddr,
+			IFs:             make(map[common.IFIDType]*IFInfo),
 		}
 		for ifid, rawIntf := range rawBr.Interfaces {
 			var err error
@@ -228,10 +259,11 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 			}
 			brInfo.IFIDs = append(brInfo.IFIDs, ifid)
 			ifinfo := IFInfo{
-				ID:           ifid,
-				BRName:       name,
-				InternalAddr: intAddr,
-				MTU:          rawIntf.MTU,
+				ID:              ifid,
+				BRName:          name,
+				InternalAddr:    intAddr,
+				AltInternalAddr: altIntAddr,
+				MTU:             rawIntf.MTU,
 			}
 			if ifinfo.IA, err = addr.ParseIA(rawIntf.IA); err != nil {
 				return err
@@ -386,6 +418,8 @@ func (t *RWTopology) Copy() *RWTopology {
 		MTU:        t.MTU,
 		Attributes: append(t.Attributes[:0:0], t.Attributes...),
 
+		EndhostPorts: t.EndhostPorts,
+
 		BR:        copyBRMap(t.BR),
 		BRNames:   append(t.BRNames[:0:0], t.BRNames...),
 		IFInfoMap: t.IFInfoMap.copy(),
@@ -431,10 +465,11 @@ func (i *BRInfo) copy() *BRInfo {
 		return nil
 	}
 	return &BRInfo{
-		Name:         i.Name,
-		InternalAddr: copyUDPAddr(i.InternalAddr),
-		IFIDs:        append(i.IFIDs[:0:0], i.IFIDs...),
-		IFs:          copyIFsMap(i.IFs),
+		Name:            i.Name,
+		InternalAddr:    copyUDPAddr(i.InternalAddr),
+		AltInternalAddr: copyUDPAddr(i.AltInternalAddr),
+		IFIDs:           append(i.IFIDs[:0:0], i.IFIDs...),
+		IFs:             copyIFsMap(i.IFs),
 	}
 }
 
@@ -481,6 +516,15 @@ func svcMapFromRaw(ras map[string]*jsontopo.ServerInfo) (IDAddrMap, error) {
 			return nil, serrors.WrapStr("could not parse address", err,
 				"address", svc.Addr, "process_name", name)
 		}
+		alt, err := rawAltAddrToUDPAddr(svc.AltAddr, svcTopoAddr.SCIONAddress)
+		if err != nil {
+			return nil, serrors.WrapStr("could not parse alternative address", err,
+				"address", svc.AltAddr, "process_name", name)
+		}
+		if alt != nil {
+			svcTopoAddr.AltSCIONAddress = alt
+			svcTopoAddr.AltUnderlayAddress = endhostAddr(alt)
+		}
 		svcMap[name] = *svcTopoAddr
 	}
 	return svcMap, nil
@@ -574,16 +618,17 @@ func (i *IFInfo) copy() *IFInfo {
 		return nil
 	}
 	return &IFInfo{
-		ID:           i.ID,
-		BRName:       i.BRName,
-		Underlay:     i.Underlay,
-		InternalAddr: copyUDPAddr(i.InternalAddr),
-		Local:        copyUDPAddr(i.Local),
-		Remote:       copyUDPAddr(i.Remote),
-		RemoteIFID:   i.RemoteIFID,
-		IA:           i.IA,
-		LinkType:     i.LinkType,
-		MTU:          i.MTU,
+		ID:              i.ID,
+		BRName:          i.BRName,
+		Underlay:        i.Underlay,
+		InternalAddr:    copyUDPAddr(i.InternalAddr),
+		AltInternalAddr: copyUDPAddr(i.AltInternalAddr),
+		Local:           copyUDPAddr(i.Local),
+		Remote:          copyUDPAddr(i.Remote),
+		RemoteIFID:      i.RemoteIFID,
+		IA:              i.IA,
+		LinkType:        i.LinkType,
+		MTU:             i.MTU,
 	}
 }
 
@@ -595,7 +640,23 @@ func (a *TopoAddr) UnderlayAddr() *net.UDPAddr {
 	return a.UnderlayAddress
 }
 
+// ForFamily returns the addresses of the service in the IP family of ip. These
+// are the alternative addresses if only they are in that family, and the
+// primary addresses otherwise. If ip is nil, the primary addresses are
+// returned.
+func (a *TopoAddr) ForFamily(ip net.IP) *TopoAddr {
+	if ip == nil || a.AltSCIONAddress == nil ||
+		sameFamily(a.SCIONAddress.IP, ip) || !sameFamily(a.AltSCIONAddress.IP, ip) {
+		return &TopoAddr{SCIONAddress: a.SCIONAddress, UnderlayAddress: a.UnderlayAddress}
+	}
+	return &TopoAddr{SCIONAddress: a.AltSCIONAddress, UnderlayAddress: a.AltUnderlayAddress}
+}
+
 func (a *TopoAddr) String() string {
+	if a.AltSCIONAddress != nil {
+		return fmt.Sprintf("TopoAddr{SCION: %v, Underlay: %v, AltSCION: %v, AltUnderlay: %v}",
+			a.SCIONAddress, a.UnderlayAddress, a.AltSCIONAddress, a.AltUnderlayAddress)
+	}
 	return fmt.Sprintf("TopoAddr{SCION: %v, Underlay: %v}", a.SCIONAddress, a.UnderlayAddress)
 }
 
@@ -605,8 +666,10 @@ func (a *TopoAddr) copy() *TopoAddr {
 		return nil
 	}
 	return &TopoAddr{
-		SCIONAddress:    copyUDPAddr(a.SCIONAddress),
-		UnderlayAddress: toUDPAddr(a.UnderlayAddress),
+		SCIONAddress:       copyUDPAddr(a.SCIONAddress),
+		UnderlayAddress:    toUDPAddr(a.UnderlayAddress),
+		AltSCIONAddress:    copyUDPAddr(a.AltSCIONAddress),
+		AltUnderlayAddress: copyUDPAddr(a.AltUnderlayAddress),
 	}
 }
 
@@ -643,3 +706,8 @@ func copyUDPAddr(a *net.UDPAddr) *net.UDPAddr {
 		Zone: a.Zone,
 	}
 }
+
+// sameFamily returns whether a and b are both IPv4 or both IPv6 addresses.
+func sameFamily(a, b net.IP) bool {
+	return (a.To4() == nil) == (b.To4() == nil)
+}
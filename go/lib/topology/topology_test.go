@@ -36,6 +36,23 @@ func TestMeta(t *testing.T) {
 	assert.Equal(t, addr.MustIAFrom(1, 0xff0000000311), c.IA, "Field 'ISD_AS'")
 	assert.Equal(t, 1472, c.MTU, "Field 'MTU'")
 	assert.Empty(t, c.Attributes, "Field 'Attributes'")
+	assert.Equal(t, underlay.PortRange{Start: 31000, End: 32767}, c.EndhostPorts,
+		"Field 'EndhostPortRange'")
+}
+
+func TestEndhostPortRange(t *testing.T) {
+	for r, assertErr := range map[string]assert.ErrorAssertionFunc{
+		"":            assert.NoError,
+		"31000-32767": assert.NoError,
+		"32767-31000": assert.Error,
+		"30000-31000": assert.Error,
+	} {
+		_, err := RWTopologyFromJSONTopology(&jsontopo.Topology{
+			IA:               "1-ff00:0:311",
+			EndhostPortRange: r,
+		})
+		assertErr(t, err, r)
+	}
 }
 
 func TestActive(t *testing.T) {
@@ -567,6 +584,76 @@ func TestRawAddrMap_ToTopoAddr(t *testing.T) {
 	}
 }
 
+func TestDualStack(t *testing.T) {
+	c := MustLoadTopo(t, "testdata/dualstack.json")
+
+	br := c.BR["br1-ff00:0:311-1"]
+	assert.Equal(t, &net.UDPAddr{IP: net.IP{10, 1, 0, 1}, Port: 30042}, br.InternalAddr)
+	assert.Equal(t, &net.UDPAddr{IP: net.ParseIP("2001:db8:a0b:12f0::1"), Port: 30042},
+		br.AltInternalAddr)
+	assert.Equal(t, br.AltInternalAddr, c.IFInfoMap[1].AltInternalAddr)
+	assert.Equal(t, br.AltInternalAddr, c.Copy().BR["br1-ff00:0:311-1"].AltInternalAddr)
+
+	cs := c.CS["cs1-ff00:0:311-1"]
+	assert.Equal(t, &TopoAddr{
+		SCIONAddress:       &net.UDPAddr{IP: net.IP{10, 1, 0, 2}, Port: 30254},
+		UnderlayAddress:    &net.UDPAddr{IP: net.IP{10, 1, 0, 2}, Port: 30041},
+		AltSCIONAddress:    &net.UDPAddr{IP: net.ParseIP("2001:db8:a0b:12f0::2"), Port: 30254},
+		AltUnderlayAddress: &net.UDPAddr{IP: net.ParseIP("2001:db8:a0b:12f0::2"), Port: 30041},
+	}, &cs)
+	assert.Equal(t, cs, c.Copy().CS["cs1-ff00:0:311-1"])
+
+	t.Run("ForFamily", func(t *testing.T) {
+		v4 := &TopoAddr{SCIONAddress: cs.SCIONAddress, UnderlayAddress: cs.UnderlayAddress}
+		v6 := &TopoAddr{SCIONAddress: cs.AltSCIONAddress, UnderlayAddress: cs.AltUnderlayAddress}
+		assert.Equal(t, v4, cs.ForFamily(nil))
+		assert.Equal(t, v4, cs.ForFamily(net.IP{10, 1, 0, 1}))
+		assert.Equal(t, v6, cs.ForFamily(net.ParseIP("2001:db8:a0b:12f0::1")))
+		single := c.CS["cs1-ff00:0:311-2"]
+		assert.Equal(t, single.SCIONAddress, single.ForFamily(net.IP{10, 1, 0, 1}).SCIONAddress)
+	})
+	t.Run("UnderlayMulticastFor", func(t *testing.T) {
+		topo := topologyS{Topology: c}
+		addrs, err := topo.UnderlayMulticastFor(addr.SvcCS, net.ParseIP("2001:db8:a0b:12f0::1"))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*net.UDPAddr{
+			{IP: net.ParseIP("2001:db8:a0b:12f0::2"), Port: 30041},
+			{IP: net.ParseIP("2001:db8:a0b:12f0::3"), Port: 30041},
+		}, addrs)
+		addrs, err = topo.UnderlayMulticast(addr.SvcCS)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*net.UDPAddr{
+			{IP: net.IP{10, 1, 0, 2}, Port: 30041},
+			{IP: net.ParseIP("2001:db8:a0b:12f0::3"), Port: 30041},
+		}, addrs)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testCases := map[string]*jsontopo.Topology{
+			"same family": {
+				IA: "1-ff00:0:311",
+				ControlService: map[string]*jsontopo.ServerInfo{
+					"cs": {Addr: "10.1.0.2:30254", AltAddr: "10.1.0.3:30254"},
+				},
+			},
+			"different router ports": {
+				IA: "1-ff00:0:311",
+				BorderRouters: map[string]*jsontopo.BRInfo{
+					"br": {
+						InternalAddr:    "10.1.0.1:30042",
+						AltInternalAddr: "[2001:db8:a0b:12f0::1]:30043",
+					},
+				},
+			},
+		}
+		for name, raw := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := RWTopologyFromJSONTopology(raw)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
 func TestServiceNamesGetRandom(t *testing.T) {
 	names := ServiceNames(nil)
 	name, err := names.GetRandom()
@@ -18,6 +18,7 @@ package underlay
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -94,3 +95,76 @@ func (ot Type) IsUDP() bool {
 	}
 	return false
 }
+
+// PortRange is a range of underlay ports, both ends inclusive, to which the end
+// hosts of an AS bind their SCION/UDP sockets directly, without the
+// dispatcher. The packets for a SCION/UDP port in the range are delivered to
+// the same underlay port, and all other packets to EndhostPort. The zero value
+// is the empty range. In text, it is written as "start-end".
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+// ParsePortRange parses a port range of the form "start-end". The empty string
+// is the empty range.
+func ParsePortRange(s string) (PortRange, error) {
+	if s == "" {
+		return PortRange{}, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return PortRange{}, serrors.New("port range must be of the form start-end", "range", s)
+	}
+	start, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return PortRange{}, serrors.WrapStr("parsing start port", err, "range", s)
+	}
+	end, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return PortRange{}, serrors.WrapStr("parsing end port", err, "range", s)
+	}
+	if start == 0 || start > end {
+		return PortRange{}, serrors.New("invalid port range", "range", s)
+	}
+	return PortRange{Start: uint16(start), End: uint16(end)}, nil
+}
+
+// Empty returns whether the range contains no port.
+func (r PortRange) Empty() bool {
+	return r.Start == 0 || r.Start > r.End
+}
+
+// Contains returns whether port is in the range.
+func (r PortRange) Contains(port uint16) bool {
+	return !r.Empty() && r.Start <= port && port <= r.End
+}
+
+// UnderlayPort returns the underlay port to which the packets for the
+// SCION/UDP port of an end host are delivered.
+func (r PortRange) UnderlayPort(port uint16) int {
+	if r.Contains(port) {
+		return int(port)
+	}
+	return EndhostPort
+}
+
+func (r PortRange) String() string {
+	if r.Empty() {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+func (r PortRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *PortRange) UnmarshalText(text []byte) error {
+	pr, err := ParsePortRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = pr
+	return nil
+}
@@ -32,3 +32,54 @@ func TestTypeUnmarshalJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal([]byte(`{"type": "UDP/IPv4"}`), &e))
 	assert.Equal(t, exampleStruct{Type: underlay.UDPIPv4}, e)
 }
+
+func TestPortRange(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		testCases := map[string]struct {
+			input       string
+			want        underlay.PortRange
+			assertError assert.ErrorAssertionFunc
+		}{
+			"empty": {input: "", assertError: assert.NoError},
+			"range": {
+				input:       "31000-32767",
+				want:        underlay.PortRange{Start: 31000, End: 32767},
+				assertError: assert.NoError,
+			},
+			"single port": {
+				input:       "31000-31000",
+				want:        underlay.PortRange{Start: 31000, End: 31000},
+				assertError: assert.NoError,
+			},
+			"single number": {input: "31000", assertError: assert.Error},
+			"reversed":      {input: "32767-31000", assertError: assert.Error},
+			"zero start":    {input: "0-31000", assertError: assert.Error},
+			"too large":     {input: "31000-65536", assertError: assert.Error},
+		}
+		for name, tc := range testCases {
+			t.Run(name, func(t *testing.T) {
+				r, err := underlay.ParsePortRange(tc.input)
+				tc.assertError(t, err)
+				assert.Equal(t, tc.want, r)
+			})
+		}
+	})
+	t.Run("underlay port", func(t *testing.T) {
+		r := underlay.PortRange{Start: 31000, End: 32767}
+		assert.Equal(t, 31000, r.UnderlayPort(31000))
+		assert.Equal(t, 32767, r.UnderlayPort(32767))
+		assert.Equal(t, underlay.EndhostPort, r.UnderlayPort(30999))
+		assert.Equal(t, underlay.EndhostPort, underlay.PortRange{}.UnderlayPort(31000))
+	})
+	t.Run("text", func(t *testing.T) {
+		type exampleStruct struct {
+			Ports underlay.PortRange `json:"ports"`
+		}
+		var e exampleStruct
+		require.NoError(t, json.Unmarshal([]byte(`{"ports": "31000-32767"}`), &e))
+		assert.Equal(t, underlay.PortRange{Start: 31000, End: 32767}, e.Ports)
+		raw, err := json.Marshal(e)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ports": "31000-32767"}`, string(raw))
+	})
+}
@@ -60,6 +60,10 @@ type Config struct {
 
 // New opens a new underlay socket on the specified addresses.
 //
+// If only the listen address is set and its IP is nil, a dual-stack socket is
+// opened on the wildcard address, which sends and receives both IPv4 and IPv6
+// packets on the listen port.
+//
 // The config can be used to customize socket behavior.
 func New(listen, remote *net.UDPAddr, cfg *Config) (Conn, error) {
 	a := listen
@@ -69,6 +73,9 @@ func New(listen, remote *net.UDPAddr, cfg *Config) (Conn, error) {
 	if listen == nil && remote == nil {
 		panic("either listen or remote must be set")
 	}
+	if remote == nil && listen.IP == nil {
+		return newConnUDPDualStack(listen, cfg)
+	}
 	if a.IP.To4() != nil {
 		return newConnUDPIPv4(listen, remote, cfg)
 	}
@@ -84,9 +91,10 @@ type connUDPBase struct {
 	ipProtoHandler ipProtoDetails
 }
 
-func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr, cfg *Config) error {
+func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr,
+	cfg *Config) (err error) {
+
 	var c *net.UDPConn
-	var err error
 	if laddr == nil {
 		return serrors.New("listen address must be specified")
 	}
@@ -101,6 +109,11 @@ func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr, cf
 				"network", network, "listen", laddr, "remote", raddr)
 		}
 	}
+	defer func() {
+		if err != nil {
+			c.Close()
+		}
+	}()
 	// Set and confirm receive buffer size
 	before, err := sockctrl.GetsockoptInt(c, syscall.SOL_SOCKET, syscall.SO_RCVBUF)
 	if err != nil {
@@ -0,0 +1,86 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.9 && linux
+// +build go1.9,linux
+
+package conn
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/net/ipv6"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// connUDPDualStack is an IPv6 socket on the wildcard address that sends and
+// receives IPv4 packets as well. The IPv4 addresses are reported as such, not
+// as IPv4-mapped IPv6 addresses.
+type connUDPDualStack struct {
+	connUDPIPv6
+}
+
+func newConnUDPDualStack(listen *net.UDPAddr, cfg *Config) (*connUDPDualStack, error) {
+	cc := &connUDPDualStack{}
+	cc.connUDPBase.ipProtoHandler = cc
+	// On the wildcard address, the network udp opens an IPv6 socket with
+	// IPV6_V6ONLY disabled, if the host supports it.
+	if err := cc.initConnUDP("udp", listen, nil, cfg); err != nil {
+		return nil, err
+	}
+	cc.pconn = ipv6.NewPacketConn(cc.conn)
+	return cc, nil
+}
+
+// ReadBatch reads up to len(msgs) packets, and stores them in msgs.
+// It returns the number of packets read, and an error if any.
+func (c *connUDPDualStack) ReadBatch(msgs Messages) (int, error) {
+	n, err := c.connUDPIPv6.ReadBatch(msgs)
+	for i := 0; i < n; i++ {
+		if a, ok := msgs[i].Addr.(*net.UDPAddr); ok {
+			a.IP = unmapIP(a.IP)
+		}
+	}
+	return n, err
+}
+
+func (c *connUDPDualStack) SetSocketOptions(so *net.UDPConn) error {
+	// Without IPv6 support, the network udp falls back to an IPv4 socket.
+	if so.LocalAddr().(*net.UDPAddr).IP.To4() != nil {
+		return serrors.New("dual-stack sockets are not supported by the host")
+	}
+	return c.connUDPIPv6.SetSocketOptions(so)
+}
+
+func (c *connUDPDualStack) BuildAddress(sa syscall.Sockaddr) *net.UDPAddr {
+	a := c.connUDPIPv6.BuildAddress(sa)
+	a.IP = unmapIP(a.IP)
+	return a
+}
+
+func (c *connUDPDualStack) GetDstAddress(msgs []syscall.SocketControlMessage) (net.IP, error) {
+	ip, err := c.connUDPIPv6.GetDstAddress(msgs)
+	return unmapIP(ip), err
+}
+
+// unmapIP returns the IPv4 address of an IPv4-mapped IPv6 address, and any
+// other address unchanged.
+func unmapIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.9 && linux
+// +build go1.9,linux
+
+package conn
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDualStack(t *testing.T) {
+	c, err := New(&net.UDPAddr{}, nil, &Config{ReceiveBufferSize: 1 << 16})
+	if err != nil {
+		t.Skipf("dual-stack sockets not supported: %v", err)
+	}
+	defer c.Close()
+	port := c.(*connUDPDualStack).conn.LocalAddr().(*net.UDPAddr).Port
+
+	tests := map[string]struct {
+		network string
+		ip      net.IP
+	}{
+		"IPv4": {network: "udp4", ip: net.IPv4(127, 0, 0, 1).To4()},
+		"IPv6": {network: "udp6", ip: net.IPv6loopback},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			peer, err := net.ListenUDP(tc.network, &net.UDPAddr{IP: tc.ip})
+			if err != nil {
+				t.Skipf("%s not supported: %v", name, err)
+			}
+			defer peer.Close()
+			peerAddr := peer.LocalAddr().(*net.UDPAddr)
+			require.NoError(t, peer.SetDeadline(time.Now().Add(time.Second)))
+			require.NoError(t, c.SetDeadline(time.Now().Add(time.Second)))
+
+			_, err = peer.WriteToUDP([]byte("request"), &net.UDPAddr{IP: tc.ip, Port: port})
+			require.NoError(t, err)
+			buf := make([]byte, 16)
+			n, from, to, err := c.ReadPacket(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "request", string(buf[:n]))
+			assert.Equal(t, peerAddr, from)
+			assert.Equal(t, tc.ip, to)
+
+			msgs := NewReadMessages(1)
+			msgs[0].Buffers[0] = []byte("reply")
+			msgs[0].Addr = from
+			_, err = c.WriteBatch(msgs, 0)
+			require.NoError(t, err)
+			n, _, err = peer.ReadFromUDP(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "reply", string(buf[:n]))
+
+			_, err = peer.WriteToUDP([]byte("batch"), &net.UDPAddr{IP: tc.ip, Port: port})
+			require.NoError(t, err)
+			msgs[0].Buffers[0] = buf
+			_, err = c.ReadBatch(msgs)
+			require.NoError(t, err)
+			assert.Equal(t, "batch", string(buf[:msgs[0].N]))
+			assert.Equal(t, peerAddr, msgs[0].Addr)
+		})
+	}
+}
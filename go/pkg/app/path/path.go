@@ -70,6 +70,37 @@ func Filter(seq string, paths []snet.Path) ([]snet.Path, error) {
 	return s.Eval(paths), nil
 }
 
+// FilterACL filters out paths according to a list of ACL entries. The entries
+// may contain hop predicates and geo predicates. If the list is empty, all
+// paths are returned.
+func FilterACL(entries []string, paths []snet.Path) ([]snet.Path, error) {
+	if len(entries) == 0 {
+		return paths, nil
+	}
+	aclEntries := make([]*pathpol.ACLEntry, 0, len(entries))
+	for _, str := range entries {
+		entry := &pathpol.ACLEntry{}
+		if err := entry.LoadFromString(str); err != nil {
+			return nil, serrors.WrapStr("parsing ACL entry", err, "entry", str)
+		}
+		aclEntries = append(aclEntries, entry)
+	}
+	acl, err := pathpol.NewACL(aclEntries...)
+	if err != nil {
+		return nil, err
+	}
+	return acl.Eval(paths), nil
+}
+
+// LoadPolicy loads the path policy from the file. If file is empty, a nil
+// policy, which allows all paths, is returned.
+func LoadPolicy(file string) (*pathpol.Policy, error) {
+	if file == "" {
+		return nil, nil
+	}
+	return pathpol.LoadPolicyFromYaml(file)
+}
+
 // Choose selects a path to the remote.
 func Choose(
 	ctx context.Context,
@@ -79,7 +110,7 @@ func Choose(
 ) (snet.Path, error) {
 
 	o := applyOption(opts)
-	paths, err := fetchPaths(ctx, conn, remote, o.refresh, o.seq)
+	paths, err := fetchPaths(ctx, conn, remote, o.refresh, o.seq, o.policy)
 	if err != nil {
 		return nil, serrors.WrapStr("fetching paths", err)
 	}
@@ -149,6 +180,7 @@ func fetchPaths(
 	remote addr.IA,
 	refresh bool,
 	seq string,
+	policy *pathpol.Policy,
 ) ([]snet.Path, error) {
 
 	allPaths, err := conn.Paths(ctx, remote, 0, daemon.PathReqFlags{Refresh: refresh})
@@ -160,6 +192,7 @@ func fetchPaths(
 	if err != nil {
 		return nil, err
 	}
+	paths = policy.Filter(paths)
 	if len(paths) == 0 {
 		return nil, serrors.New("no path available")
 	}
@@ -295,6 +328,7 @@ type options struct {
 	interactive bool
 	refresh     bool
 	seq         string
+	policy      *pathpol.Policy
 	colorScheme ColorScheme
 	probeCfg    *ProbeConfig
 }
@@ -327,6 +361,12 @@ func WithSequence(seq string) Option {
 	}
 }
 
+func WithPolicy(policy *pathpol.Policy) Option {
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
 func WithColorScheme(cs ColorScheme) Option {
 	return func(o *options) {
 		o.colorScheme = cs
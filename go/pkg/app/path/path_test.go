@@ -64,3 +64,63 @@ func TestFilter(t *testing.T) {
 	}
 
 }
+
+func TestFilterACL(t *testing.T) {
+	inZurich := path.Path{
+		Dst: xtest.MustParseIA("1-ff00:0:112"),
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 1},
+				{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 2},
+			},
+			Geo: []snet.GeoCoordinates{
+				{Latitude: 47.37, Longitude: 8.54, Address: "Zürich, Switzerland"},
+				{Latitude: 47.37, Longitude: 8.54, Address: "Zürich, Switzerland"},
+			},
+		},
+	}
+	testCases := map[string]struct {
+		input, want []snet.Path
+		acl         []string
+		asserFunc   assert.ErrorAssertionFunc
+	}{
+		"no entries": {
+			input:     []snet.Path{inZurich},
+			want:      []snet.Path{inZurich},
+			asserFunc: assert.NoError,
+		},
+		"allowed": {
+			input:     []snet.Path{inZurich},
+			acl:       []string{"- addr=Germany", "+"},
+			want:      []snet.Path{inZurich},
+			asserFunc: assert.NoError,
+		},
+		"denied": {
+			input:     []snet.Path{inZurich},
+			acl:       []string{"+ 2", "-"},
+			want:      []snet.Path{},
+			asserFunc: assert.NoError,
+		},
+		"no default": {
+			acl:       []string{"- addr=Germany"},
+			asserFunc: assert.Error,
+		},
+		"invalid": {
+			acl:       []string{"dummy"},
+			asserFunc: assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := apppath.FilterACL(tc.acl, tc.input)
+			tc.asserFunc(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -56,5 +56,36 @@ Available operators:
   * (the preceding ISD-level HopPredicate may appear zero or more times)
   | (logical OR)
 
+`
+	// PolicyUsage defines the usage message for the policy flag.
+	PolicyUsage = "File with a path policy in YAML or JSON format (see doc/PathPolicy.md)"
+	// ACLUsage defines the usage message for the ACL flag.
+	ACLUsage = "ACL entry, can be repeated (entries are evaluated in order)"
+	// ACLHelp defines the help message for the ACL entries.
+	ACLHelp = `The paths can also be filtered with an ACL. An ACL is an ordered list of
+entries of the form '<+|-> [predicate]', where '+' allows and '-' denies the
+matching hops. Every interface on a path is checked against the entries in
+order and the first matching entry decides. A path is only allowed if all of
+its interfaces are allowed. The last entry must match all hops, i.e., it must
+not have a predicate, or it must be the predicate 0.
+
+In addition to hop predicates, an ACL entry can use geo predicates that match
+the static information announced by the ASes on the path:
+
+  Router address contains text (ignoring case):  addr=<text>
+  AS note contains text (ignoring case):         note=<text>
+  Router within radius (km) of a coordinate:     geo=<latitude>,<longitude>,<radius>
+  Router does not announce a location:           geo=unknown
+
+ACL Examples:
+
+  --acl="+ 1" --acl="+ 2" --acl="-"
+
+The above example only allows paths that stay within ISDs 1 and 2.
+
+  --acl="- addr=Germany" --acl="- geo=unknown" --acl="+"
+
+The above example denies paths with routers located in Germany, or with routers
+that do not announce their location.
 `
 )
@@ -134,6 +134,10 @@ type SDConfig struct {
 	// If HiddenPathGroups begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathGroups string `toml:"hidden_path_groups,omitempty"`
+	// PathPolicy is a file that contains a path policy in YAML or JSON
+	// format. If set, only paths that match the policy are returned to
+	// clients.
+	PathPolicy string `toml:"path_policy,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -64,4 +64,5 @@ func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Address)
 	assert.False(t, cfg.DisableSegVerification)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Empty(t, cfg.PathPolicy)
 }
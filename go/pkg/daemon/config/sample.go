@@ -33,4 +33,8 @@ query_interval = "5m"
 
 # The configuration containing hidden path groups. (default "")
 hidden_path_groups =  ""
+
+# The file containing a path policy in YAML or JSON format. If set, only paths
+# that match the policy are returned to clients. (default "")
+path_policy = ""
 `
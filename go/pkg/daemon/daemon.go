@@ -30,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -117,6 +118,9 @@ type ServerConfig struct {
 	DRKeyClient drkey.ClientEngine
 	ColFetcher  colibri.Fetcher
 	ColClient   *colibri.DaemonClient
+	// PathPolicy filters the paths that are returned to clients. If nil, all
+	// paths are returned.
+	PathPolicy *pathpol.Policy
 }
 
 // NewServer constructs a daemon API server.
@@ -131,6 +135,7 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 		DRKeyClient: cfg.DRKeyClient,
 		ColFetcher:  cfg.ColFetcher,
 		ColClient:   cfg.ColClient,
+		PathPolicy:  cfg.PathPolicy,
 		Metrics: servers.Metrics{
 			PathsRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -64,6 +65,9 @@ type DaemonServer struct {
 	DRKeyClient daemon_drkey.ClientEngine
 	ColFetcher  colibri.Fetcher
 	ColClient   *colibri.DaemonClient
+	// PathPolicy filters the paths that are returned to clients. If nil, all
+	// paths are returned.
+	PathPolicy *pathpol.Policy
 
 	Metrics Metrics
 
@@ -104,6 +108,7 @@ func (s *DaemonServer) paths(ctx context.Context,
 			"src", srcIA, "dst", dstIA, "refresh", req.Refresh)
 		return nil, err
 	}
+	paths = s.PathPolicy.Filter(paths)
 	reply := &sdpb.PathsResponse{}
 	for _, p := range paths {
 		reply.Paths = append(reply.Paths, pathToPB(p))
@@ -163,6 +168,13 @@ func pathToPB(path snet.Path) *sdpb.Path {
 	if ok {
 		raw = scionPath.Raw
 	}
+	var epicAuths *sdpb.EpicAuths
+	if meta.EpicAuths.SupportsEpic() {
+		epicAuths = &sdpb.EpicAuths{
+			AuthPhvf: meta.EpicAuths.AuthPHVF,
+			AuthLhvf: meta.EpicAuths.AuthLHVF,
+		}
+	}
 	nextHopStr := ""
 	if nextHop := path.UnderlayNextHop(); nextHop != nil {
 		nextHopStr = nextHop.String()
@@ -182,6 +194,7 @@ func pathToPB(path snet.Path) *sdpb.Path {
 		LinkType:        linkType,
 		InternalHops:    meta.InternalHops,
 		Notes:           meta.Notes,
+		EpicAuths:       epicAuths,
 	}
 
 }
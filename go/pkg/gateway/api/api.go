@@ -14,7 +14,22 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+)
+
+const (
+	// defaultCaptureDuration is the duration of a capture if none is requested.
+	defaultCaptureDuration = 10 * time.Second
+	// maxCaptureDuration is the maximum duration of a capture.
+	maxCaptureDuration = 5 * time.Minute
 )
 
 // Server implements the Posix Gateway Service API.
@@ -22,6 +37,9 @@ type Server struct {
 	Config   http.HandlerFunc
 	Info     http.HandlerFunc
 	LogLevel http.HandlerFunc
+	// Capturer captures the traffic of the dataplane. If nil, captures are
+	// not supported.
+	Capturer *dataplane.Capturer
 }
 
 // GetConfig is an indirection to the http handler.
@@ -43,3 +61,85 @@ func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	s.LogLevel(w, r)
 }
+
+// GetCapture captures the traffic of the dataplane and writes it as a pcapng
+// file. The response is only sent once the capture completes.
+func (s *Server) GetCapture(w http.ResponseWriter, r *http.Request, params GetCaptureParams) {
+	if s.Capturer == nil {
+		Error(w, Problem{
+			Status: http.StatusNotImplemented,
+			Title:  "capture not supported",
+			Type:   api.StringRef(api.NotImplemented),
+		})
+		return
+	}
+	opts := dataplane.CaptureOptions{
+		Packets:  true,
+		Shares:   true,
+		Duration: defaultCaptureDuration,
+	}
+	var errs serrors.List
+	if params.Duration != nil {
+		d, err := time.ParseDuration(*params.Duration)
+		switch {
+		case err != nil:
+			errs = append(errs, serrors.WrapStr("parsing duration", err))
+		case d <= 0 || d > maxCaptureDuration:
+			errs = append(errs, serrors.New("duration out of range", "duration", d,
+				"max", maxCaptureDuration))
+		default:
+			opts.Duration = d
+		}
+	}
+	if params.Count != nil {
+		if *params.Count < 1 {
+			errs = append(errs, serrors.New("count must be positive", "count", *params.Count))
+		}
+		opts.Count = *params.Count
+	}
+	if params.Packets != nil {
+		opts.Packets = *params.Packets
+	}
+	if params.Shares != nil {
+		opts.Shares = *params.Shares
+	}
+	if !opts.Packets && !opts.Shares {
+		errs = append(errs, serrors.New("neither packets nor shares are captured"))
+	}
+	if params.RemoteIsdAs != nil {
+		if ia, err := addr.ParseIA(string(*params.RemoteIsdAs)); err == nil {
+			opts.RemoteIA = ia
+		} else {
+			errs = append(errs, serrors.WrapStr("parsing remote_isd_as", err))
+		}
+	}
+	if err := errs.ToError(); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed query parameters",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pcapng")
+	w.Header().Set("Content-Disposition", `attachment; filename="capture.pcapng"`)
+	logger := log.FromCtx(r.Context())
+	stats, err := s.Capturer.Capture(r.Context(), w, opts)
+	if err != nil {
+		// The status has been sent with the first record already.
+		logger.Info("Capture failed", "err", err)
+		return
+	}
+	logger.Debug("Capture completed", "captured", stats.Captured, "dropped", stats.Dropped)
+}
+
+// Error creates an detailed error response.
+func Error(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	enc.Encode(p)
+}
@@ -18,17 +18,35 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVTW/bOBD9K8TsHgXL+dg96NYERWDAbYK6t8AHWhrLDCgOS1KuDUP/vRjSUmM5QYoe",
-	"ArQ3ajh88+bxcXSAkhpLBk3wUBzAobdkPMaPG1l9wW8t+sBfJZmAJi6ltVqVMigy+ZMnwzFfbrCRvPrX",
-	"4RoK+Cf/CZ2nXZ8vgjSVdNVH58hB13UZVOhLpyyDQcE1hTsW5d3jQcadUz3HLWpeW0cWXVCJqO7Dp1hz",
-	"qmtlapG2M0DTNlA8QoWrtoYMlFkThyOXZQa4k43VCEW/E/aWv3xwytSRDlNTDiuGSbDLIY1WT1gG6DI4",
-	"7fKMLvbhU7oxWzTovazxzeoD61F1zov0z/AX6LaqRPHhYSbW5ETYoHggr3biTgb8LvdcU4UowEmcT0AG",
-	"W3Q+IU0n08kFN0oWjbQKCriaTCeXkIGVYRObzEsya1XzssboGpYgemZWQQF3GG5TRnbqusvpdGS3gLuQ",
-	"Wy3VyGhjgc7MtGjLEr1ft1rc98WZ9nUq8ZJRByr5M/dHI7ZNI92etXHKBB/l+3r/aS5So22CF2ulccJK",
-	"ytrzPZXUNGRgyRh5fzGvKTJLvvuz9LiRXpWCW3NN0sDKGoVcURuiSkzdkRb+aEDriGm8qpKmOh+e9GtS",
-	"DdPgTbl+f1oNNd5NyzsMQo/G1plGGdj2BVEWI1Ei/g1V+3fRox+2z+unWRVci91fdUuLX7mleAQdT00o",
-	"Hg/QOg0FbEKwRZ4fNuRDVxwsudDl0qp8e8EjVjolVzppxClpiq9lqwMUoKmUOobZA+RG21fT6//+ZxWW",
-	"A53xP+A2sou/ANxZ8liJ1V4sbmf3n/vnGd+lkQ2PkmMz3bL7EQAA//93UAL/KQgAAA==",
+	"H4sIAAAAAAAC/9xYW4/buBX+KwfcfehiZVvOpdvobZKdpkYnO0Y8waLIToNj8kjijkQqJOXYneq/FyRl",
+	"Rb5MLiiQovtmkYeHH79z9z3jum60IuUsy+6ZIdtoZSl8PEfxmt63ZJ3/4lo5UuEnNk0lOTqp1ex3q5Vf",
+	"s7ykGv2v7w3lLGPfzT6qnsVdO1s5VAKNuDRGG9Z1XcIEWW5k45WxzN8Jpr/U7/YHvd6FFRfhB22xbipi",
+	"GZtP8jxNszSbz1OWsAadI+PV/PO338SPkz+9xUmeTp7d3s+TJ132w/2j7nDph397ue9Zwpx0QeNi9fPk",
+	"YgULQcrJXJLxe7vGb1lnpCpYl7ArXVzRhioPpjG6IeNkpKzaLx++6koXhVQFxO2EkWprlr1lgtZtwRIm",
+	"Va79cmDlNhm9sN85gtAlzJMkDQmvJqq9HcT0+nfiziNdGr2uqD4FKsihPIP0Asq2RgWGUOC6IqBtU6EK",
+	"tgbbEJe55OA0uFJa0Jy3xpDiBDoHVxI08UJwJTqQFkqqmryt/IlKc3R0IIVKQCE3BCg20itRUOoPXrgx",
+	"mhOJKfxqpHOkQCq4VEUlbRlODfhybYBUIRWRsQm0tsWq2oHSDmwrHYkgobQCR7xUkmMF1uEdlboSZGzQ",
+	"5qU9vEr+i8SUjQ3wQitFPDzfaRDocI2WwMmaBOjWnfMPqaxDxekcvW9eL8BQTpG1SNPe2WwgZ2D5QXYT",
+	"oGkxhfUOUAjvVwi5waImNVJmQBuw7XrSoCujxUbm2TU0hVe4gzVBa0kcGcho7eKl0g6HpIr4dGs4AdeC",
+	"Dqma9YIzPnA2CS79ndN3pCbelyfecJPA3iSyl2tTo2MZa42cDMyco9U6dK09JfWmJPjbzc0SokBABgUp",
+	"Mujtv94F2NrIQiqwZDZkglN82oUP3vY0fZywGrey9oH79NmzhNVSxa95mg5gpXJUkGHdkFFOPcCW2njn",
+	"rGs0u5O4CYb5Xzv9ikyIxzcKNygrf+c5g8QF/8Ic28rbENe6ddm6QnXHki/x/VbJ9y1Vu+MgGPMBWlW7",
+	"vfeFMrR1I942UpCAi+ViCtdNo3tnHkdSzF5Sweu/vpj89Jf0pwRkyE6KpCvJgCGu65qUiGfXBIL2QAPh",
+	"nq9GS+X8NsYcORnMITRvffDFe5Q2UFR6HUwS39e725GZvyx4viJEjspCHy97VzxXHw7r8UmVoP3yoSWD",
+	"NNRkLRafhzFUtaPbu64vfCf69+53sVwMzC21lVt4iY4+4G5UsA/W/QmWsA0ZGzWl03Q69w/VDSlsJMvY",
+	"42k6fRSbhTI8csaxca0JnlyQO4XzIu5Hx3StUlSRgAb5HbkYSn7jyWoJtkQvR1teoipIwAfpyphQqdaO",
+	"oIg4bdLHs2uNCnprQAsIDcdGFZDLikDHICHo8YHvpypyZKdwuSGz2yuNPUsCIdF7vUKavmRJuz8twH86",
+	"C/qDDwVHJkdOU/DJs4eNhj5KD8grqe5iGF4tfvn7zT+Wl+/erC5fp+EmjipGi8+5o0OejF+lIa/5DoS0",
+	"lrjTxru8968QKAvBMvaSXE9vMInBmhwZy7K3x1b4uY3H9pHfI53CwkHdWhfyGW05kYCnUEvVeqZCc8Uy",
+	"9r4l491GYU0sY6JXxpJR4/oxjc1TexCcj8P3iZsfQ3wVCwSotl6T8UAHOsfeEumO1J/YFghNtYvGj0nK",
+	"EPKSxENP4bpV7uAdA+55mqbjQnVapk7f0FvjrK8/BKHfPk+mMy0NF6+1rgjV5y7+4jh6CFFU8N8BuvZV",
+	"h4/pMJiHviwfQ4nR9xCQKPROWvEOD/F8akyKo07X3SaHE9mjNP3EKLadxPRxOI4NxWMtFQZox37cJedN",
+	"cdZrfTJ98kkYfSH78esmw/2kcgbOQm2wksNcOI2DYeyfjj320ES+XffjSyhSWPi0wvbZ/tZr8dU2l8Uo",
+	"9Z+mpyjxWUv4rmTWVCiPHv1Ztlct52Stn5Ku95ePWD5H2gBlNprRD1lZGqlcLFk316+uID50n0N9gZmO",
+	"SdF1rVXPyb4oP8TIIs6k/198PEcrOUgVw8Fz0GBBEBrWobE0ugLbNx9hArX2QZYqXcyGcf8hqoZ/Cr4q",
+	"kL8ucoY7vhmXL8lPDod/aZxwlLCmPUPK6oiUoP+5Frtvwsf+j5jx/bFP9VWh+0NZafUlVgpHwkgce67W",
+	"VCxjpXNNNpvdl9q6LrtvtHHdDBs528x9e41G+rkncORFDpunMFOGZe8D2hxtP06fPP2zZ+F2gHNceZeh",
+	"7Ay19ziZT0fNTxRhZ9qJ8MIwQtA2ToXrHaxeLK5/2Ye4HSsK4qy77f4zACxiNw0TFQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -12,6 +12,9 @@ const (
 	LogLevelLevelInfo LogLevelLevel = "info"
 )
 
+// IsdAs defines model for IsdAs.
+type IsdAs string
+
 // LogLevel defines model for LogLevel.
 type LogLevel struct {
 	// Logging level
@@ -21,6 +24,24 @@ type LogLevel struct {
 // Logging level
 type LogLevelLevel string
 
+// Problem defines model for Problem.
+type Problem struct {
+	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Detail *string `json:"detail,omitempty"`
+
+	// A URI reference that identifies the specific occurrence of the problem, e.g. by adding a fragment identifier or sub-path to the problem type. May be used to locate the root of this problem in the source code.
+	Instance *string `json:"instance,omitempty"`
+
+	// The HTTP status code generated by the origin server for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// A short summary of the problem type. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Title string `json:"title"`
+
+	// A URI reference that uniquely identifies the problem type only in the context of the provided API. Opposed to the specification in RFC-7807, it is neither recommended to be dereferencable and point to a human-readable documentation nor globally unique for the problem type.
+	Type *string `json:"type,omitempty"`
+}
+
 // StandardError defines model for StandardError.
 type StandardError struct {
 	// Error message
@@ -30,6 +51,24 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest StandardError
 
+// GetCaptureParams defines parameters for GetCapture.
+type GetCaptureParams struct {
+	// Duration of the capture. It must not exceed 5 minutes.
+	Duration *string `json:"duration,omitempty"`
+
+	// Maximum number of captured packets and shares. The capture completes early once it is reached.
+	Count *int `json:"count,omitempty"`
+
+	// Capture the tunneled packets.
+	Packets *bool `json:"packets,omitempty"`
+
+	// Capture the shares exchanged with the remote gateways.
+	Shares *bool `json:"shares,omitempty"`
+
+	// Only capture the traffic of the remote ISD-AS.
+	RemoteIsdAs *IsdAs `json:"remote_isd_as,omitempty"`
+}
+
 // SetLogLevelJSONBody defines parameters for SetLogLevel.
 type SetLogLevelJSONBody LogLevel
 
@@ -0,0 +1,181 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri"
+	"github.com/scionproto/scion/go/lib/colibri/client"
+	"github.com/scionproto/scion/go/lib/colibri/client/sorting"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+)
+
+// ColibriReserver sets up COLIBRI E2E reservations from the local gateway to
+// a remote gateway. Each reservation is set up on a trip that shares no link
+// with the paths of the other share indices of the session, and that
+// satisfies the path policy of the session.
+type ColibriReserver struct {
+	Daemon   daemon.Connector
+	LocalIA  addr.IA
+	LocalIP  net.IP
+	RemoteIA addr.IA
+	RemoteIP net.IP
+	// Bandwidth is the bandwidth class requested for each reservation.
+	Bandwidth reservation.BWCls
+	// PathPolicy, if set, filters the trips the reservations are set up on.
+	PathPolicy policies.PathPolicy
+}
+
+// Reserve implements dataplane.Reserver. The trips with the most bandwidth
+// and the fewest ASes are preferred.
+func (r *ColibriReserver) Reserve(ctx context.Context, avoid []snet.Path,
+	events dataplane.ReservationEvents) (dataplane.Reservation, error) {
+
+	stitchable, err := r.Daemon.ColibriListRsvs(ctx, r.RemoteIA)
+	if err != nil {
+		return nil, serrors.WrapStr("listing segment reservations", err,
+			"remote_isd_as", r.RemoteIA)
+	}
+	trips := colibri.CombineAll(stitchable)
+	sort.SliceStable(trips, func(a, b int) bool {
+		return sorting.ByNumberOfASes(*trips[a], *trips[b])
+	})
+	sort.SliceStable(trips, func(a, b int) bool {
+		return sorting.ByBW(*trips[a], *trips[b])
+	})
+	var used []snet.PathInterface
+	for _, path := range avoid {
+		if meta := path.Metadata(); meta != nil {
+			used = append(used, meta.Interfaces...)
+		}
+	}
+
+	// Admission may fail on some of the trips, so the next trip is tried if
+	// needed.
+	var errs serrors.List
+	for _, trip := range client.TripsAvoiding(trips, used...) {
+		if !r.allowed(trip) {
+			continue
+		}
+		rsv, err := r.open(ctx, trip, events)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return rsv, nil
+	}
+	if len(errs) == 0 {
+		return nil, serrors.New("no disjoint trip available", "remote_isd_as", r.RemoteIA)
+	}
+	return nil, errs.ToError()
+}
+
+// allowed returns whether the trip satisfies the path policy.
+func (r *ColibriReserver) allowed(trip *colibri.FullTrip) bool {
+	if r.PathPolicy == nil {
+		return true
+	}
+	path := snetpath.Path{
+		Dst:  r.RemoteIA,
+		Meta: tripMetadata(*trip),
+	}
+	return len(r.PathPolicy.Filter([]snet.Path{path})) != 0
+}
+
+// open sets up the reservation on the trip.
+func (r *ColibriReserver) open(ctx context.Context, trip *colibri.FullTrip,
+	events dataplane.ReservationEvents) (*colibriReservation, error) {
+
+	rsv, err := client.NewReservationOnTrip(ctx, r.Daemon, r.LocalIA, r.LocalIP,
+		r.RemoteIA, r.RemoteIP, trip, r.Bandwidth, 0)
+	if err != nil {
+		return nil, serrors.WrapStr("creating reservation", err, "trip", trip)
+	}
+	c := &colibriReservation{
+		rsv:      rsv,
+		remoteIA: r.RemoteIA,
+	}
+	renewed := func(*client.Reservation) {
+		if events.Renewed != nil {
+			events.Renewed(c)
+		}
+	}
+	failed := func(*client.Reservation, error) *colibri.FullTrip {
+		c.mtx.Lock()
+		c.failed = true
+		c.mtx.Unlock()
+		if events.Failed != nil {
+			events.Failed(c)
+		}
+		return nil
+	}
+	if err := rsv.Open(ctx, renewed, failed); err != nil {
+		return nil, serrors.WrapStr("setting up reservation", err, "trip", trip)
+	}
+	return c, nil
+}
+
+// colibriReservation is a COLIBRI E2E reservation of a session.
+type colibriReservation struct {
+	rsv      *client.Reservation
+	remoteIA addr.IA
+
+	mtx sync.Mutex
+	// failed is set once the renewal of the reservation failed.
+	failed bool
+}
+
+// Path returns the COLIBRI path of the reservation. The path metadata lists
+// the interfaces of the trip of the reservation. The MTU is not known.
+func (c *colibriReservation) Path() snet.Path {
+	return snetpath.Path{
+		Dst:           c.remoteIA,
+		DataplanePath: c.rsv.Dataplane(),
+		NextHop:       c.rsv.UnderlayNextHop(),
+		Meta:          tripMetadata(c.rsv.CurrentTrip()),
+	}
+}
+
+// tripMetadata returns the path metadata of the trip. Only the interfaces and
+// the expiration time are known.
+func tripMetadata(trip colibri.FullTrip) snet.PathMetadata {
+	return snet.PathMetadata{
+		Interfaces: trip.PathSteps().Interfaces(),
+		Expiry:     trip.ExpirationTime(),
+	}
+}
+
+// Close tears down the reservation. A reservation whose renewal failed
+// already stopped renewing and expires on its own, so it is left alone.
+func (c *colibriReservation) Close(ctx context.Context) error {
+	c.mtx.Lock()
+	failed := c.failed
+	c.mtx.Unlock()
+	if failed {
+		return nil
+	}
+	return c.rsv.Close(ctx)
+}
@@ -19,7 +19,13 @@ import (
 	"net"
 	"strconv"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 // Defaults.
@@ -35,6 +41,15 @@ const (
 	DefaultTunnelRoutingTableID = 11
 )
 
+// Tunnel modes.
+const (
+	// TunnelModeIP tunnels IPv4 and IPv6 packets over TUN devices.
+	TunnelModeIP = "ip"
+	// TunnelModeEthernet tunnels Ethernet frames, including VLAN-tagged
+	// frames, over TAP devices.
+	TunnelModeEthernet = "ethernet"
+)
+
 // Gateway holds the gateway specific configuration.
 type Gateway struct {
 	config.NoDefaulter
@@ -51,6 +66,12 @@ type Gateway struct {
 	DataAddr string `toml:"data_addr,omitempty"`
 	// Probe address, for probing paths.
 	ProbeAddr string `toml:"probe_addr,omitempty"`
+	// TopologyFile is the file path of the topology of the local AS. If the
+	// topology has an end host port range, the data and probe connections bind
+	// their ports directly instead of registering with the dispatcher, and the
+	// ports of DataAddr and ProbeAddr must be in the range.
+	// (default "", use the dispatcher)
+	TopologyFile string `toml:"topology_file,omitempty"`
 }
 
 func (cfg *Gateway) Validate() error {
@@ -66,6 +87,49 @@ func (cfg *Gateway) Validate() error {
 	return nil
 }
 
+// EndhostPorts returns the end host port range from the topology file. The
+// range is empty if no topology file is configured. It is an error if the
+// ports of DataAddr and ProbeAddr are not in a non-empty range.
+func (cfg *Gateway) EndhostPorts() (underlay.PortRange, error) {
+	if cfg.TopologyFile == "" {
+		return underlay.PortRange{}, nil
+	}
+	topo, err := topology.RWTopologyFromJSONFile(cfg.TopologyFile)
+	if err != nil {
+		return underlay.PortRange{}, serrors.WrapStr("loading topology", err,
+			"file", cfg.TopologyFile)
+	}
+	if topo.EndhostPorts.Empty() {
+		return underlay.PortRange{}, nil
+	}
+	for name, a := range map[string]string{
+		"data_addr":  cfg.DataAddr,
+		"probe_addr": cfg.ProbeAddr,
+	} {
+		if err := checkPortInRange(a, topo.EndhostPorts); err != nil {
+			return underlay.PortRange{}, serrors.WithCtx(err, "field", name)
+		}
+	}
+	return topo.EndhostPorts, nil
+}
+
+// checkPortInRange checks that the port of the address is in the port range.
+func checkPortInRange(address string, ports underlay.PortRange) error {
+	_, p, err := net.SplitHostPort(address)
+	if err != nil {
+		return serrors.WrapStr("parsing address", err, "address", address)
+	}
+	port, err := strconv.ParseUint(p, 10, 16)
+	if err != nil {
+		return serrors.WrapStr("parsing port", err, "address", address)
+	}
+	if !ports.Contains(uint16(port)) {
+		return serrors.New("port outside of the end host port range",
+			"port", port, "range", ports)
+	}
+	return nil
+}
+
 func (cfg *Gateway) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, gatewaySample)
 }
@@ -80,6 +144,10 @@ type Tunnel struct {
 
 	// Name is the name of TUN device to create.
 	Name string `toml:"name,omitempty"`
+	// Mode is the tunnel mode, either TunnelModeIP or TunnelModeEthernet. In
+	// ethernet mode, all devices are TAP devices, and no routes are installed
+	// on them. (default TunnelModeIP)
+	Mode string `toml:"mode,omitempty"`
 	// SrcIPv4 is the source address to put into the routing table.
 	SrcIPv4 net.IP `toml:"src_ipv4,omitempty"`
 	// SrcIPv6 is the source address to put into the routing table.
@@ -87,12 +155,75 @@ type Tunnel struct {
 	NumberOfPathsT int    `toml:"number_of_paths_t,omitempty"`
 	NumberOfPathsN int    `toml:"number_of_paths_n,omitempty"`
 	AESKey         string `toml:"aes_key,omitempty"`
+	// DRKey makes the gateway derive the keys of the frames from DRKey for the
+	// 4SP protocol, through the SCION Daemon, instead of using AESKey. Every
+	// direction between two gateways uses its own Host-Host key, which changes
+	// with the DRKey epochs. The remote gateways must enable it too.
+	// (default false)
+	DRKey bool `toml:"drkey,omitempty"`
+	// FollowReplyPaths makes the shares sent to a remote gateway follow the
+	// paths on which the shares of the remote gateway arrive, per share index.
+	// The paths selected by the gateway are used for the share indices that
+	// were not received recently. (default false)
+	FollowReplyPaths bool `toml:"follow_reply_paths,omitempty"`
+	// PMTUDiscovery enables the discovery of the MTU of the paths with probe
+	// frames. IP packets that do not fit into a single frame are then dropped
+	// and answered with ICMP Fragmentation Needed or Packet Too Big errors,
+	// instead of being split across frames. (default false)
+	PMTUDiscovery bool `toml:"pmtu_discovery,omitempty"`
+	// ColibriBandwidth, if set, is the bandwidth class of the COLIBRI E2E
+	// reservations the shares are sent over. A reservation is set up per share
+	// index, on trips that share no link. Share indices without reservation,
+	// e.g., because the admission failed, use the best-effort paths.
+	// (default 0, no reservations)
+	ColibriBandwidth reservation.BWCls `toml:"colibri_bw_class,omitempty"`
+	// Devices lists additional tunnel devices. Traffic that is not mapped to
+	// any of them uses the device called Name.
+	Devices []TunnelDevice `toml:"devices,omitempty"`
 }
 
 func (cfg *Tunnel) Validate() error {
 	if cfg.Name == "" {
 		cfg.Name = DefaultTunnelName
 	}
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = TunnelModeIP
+	case TunnelModeIP, TunnelModeEthernet:
+	default:
+		return serrors.New("unsupported tunnel mode", "mode", cfg.Mode)
+	}
+	if err := cfg.ColibriBandwidth.Validate(); err != nil {
+		return err
+	}
+	names := map[string]struct{}{cfg.Name: {}}
+	remotes := make(map[addr.IA]string)
+	for i := range cfg.Devices {
+		d := &cfg.Devices[i]
+		if err := d.Validate(); err != nil {
+			return serrors.WithCtx(err, "device", i)
+		}
+		if _, ok := names[d.Name]; ok {
+			return serrors.New("duplicate tunnel device name", "name", d.Name)
+		}
+		names[d.Name] = struct{}{}
+		// The frames read from a device that do not carry IP packets are sent
+		// to the remote ISD-AS of the device, which must thus be unique.
+		if cfg.Mode == TunnelModeEthernet && len(d.RemoteIAs) != 1 {
+			return serrors.New("tunnel device must map exactly one remote ISD-AS in "+
+				"ethernet mode", "name", d.Name, "remote_isd_as", d.RemoteIAs)
+		}
+		if d.TrafficClass != "" {
+			continue
+		}
+		for _, ia := range d.RemoteIAs {
+			if other, ok := remotes[ia]; ok {
+				return serrors.New("remote ISD-AS mapped to multiple tunnel devices",
+					"isd_as", ia, "devices", []string{other, d.Name})
+			}
+			remotes[ia] = d.Name
+		}
+	}
 	return nil
 }
 
@@ -104,6 +235,60 @@ func (cfg *Tunnel) ConfigName() string {
 	return "tunnel"
 }
 
+// TunnelDevice maps traffic to a dedicated tunnel device. Egress routes for the
+// remote ASes in RemoteIAs are installed on the device, and ingress packets
+// from those ASes are delivered to it. If TrafficClass is set, the device only
+// receives the ingress packets that match the class; egress routes stay on the
+// device the remote AS is otherwise mapped to.
+type TunnelDevice struct {
+	// Name is the name of the TUN device to create.
+	Name string `toml:"name,omitempty"`
+	// RemoteIAs are the remote ASes that are mapped to this device. If empty,
+	// the device applies to all remote ASes, which is only allowed together
+	// with TrafficClass.
+	RemoteIAs []addr.IA `toml:"remote_isd_as,omitempty"`
+	// TrafficClass is a traffic class in the pktcls syntax, e.g., "dscp=0x2e".
+	// If set, only matching ingress packets are delivered to the device.
+	TrafficClass string `toml:"traffic_class,omitempty"`
+	// VRF is the name of the Linux VRF device the TUN device is enslaved to.
+	// The VRF device is created if it does not exist yet. If empty, the TUN
+	// device is not part of a VRF and routes go to the main routing table.
+	VRF string `toml:"vrf,omitempty"`
+	// VRFTable is the routing table of the VRF. It must be set if VRF is set.
+	VRFTable uint32 `toml:"vrf_table,omitempty"`
+}
+
+func (cfg *TunnelDevice) Validate() error {
+	if cfg.Name == "" {
+		return serrors.New("tunnel device name must be set")
+	}
+	if cfg.TrafficClass == "" && len(cfg.RemoteIAs) == 0 {
+		return serrors.New("tunnel device must match a remote ISD-AS or a traffic class",
+			"name", cfg.Name)
+	}
+	if cfg.TrafficClass != "" {
+		if _, err := pktcls.BuildClassTree(cfg.TrafficClass); err != nil {
+			return serrors.WrapStr("parsing traffic class", err, "name", cfg.Name)
+		}
+	}
+	if cfg.VRF != "" && cfg.VRFTable == 0 {
+		return serrors.New("VRF routing table must be set", "name", cfg.Name, "vrf", cfg.VRF)
+	}
+	if cfg.VRF == "" && cfg.VRFTable != 0 {
+		return serrors.New("VRF routing table set without VRF", "name", cfg.Name)
+	}
+	return nil
+}
+
+// Matcher returns the traffic class of the device. If no traffic class is
+// set, nil is returned.
+func (cfg *TunnelDevice) Matcher() (pktcls.Cond, error) {
+	if cfg.TrafficClass == "" {
+		return nil, nil
+	}
+	return pktcls.BuildClassTree(cfg.TrafficClass)
+}
+
 // DefaultAddress determines the default address. If port is not specified, or
 // is zero, it is set to the default port. If the input is garbage, the output
 // is garbage as well.
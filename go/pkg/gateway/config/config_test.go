@@ -16,11 +16,19 @@ package config_test
 
 import (
 	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
+	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/gateway/config"
 	"github.com/scionproto/scion/go/pkg/gateway/config/configtest"
 )
@@ -47,6 +55,120 @@ func TestTunnelSample(t *testing.T) {
 	configtest.CheckTunnel(t, &cfg)
 }
 
+func TestTunnelMode(t *testing.T) {
+	testCases := map[string]struct {
+		Input     string
+		AssertErr assert.ErrorAssertionFunc
+		Expected  string
+	}{
+		"default": {
+			Input:     ``,
+			AssertErr: assert.NoError,
+			Expected:  config.TunnelModeIP,
+		},
+		"ip": {
+			Input:     `mode = "ip"`,
+			AssertErr: assert.NoError,
+			Expected:  config.TunnelModeIP,
+		},
+		"ethernet": {
+			Input:     `mode = "ethernet"`,
+			AssertErr: assert.NoError,
+			Expected:  config.TunnelModeEthernet,
+		},
+		"unsupported": {
+			Input:     `mode = "mpls"`,
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg config.Tunnel
+			err := toml.NewDecoder(strings.NewReader(tc.Input)).Strict(true).Decode(&cfg)
+			require.NoError(t, err)
+			err = cfg.Validate()
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.Expected, cfg.Mode)
+		})
+	}
+}
+
+func TestTunnelColibriBandwidth(t *testing.T) {
+	var cfg config.Tunnel
+	err := toml.NewDecoder(strings.NewReader(`colibri_bw_class = 13`)).Strict(true).Decode(&cfg)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.EqualValues(t, 13, cfg.ColibriBandwidth)
+
+	cfg.ColibriBandwidth = 64
+	assert.Error(t, cfg.Validate())
+}
+
+func TestGatewayEndhostPorts(t *testing.T) {
+	dir := t.TempDir()
+	writeTopology := func(t *testing.T, name, ports string) string {
+		file := filepath.Join(dir, name)
+		topo := fmt.Sprintf(`{"isd_as": "1-ff00:0:110", "endhost_port_range": %q}`, ports)
+		require.NoError(t, os.WriteFile(file, []byte(topo), 0644))
+		return file
+	}
+	withRange := writeTopology(t, "range.json", "31000-32767")
+	withoutRange := writeTopology(t, "norange.json", "")
+
+	testCases := map[string]struct {
+		Input     string
+		Expected  underlay.PortRange
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"default": {
+			Input:     ``,
+			AssertErr: assert.NoError,
+		},
+		"no range in topology": {
+			Input:     fmt.Sprintf(`topology_file = %q`, withoutRange),
+			AssertErr: assert.NoError,
+		},
+		"ports in range": {
+			Input: fmt.Sprintf(`topology_file = %q
+data_addr = ":31056"
+probe_addr = "192.0.2.1:31856"`, withRange),
+			Expected:  underlay.PortRange{Start: 31000, End: 32767},
+			AssertErr: assert.NoError,
+		},
+		"default ports outside of range": {
+			Input:     fmt.Sprintf(`topology_file = %q`, withRange),
+			AssertErr: assert.Error,
+		},
+		"probe port outside of range": {
+			Input: fmt.Sprintf(`topology_file = %q
+data_addr = ":31056"`, withRange),
+			AssertErr: assert.Error,
+		},
+		"missing topology": {
+			Input:     fmt.Sprintf(`topology_file = %q`, filepath.Join(dir, "missing.json")),
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg config.Gateway
+			err := toml.NewDecoder(strings.NewReader(tc.Input)).Strict(true).Decode(&cfg)
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			ports, err := cfg.EndhostPorts()
+			tc.AssertErr(t, err)
+			assert.Equal(t, tc.Expected, ports)
+		})
+	}
+}
+
 func TestDefaultAddress(t *testing.T) {
 	testCases := map[string]struct {
 		Input    string
@@ -86,3 +208,158 @@ func TestDefaultAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestTunnelDevices(t *testing.T) {
+	testCases := map[string]struct {
+		Input        string
+		AssertErr    assert.ErrorAssertionFunc
+		ExpectedIAs  []addr.IA
+		ExpectedVRFs []string
+	}{
+		"valid": {
+			Input: `
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110", "1-ff00:0:111"]
+vrf = "vrf-a"
+vrf_table = 100
+
+[[devices]]
+name = "sig-voice"
+traffic_class = "dscp=0x2e"
+`,
+			AssertErr: assert.NoError,
+			ExpectedIAs: []addr.IA{
+				xtest.MustParseIA("1-ff00:0:110"),
+				xtest.MustParseIA("1-ff00:0:111"),
+			},
+			ExpectedVRFs: []string{"vrf-a", ""},
+		},
+		"no name": {
+			Input: `
+[[devices]]
+remote_isd_as = ["1-ff00:0:110"]
+`,
+			AssertErr: assert.Error,
+		},
+		"duplicate name": {
+			Input: `
+[[devices]]
+name = "sig"
+remote_isd_as = ["1-ff00:0:110"]
+`,
+			AssertErr: assert.Error,
+		},
+		"nothing matched": {
+			Input: `
+[[devices]]
+name = "sig-a"
+`,
+			AssertErr: assert.Error,
+		},
+		"IA mapped twice": {
+			Input: `
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110"]
+
+[[devices]]
+name = "sig-b"
+remote_isd_as = ["1-ff00:0:110"]
+`,
+			AssertErr: assert.Error,
+		},
+		"IA mapped twice with traffic class": {
+			Input: `
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110"]
+
+[[devices]]
+name = "sig-b"
+remote_isd_as = ["1-ff00:0:110"]
+traffic_class = "protocol=tcp"
+`,
+			AssertErr: assert.NoError,
+			ExpectedIAs: []addr.IA{
+				xtest.MustParseIA("1-ff00:0:110"),
+			},
+			ExpectedVRFs: []string{"", ""},
+		},
+		"invalid traffic class": {
+			Input: `
+[[devices]]
+name = "sig-a"
+traffic_class = "dscp=2"
+`,
+			AssertErr: assert.Error,
+		},
+		"ethernet": {
+			Input: `
+mode = "ethernet"
+
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110"]
+
+[[devices]]
+name = "sig-b"
+remote_isd_as = ["1-ff00:0:111"]
+traffic_class = "dscp=0x2e"
+`,
+			AssertErr: assert.NoError,
+			ExpectedIAs: []addr.IA{
+				xtest.MustParseIA("1-ff00:0:110"),
+			},
+			ExpectedVRFs: []string{"", ""},
+		},
+		"ethernet with multiple IAs": {
+			Input: `
+mode = "ethernet"
+
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110", "1-ff00:0:111"]
+`,
+			AssertErr: assert.Error,
+		},
+		"ethernet with traffic class for all IAs": {
+			Input: `
+mode = "ethernet"
+
+[[devices]]
+name = "sig-a"
+traffic_class = "dscp=0x2e"
+`,
+			AssertErr: assert.Error,
+		},
+		"VRF without table": {
+			Input: `
+[[devices]]
+name = "sig-a"
+remote_isd_as = ["1-ff00:0:110"]
+vrf = "vrf-a"
+`,
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg config.Tunnel
+			err := toml.NewDecoder(strings.NewReader(tc.Input)).Strict(true).Decode(&cfg)
+			require.NoError(t, err)
+			tc.AssertErr(t, cfg.Validate())
+			if tc.ExpectedIAs == nil {
+				return
+			}
+			assert.Equal(t, tc.ExpectedIAs, cfg.Devices[0].RemoteIAs)
+			var vrfs []string
+			for _, d := range cfg.Devices {
+				vrfs = append(vrfs, d.VRF)
+			}
+			assert.Equal(t, tc.ExpectedVRFs, vrfs)
+		})
+	}
+}
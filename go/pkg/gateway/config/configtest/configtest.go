@@ -31,10 +31,15 @@ func CheckGateway(t *testing.T, cfg *config.Gateway) {
 	assert.Equal(t, config.DefaultCtrlAddr, cfg.CtrlAddr)
 	assert.Equal(t, config.DefaultDataAddr, cfg.DataAddr)
 	assert.Equal(t, config.DefaultProbeAddr, cfg.ProbeAddr)
+	assert.Empty(t, cfg.TopologyFile)
 }
 
 func InitTunnel(cfg *config.Tunnel) {}
 
 func CheckTunnel(t *testing.T, cfg *config.Tunnel) {
 	assert.Equal(t, config.DefaultTunnelName, cfg.Name)
+	assert.Equal(t, config.TunnelModeIP, cfg.Mode)
+	assert.False(t, cfg.FollowReplyPaths)
+	assert.Zero(t, cfg.ColibriBandwidth)
+	assert.False(t, cfg.DRKey)
 }
@@ -57,15 +57,69 @@ data_addr = ":30056"
 #
 # (default ":30856")
 probe_addr = ":30856"
+
+# The file path of the topology of the local AS. If the topology sets the
+# endhost_port_range attribute, the data and probe connections bind their ports
+# directly, without going through the dispatcher. The ports of data_addr and
+# probe_addr must then be in the range.
+# (default "")
+topology_file = ""
 `
 
 const tunnelSample = `
 # Name of TUN device to create. (default "sig")
 name = "sig"
+# Tunnel mode, either "ip" or "ethernet". In "ethernet" mode, the devices are
+# TAP devices and Ethernet frames, including VLAN-tagged frames, are tunneled
+# instead of IP packets. Frames that do not carry IP packets are sent to the
+# remote AS the device was created for, so every remote AS gets its own device,
+# named "s." followed by the base32 encoding of its ISD-AS, instead of the
+# device above. Additional devices must map exactly one remote AS each. No
+# routes are installed in this mode. Both gateways must use the same mode.
+# (default "ip")
+mode = "ip"
 # Source hint to put to put into the routing table for IPv4 routes.
 # (default "")
 src_ipv4 = "192.0.2.100"
 # Source hint to put to put into the routing table for IPv6 routes.
 # (default "")
 src_ipv6 = "2001:db8::2:1"
+# Send the shares to a remote gateway on the reversed paths on which the shares
+# with the same index arrive from it, such that the reply traffic follows the
+# disjoint paths selected by the remote gateway. The locally selected paths are
+# used for the share indices that were not received recently. (default false)
+follow_reply_paths = false
+# Discover the MTU of the paths to the remote gateways with probe frames. IP
+# packets that do not fit into a single frame are then dropped and answered
+# with ICMP Fragmentation Needed or Packet Too Big errors, instead of being
+# split across frames. (default false)
+pmtu_discovery = false
+# Bandwidth class of the COLIBRI E2E reservations the shares are sent over.
+# Each share index gets a reservation on a trip that shares no link with the
+# other reservations, and uses the best-effort path while the admission or
+# renewal of its reservation fails. (default 0, no reservations)
+colibri_bw_class = 0
+# Derive the keys of the frames from DRKey, through the SCION Daemon, instead of
+# using a pre-shared key. Every direction between two gateways uses its own key,
+# which changes with the DRKey epochs. The remote gateways must enable it too.
+# (default false)
+drkey = false
+
+# Additional tunnel devices. Each entry maps a set of remote ISD-AS numbers
+# and/or a traffic class to a dedicated TUN device, optionally enslaved to a
+# Linux VRF. Egress routes for the listed ASes are installed on the device
+# (in the VRF routing table if a VRF is set), and ingress packets from these
+# ASes are delivered to it. Entries with a traffic class only receive the
+# matching ingress packets. Traffic not covered by any entry uses the default
+# device above.
+#
+# [[tunnel.devices]]
+# name = "sig-tenant-a"
+# remote_isd_as = ["1-ff00:0:110"]
+# vrf = "vrf-tenant-a"
+# vrf_table = 100
+#
+# [[tunnel.devices]]
+# name = "sig-voice"
+# traffic_class = "dscp=0x2e"
 `
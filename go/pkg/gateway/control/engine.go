@@ -283,18 +283,14 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 	e.deviceHandles = make([]DeviceHandle, 0, numSessions)
 
 	for _, config := range e.SessionConfigs {
-		dataplaneSession := e.DataplaneSessionFactory.New(
-			config.ID,
-			config.PolicyID,
-			config.IA,
-			config.Gateway.Data,
-		)
+		dataplaneSession := e.DataplaneSessionFactory.New(config)
 		remoteIA := config.IA
 		pathMonitorRegistration := e.PathMonitor.Register(
 			ctx,
 			remoteIA,
 			&policies.Policies{
 				PathPolicy: config.PathPolicy,
+				SetPolicy:  config.SetPolicy,
 				PerfPolicy: config.PerfPolicy,
 				PathCount:  config.PathCount,
 			},
@@ -376,6 +372,7 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 	e.router = &Router{
 		RoutingTable:        e.RoutingTable,
 		RoutingTableIndices: e.RoutingTableIndices,
+		LoadSharingIndices:  loadSharingIndices(e.SessionConfigs, e.RoutingTableIndices),
 		DataplaneSessions:   writers,
 		Events:              e.eventNotifications,
 	}
@@ -391,6 +388,30 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 	return nil
 }
 
+// loadSharingIndices returns the routing table indices whose sessions all
+// belong to load sharing policies.
+func loadSharingIndices(configs []*SessionConfig,
+	routingTableIndices map[int][]uint8) map[int]bool {
+
+	modes := make(map[uint8]HAMode, len(configs))
+	for _, config := range configs {
+		modes[config.ID] = config.HAMode
+	}
+	indices := make(map[int]bool)
+	for rtID, sessIDs := range routingTableIndices {
+		loadSharing := len(sessIDs) > 1
+		for _, sessID := range sessIDs {
+			if modes[sessID] != HAModeLoadSharing {
+				loadSharing = false
+			}
+		}
+		if loadSharing {
+			indices[rtID] = true
+		}
+	}
+	return indices
+}
+
 // Close stops all internal goroutines and waits for them to finish.
 func (e *Engine) Close(ctx context.Context) error {
 	return e.workerBase.CloseWrapper(ctx, e.close)
@@ -451,9 +472,10 @@ type PktWriter interface {
 }
 
 // DataplaneSessionFactory is used to construct a data-plane session with a specific ID towards a
-// remote.
+// remote. The session sends its traffic to the data-plane address of the gateway in the
+// configuration, as specified by the session policy settings in the configuration.
 type DataplaneSessionFactory interface {
-	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr) DataplaneSession
+	New(config *SessionConfig) DataplaneSession
 }
 
 // PathMonitor is used to construct registrations for path discovery.
@@ -26,10 +26,12 @@ var (
 	NewPrefixWatcher        = newPrefixWatcher
 
 	CopyPathPolicy     = copyPathPolicy
+	CopySetPolicy      = copySetPolicy
 	BuildRoutingChains = buildRoutingChains
 )
 
 type ConjunctionPathPol = conjuctionPathPol
+type EPICPathPol = epicPathPol
 type Diff = diff
 
 func (w *GatewayWatcher) RunOnce(ctx context.Context) {
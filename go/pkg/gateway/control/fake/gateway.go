@@ -145,8 +145,12 @@ func (g *Gateway) Run(ctx context.Context) error {
 			}
 			newHandles = append(newHandles, handle)
 
-			newSessions[s.ID] = dataPlaneSessionFactory.
-				New(uint8(s.ID), s.PolicyID, s.RemoteIA, s.RemoteAddr)
+			newSessions[s.ID] = dataPlaneSessionFactory.New(&control.SessionConfig{
+				ID:       uint8(s.ID),
+				PolicyID: s.PolicyID,
+				IA:       s.RemoteIA,
+				Gateway:  control.Gateway{Data: s.RemoteAddr},
+			})
 			if err := newSessions[s.ID].SetPaths(s.Paths); err != nil {
 				return err
 			}
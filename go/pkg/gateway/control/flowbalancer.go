@@ -0,0 +1,105 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// FlowBalancer shares the load between sessions to different remote gateways.
+// All the packets of a flow are written to the same session. The assignment of
+// flows to sessions uses rendezvous hashing, such that removing a session only
+// moves the flows of that session, and adding a session only moves the flows
+// that are assigned to the new session.
+//
+// A flow is identified by the source and destination addresses and the
+// transport ports of the packet, irrespective of the direction.
+type FlowBalancer struct {
+	ids      []uint8
+	sessions []PktWriter
+}
+
+// NewFlowBalancer creates a flow balancer for the sessions with the given IDs.
+// The IDs determine the assignment of flows, a session keeps its flows for as
+// long as it is part of the balancer with the same ID.
+func NewFlowBalancer(ids []uint8, sessions []PktWriter) (*FlowBalancer, error) {
+	if len(ids) != len(sessions) {
+		return nil, serrors.New("number of IDs and sessions differ",
+			"ids", len(ids), "sessions", len(sessions))
+	}
+	if len(ids) == 0 {
+		return nil, serrors.New("no sessions")
+	}
+	return &FlowBalancer{
+		ids:      append([]uint8(nil), ids...),
+		sessions: append([]PktWriter(nil), sessions...),
+	}, nil
+}
+
+// Write writes the packet to the session the flow of the packet is assigned
+// to.
+func (b *FlowBalancer) Write(packet gopacket.Packet) {
+	b.sessions[b.index(flowHash(packet))].Write(packet)
+}
+
+// SessionID returns the ID of the session the flow of the packet is assigned
+// to.
+func (b *FlowBalancer) SessionID(packet gopacket.Packet) uint8 {
+	return b.ids[b.index(flowHash(packet))]
+}
+
+// index returns the index of the session with the highest score for the flow.
+func (b *FlowBalancer) index(flow uint64) int {
+	best, bestScore := 0, uint64(0)
+	for i, id := range b.ids {
+		score := mix64(flow ^ (uint64(id)+1)*0x9e3779b97f4a7c15)
+		if i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+func (b *FlowBalancer) String() string {
+	return fmt.Sprintf("FlowBalancer%v", b.ids)
+}
+
+// flowHash returns a hash of the flow of the packet that is the same for both
+// directions of the flow.
+func flowHash(packet gopacket.Packet) uint64 {
+	var h uint64
+	if network := packet.NetworkLayer(); network != nil {
+		h = network.NetworkFlow().FastHash()
+	}
+	if transport := packet.TransportLayer(); transport != nil {
+		h = mix64(h ^ transport.TransportFlow().FastHash())
+	}
+	return h
+}
+
+// mix64 is the finalizer of splitmix64. It spreads the bits of the input over
+// the output.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
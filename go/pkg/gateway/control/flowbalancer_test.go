@@ -0,0 +1,124 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestNewFlowBalancer(t *testing.T) {
+	_, err := control.NewFlowBalancer(nil, nil)
+	assert.Error(t, err)
+	_, err = control.NewFlowBalancer([]uint8{1, 2}, []control.PktWriter{testPktWriter{ID: 1}})
+	assert.Error(t, err)
+	b, err := control.NewFlowBalancer([]uint8{1, 2},
+		[]control.PktWriter{testPktWriter{ID: 1}, testPktWriter{ID: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, "FlowBalancer[1 2]", b.String())
+}
+
+func TestFlowBalancer(t *testing.T) {
+	newBalancer := func(t *testing.T, ids ...uint8) *control.FlowBalancer {
+		sessions := make([]control.PktWriter, 0, len(ids))
+		for range ids {
+			sessions = append(sessions, &countingPktWriter{})
+		}
+		b, err := control.NewFlowBalancer(ids, sessions)
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("both directions of a flow use the same session", func(t *testing.T) {
+		b := newBalancer(t, 1, 2, 3, 4)
+		for port := uint16(1000); port < 1100; port++ {
+			forward := udpPacket(t, "10.0.0.1", "10.1.0.1", port, 53)
+			reverse := udpPacket(t, "10.1.0.1", "10.0.0.1", 53, port)
+			assert.Equal(t, b.SessionID(forward), b.SessionID(reverse))
+		}
+	})
+
+	t.Run("flows are spread over all sessions", func(t *testing.T) {
+		b := newBalancer(t, 1, 2, 3)
+		counts := map[uint8]int{}
+		for port := uint16(1000); port < 1300; port++ {
+			counts[b.SessionID(udpPacket(t, "10.0.0.1", "10.1.0.1", port, 53))]++
+		}
+		for _, id := range []uint8{1, 2, 3} {
+			assert.Greater(t, counts[id], 50, "session %d", id)
+		}
+	})
+
+	t.Run("removing a session only moves its flows", func(t *testing.T) {
+		all := newBalancer(t, 1, 2, 3)
+		reduced := newBalancer(t, 1, 3)
+		for port := uint16(1000); port < 1300; port++ {
+			pkt := udpPacket(t, "10.0.0.1", "10.1.0.1", port, 53)
+			if id := all.SessionID(pkt); id != 2 {
+				assert.Equal(t, id, reduced.SessionID(pkt))
+			}
+		}
+	})
+
+	t.Run("packets are written to the assigned session", func(t *testing.T) {
+		sessions := []*countingPktWriter{{}, {}}
+		b, err := control.NewFlowBalancer([]uint8{7, 9},
+			[]control.PktWriter{sessions[0], sessions[1]})
+		require.NoError(t, err)
+		expected := map[uint8]int{}
+		for port := uint16(1000); port < 1100; port++ {
+			pkt := udpPacket(t, "10.0.0.1", "10.1.0.1", port, 53)
+			expected[b.SessionID(pkt)]++
+			b.Write(pkt)
+		}
+		assert.Equal(t, expected[7], sessions[0].count)
+		assert.Equal(t, expected[9], sessions[1].count)
+	})
+}
+
+type countingPktWriter struct {
+	count int
+}
+
+func (w *countingPktWriter) Write(gopacket.Packet) {
+	w.count++
+}
+
+func udpPacket(t *testing.T, src, dst string, srcPort, dstPort uint16) gopacket.Packet {
+	t.Helper()
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.ParseIP(src).To4(),
+		DstIP:    net.ParseIP(dst).To4(),
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(srcPort),
+		DstPort: layers.UDPPort(dstPort),
+	}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload("data")))
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+}
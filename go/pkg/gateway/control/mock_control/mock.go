@@ -599,17 +599,17 @@ func (m *MockDataplaneSessionFactory) EXPECT() *MockDataplaneSessionFactoryMockR
 }
 
 // New mocks base method.
-func (m *MockDataplaneSessionFactory) New(arg0 byte, arg1 int, arg2 addr.IA, arg3 net.Addr) control.DataplaneSession {
+func (m *MockDataplaneSessionFactory) New(arg0 *control.SessionConfig) control.DataplaneSession {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3, 3, 3)
+	ret := m.ctrl.Call(m, "New", arg0)
 	ret0, _ := ret[0].(control.DataplaneSession)
 	return ret0
 }
 
 // New indicates an expected call of New.
-func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0)
 }
 
 // MockPktWriter is a mock of PktWriter interface.
@@ -21,8 +21,10 @@ import (
 	"io"
 	"sync"
 
+	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/worker"
@@ -50,6 +52,15 @@ type RoutingTableReader interface {
 	RouteIPv6(pkt layers.IPv6) PktWriter
 }
 
+// EthernetRoutingTableReader contains the read operations of a data-plane
+// routing table that are used for Ethernet frames that do not carry IP packets.
+type EthernetRoutingTableReader interface {
+	// RouteEthernet returns the session for a frame to the remote ISD-AS. The
+	// traffic matchers of the routing chains for the remote ISD-AS are
+	// evaluated on the given layer, the first match is selected.
+	RouteEthernet(ia addr.IA, pkt gopacket.Layer) PktWriter
+}
+
 // RoutingTableWriter contains the write operations of a data-plane routing table.
 type RoutingTableWriter interface {
 	// SetSession sets the session for the routing table index. It replaces an
@@ -71,6 +82,10 @@ type Router struct {
 	// RoutingTableIndices maps a routing table index to a priority-ordered list
 	// of session ids.
 	RoutingTableIndices map[int][]uint8
+	// LoadSharingIndices are the routing table indices whose sessions share
+	// the load. The flows are balanced across all the sessions that are up,
+	// instead of using the first session that is up.
+	LoadSharingIndices map[int]bool
 	// DataplaneSessions are the dataplane sessions.
 	DataplaneSessions map[uint8]PktWriter
 	// Events is the channel that session events are read from. Note that
@@ -84,6 +99,9 @@ type Router struct {
 	sessionStates map[uint8]Event
 	// currentSessions maps routing table indices to the session in use.
 	currentSessions map[int]uint8
+	// currentGroups maps load sharing routing table indices to the sessions in
+	// use.
+	currentGroups map[int][]uint8
 
 	workerBase worker.Base
 }
@@ -119,6 +137,7 @@ func (r *Router) Close(ctx context.Context) error {
 
 func (r *Router) initData(ctx context.Context) error {
 	r.currentSessions = make(map[int]uint8, len(r.RoutingTableIndices))
+	r.currentGroups = make(map[int][]uint8, len(r.LoadSharingIndices))
 	r.sessionStates = make(map[uint8]Event, len(r.DataplaneSessions))
 	return nil
 }
@@ -134,6 +153,11 @@ func (r *Router) handleEvent(event SessionEvent) error {
 	}
 	var errors serrors.List
 	r.sessionStates[event.SessionID] = event.Event
+	for rtID := range r.LoadSharingIndices {
+		if containsID(r.RoutingTableIndices[rtID], event.SessionID) {
+			r.updateGroup(rtID)
+		}
+	}
 	switch event.Event {
 	case EventUp:
 		getIdx := func(ids []uint8, search uint8) int {
@@ -146,8 +170,9 @@ func (r *Router) handleEvent(event SessionEvent) error {
 		}
 		for rtID, sessIDs := range r.RoutingTableIndices {
 			// Skip routing table indices that do not contain the session this
-			// event is for.
-			if getIdx(sessIDs, event.SessionID) == -1 {
+			// event is for, and the load sharing indices that are handled
+			// above.
+			if getIdx(sessIDs, event.SessionID) == -1 || r.LoadSharingIndices[rtID] {
 				continue
 			}
 			// check if there is already a session for this index.
@@ -207,6 +232,45 @@ func (r *Router) handleEvent(event SessionEvent) error {
 	return errors.ToError()
 }
 
+// updateGroup sets the sessions that are up for the load sharing routing table
+// ID.
+func (r *Router) updateGroup(rtID int) {
+	var ids []uint8
+	var sessions []PktWriter
+	for _, sessID := range r.RoutingTableIndices[rtID] {
+		if r.sessionStates[sessID] == EventUp {
+			ids = append(ids, sessID)
+			sessions = append(sessions, r.DataplaneSessions[sessID])
+		}
+	}
+	if equalIDs(ids, r.currentGroups[rtID]) {
+		return
+	}
+	if len(ids) == 0 {
+		if err := r.RoutingTable.ClearSession(rtID); err != nil {
+			// if the routing table doesn't know the index it means
+			// something was wrongly programmed.
+			panic(serrors.WrapStr("deleting from routing table", err, "id", rtID))
+		}
+		delete(r.currentGroups, rtID)
+		return
+	}
+	var writer PktWriter = sessions[0]
+	if len(sessions) > 1 {
+		balancer, err := NewFlowBalancer(ids, sessions)
+		if err != nil {
+			panic(serrors.WrapStr("creating flow balancer", err, "id", rtID))
+		}
+		writer = balancer
+	}
+	if err := r.RoutingTable.SetSession(rtID, writer); err != nil {
+		// if the routing table doesn't know the index it means
+		// something was wrongly programmed.
+		panic(serrors.WrapStr("adding to routing table", err, "id", rtID))
+	}
+	r.currentGroups[rtID] = ids
+}
+
 // findSession finds the first session that is up for the routing table ID. The
 // second return value is the index, it's -1 if no session that is up is found.
 func (r *Router) findSession(rtID int) (uint8, int) {
@@ -225,12 +289,16 @@ func (r *Router) DiagnosticsWrite(w io.Writer) {
 
 	type Diagnostics struct {
 		RoutingTableIndices map[int][]uint8
+		LoadSharingIndices  map[int]bool
 		CurrentSessions     map[int]uint8
+		CurrentGroups       map[int][]uint8
 		SessionStates       map[uint8]Event
 	}
 	d := Diagnostics{
 		RoutingTableIndices: r.RoutingTableIndices,
+		LoadSharingIndices:  r.LoadSharingIndices,
 		CurrentSessions:     r.currentSessions,
+		CurrentGroups:       r.currentGroups,
 		SessionStates:       r.sessionStates,
 	}
 	raw, err := json.MarshalIndent(d, "", "    ")
@@ -241,3 +309,24 @@ func (r *Router) DiagnosticsWrite(w io.Writer) {
 	w.Write(raw)
 	w.Write([]byte("\n"))
 }
+
+func containsID(ids []uint8, id uint8) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+func equalIDs(a, b []uint8) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -16,6 +16,7 @@ package control_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -116,3 +117,79 @@ func TestRouterRun(t *testing.T) {
 		t.Fatalf("Timeout waiting on run to complete")
 	}
 }
+
+func TestRouterLoadSharing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rt := mock_control.NewMockRoutingTable(ctrl)
+
+	events := make(chan control.SessionEvent)
+	router := control.Router{
+		RoutingTable: rt,
+		RoutingTableIndices: map[int][]uint8{
+			1: {100, 101},
+			2: {100, 101, 102},
+		},
+		LoadSharingIndices: map[int]bool{
+			2: true,
+		},
+		DataplaneSessions: map[uint8]control.PktWriter{
+			100: testPktWriter{ID: 100},
+			101: testPktWriter{ID: 101},
+			102: testPktWriter{ID: 102},
+		},
+		Events: events,
+	}
+	go func() { router.Run(context.Background()) }()
+	defer router.Close(context.Background())
+
+	written := make(chan string)
+	writeCallChan := func(_ int, w control.PktWriter) error {
+		written <- fmt.Sprint(w)
+		return nil
+	}
+	expectWrite := func(t *testing.T, expected string) {
+		t.Helper()
+		select {
+		case w := <-written:
+			assert.Equal(t, expected, w)
+		case <-time.After(time.Second):
+			t.Fatalf("Timeout waiting for routing table update")
+		}
+	}
+
+	// A single session that is up is used directly.
+	rt.EXPECT().SetSession(1, router.DataplaneSessions[101])
+	rt.EXPECT().SetSession(2, router.DataplaneSessions[101]).Do(writeCallChan)
+	events <- control.SessionEvent{SessionID: 101, Event: control.EventUp}
+	expectWrite(t, fmt.Sprint(router.DataplaneSessions[101]))
+
+	// The active-standby index keeps the first session that went up, the load
+	// sharing index balances between all sessions that are up.
+	rt.EXPECT().SetSession(2, gomock.Any()).Do(writeCallChan)
+	events <- control.SessionEvent{SessionID: 102, Event: control.EventUp}
+	expectWrite(t, "FlowBalancer[101 102]")
+
+	rt.EXPECT().SetSession(1, router.DataplaneSessions[100])
+	rt.EXPECT().SetSession(2, gomock.Any()).Do(writeCallChan)
+	events <- control.SessionEvent{SessionID: 100, Event: control.EventUp}
+	expectWrite(t, "FlowBalancer[100 101 102]")
+
+	// Sessions that go down are removed from the balancer.
+	rt.EXPECT().SetSession(1, router.DataplaneSessions[101])
+	rt.EXPECT().SetSession(2, gomock.Any()).Do(writeCallChan)
+	events <- control.SessionEvent{SessionID: 100, Event: control.EventDown}
+	expectWrite(t, "FlowBalancer[101 102]")
+
+	rt.EXPECT().ClearSession(1)
+	rt.EXPECT().SetSession(2, router.DataplaneSessions[102]).Do(writeCallChan)
+	events <- control.SessionEvent{SessionID: 101, Event: control.EventDown}
+	expectWrite(t, fmt.Sprint(router.DataplaneSessions[102]))
+
+	rt.EXPECT().ClearSession(2).Do(func(int) error {
+		written <- ""
+		return nil
+	})
+	events <- control.SessionEvent{SessionID: 102, Event: control.EventDown}
+	expectWrite(t, "")
+}
@@ -54,6 +54,9 @@ type SessionConfig struct {
 	// PathPolicy specifies the path properties that paths used for this session
 	// must satisfy.
 	PathPolicy policies.PathPolicy
+	// SetPolicy specifies the properties that the set of paths used
+	// simultaneously for this session must satisfy.
+	SetPolicy policies.SetPolicy
 	// PathCount is the max number of paths to use.
 	PathCount int
 	// Gateway describes a discovered remote gateway instance.
@@ -61,6 +64,20 @@ type SessionConfig struct {
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// RateLimit, if set, limits the rate of the packets sent on the session.
+	RateLimit *RateLimit
+	// Padding, if set, pads the encrypted frames of the session.
+	Padding *Padding
+	// CoverTraffic, if set, sends dummy frames on the session.
+	CoverTraffic *CoverTraffic
+	// EPIC sends the shares of the session on EPIC paths.
+	EPIC bool
+	// Classes define how the packets of traffic classes are sent on the
+	// session.
+	Classes []ClassAction
+	// HAMode determines how the session is used together with the sessions
+	// of the same policy to other remote gateways.
+	HAMode HAMode
 }
 
 // SessionConfigurator builds session configurations from the static traffic
@@ -216,14 +233,24 @@ func diffSessionPolicy(a, b SessionPolicy) bool {
 		a.PathCount != b.PathCount ||
 		// no better way than comparing pointers here:
 		a.PerfPolicy != b.PerfPolicy ||
-		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) {
+		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) ||
+		classesKey(a.Classes) != classesKey(b.Classes) ||
+		a.HAMode != b.HAMode ||
+		a.EPIC != b.EPIC {
 		return true
 	}
-	if a.PathPolicy == b.PathPolicy {
+	return diffJSON(a.PathPolicy, b.PathPolicy) || diffJSON(a.SetPolicy, b.SetPolicy) ||
+		diffJSON(a.RateLimit, b.RateLimit) || diffJSON(a.Padding, b.Padding) ||
+		diffJSON(a.CoverTraffic, b.CoverTraffic)
+}
+
+// diffJSON returns true if the 2 policies differ in their JSON representation.
+func diffJSON(a, b interface{}) bool {
+	if a == b {
 		return false
 	}
-	rawA, aErr := json.Marshal(a.PathPolicy)
-	rawB, bErr := json.Marshal(b.PathPolicy)
+	rawA, aErr := json.Marshal(a)
+	rawB, bErr := json.Marshal(b)
 	// in case of a marshalling error we can't decide further on equality so we
 	// pessimistically assume a change.
 	if aErr != nil || bErr != nil {
@@ -309,6 +336,9 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				sessionPolicy.IA,
 				entry.Gateway.Interfaces,
 			)
+			if sessionPolicy.EPIC {
+				pathPol = conjuctionPathPol{Pol1: pathPol, Pol2: epicPathPol{}}
+			}
 			result = append(result, &SessionConfig{
 				ID:             sessID,
 				PolicyID:       sessionPolicy.ID,
@@ -316,9 +346,16 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				TrafficMatcher: sessionPolicy.TrafficMatcher,
 				PerfPolicy:     sessionPolicy.PerfPolicy,
 				PathPolicy:     pathPol,
+				SetPolicy:      sessionPolicy.SetPolicy,
 				PathCount:      sessionPolicy.PathCount,
 				Gateway:        entry.Gateway,
 				Prefixes:       mergePrefixes(sessionPolicy.Prefixes, entry.Prefixes),
+				RateLimit:      sessionPolicy.RateLimit,
+				Padding:        sessionPolicy.Padding,
+				CoverTraffic:   sessionPolicy.CoverTraffic,
+				EPIC:           sessionPolicy.EPIC,
+				Classes:        sessionPolicy.Classes,
+				HAMode:         sessionPolicy.HAMode,
 			})
 			sessID++
 		}
@@ -371,6 +408,19 @@ func (p conjuctionPathPol) Filter(s []snet.Path) []snet.Path {
 	return p.Pol2.Filter(p.Pol1.Filter(s))
 }
 
+// epicPathPol only accepts paths that can be used to send EPIC packets.
+type epicPathPol struct{}
+
+func (epicPathPol) Filter(paths []snet.Path) []snet.Path {
+	var result []snet.Path
+	for _, path := range paths {
+		if meta := path.Metadata(); meta != nil && meta.EpicAuths.SupportsEpic() {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
 func newPathPolForEnteringAS(ia addr.IA, allowedInterfaces []uint64) policies.PathPolicy {
 	if len(allowedInterfaces) == 0 {
 		return DefaultPathPolicy
@@ -395,3 +445,13 @@ func prefixesKey(prefixes []*net.IPNet) string {
 	sort.Strings(keyParts)
 	return strings.Join(keyParts, "-")
 }
+
+// classesKey returns a key that is equal for equal class actions. The order of
+// the class actions is significant.
+func classesKey(classes []ClassAction) string {
+	keyParts := make([]string, 0, len(classes))
+	for _, c := range classes {
+		keyParts = append(keyParts, c.String())
+	}
+	return strings.Join(keyParts, ";")
+}
@@ -299,6 +299,154 @@ func TestBuildSessionConfigs(t *testing.T) {
 				},
 			},
 		},
+		"set policy": {
+			SessionPolicies: control.SessionPolicies{
+				{
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					ID:             42,
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					SetPolicy:      &pathpol.SetPolicy{MaxASOverlap: 1},
+					PathCount:      3,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/24")},
+				},
+			},
+			RoutingUpdate: control.RemoteGateways{
+				Gateways: map[addr.IA][]control.RemoteGateway{
+					xtest.MustParseIA("1-ff00:0:110"): {
+						{
+							Gateway: control.Gateway{
+								Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+							},
+							Prefixes: xtest.MustParseCIDRs(t, "10.2.0.0/24"),
+						},
+					},
+				},
+			},
+			Expected: []*control.SessionConfig{
+				{
+					ID:             0,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					SetPolicy:      &pathpol.SetPolicy{MaxASOverlap: 1},
+					PathCount:      3,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24", "10.2.0.0/24"),
+					Gateway: control.Gateway{
+						Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+					},
+				},
+			},
+		},
+		"classes": {
+			SessionPolicies: control.SessionPolicies{
+				{
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					ID:             42,
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/24")},
+					Classes: []control.ClassAction{
+						mustClassAction(t, "dscp=0x8", "bypass", 0, 0),
+					},
+				},
+			},
+			RoutingUpdate: control.RemoteGateways{
+				Gateways: map[addr.IA][]control.RemoteGateway{
+					xtest.MustParseIA("1-ff00:0:110"): {
+						{
+							Gateway: control.Gateway{
+								Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+							},
+							Prefixes: xtest.MustParseCIDRs(t, "10.2.0.0/24"),
+						},
+					},
+				},
+			},
+			Expected: []*control.SessionConfig{
+				{
+					ID:             0,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24", "10.2.0.0/24"),
+					Gateway: control.Gateway{
+						Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+					},
+					Classes: []control.ClassAction{
+						mustClassAction(t, "dscp=0x8", "bypass", 0, 0),
+					},
+				},
+			},
+		},
+		"ha mode": {
+			SessionPolicies: control.SessionPolicies{
+				{
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					ID:             42,
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/24")},
+					HAMode:         control.HAModeLoadSharing,
+				},
+			},
+			RoutingUpdate: control.RemoteGateways{
+				Gateways: map[addr.IA][]control.RemoteGateway{
+					xtest.MustParseIA("1-ff00:0:110"): {
+						{
+							Gateway: control.Gateway{
+								Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+							},
+						},
+						{
+							Gateway: control.Gateway{
+								Probe: mustParseUDPAddr(t, "10.0.1.2:25"),
+							},
+						},
+					},
+				},
+			},
+			Expected: []*control.SessionConfig{
+				{
+					ID:             0,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+					Gateway: control.Gateway{
+						Probe: mustParseUDPAddr(t, "10.0.1.1:25"),
+					},
+					HAMode: control.HAModeLoadSharing,
+				},
+				{
+					ID:             1,
+					PolicyID:       42,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     dummyPerfPolicy{},
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/24"),
+					Gateway: control.Gateway{
+						Probe: mustParseUDPAddr(t, "10.0.1.2:25"),
+					},
+					HAMode: control.HAModeLoadSharing,
+				},
+			},
+		},
 		"complex": {
 			SessionPolicies: control.SessionPolicies{
 				{
@@ -670,6 +818,29 @@ func TestConjuctionPolicy(t *testing.T) {
 	}
 }
 
+func TestEPICPathPol(t *testing.T) {
+	auth := make([]byte, 16)
+	epicPath := path.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 2},
+				{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 1},
+			},
+			EpicAuths: snet.EpicAuths{AuthPHVF: auth, AuthLHVF: auth},
+		},
+	}
+	scionPath := path.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 3},
+				{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 2},
+			},
+		},
+	}
+	accepted := control.EPICPathPol{}.Filter([]snet.Path{epicPath, scionPath})
+	assert.Equal(t, []snet.Path{epicPath}, accepted)
+}
+
 func TestNewPathPolForEnteringAS(t *testing.T) {
 	testCases := map[string]struct {
 		Interfaces    []uint64
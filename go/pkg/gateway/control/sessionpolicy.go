@@ -17,6 +17,7 @@ package control
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
 
@@ -46,8 +47,22 @@ type LegacySessionPolicyAdapter struct{}
 func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (SessionPolicies, error) {
 	type JSONFormat struct {
 		ASes map[addr.IA]struct {
-			Nets      []string
-			PathCount int
+			Nets         []string
+			PathCount    int
+			PathPolicy   json.RawMessage
+			SetPolicy    *pathpol.SetPolicy
+			RateLimit    *RateLimit
+			Padding      *Padding
+			CoverTraffic *CoverTraffic
+			EPIC         bool
+			HAMode       string
+			Classes      []struct {
+				TrafficClass string
+				Action       string
+				T            int
+				N            int
+				RateLimit    *RateLimit
+			}
 		}
 		ConfigVersion uint64
 	}
@@ -65,7 +80,7 @@ func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (Sessio
 		if asEntry.PathCount != 0 {
 			pathCount = asEntry.PathCount
 		}
-		policies = append(policies, SessionPolicy{
+		policy := SessionPolicy{
 			ID:             0,
 			IA:             ia,
 			TrafficMatcher: pktcls.CondTrue,
@@ -73,7 +88,69 @@ func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (Sessio
 			PathPolicy:     DefaultPathPolicy,
 			PathCount:      pathCount,
 			Prefixes:       prefixes,
-		})
+			EPIC:           asEntry.EPIC,
+		}
+		if asEntry.PathPolicy != nil {
+			// Parse the path policy strictly, the same way as a policy file,
+			// such that unknown attributes are rejected.
+			pathPolicy, err := pathpol.ParsePolicyYaml(asEntry.PathPolicy)
+			if err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+			policy.PathPolicy = pathPolicy
+		}
+		// Only set the set policy if it is present, a typed nil pointer would
+		// not compare equal to a nil interface.
+		if asEntry.SetPolicy != nil {
+			policy.SetPolicy = asEntry.SetPolicy
+		}
+		if asEntry.HAMode != "" {
+			mode, err := ParseHAMode(asEntry.HAMode)
+			if err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+			policy.HAMode = mode
+		}
+		if asEntry.RateLimit != nil {
+			if err := asEntry.RateLimit.Validate(); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+			policy.RateLimit = asEntry.RateLimit
+		}
+		if asEntry.Padding != nil {
+			if err := asEntry.Padding.Validate(); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+			policy.Padding = asEntry.Padding
+		}
+		if asEntry.CoverTraffic != nil {
+			if err := asEntry.CoverTraffic.Validate(); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+			policy.CoverTraffic = asEntry.CoverTraffic
+		}
+		if len(asEntry.Classes) > MaxClasses {
+			return nil, serrors.New("too many classes", "isd_as", ia,
+				"classes", len(asEntry.Classes), "max", MaxClasses)
+		}
+		for i, c := range asEntry.Classes {
+			class, err := NewClassAction(c.TrafficClass, c.Action, c.T, c.N)
+			if err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia, "class", i)
+			}
+			if c.RateLimit != nil {
+				if err := c.RateLimit.Validate(); err != nil {
+					return nil, serrors.WithCtx(err, "isd_as", ia, "class", i)
+				}
+				class.RateLimit = c.RateLimit
+			}
+			if class.N > pathCount {
+				return nil, serrors.New("class uses more shares than paths",
+					"isd_as", ia, "class", i, "shares", class.N, "path_count", pathCount)
+			}
+			policy.Classes = append(policy.Classes, class)
+		}
+		policies = append(policies, policy)
 	}
 	return policies, nil
 }
@@ -148,10 +225,14 @@ func (p SessionPolicies) Copy() SessionPolicies {
 // - policy id, together with the remote IA uniquely identifying the policy,
 // - traffic class, defined by a traffic matcher,
 // - a path class defined by a path policy,
+// - an optional set policy constraining the paths used together,
 // - a performance policy,
 // - a path count,
 // - a remote IA,
-// - a set of prefixes.
+// - a set of prefixes,
+// - a rate limit,
+// - a list of traffic class actions,
+// - a high availability mode.
 type SessionPolicy struct {
 	// IA is the ISD-AS number of the remote AS.
 	IA addr.IA
@@ -167,12 +248,41 @@ type SessionPolicy struct {
 	// PathPolicy specifies the path properties that paths used for this session
 	// must satisfy.
 	PathPolicy policies.PathPolicy
+	// SetPolicy specifies the properties that the set of paths used
+	// simultaneously for this session must satisfy. If unset, any set of
+	// paths can be used.
+	SetPolicy policies.SetPolicy
 	// PathCount  defines the number of paths that can be simultaneously used
 	// within a session.
 	PathCount int
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// RateLimit, if set, limits the rate of all the packets sent on the
+	// session.
+	RateLimit *RateLimit
+	// Padding, if set, pads the encrypted frames of the session to a fixed set
+	// of sizes.
+	Padding *Padding
+	// CoverTraffic, if set, sends dummy frames on the session when there is
+	// little traffic.
+	CoverTraffic *CoverTraffic
+	// EPIC sends the shares of the session on EPIC paths, such that the
+	// routers of the last two ASes and the remote gateway authenticate their
+	// source. The remote gateway verifies the shares with keys derived from
+	// DRKey, hence EPIC requires DRKey keys. Only paths whose metadata
+	// contains EPIC authenticators are used. If all the session
+	// policies for the remote AS enable EPIC, the shares from the remote AS
+	// are only accepted on EPIC paths.
+	EPIC bool
+	// Classes are evaluated in order for every packet sent on the session, and
+	// the packet is handled according to the first matching class. Packets
+	// that do not match any class are secret shared with the T and N
+	// configured for the gateway. At most MaxClasses classes are allowed.
+	Classes []ClassAction
+	// HAMode determines how the sessions to the different remote gateways of
+	// the IA are used.
+	HAMode HAMode
 }
 
 // Copy creates a deep copy.
@@ -182,11 +292,282 @@ func (sp SessionPolicy) Copy() SessionPolicy {
 		IA:             sp.IA,
 		TrafficMatcher: copyTrafficMatcher(sp.TrafficMatcher),
 		// TODO(lukedirtwalker): find a way to properly copy perf policies.
-		PerfPolicy: sp.PerfPolicy,
-		PathPolicy: copyPathPolicy(sp.PathPolicy),
-		PathCount:  sp.PathCount,
-		Prefixes:   copyPrefixes(sp.Prefixes),
+		PerfPolicy:   sp.PerfPolicy,
+		PathPolicy:   copyPathPolicy(sp.PathPolicy),
+		SetPolicy:    copySetPolicy(sp.SetPolicy),
+		PathCount:    sp.PathCount,
+		Prefixes:     copyPrefixes(sp.Prefixes),
+		RateLimit:    copyRateLimit(sp.RateLimit),
+		Padding:      copyPadding(sp.Padding),
+		CoverTraffic: copyCoverTraffic(sp.CoverTraffic),
+		EPIC:         sp.EPIC,
+		Classes:      copyClassActions(sp.Classes),
+		HAMode:       sp.HAMode,
+	}
+}
+
+// HAMode is the way the sessions to several remote gateways of the same IA
+// are used.
+type HAMode int
+
+const (
+	// HAModeActiveStandby sends all the traffic on the session to the first
+	// healthy remote gateway. The other sessions are standby sessions that take
+	// over if the active session becomes unhealthy.
+	HAModeActiveStandby HAMode = iota
+	// HAModeLoadSharing shares the traffic between the sessions to all
+	// healthy remote gateways. All the packets of a flow are sent on the same
+	// session. If a session becomes unhealthy, only its flows are moved.
+	HAModeLoadSharing
+)
+
+var haModeNames = map[HAMode]string{
+	HAModeActiveStandby: "active-standby",
+	HAModeLoadSharing:   "load-sharing",
+}
+
+func (m HAMode) String() string {
+	if name, ok := haModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", int(m))
+}
+
+// ParseHAMode parses the name of a high availability mode.
+func ParseHAMode(s string) (HAMode, error) {
+	for m, name := range haModeNames {
+		if s == name {
+			return m, nil
+		}
+	}
+	return 0, serrors.New("unknown HA mode", "mode", s)
+}
+
+// ClassActionType is the way the packets of a traffic class are sent.
+type ClassActionType int
+
+// MaxClasses is the maximum number of classes of a session policy. The frames
+// of every class, and of the packets that match no class, are sent in their
+// own stream, and the stream ID has room for 16 of them.
+const MaxClasses = 15
+
+const (
+	// ClassActionSecretShare encrypts the frames, splits them into N shares,
+	// T of which are needed to reconstruct a frame, and sends every share on
+	// a different path.
+	ClassActionSecretShare ClassActionType = iota
+	// ClassActionEncrypt encrypts the frames and sends them on a single path.
+	ClassActionEncrypt
+	// ClassActionBypass sends the frames unencrypted on a single path, like a
+	// plain SCION IP gateway.
+	ClassActionBypass
+)
+
+var classActionNames = map[ClassActionType]string{
+	ClassActionSecretShare: "secret-share",
+	ClassActionEncrypt:     "encrypt",
+	ClassActionBypass:      "bypass",
+}
+
+func (t ClassActionType) String() string {
+	if name, ok := classActionNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", int(t))
+}
+
+// ParseClassActionType parses the name of a class action type.
+func ParseClassActionType(s string) (ClassActionType, error) {
+	for t, name := range classActionNames {
+		if s == name {
+			return t, nil
+		}
+	}
+	return 0, serrors.New("unknown class action", "action", s)
+}
+
+// ClassAction defines how the packets of a traffic class are sent on a
+// session.
+type ClassAction struct {
+	// TrafficMatcher contains the conditions the packets must satisfy to be
+	// handled by this action.
+	TrafficMatcher pktcls.Cond
+	// Type is the way the matching packets are sent.
+	Type ClassActionType
+	// T is the number of shares needed to reconstruct a frame. It is only used
+	// with ClassActionSecretShare. If zero, the T of the gateway is used.
+	T int
+	// N is the number of shares a frame is split into. It is only used with
+	// ClassActionSecretShare. If zero, the N of the gateway is used.
+	N int
+	// RateLimit, if set, limits the rate of the packets of the class. It
+	// applies in addition to the rate limit of the session.
+	RateLimit *RateLimit
+}
+
+// NewClassAction creates a class action from the traffic class in the pktcls
+// syntax and the name of the action.
+func NewClassAction(trafficClass, action string, t, n int) (ClassAction, error) {
+	matcher, err := pktcls.BuildClassTree(trafficClass)
+	if err != nil {
+		return ClassAction{}, serrors.WrapStr("parsing traffic class", err)
+	}
+	actionType, err := ParseClassActionType(action)
+	if err != nil {
+		return ClassAction{}, err
+	}
+	if actionType != ClassActionSecretShare {
+		if t != 0 || n != 0 {
+			return ClassAction{}, serrors.New("T and N are only allowed for secret sharing",
+				"action", actionType)
+		}
+	} else if t != 0 || n != 0 {
+		if t < 1 || t > n || n > 255 {
+			return ClassAction{}, serrors.New("invalid T and N", "T", t, "N", n)
+		}
+	}
+	return ClassAction{
+		TrafficMatcher: matcher,
+		Type:           actionType,
+		T:              t,
+		N:              n,
+	}, nil
+}
+
+func (c ClassAction) String() string {
+	s := fmt.Sprintf("%s:%s", c.TrafficMatcher, c.Type)
+	if c.Type == ClassActionSecretShare && c.N != 0 {
+		s = fmt.Sprintf("%s(%d,%d)", s, c.T, c.N)
+	}
+	if c.RateLimit != nil {
+		s = fmt.Sprintf("%s@%s", s, c.RateLimit)
 	}
+	return s
+}
+
+func copyClassActions(classes []ClassAction) []ClassAction {
+	if classes == nil {
+		return nil
+	}
+	copy := make([]ClassAction, 0, len(classes))
+	for _, c := range classes {
+		c.TrafficMatcher = copyTrafficMatcher(c.TrafficMatcher)
+		c.RateLimit = copyRateLimit(c.RateLimit)
+		copy = append(copy, c)
+	}
+	return copy
+}
+
+// RateLimit limits the rate of packets with a token bucket. Packets exceeding
+// the rate are dropped.
+type RateLimit struct {
+	// Rate is the sustained rate in bits per second.
+	Rate uint64
+	// Burst is the size of the token bucket in bytes, i.e., the number of
+	// bytes that can be sent at once after an idle period. If zero, the
+	// dataplane picks a burst size suitable for the rate.
+	Burst uint64
+}
+
+// Validate checks that the rate limit is sound.
+func (r *RateLimit) Validate() error {
+	if r.Rate == 0 {
+		return serrors.New("rate limit must have a non-zero rate")
+	}
+	return nil
+}
+
+func (r *RateLimit) String() string {
+	return fmt.Sprintf("%dbit/s,%dB", r.Rate, r.Burst)
+}
+
+func copyRateLimit(r *RateLimit) *RateLimit {
+	if r == nil {
+		return nil
+	}
+	copy := *r
+	return &copy
+}
+
+// Padding pads the payload of the encrypted frames, such that the frames on
+// the wire only take a few distinct sizes.
+type Padding struct {
+	// Buckets are the sizes in bytes the frame payloads are padded to, before
+	// encryption. A payload is padded to the smallest bucket it fits in, or to
+	// the largest size allowed by the path MTU if it fits in none. If empty,
+	// all payloads are padded to the largest size.
+	Buckets []int
+}
+
+// Validate checks that the padding is sound.
+func (p *Padding) Validate() error {
+	for i, b := range p.Buckets {
+		if b <= 0 {
+			return serrors.New("padding bucket must be positive", "bucket", b)
+		}
+		if i > 0 && b <= p.Buckets[i-1] {
+			return serrors.New("padding buckets must be strictly increasing",
+				"buckets", p.Buckets)
+		}
+	}
+	return nil
+}
+
+func (p *Padding) String() string {
+	return fmt.Sprintf("buckets%v", p.Buckets)
+}
+
+func copyPadding(p *Padding) *Padding {
+	if p == nil {
+		return nil
+	}
+	return &Padding{Buckets: append([]int(nil), p.Buckets...)}
+}
+
+// CoverTrafficMode determines when dummy frames are sent.
+type CoverTrafficMode string
+
+const (
+	// CoverTrafficConstant sends a dummy frame whenever no frame was sent on
+	// the session for 1/Rate seconds, such that the session sends at least
+	// Rate frames per second.
+	CoverTrafficConstant CoverTrafficMode = "constant"
+	// CoverTrafficRandom sends dummy frames independently of the traffic,
+	// with exponentially distributed gaps of 1/Rate seconds on average.
+	CoverTrafficRandom CoverTrafficMode = "random"
+)
+
+// CoverTraffic configures the dummy frames sent on a session to hide the
+// timing of the traffic. The dummy frames are marked inside the encrypted
+// payload, the remote gateway discards them.
+type CoverTraffic struct {
+	// Mode is the way dummy frames are sent.
+	Mode CoverTrafficMode
+	// Rate is the rate of the dummy frames in frames per second.
+	Rate float64
+}
+
+// Validate checks that the cover traffic is sound.
+func (c *CoverTraffic) Validate() error {
+	if c.Mode != CoverTrafficConstant && c.Mode != CoverTrafficRandom {
+		return serrors.New("unknown cover traffic mode", "mode", c.Mode)
+	}
+	if c.Rate <= 0 {
+		return serrors.New("cover traffic must have a positive rate", "rate", c.Rate)
+	}
+	return nil
+}
+
+func (c *CoverTraffic) String() string {
+	return fmt.Sprintf("%s,%gframes/s", c.Mode, c.Rate)
+}
+
+func copyCoverTraffic(c *CoverTraffic) *CoverTraffic {
+	if c == nil {
+		return nil
+	}
+	copy := *c
+	return &copy
 }
 
 func copyTrafficMatcher(m pktcls.Cond) pktcls.Cond {
@@ -214,6 +595,21 @@ func copyPathPolicy(p policies.PathPolicy) policies.PathPolicy {
 	return &pol
 }
 
+func copySetPolicy(p policies.SetPolicy) policies.SetPolicy {
+	if p == nil {
+		return nil
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	pol := pathpol.SetPolicy{}
+	if err = json.Unmarshal(raw, &pol); err != nil {
+		panic(err)
+	}
+	return &pol
+}
+
 func copyPrefixes(prefixes []*net.IPNet) []*net.IPNet {
 	copy := make([]*net.IPNet, 0, len(prefixes))
 	for _, p := range prefixes {
@@ -18,7 +18,9 @@ import (
 	"context"
 	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 	"github.com/scionproto/scion/go/pkg/gateway/control/mock_control"
@@ -107,6 +110,388 @@ func TestLegacySessionPolicyAdapterParse(t *testing.T) {
 			},
 			AssertErr: assert.NoError,
 		},
+		"path policy": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathPolicy": {
+					  "max_latency": "100ms",
+					  "min_bandwidth": 100000,
+					  "max_hops": 4,
+					  "min_expiry": "10m",
+					  "min_mtu": 1280
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy: &pathpol.Policy{
+						MaxLatency:   &util.DurWrap{Duration: 100 * time.Millisecond},
+						MinBandwidth: 100000,
+						MaxHops:      4,
+						MinExpiry:    &util.DurWrap{Duration: 10 * time.Minute},
+						MinMTU:       1280,
+					},
+					PathCount: 1,
+					Prefixes:  []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"path policy with unknown attribute": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathPolicy": {
+					  "max_latancy": "100ms"
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"set policy": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathCount": 3,
+					"SetPolicy": {
+					  "max_as_overlap": 1,
+					  "min_transit_isds": 2
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					SetPolicy:      &pathpol.SetPolicy{MaxASOverlap: 1, MinTransitISDs: 2},
+					PathCount:      3,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"classes": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathCount": 3,
+					"Classes": [
+					  {"TrafficClass": "dscp=0x8", "Action": "bypass"},
+					  {"TrafficClass": "dst=10.0.0.0/8", "Action": "encrypt"},
+					  {"TrafficClass": "dscp=0x2e", "Action": "secret-share", "T": 2, "N": 3}
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+					Classes: []control.ClassAction{
+						mustClassAction(t, "dscp=0x8", "bypass", 0, 0),
+						mustClassAction(t, "dst=10.0.0.0/8", "encrypt", 0, 0),
+						mustClassAction(t, "dscp=0x2e", "secret-share", 2, 3),
+					},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"rate limits": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"RateLimit": {"Rate": 100000000, "Burst": 1000000},
+					"Classes": [
+					  {"TrafficClass": "dscp=0x8", "Action": "bypass",
+					   "RateLimit": {"Rate": 10000000}}
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+					RateLimit:      &control.RateLimit{Rate: 100000000, Burst: 1000000},
+					Classes: []control.ClassAction{
+						func() control.ClassAction {
+							c := mustClassAction(t, "dscp=0x8", "bypass", 0, 0)
+							c.RateLimit = &control.RateLimit{Rate: 10000000}
+							return c
+						}(),
+					},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"rate limit without rate": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"RateLimit": {"Burst": 1000000}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"padding and cover traffic": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"Padding": {"Buckets": [256, 512, 1024]},
+					"CoverTraffic": {"Mode": "random", "Rate": 100}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+					Padding:        &control.Padding{Buckets: []int{256, 512, 1024}},
+					CoverTraffic: &control.CoverTraffic{
+						Mode: control.CoverTrafficRandom,
+						Rate: 100,
+					},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"epic": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"EPIC": true
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+					EPIC:           true,
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"unsorted padding buckets": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"Padding": {"Buckets": [512, 256]}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"unknown cover traffic mode": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"CoverTraffic": {"Mode": "bursty", "Rate": 100}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"ha mode": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"HAMode": "load-sharing"
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+					HAMode:         control.HAModeLoadSharing,
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"unknown ha mode": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"HAMode": "round-robin"
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"class with unknown action": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"Classes": [
+					  {"TrafficClass": "dscp=0x8", "Action": "drop"}
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"too many classes": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"Classes": [` +
+				strings.Repeat(`{"TrafficClass": "dscp=0x8", "Action": "bypass"},`, 15) + `
+					  {"TrafficClass": "dscp=0x8", "Action": "bypass"}
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"class with more shares than paths": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathCount": 2,
+					"Classes": [
+					  {"TrafficClass": "dscp=0x8", "Action": "secret-share", "T": 2, "N": 3}
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -119,6 +504,92 @@ func TestLegacySessionPolicyAdapterParse(t *testing.T) {
 	}
 }
 
+func TestNewClassAction(t *testing.T) {
+	testCases := map[string]struct {
+		TrafficClass string
+		Action       string
+		T            int
+		N            int
+		Expected     string
+		AssertErr    assert.ErrorAssertionFunc
+	}{
+		"bypass": {
+			TrafficClass: "dscp=0x8",
+			Action:       "bypass",
+			Expected:     "dscp=0x8:bypass",
+			AssertErr:    assert.NoError,
+		},
+		"encrypt": {
+			TrafficClass: "dscp=0x8",
+			Action:       "encrypt",
+			Expected:     "dscp=0x8:encrypt",
+			AssertErr:    assert.NoError,
+		},
+		"secret-share with defaults": {
+			TrafficClass: "dscp=0x8",
+			Action:       "secret-share",
+			Expected:     "dscp=0x8:secret-share",
+			AssertErr:    assert.NoError,
+		},
+		"secret-share": {
+			TrafficClass: "dscp=0x8",
+			Action:       "secret-share",
+			T:            2,
+			N:            4,
+			Expected:     "dscp=0x8:secret-share(2,4)",
+			AssertErr:    assert.NoError,
+		},
+		"invalid traffic class": {
+			TrafficClass: "dscp=",
+			Action:       "bypass",
+			AssertErr:    assert.Error,
+		},
+		"unknown action": {
+			TrafficClass: "dscp=0x8",
+			Action:       "drop",
+			AssertErr:    assert.Error,
+		},
+		"bypass with T and N": {
+			TrafficClass: "dscp=0x8",
+			Action:       "bypass",
+			T:            1,
+			N:            2,
+			AssertErr:    assert.Error,
+		},
+		"T larger than N": {
+			TrafficClass: "dscp=0x8",
+			Action:       "secret-share",
+			T:            3,
+			N:            2,
+			AssertErr:    assert.Error,
+		},
+		"N too large": {
+			TrafficClass: "dscp=0x8",
+			Action:       "secret-share",
+			T:            2,
+			N:            256,
+			AssertErr:    assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			c, err := control.NewClassAction(tc.TrafficClass, tc.Action, tc.T, tc.N)
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.Expected, c.String())
+		})
+	}
+}
+
+func mustClassAction(t *testing.T, trafficClass, action string, T, N int) control.ClassAction {
+	c, err := control.NewClassAction(trafficClass, action, T, N)
+	require.NoError(t, err)
+	return c
+}
+
 func TestLoadSessionPolicies(t *testing.T) {
 	file, err := os.CreateTemp("", "control_sess_pol_load")
 	require.NoError(t, err)
@@ -221,8 +692,18 @@ func TestCopyPathPolicy(t *testing.T) {
 				{Action: pathpol.Allow},
 			},
 		},
+		MaxLatency: &util.DurWrap{Duration: 100 * time.Millisecond},
+		MinMTU:     1280,
 	}
 	p := control.CopyPathPolicy(input)
 	assert.Equal(t, input, p)
 	assert.NotSame(t, input, p)
 }
+
+func TestCopySetPolicy(t *testing.T) {
+	assert.Nil(t, control.CopySetPolicy(nil))
+	input := &pathpol.SetPolicy{MaxASOverlap: 1, MinTransitISDs: 2}
+	p := control.CopySetPolicy(input)
+	assert.Equal(t, input, p)
+	assert.NotSame(t, input, p)
+}
@@ -18,8 +18,10 @@ import (
 	"io"
 	"sync"
 
+	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
@@ -59,6 +61,16 @@ func (t *AtomicRoutingTable) RouteIPv6(packet layers.IPv6) control.PktWriter {
 	return table.RouteIPv6(packet)
 }
 
+// RouteEthernet routes the frame using the current routing table. It returns
+// nil if the current routing table does not support routing Ethernet frames.
+func (t *AtomicRoutingTable) RouteEthernet(ia addr.IA, pkt gopacket.Layer) control.PktWriter {
+	table, ok := t.getPointer().(control.EthernetRoutingTableReader)
+	if !ok {
+		return nil
+	}
+	return table.RouteEthernet(ia, pkt)
+}
+
 func (t *AtomicRoutingTable) SetRoutingTable(table control.RoutingTable) io.Closer {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
@@ -0,0 +1,365 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// LinkTypeShares is the link type of the captured shares. The shares are
+	// captured as 4SP frames, starting with the frame header, which the
+	// Wireshark dissector in tools/wireshark/4sp.lua decodes.
+	LinkTypeShares = layers.LinkType(147) // LINKTYPE_USER0
+	// captureQueueLen is the number of captured records that are queued for
+	// a capture. Records are dropped if the queue is full.
+	captureQueueLen = 4096
+)
+
+// CaptureDirection is the direction of a captured packet or share.
+type CaptureDirection uint8
+
+const (
+	// CaptureEgress are the packets read from the local network and the shares
+	// sent to the remote gateways.
+	CaptureEgress CaptureDirection = iota
+	// CaptureIngress are the shares received from the remote gateways and the
+	// packets written to the local network.
+	CaptureIngress
+)
+
+func (d CaptureDirection) String() string {
+	if d == CaptureIngress {
+		return "ingress"
+	}
+	return "egress"
+}
+
+// CaptureOptions select what is captured.
+type CaptureOptions struct {
+	// Packets captures the IP packets, or Ethernet frames, that are tunneled.
+	Packets bool
+	// Shares captures the shares, and the frames sent in one piece, that
+	// carry the packets between the gateways, as well as the path MTU probes.
+	Shares bool
+	// RemoteIA restricts the capture to the traffic of the remote IA. If it is
+	// the zero value, the traffic of all remote IAs is captured.
+	RemoteIA addr.IA
+	// Duration is the maximum duration of the capture. If zero, the capture
+	// runs until the context is done.
+	Duration time.Duration
+	// Count is the maximum number of captured records. If zero, the number is
+	// not limited.
+	Count int
+}
+
+// CaptureStats are the statistics of a completed capture.
+type CaptureStats struct {
+	// Captured is the number of records written.
+	Captured int
+	// Dropped is the number of records dropped because they were captured
+	// faster than they could be written.
+	Dropped int
+}
+
+// Capturer hands copies of the packets and shares that pass through the
+// dataplane to the running captures. If no capture runs, the dataplane only
+// pays for an atomic load. The zero value is ready to use, and the methods of
+// a nil Capturer capture nothing.
+type Capturer struct {
+	mtx sync.Mutex
+	// captures holds the []*capture of the running captures. It is replaced,
+	// not modified, when a capture starts or stops.
+	captures atomic.Value
+}
+
+// Capture captures the traffic selected by the options and writes it to w as
+// a pcapng file, until the context is done, the duration has passed or the
+// count is reached. Every remote IA, path and direction gets its own interface
+// in the file. The tunneled packets are captured with the link type matching
+// the tunnel mode. The shares are captured with LinkTypeShares.
+func (c *Capturer) Capture(ctx context.Context, w io.Writer,
+	opts CaptureOptions) (CaptureStats, error) {
+
+	if !opts.Packets && !opts.Shares {
+		return CaptureStats{}, serrors.New("nothing to capture")
+	}
+	capt := &capture{
+		opts:    opts,
+		records: make(chan captureRecord, captureQueueLen),
+	}
+	c.add(capt)
+	defer c.remove(capt)
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+	cw := &captureWriter{w: w, interfaces: make(map[string]int)}
+	var stats CaptureStats
+	for opts.Count == 0 || stats.Captured < opts.Count {
+		var rec captureRecord
+		select {
+		case <-ctx.Done():
+			return cw.close(capt, stats)
+		case rec = <-capt.records:
+		}
+		if err := cw.write(rec); err != nil {
+			stats.Dropped = int(atomic.LoadInt64(&capt.dropped))
+			return stats, err
+		}
+		stats.Captured++
+	}
+	return cw.close(capt, stats)
+}
+
+func (c *Capturer) add(capt *capture) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	old := c.load()
+	captures := make([]*capture, 0, len(old)+1)
+	captures = append(captures, old...)
+	c.captures.Store(append(captures, capt))
+}
+
+func (c *Capturer) remove(capt *capture) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	old := c.load()
+	captures := make([]*capture, 0, len(old))
+	for _, other := range old {
+		if other != capt {
+			captures = append(captures, other)
+		}
+	}
+	c.captures.Store(captures)
+}
+
+func (c *Capturer) load() []*capture {
+	captures, _ := c.captures.Load().([]*capture)
+	return captures
+}
+
+// packet captures a tunneled packet of the remote IA.
+func (c *Capturer) packet(dir CaptureDirection, ia addr.IA, frameType uint8, data []byte) {
+	if c == nil {
+		return
+	}
+	captures := c.load()
+	if len(captures) == 0 {
+		return
+	}
+	rec := captureRecord{
+		ts:        time.Now(),
+		dir:       dir,
+		ia:        ia,
+		frameType: frameType,
+		data:      data,
+	}
+	for _, capt := range captures {
+		if capt.opts.Packets {
+			capt.push(rec)
+		}
+	}
+}
+
+// share captures a share exchanged with the remote IA. The peer identifies the
+// path of sent shares, and the remote gateway of received shares. It is only
+// formatted if a capture runs.
+func (c *Capturer) share(dir CaptureDirection, ia addr.IA, peer fmt.Stringer, data []byte) {
+	if c == nil {
+		return
+	}
+	captures := c.load()
+	if len(captures) == 0 {
+		return
+	}
+	rec := captureRecord{
+		ts:    time.Now(),
+		dir:   dir,
+		ia:    ia,
+		share: true,
+		peer:  peer.String(),
+		data:  data,
+	}
+	for _, capt := range captures {
+		if capt.opts.Shares {
+			capt.push(rec)
+		}
+	}
+}
+
+// captureRecord is a captured packet or share.
+type captureRecord struct {
+	ts  time.Time
+	dir CaptureDirection
+	ia  addr.IA
+	// share is set for shares, and unset for tunneled packets.
+	share bool
+	// frameType is the type of the frames the tunneled packet is sent in.
+	frameType uint8
+	// peer is the path or the remote gateway of the share.
+	peer string
+	data []byte
+}
+
+// capture is a running capture.
+type capture struct {
+	// dropped is the number of records dropped because records was full. It
+	// is accessed atomically, and thus comes first for 64-bit alignment.
+	dropped int64
+	opts    CaptureOptions
+	records chan captureRecord
+}
+
+// push queues a copy of the record, if it matches the options of the capture.
+// It never blocks.
+func (c *capture) push(rec captureRecord) {
+	if !c.opts.RemoteIA.IsZero() && !c.opts.RemoteIA.Equal(rec.ia) {
+		return
+	}
+	rec.data = append([]byte(nil), rec.data...)
+	select {
+	case c.records <- rec:
+	default:
+		atomic.AddInt64(&c.dropped, 1)
+	}
+}
+
+// captureWriter writes the records to a pcapng file. The file is started with
+// the interface of the first record.
+type captureWriter struct {
+	w  io.Writer
+	ng *pcapgo.NgWriter
+	// interfaces maps the interface names to the interface IDs.
+	interfaces map[string]int
+}
+
+func (w *captureWriter) write(rec captureRecord) error {
+	id, err := w.intf(recordInterface(rec))
+	if err != nil {
+		return err
+	}
+	ci := gopacket.CaptureInfo{
+		Timestamp:      rec.ts,
+		CaptureLength:  len(rec.data),
+		Length:         len(rec.data),
+		InterfaceIndex: id,
+	}
+	if err := w.ng.WritePacket(ci, rec.data); err != nil {
+		return serrors.WrapStr("writing record", err)
+	}
+	return nil
+}
+
+// intf returns the ID of the interface, adding it to the file if needed.
+func (w *captureWriter) intf(intf pcapgo.NgInterface) (int, error) {
+	if id, ok := w.interfaces[intf.Name]; ok {
+		return id, nil
+	}
+	var id int
+	if w.ng == nil {
+		ng, err := pcapgo.NewNgWriterInterface(w.w, intf, pcapgo.NgWriterOptions{
+			SectionInfo: pcapgo.NgSectionInfo{
+				Hardware:    runtime.GOARCH,
+				OS:          runtime.GOOS,
+				Application: "posix-gateway",
+			},
+		})
+		if err != nil {
+			return 0, serrors.WrapStr("writing section header", err)
+		}
+		w.ng = ng
+	} else {
+		var err error
+		if id, err = w.ng.AddInterface(intf); err != nil {
+			return 0, serrors.WrapStr("writing interface", err)
+		}
+	}
+	w.interfaces[intf.Name] = id
+	return id, nil
+}
+
+// close completes the file. A file without records gets an interface without
+// link type, such that it is still valid.
+func (w *captureWriter) close(capt *capture, stats CaptureStats) (CaptureStats, error) {
+	stats.Dropped = int(atomic.LoadInt64(&capt.dropped))
+	if w.ng == nil {
+		if _, err := w.intf(pcapgo.NgInterface{
+			Name:                "none",
+			LinkType:            layers.LinkTypeNull,
+			TimestampResolution: 9,
+		}); err != nil {
+			return stats, err
+		}
+	}
+	if err := w.ng.Flush(); err != nil {
+		return stats, serrors.WrapStr("flushing capture", err)
+	}
+	return stats, nil
+}
+
+// capturePeer returns the peer of the shares received from the address.
+func capturePeer(src net.Addr) fmt.Stringer {
+	if v, ok := src.(*snet.UDPAddr); ok && v.Host != nil {
+		return v.Host
+	}
+	return src
+}
+
+// recordInterface returns the interface of the record. The interfaces of the
+// tunneled packets are named "<direction> <remote IA>", the interfaces of the
+// shares are named "<direction> <remote IA> <path fingerprint>" for sent
+// shares and "<direction> <remote IA> <remote gateway>" for received shares.
+func recordInterface(rec captureRecord) pcapgo.NgInterface {
+	intf := pcapgo.NgInterface{
+		Name:                fmt.Sprintf("%s %s", rec.dir, rec.ia),
+		OS:                  runtime.GOOS,
+		TimestampResolution: 9,
+	}
+	switch {
+	case rec.share:
+		intf.Name = fmt.Sprintf("%s %s", intf.Name, rec.peer)
+		intf.LinkType = LinkTypeShares
+		if rec.dir == CaptureEgress {
+			intf.Description = fmt.Sprintf("shares sent to %s on path %s", rec.ia, rec.peer)
+		} else {
+			intf.Description = fmt.Sprintf("shares received from %s at %s", rec.ia, rec.peer)
+		}
+	case rec.frameType == FrameTypeEthernet:
+		intf.LinkType = layers.LinkTypeEthernet
+		intf.Description = fmt.Sprintf("%s Ethernet frames of %s", rec.dir, rec.ia)
+	default:
+		intf.LinkType = layers.LinkTypeRaw
+		intf.Description = fmt.Sprintf("%s IP packets of %s", rec.dir, rec.ia)
+	}
+	return intf
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestCapturer(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:110")
+	ia2 := xtest.MustParseIA("1-ff00:0:111")
+	gw := &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 30056}
+	packet := newLargeIPv4Packet(t, 100, 0).Data()
+	share := newProbe(FrameTypeIP, 1, 2, 100)
+
+	t.Run("nil capturer", func(t *testing.T) {
+		var c *Capturer
+		c.packet(CaptureEgress, ia1, FrameTypeIP, packet)
+		c.share(CaptureEgress, ia1, gw, share)
+	})
+
+	t.Run("nothing to capture", func(t *testing.T) {
+		c := &Capturer{}
+		_, err := c.Capture(context.Background(), io.Discard, CaptureOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("packets and shares", func(t *testing.T) {
+		c := &Capturer{}
+		var buf bytes.Buffer
+		done := make(chan CaptureStats)
+		go func() {
+			stats, err := c.Capture(context.Background(), &buf, CaptureOptions{
+				Packets:  true,
+				Shares:   true,
+				RemoteIA: ia1,
+				Count:    3,
+			})
+			assert.NoError(t, err)
+			done <- stats
+		}()
+		waitForCapture(t, c)
+		c.packet(CaptureEgress, ia1, FrameTypeIP, packet)
+		// Traffic of other remote IAs is not captured.
+		c.packet(CaptureEgress, ia2, FrameTypeIP, packet)
+		c.share(CaptureIngress, ia1, gw, share)
+		c.packet(CaptureEgress, ia1, FrameTypeIP, packet)
+		stats := <-done
+		assert.Equal(t, CaptureStats{Captured: 3}, stats)
+
+		r, err := pcapgo.NewNgReader(&buf, pcapgo.NgReaderOptions{WantMixedLinkType: true})
+		require.NoError(t, err)
+		var recs [][]byte
+		var intfs []int
+		for {
+			data, ci, err := r.ReadPacketData()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			recs = append(recs, data)
+			intfs = append(intfs, ci.InterfaceIndex)
+		}
+		assert.Equal(t, [][]byte{packet, share, packet}, recs)
+		assert.Equal(t, []int{0, 1, 0}, intfs)
+		require.Equal(t, 2, r.NInterfaces())
+		intf, err := r.Interface(0)
+		require.NoError(t, err)
+		assert.Equal(t, "egress 1-ff00:0:110", intf.Name)
+		assert.Equal(t, layers.LinkTypeRaw, intf.LinkType)
+		intf, err = r.Interface(1)
+		require.NoError(t, err)
+		assert.Equal(t, "ingress 1-ff00:0:110 192.0.2.1:30056", intf.Name)
+		assert.Equal(t, LinkTypeShares, intf.LinkType)
+	})
+
+	t.Run("empty capture", func(t *testing.T) {
+		c := &Capturer{}
+		var buf bytes.Buffer
+		stats, err := c.Capture(context.Background(), &buf, CaptureOptions{
+			Shares:   true,
+			Duration: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, CaptureStats{}, stats)
+		r, err := pcapgo.NewNgReader(&buf, pcapgo.DefaultNgReaderOptions)
+		require.NoError(t, err)
+		_, _, err = r.ReadPacketData()
+		assert.Equal(t, io.EOF, err)
+		// Nothing is captured once the capture completed.
+		assert.Empty(t, c.load())
+	})
+
+	t.Run("full queue", func(t *testing.T) {
+		c := &Capturer{}
+		// The capture is not running, so the records queue up.
+		capt := &capture{
+			opts:    CaptureOptions{Shares: true},
+			records: make(chan captureRecord, captureQueueLen),
+		}
+		c.add(capt)
+		for i := 0; i < captureQueueLen+5; i++ {
+			c.share(CaptureEgress, ia1, gw, share)
+		}
+		assert.Len(t, capt.records, captureQueueLen)
+		assert.EqualValues(t, 5, capt.dropped)
+	})
+}
+
+// waitForCapture waits until a capture runs.
+func waitForCapture(t *testing.T, c *Capturer) {
+	t.Helper()
+	for start := time.Now(); len(c.load()) == 0; time.Sleep(time.Millisecond) {
+		require.Less(t, time.Since(start), time.Second, "capture did not start")
+	}
+}
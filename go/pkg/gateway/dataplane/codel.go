@@ -0,0 +1,105 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// codelTarget is the acceptable standing queue delay.
+	codelTarget = 5 * time.Millisecond
+	// codelInterval is the time the queue delay must stay above the target
+	// before packets are dropped. It should be in the order of the round trip
+	// time of the flows.
+	codelInterval = 100 * time.Millisecond
+)
+
+// codel implements the dropping decision of the CoDel queue management
+// algorithm as specified in RFC 8289. It is called for every packet taken from
+// the queue with the time the packet spent in the queue.
+type codel struct {
+	target   time.Duration
+	interval time.Duration
+	// firstAbove is the time at which the sojourn time will have been above
+	// the target for an interval. It is zero if the sojourn time is below the
+	// target.
+	firstAbove time.Time
+	// dropNext is the time of the next drop in the dropping state.
+	dropNext time.Time
+	// count is the number of drops since entering the dropping state.
+	count int
+	// lastCount is the count of the previous dropping state.
+	lastCount int
+	dropping  bool
+}
+
+func newCodel() *codel {
+	return &codel{target: codelTarget, interval: codelInterval}
+}
+
+// Drop returns true if the packet that spent sojourn in the queue should be
+// dropped.
+func (c *codel) Drop(sojourn time.Duration, now time.Time) bool {
+	okToDrop := c.okToDrop(sojourn, now)
+	if c.dropping {
+		if !okToDrop {
+			// The sojourn time went below the target, leave the dropping state.
+			c.dropping = false
+			return false
+		}
+		if now.Before(c.dropNext) {
+			return false
+		}
+		c.count++
+		c.dropNext = c.controlLaw(c.dropNext)
+		return true
+	}
+	if !okToDrop {
+		return false
+	}
+	c.dropping = true
+	// If the last dropping state was recent, resume with a dropping rate
+	// close to the one that controlled the queue.
+	delta := c.count - c.lastCount
+	c.count = 1
+	if delta > 1 && now.Sub(c.dropNext) < 16*c.interval {
+		c.count = delta
+	}
+	c.dropNext = c.controlLaw(now)
+	c.lastCount = c.count
+	return true
+}
+
+// okToDrop returns true if the sojourn time has been above the target for at
+// least an interval.
+func (c *codel) okToDrop(sojourn time.Duration, now time.Time) bool {
+	if sojourn < c.target {
+		c.firstAbove = time.Time{}
+		return false
+	}
+	if c.firstAbove.IsZero() {
+		c.firstAbove = now.Add(c.interval)
+		return false
+	}
+	return !now.Before(c.firstAbove)
+}
+
+// controlLaw returns the time of the next drop. The drop rate increases with
+// the square root of the number of drops.
+func (c *codel) controlLaw(t time.Time) time.Time {
+	return t.Add(time.Duration(float64(c.interval) / math.Sqrt(float64(c.count))))
+}
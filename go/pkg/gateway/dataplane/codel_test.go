@@ -0,0 +1,64 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodel(t *testing.T) {
+	now := time.Now()
+
+	t.Run("below target", func(t *testing.T) {
+		c := newCodel()
+		for i := 0; i < 100; i++ {
+			assert.False(t, c.Drop(codelTarget-1, now.Add(time.Duration(i)*time.Millisecond)))
+		}
+	})
+
+	t.Run("above target for less than an interval", func(t *testing.T) {
+		c := newCodel()
+		assert.False(t, c.Drop(codelTarget, now))
+		assert.False(t, c.Drop(codelTarget, now.Add(codelInterval-1)))
+	})
+
+	t.Run("standing queue", func(t *testing.T) {
+		c := newCodel()
+		assert.False(t, c.Drop(codelTarget, now))
+		// Entering the dropping state drops a packet.
+		assert.True(t, c.Drop(codelTarget, now.Add(codelInterval)))
+		// The next drop is one interval later.
+		assert.False(t, c.Drop(codelTarget, now.Add(codelInterval+time.Millisecond)))
+		assert.True(t, c.Drop(codelTarget, now.Add(2*codelInterval)))
+		// The drops get more frequent, interval/sqrt(2) later.
+		next := now.Add(2*codelInterval + codelInterval*10000/14142)
+		assert.False(t, c.Drop(codelTarget, next.Add(-time.Millisecond)))
+		assert.True(t, c.Drop(codelTarget, next.Add(time.Millisecond)))
+	})
+
+	t.Run("queue drains", func(t *testing.T) {
+		c := newCodel()
+		assert.False(t, c.Drop(codelTarget, now))
+		assert.True(t, c.Drop(codelTarget, now.Add(codelInterval)))
+		assert.False(t, c.Drop(0, now.Add(2*codelInterval)))
+		assert.False(t, c.dropping)
+		// A new standing queue must persist for an interval again.
+		assert.False(t, c.Drop(codelTarget, now.Add(3*codelInterval)))
+		assert.True(t, c.Drop(codelTarget, now.Add(4*codelInterval)))
+	})
+}
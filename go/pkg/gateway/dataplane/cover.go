@@ -0,0 +1,82 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+// coverTraffic requests dummy frames from an encoder according to the cover
+// traffic configuration of a session.
+type coverTraffic struct {
+	mode control.CoverTrafficMode
+	// interval is the mean time between dummy frames.
+	interval time.Duration
+	// encoder is the encoder that sends the dummy frames.
+	encoder *encoder
+	// lastSent is the time of the last frame sent on the session, in Unix
+	// nanoseconds. It is updated by the session.
+	lastSent int64
+}
+
+func newCoverTraffic(cfg *control.CoverTraffic, enc *encoder) *coverTraffic {
+	return &coverTraffic{
+		mode:     cfg.Mode,
+		interval: time.Duration(float64(time.Second) / cfg.Rate),
+		encoder:  enc,
+		lastSent: time.Now().UnixNano(),
+	}
+}
+
+// FrameSent records that a frame was sent on the session.
+func (c *coverTraffic) FrameSent(now time.Time) {
+	atomic.StoreInt64(&c.lastSent, now.UnixNano())
+}
+
+// Run requests dummy frames until the encoder is closed.
+func (c *coverTraffic) Run() {
+	for {
+		time.Sleep(c.next(time.Now()))
+		if c.mode == control.CoverTrafficConstant && c.idle(time.Now()) < c.interval {
+			// A frame was sent in the meantime.
+			continue
+		}
+		if !c.encoder.ring.WriteCover() {
+			return
+		}
+	}
+}
+
+// next returns the time to wait before the next dummy frame may be needed.
+func (c *coverTraffic) next(now time.Time) time.Duration {
+	if c.mode == control.CoverTrafficRandom {
+		// Exponentially distributed gaps make the dummy frames a Poisson
+		// process, independent of the traffic.
+		return time.Duration(rand.ExpFloat64() * float64(c.interval))
+	}
+	if wait := c.interval - c.idle(now); wait > 0 {
+		return wait
+	}
+	return c.interval
+}
+
+// idle returns the time since the last frame was sent.
+func (c *coverTraffic) idle(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, atomic.LoadInt64(&c.lastSent)))
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestCoverTraffic(t *testing.T) {
+	t.Run("constant", func(t *testing.T) {
+		c := newCoverTraffic(&control.CoverTraffic{
+			Mode: control.CoverTrafficConstant,
+			Rate: 10,
+		}, newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey)))
+		assert.Equal(t, 100*time.Millisecond, c.interval)
+
+		now := time.Now()
+		c.FrameSent(now)
+		// The dummy frame is due one interval after the last frame.
+		assert.Equal(t, 70*time.Millisecond, c.next(now.Add(30*time.Millisecond)))
+		// If it is overdue, the next one is due one interval later, such that
+		// the dummy frame can be sent in the meantime.
+		assert.Equal(t, 100*time.Millisecond, c.next(now.Add(150*time.Millisecond)))
+	})
+
+	t.Run("random", func(t *testing.T) {
+		c := newCoverTraffic(&control.CoverTraffic{
+			Mode: control.CoverTrafficRandom,
+			Rate: 10,
+		}, newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey)))
+		now := time.Now()
+		var total time.Duration
+		for i := 0; i < 1000; i++ {
+			total += c.next(now)
+		}
+		// The gaps do not depend on the traffic and are 100ms on average.
+		assert.InDelta(t, 100*time.Millisecond, total/1000, float64(20*time.Millisecond))
+	})
+
+	t.Run("stops when the encoder is closed", func(t *testing.T) {
+		enc := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
+		c := newCoverTraffic(&control.CoverTraffic{
+			Mode: control.CoverTrafficRandom,
+			Rate: 1000,
+		}, enc)
+		enc.Close()
+		done := make(chan struct{})
+		go func() {
+			c.Run()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("cover traffic did not stop")
+		}
+	})
+}
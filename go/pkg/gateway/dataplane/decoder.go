@@ -5,26 +5,50 @@ import (
 	"sync"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/ringbuf"
 )
 
 type Decoder struct {
-	// requiredSharesForDecode is equal to T in a (T,N) secret sharing scheme
-	requiredSharesForDecode int
-	// shareBufGroupMap is a map of shareBufGroups for each groupSeqNr
-	shareBufGroupMap map[uint64]*shareBufGroup
+	// remoteIA is the ISD-AS of the remote gateway the frames are received
+	// from.
+	remoteIA addr.IA
+	// policies determine the modes and thresholds of the frames that are
+	// accepted from the remote gateway.
+	policies *FramePolicies
+	// shareBufGroupMap is a map of shareBufGroups for each stream and groupSeqNr
+	shareBufGroupMap map[shareGroupKey]*shareBufGroup
 	// mutex for the shareBufGroupMap
 	mutex sync.Mutex
-	// aesKey is the AES key used to decrypt the frames after combining the shares
-	// The key is currently provided in the config .toml file
-	aesKey string
+	// keys provide the AES keys used to decrypt the frames after combining the
+	// shares.
+	keys Keys
+	// metrics are the metrics of the worker the decoder belongs to.
+	metrics IngressMetrics
+	// rejected counts the frames whose mode or threshold is not configured
+	// for the remote gateway.
+	rejected metrics.Counter
+	// indices are the share indices received so far. They are used to
+	// determine which shares of a group were lost.
+	indices shareIndexSet
+	// sharesLost caches the lost shares counters per share index.
+	sharesLost *indexCounters
 }
 
-func newDecoder(requiredSharesForDecode int, aesKey string) *Decoder {
+func newDecoder(remoteIA addr.IA, policies *FramePolicies, keys Keys,
+	ingressMetrics IngressMetrics) *Decoder {
+
 	d := &Decoder{
-		requiredSharesForDecode: requiredSharesForDecode,
-		shareBufGroupMap:        make(map[uint64]*shareBufGroup),
-		aesKey:                  aesKey,
+		remoteIA:         remoteIA,
+		policies:         policies,
+		shareBufGroupMap: make(map[shareGroupKey]*shareBufGroup),
+		keys:             keys,
+		metrics:          ingressMetrics,
+		rejected: metrics.CounterWith(ingressMetrics.FramesDiscarded,
+			"reason", "policy"),
+		sharesLost: newIndexCounters(ingressMetrics.SharesLost),
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -33,19 +57,54 @@ func newDecoder(requiredSharesForDecode int, aesKey string) *Decoder {
 	return d
 }
 
+// shareGroupKey identifies a share group. The sequence numbers of the streams of
+// a session are independent, so groups of different streams must be kept apart.
+type shareGroupKey struct {
+	stream     uint32
+	groupSeqNr uint64
+}
+
+// Insert inserts the share into the decoder and returns the decoded frame if
+// the share completes one. Shares and frames whose mode or threshold differ
+// from the ones configured for the class of their stream are discarded, such
+// that a spoofed header can neither skip the decryption nor lower the
+// threshold.
 func (d *Decoder) Insert(ctx context.Context, share *shareBuf) *frameBuf {
-	groupSeqNr := uint64(share.seqNr >> 8)
+	mode := frameMode(share)
+	stream := frameStream(share)
+	threshold := share.raw[thresholdPos]
+	if !d.policies.accepts(d.remoteIA, streamClass(stream), mode, threshold) {
+		increaseCounterMetric(d.rejected, 1)
+		share.Release()
+		return nil
+	}
+	switch mode {
+	case frameModeShared:
+	case frameModeEncrypted:
+		return d.decrypt(d.unshared(share))
+	case frameModePlain:
+		return d.unshared(share)
+	default:
+		share.Release()
+		return nil
+	}
+
+	key := shareGroupKey{
+		stream:     stream,
+		groupSeqNr: uint64(share.seqNr >> 8),
+	}
 
 	d.mutex.Lock()
 	defer func() {
 		d.mutex.Unlock()
 	}()
-	sbg, ok := d.shareBufGroupMap[groupSeqNr] // this is executed despite cleanup having the lock
+	sbg, ok := d.shareBufGroupMap[key] // this is executed despite cleanup having the lock
+	d.indices.add(GetPathIndex(share))
 
 	if !ok {
 		// There is no sbg for the groupSeqNr, so create one
-		sbg = NewShareBufGroup(share, uint8(d.requiredSharesForDecode))
-		d.shareBufGroupMap[groupSeqNr] = sbg
+		sbg = NewShareBufGroup(share, threshold)
+		d.shareBufGroupMap[key] = sbg
 	}
 
 	if ok {
@@ -53,6 +112,8 @@ func (d *Decoder) Insert(ctx context.Context, share *shareBuf) *frameBuf {
 
 		// Check if groupSeqNr is already combined
 		if sbg.isCombined {
+			// Remember the late share for the loss accounting.
+			sbg.received.add(GetPathIndex(share))
 			share.Release()
 			return nil
 		}
@@ -65,15 +126,52 @@ func (d *Decoder) Insert(ctx context.Context, share *shareBuf) *frameBuf {
 		// Combination was unsuccessful.
 		return nil
 	}
+	increaseCounterMetric(d.metrics.ShareGroupsCombined, 1)
+	if d.metrics.CombineDuration != nil {
+		d.metrics.CombineDuration.Observe(time.Since(sbg.created).Seconds())
+	}
+
+	return d.decrypt(combinedFrame)
+}
+
+// unshared turns a frame that was sent in one piece into a frameBuf. The share
+// is released.
+func (d *Decoder) unshared(share *shareBuf) *frameBuf {
+	defer share.Release()
+	entries := make(ringbuf.EntryList, 1)
+	if n := newFrameBufs(entries); n != 1 {
+		return nil
+	}
+	frame := entries[0].(*frameBuf)
+	copy(frame.raw, share.raw[:share.frameLen])
+	frame.seqNr = share.seqNr >> 8
+	frame.frameLen = share.frameLen
+	return frame
+}
 
-	// AES-Decrypt the combined frame
-	decryptedFrame, err := Decrypt(combinedFrame.raw[hdrLen:combinedFrame.frameLen], d.aesKey)
-	if err != nil {
+// decrypt AES-decrypts the payload of the frame in place. The keys are tried in
+// order. If decryption fails with all of them, the frame is released and nil is
+// returned.
+func (d *Decoder) decrypt(frame *frameBuf) *frameBuf {
+	if frame == nil {
+		return nil
+	}
+	keys, err := d.keys.DecryptionKeys(time.Now())
+	var decryptedFrame []byte
+	for _, key := range keys {
+		decryptedFrame, err = Decrypt(frame.raw[hdrLen:frame.frameLen], key)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil || len(keys) == 0 {
+		increaseCounterMetric(d.metrics.DecryptErrors, 1)
+		frame.Release()
 		return nil
 	}
-	n := copy(combinedFrame.raw[hdrLen:], decryptedFrame)
-	combinedFrame.frameLen = hdrLen + n
-	return combinedFrame
+	n := copy(frame.raw[hdrLen:], decryptedFrame)
+	frame.frameLen = hdrLen + n
+	return frame
 }
 
 func (d *Decoder) runCleanupLoop() {
@@ -83,27 +181,47 @@ func (d *Decoder) runCleanupLoop() {
 	}
 }
 
-// cleanup loops over all shareBufGroups and removes the ones that are marked for cleanup if a
-// shareBufGroup is marked for cleanup, it will be marked for cleanup. Hence, no sbg will exist
-// longer than 2 cleanup intervals.
+// cleanup loops over all shareBufGroups and removes the ones that are marked for cleanup. If a
+// shareBufGroup is not marked for cleanup, it will be marked for cleanup. Hence, no sbg will exist
+// longer than 2 cleanup intervals. Combined groups are kept for the same time, such that the late
+// shares are not mistaken for the first share of a new group and can be accounted for.
 func (d *Decoder) cleanup() {
 	d.mutex.Lock()
 	for groupSeqNr, sbg := range d.shareBufGroupMap {
-
-		if sbg.isCombined {
-			// sbg is already released, we only need to delete it from the map
-			// fmt.Println("----Decoder cleanup: sbg is combined, deleting from map")
-			delete(d.shareBufGroupMap, groupSeqNr)
+		if !sbg.isMarkedForCleanup {
+			sbg.isMarkedForCleanup = true
 			continue
 		}
-
-		if sbg.isMarkedForCleanup {
-			// delete sbg
+		if !sbg.isCombined {
+			// The group was not combined in time, i.e., fewer than T shares
+			// arrived.
+			increaseCounterMetric(d.metrics.ShareGroupsExpired, 1)
 			sbg.Release()
-			delete(d.shareBufGroupMap, groupSeqNr)
-		} else {
-			sbg.isMarkedForCleanup = true
 		}
+		d.countLost(sbg)
+		delete(d.shareBufGroupMap, groupSeqNr)
 	}
 	d.mutex.Unlock()
 }
+
+// countLost accounts the shares of the group that did not arrive. Only the
+// share indices that were seen by the decoder are considered.
+func (d *Decoder) countLost(sbg *shareBufGroup) {
+	for i := 0; i < 256; i++ {
+		index := uint8(i)
+		if d.indices.contains(index) && !sbg.received.contains(index) {
+			increaseCounterMetric(d.sharesLost.get(index), 1)
+		}
+	}
+}
+
+// shareIndexSet is a set of share indices.
+type shareIndexSet [4]uint64
+
+func (s *shareIndexSet) add(index uint8) {
+	s[index/64] |= 1 << (index % 64)
+}
+
+func (s *shareIndexSet) contains(index uint8) bool {
+	return s[index/64]&(1<<(index%64)) != 0
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDecoderMetrics(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	sharesRecv := metrics.NewTestCounter()
+	combined := metrics.NewTestCounter()
+	expired := metrics.NewTestCounter()
+	lost := metrics.NewTestCounter()
+	decryptErrors := metrics.NewTestCounter()
+	duration := &testHistogram{}
+	w := newWorker(addr, 1, NewFramePolicies(2), &MockTun{}, IngressMetrics{
+		SharesRecv:          sharesRecv,
+		ShareGroupsCombined: combined,
+		ShareGroupsExpired:  expired,
+		SharesLost:          lost,
+		DecryptErrors:       decryptErrors,
+		CombineDuration:     duration,
+	}, StaticKey(testAESKey))
+
+	pkt := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+	header := []byte{0, 1, 0xff, 0xff, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	t.Run("combined", func(t *testing.T) {
+		EncryptAndSendFrameWithHeader(t, w, pkt, header, 1)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, float64(1),
+				metrics.CounterValue(sharesRecv.With("share_index", strconv.Itoa(i))))
+		}
+		assert.Equal(t, float64(1), metrics.CounterValue(combined))
+		assert.Len(t, duration.observations(), 1)
+		assert.Equal(t, float64(0), metrics.CounterValue(decryptErrors))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		encrypted, err := Encrypt(pkt, testAESKey)
+		assert.NoError(t, err)
+		shares, err := Split(encrypted, 3, 2)
+		assert.NoError(t, err)
+		header[14], header[15] = 2, 0
+		SendFrame(t, w, append(append([]byte(nil), header...), shares[0]...))
+
+		// Groups are released in the second cleanup after they were created.
+		w.decoder.cleanup()
+		assert.Equal(t, float64(0), metrics.CounterValue(expired))
+		w.decoder.cleanup()
+		assert.Equal(t, float64(1), metrics.CounterValue(expired))
+		assert.Equal(t, float64(1), metrics.CounterValue(combined))
+
+		// All the shares of the combined group arrived, only the shares with
+		// index 1 and 2 of the expired group are lost.
+		assert.Equal(t, float64(0), metrics.CounterValue(lost.With("share_index", "0")))
+		assert.Equal(t, float64(1), metrics.CounterValue(lost.With("share_index", "1")))
+		assert.Equal(t, float64(1), metrics.CounterValue(lost.With("share_index", "2")))
+	})
+
+	t.Run("decrypt error", func(t *testing.T) {
+		shares, err := Split(pkt, 3, 2)
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			header[14], header[15] = 3, byte(i)
+			SendFrame(t, w, append(append([]byte(nil), header...), shares[i]...))
+		}
+		assert.Equal(t, float64(2), metrics.CounterValue(combined))
+		assert.Equal(t, float64(1), metrics.CounterValue(decryptErrors))
+	})
+}
+
+func TestDecoderKeys(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	const otherKey = "abcdefabcdefabcdefabcdefabcdefab"
+	pkt := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+	header := []byte{0, 1, 0xff, 0xff, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	testCases := map[string]struct {
+		keys   []string
+		errors float64
+	}{
+		"first key":  {keys: []string{testAESKey, otherKey}},
+		"second key": {keys: []string{otherKey, testAESKey}},
+		"no key":     {keys: nil, errors: 1},
+		"wrong key":  {keys: []string{otherKey}, errors: 1},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			combined := metrics.NewTestCounter()
+			decryptErrors := metrics.NewTestCounter()
+			w := newWorker(addr, 1, NewFramePolicies(2), &MockTun{}, IngressMetrics{
+				ShareGroupsCombined: combined,
+				DecryptErrors:       decryptErrors,
+			}, testKeys(tc.keys))
+			EncryptAndSendFrameWithHeader(t, w, pkt, append([]byte(nil), header...), 1)
+			assert.Equal(t, float64(1), metrics.CounterValue(combined))
+			assert.Equal(t, tc.errors, metrics.CounterValue(decryptErrors))
+		})
+	}
+}
+
+// testKeys are keys that return the same decryption keys at any time.
+type testKeys []string
+
+func (k testKeys) EncryptionKey(time.Time) (string, error) {
+	if len(k) == 0 {
+		return "", serrors.New("no key")
+	}
+	return k[0], nil
+}
+
+func (k testKeys) DecryptionKeys(time.Time) ([]string, error) {
+	return k, nil
+}
+
+// testHistogram records the observations for use in tests.
+type testHistogram struct {
+	mtx    sync.Mutex
+	values []float64
+}
+
+func (h *testHistogram) With(labels ...string) metrics.Histogram {
+	return h
+}
+
+func (h *testHistogram) Observe(v float64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.values = append(h.values, v)
+}
+
+func (h *testHistogram) observations() []float64 {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return append([]float64(nil), h.values...)
+}
@@ -17,7 +17,11 @@ package dataplane
 import (
 	"encoding/binary"
 	"math"
+	"math/rand"
 	"time"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
 // Each SIG frame starts with SIG frame header with the following format:
@@ -27,29 +31,92 @@ import (
 //  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 //  |     Version   |    Session    |            Index              |
 //  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-//  |   Reserved (12 bits)    |          Stream (20 bits)           |
+//  |   Threshold   |P| Mode|          Stream (20 bits)           |
 //  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 //  |                                                               |
 //  +                       Sequence number                         +
 //  |                                                               |
 //  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 //
-// The header is followed by raw IP packets (or parts thereof) one directly
-// following another with no intermediate padding.
+// The version field carries the frame type, which tells the receiver how to
+// delimit the encapsulated packets. For FrameTypeIP, the header is followed by
+// raw IP packets (or parts thereof) one directly following another with no
+// intermediate padding. For FrameTypeEthernet, the header is followed by
+// Ethernet frames (or parts thereof), each preceded by its length as a 2-byte
+// big-endian integer.
+//
+// The mode field tells the receiver how the frame is protected. Secret-shared
+// frames are encrypted and split into shares, each sent in its own frame with
+// the share index in the last byte of the sequence number. The threshold field
+// carries the number of shares needed to reconstruct the frame. Encrypted
+// frames and plain frames are sent in one piece, the former encrypted, the
+// latter as is. The threshold field is zero for them.
+//
+// The upper 4 bits of the stream field carry the index of the traffic class of
+// the session the frame belongs to, the lower 16 bits are random. The header
+// is not authenticated, hence the receiver only accepts a frame if its mode and
+// threshold match the ones configured for the class, see FramePolicies.
+//
+// The P flag tells the receiver that the payload of the frame is padded. The
+// padding ends with a trailer that also marks dummy frames, see padding.go.
+// The padding is added before encryption, so only encrypted frames are padded.
+//
+// Probe frames and probe replies carry no packets. They are used to discover
+// the MTU of the paths, see pmtu.go.
+
+// Frame types carried in the version field of the frame header.
+const (
+	// FrameTypeIP is the frame type for IPv4 and IPv6 packets.
+	FrameTypeIP uint8 = 0
+	// FrameTypeEthernet is the frame type for Ethernet frames, including
+	// VLAN-tagged frames.
+	FrameTypeEthernet uint8 = 1
+)
+
+// Frame modes carried in the mode field of the frame header.
+const (
+	// frameModeShared is the mode of the shares of an encrypted frame.
+	frameModeShared uint8 = 0
+	// frameModeEncrypted is the mode of an encrypted frame sent in one piece.
+	frameModeEncrypted uint8 = 1
+	// frameModePlain is the mode of an unencrypted frame sent in one piece.
+	frameModePlain uint8 = 2
+	// frameModeProbe is the mode of a path MTU probe.
+	frameModeProbe uint8 = 3
+	// frameModeProbeReply is the mode of the reply to a path MTU probe.
+	frameModeProbeReply uint8 = 4
+
+	// paddedFlag is set in the byte at modePos if the payload is padded.
+	paddedFlag uint8 = 0x80
+)
 
 const (
 	// Length of the frame header, in bytes.
 	hdrLen = 16
 	// Location of individual fields in the frame header.
-	versionPos = 0
-	sessPos    = 1
-	indexPos   = 2
-	streamPos  = 4
-	seqPos     = 8
+	versionPos   = 0
+	sessPos      = 1
+	indexPos     = 2
+	thresholdPos = 4
+	modePos      = 5
+	streamPos    = 4
+	seqPos       = 8
+
+	// streamClassShift is the position of the class index in the stream ID.
+	streamClassShift = 16
+
+	// Length of the length field preceding each Ethernet frame, in bytes.
+	ethLenFieldLen = 2
+	// Minimum length of an Ethernet frame, in bytes. Only the header, without
+	// the frame check sequence, is required.
+	ethMinLen = 14
 )
 
 // encoder reads packets from a ring buffer and transforms them into SIG frames.
 type encoder struct {
+	// frameType is the type of the frames built by this encoder. It determines
+	// which packets are accepted.
+	frameType uint8
 	// sessionID of the session this encoder belongs to.
 	sessionID uint8
 	// streamID identifies a flow within the session. Only the frames from
@@ -67,21 +134,35 @@ type encoder struct {
 	// maxMessageLength is the maximum number of bytes that can be read from packets such that the
 	// resulting encrypted frame is still below the MTU
 	maxMessageLength int
-	// aesKey is the key used to encrypt the frame. The key is currently provided in the config
-	// .toml file
-	aesKey string
+	// keys provide the key used to encrypt the frames.
+	keys Keys
+	// noKey counts the frames dropped because no key was available.
+	noKey metrics.Counter
+	// mode is the frame mode written to the frame header.
+	mode uint8
+	// threshold is the threshold written to the header of secret-shared frames.
+	threshold uint8
+	// padding, if set, pads the payload of the encrypted frames to its
+	// buckets.
+	padding *control.Padding
+	// cover is set if the encoder sends dummy frames, which requires the
+	// payloads to carry the padding trailer.
+	cover bool
+	// coverSent counts the dummy frames.
+	coverSent metrics.Counter
 }
 
 // newEncoder creates a new encoder instance.
 // mtu is max size of the frame, excluding SCION header, but including SIG header.
-func newEncoder(sessionID uint8, streamID uint32, aesKey string) *encoder {
+func newEncoder(frameType uint8, sessionID uint8, streamID uint32, keys Keys) *encoder {
 	return &encoder{
+		frameType: frameType,
 		sessionID: sessionID,
 		streamID:  streamID,
 		seq:       0,
-		ring:      newPktRing(),
+		ring:      newCodelPktRing(nil),
 		frame:     make([]byte, 0),
-		aesKey:    aesKey,
+		keys:      keys,
 	}
 }
 
@@ -106,41 +187,127 @@ func calculateMaxMessageLengthForMTU(mtu int) int {
 
 // ReadEncryptedSIGFrame reads a SIG frame using ReadRegularSIGFrame and encrypts it.
 func (e *encoder) ReadEncryptedSIGFrame(mtu int) []byte {
+	overhead := 0
+	if e.mode == frameModeShared {
+		// The secret sharing scheme takes up one tag byte for reconstruction.
+		overhead = 1
+	}
+	e.maxMessageLength = hdrLen + e.maxPacketLen(mtu)
+	for {
+		e.writeHeader(mtu - overhead)
+		frame := e.ReadRegularSIGFrame()
+
+		if frame == nil {
+			return nil
+		}
+		if e.padded() {
+			frame = e.pad(frame)
+		}
+
+		key, err := e.keys.EncryptionKey(time.Now())
+		if err != nil {
+			// Without a key the frame cannot be sent, so it is dropped and
+			// the next one is read.
+			increaseCounterMetric(e.noKey, 1)
+			continue
+		}
+		// encrypt the frame
+		share, err := Encrypt(frame[hdrLen:], key)
+		if err != nil {
+			panic(err)
+		}
+
+		return append(frame[:hdrLen], share...)
+	}
+}
+
+// maxPacketLen returns the length of the largest packet that fits into a
+// single frame of the given MTU.
+func (e *encoder) maxPacketLen(mtu int) int {
+	if e.mode == frameModePlain {
+		return mtu - hdrLen
+	}
+	overhead := 0
+	if e.mode == frameModeShared {
+		// The secret sharing scheme takes up one tag byte for reconstruction.
+		overhead = 1
+	}
+	n := calculateMaxMessageLengthForMTU(mtu-overhead) - hdrLen
+	if e.padded() {
+		n -= padTrailerLen
+	}
+	return n
+}
+
+// padded returns true if the payloads of the encrypted frames carry the
+// padding trailer.
+func (e *encoder) padded() bool {
+	return e.padding != nil || e.cover
+}
 
-	e.maxMessageLength = calculateMaxMessageLengthForMTU(mtu - 1) // -1 because the secret sharing scheme takes up one tag byte for reconstruction
+// pad pads the payload of the frame. A frame without payload is a dummy frame,
+// it is padded to a random size among the sizes of the data frames. Without
+// padding, data frames only get the trailer and dummy frames take the largest
+// size, which is the size of the data frames under load.
+func (e *encoder) pad(frame []byte) []byte {
+	max := e.maxMessageLength + padTrailerLen - hdrLen
+	var buckets []int
+	if e.padding != nil {
+		buckets = e.padding.Buckets
+	}
+	if len(frame) > hdrLen {
+		if e.padding == nil {
+			return padFrame(frame, 0, false)
+		}
+		return padFrame(frame, padSize(len(frame)-hdrLen, buckets, max), false)
+	}
+	increaseCounterMetric(e.coverSent, 1)
+	sizes := []int{max}
+	for _, b := range buckets {
+		if b < max {
+			sizes = append(sizes, b)
+		}
+	}
+	return padFrame(frame, sizes[rand.Intn(len(sizes))], true)
+}
+
+// ReadPlainSIGFrame reads a SIG frame using ReadRegularSIGFrame and returns it
+// unencrypted.
+func (e *encoder) ReadPlainSIGFrame(mtu int) []byte {
+	e.maxMessageLength = mtu
+	e.writeHeader(mtu)
+	return e.ReadRegularSIGFrame()
+}
 
-	e.frame = make([]byte, 0, mtu-1)
+// writeHeader allocates a new frame with the given capacity and writes the
+// frame header to it.
+func (e *encoder) writeHeader(capacity int) {
+	e.frame = make([]byte, 0, capacity)
 	e.frame = e.frame[:hdrLen]
 	// Write the header.
-	e.frame[versionPos] = 0
+	e.frame[versionPos] = e.frameType
 	e.frame[sessPos] = e.sessionID
 	binary.BigEndian.PutUint16(e.frame[indexPos:indexPos+2], 0xffff)
 	binary.BigEndian.PutUint32(e.frame[streamPos:streamPos+4], e.streamID&0xfffff)
+	e.frame[modePos] |= e.mode << 4
+	if e.mode != frameModePlain && e.padded() {
+		e.frame[modePos] |= paddedFlag
+	}
+	if e.mode == frameModeShared {
+		e.frame[thresholdPos] = e.threshold
+	}
 	binary.BigEndian.PutUint64(e.frame[seqPos:seqPos+8], e.seq)
 
 	// Increase the sequence number of the share group by 256 as they are identified by the last
 	// byte
 	e.seq += 256
-	frame := e.ReadRegularSIGFrame()
-
-	if frame == nil {
-		return nil
-	}
-
-	// encrypt the frame
-	share, err := Encrypt(frame[hdrLen:], e.aesKey)
-	if err != nil {
-		panic(err)
-	}
-
-	frame = frame[:hdrLen+len(share)]
-	copy(frame[hdrLen:], share)
-	return frame
 }
 
 // Reads a SIG frame from the encoder.
 // The function blocks if there are no frames available.
 // When the encoder is closed, the function returns nil.
+// If a dummy frame is requested while there is no data, the function returns
+// a frame without payload.
 // Fills up e.frame with e.maxMessageLength bytes
 func (e *encoder) ReadRegularSIGFrame() []byte {
 
@@ -173,6 +340,14 @@ func (e *encoder) ReadRegularSIGFrame() []byte {
 			// No more packets to stuff into the frame. Go on with sending.
 			return e.frame[:pos]
 		}
+		if e.pkt == nil && n == 1 {
+			// A dummy frame is requested. It is only needed if there is no
+			// data to send.
+			if pos == hdrLen {
+				return e.frame[:pos]
+			}
+			continue
+		}
 		if n == -1 {
 			if block {
 				// Ringbuffer was closed.
@@ -185,29 +360,8 @@ func (e *encoder) ReadRegularSIGFrame() []byte {
 			}
 		}
 		// We've got a packet to stuff into the frame.
-		// Let's make sure that it is a valid IPv4 or IPv6 packet.
-		if len(e.pkt) == 0 {
-			continue
-		}
-		ipVersion := e.pkt[0] >> 4
-		switch ipVersion {
-		case 4:
-			if len(e.pkt) < 20 {
-				continue
-			}
-			length := int(binary.BigEndian.Uint16(e.pkt[2:4]))
-			if length != len(e.pkt) {
-				continue
-			}
-		case 6:
-			if len(e.pkt) < 40 {
-				continue
-			}
-			length := 40 + int(binary.BigEndian.Uint16(e.pkt[4:6]))
-			if length != len(e.pkt) {
-				continue
-			}
-		default:
+		// Let's make sure that it is valid for the frame type.
+		if !e.preparePkt() {
 			continue
 		}
 		// Set the first packet index in the frame header if appropriate.
@@ -224,6 +378,42 @@ func (e *encoder) ReadRegularSIGFrame() []byte {
 	}
 }
 
+// preparePkt checks whether the currently processed packet is valid for the
+// frame type of the encoder and prepares it for being copied to the frame.
+// Returns false if the packet must be dropped.
+func (e *encoder) preparePkt() bool {
+	if e.frameType == FrameTypeEthernet {
+		if len(e.pkt) < ethMinLen || len(e.pkt) > math.MaxUint16 {
+			return false
+		}
+		pkt := make([]byte, ethLenFieldLen+len(e.pkt))
+		binary.BigEndian.PutUint16(pkt[:ethLenFieldLen], uint16(len(e.pkt)))
+		copy(pkt[ethLenFieldLen:], e.pkt)
+		e.pkt = pkt
+		return true
+	}
+	if len(e.pkt) == 0 {
+		return false
+	}
+	ipVersion := e.pkt[0] >> 4
+	switch ipVersion {
+	case 4:
+		if len(e.pkt) < 20 {
+			return false
+		}
+		length := int(binary.BigEndian.Uint16(e.pkt[2:4]))
+		return length == len(e.pkt)
+	case 6:
+		if len(e.pkt) < 40 {
+			return false
+		}
+		length := 40 + int(binary.BigEndian.Uint16(e.pkt[4:6]))
+		return length == len(e.pkt)
+	default:
+		return false
+	}
+}
+
 // copyToFrame copies as much data as possible from the currently processed packet
 // to the current frame. Returns number of bytes copied.
 func (e *encoder) copyToFrame() int {
@@ -238,7 +428,8 @@ func (e *encoder) copyToFrame() int {
 	return toCopy
 }
 
-// NewStreamID generates a new random stream ID.
+// NewStreamID generates a new random stream ID. Only the lower 16 bits are
+// set, the upper bits are left for the class index.
 func NewStreamID() uint32 {
 	return uint32(time.Now().UnixNano() & 0xffff)
 }
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
 const testAESKey = "12345678901234567890123456789012"
@@ -55,7 +57,7 @@ func TestEncoder(t *testing.T) {
 	// })
 
 	t.Run("simple IPv4 packet", func(t *testing.T) {
-		e := newEncoder(1, 2, testAESKey)
+		e := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
 		ipv4Packet := []byte{
 			// IPv4 header.
 			0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -80,6 +82,132 @@ func TestEncoder(t *testing.T) {
 		assert.Nil(t, frame)
 	})
 
+	t.Run("Ethernet frame", func(t *testing.T) {
+		e := newEncoder(FrameTypeEthernet, 1, 2, StaticKey(testAESKey))
+		ethFrame := []byte{
+			// Ethernet header with 802.1Q tag.
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 1, 2, 3, 4, 5, 0x81, 0x00, 0, 10, 0x08, 0x06,
+			// Payload.
+			1, 2, 3,
+		}
+		// Too short to be an Ethernet frame, dropped.
+		e.Write([]byte{0xff, 0xff, 0xff})
+		e.Write(ethFrame)
+		e.Close()
+		frame := e.ReadEncryptedSIGFrame(1500)
+
+		assert.EqualValues(t, []byte{
+			// SIG frame header.
+			1, 1, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+		}, frame[:hdrLen])
+
+		decrypted, err := Decrypt(frame[hdrLen:], testAESKey)
+		assert.NoError(t, err)
+		assert.EqualValues(t, append([]byte{0, byte(len(ethFrame))}, ethFrame...), decrypted)
+
+		frame = e.ReadEncryptedSIGFrame(1500)
+		assert.Nil(t, frame)
+	})
+
+	t.Run("secret-shared frame with threshold", func(t *testing.T) {
+		e := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
+		e.mode = frameModeShared
+		e.threshold = 3
+		e.Write([]byte{0x40, 0, 0, 20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+		e.Close()
+		frame := e.ReadEncryptedSIGFrame(1500)
+
+		assert.EqualValues(t, []byte{
+			// SIG frame header.
+			0, 1, 0, 0, 3, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+		}, frame[:hdrLen])
+	})
+
+	t.Run("plain frame", func(t *testing.T) {
+		e := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
+		e.mode = frameModePlain
+		ipv4Packet := []byte{
+			// IPv4 header.
+			0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1, 2, 3,
+		}
+		e.Write(ipv4Packet)
+		e.Close()
+		frame := e.ReadPlainSIGFrame(1500)
+
+		assert.EqualValues(t, append([]byte{
+			// SIG frame header.
+			0, 1, 0, 0, 0, 0x20, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+		}, ipv4Packet...), frame)
+
+		frame = e.ReadPlainSIGFrame(1500)
+		assert.Nil(t, frame)
+	})
+
+	t.Run("padded frame", func(t *testing.T) {
+		e := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
+		e.mode = frameModeEncrypted
+		e.padding = &control.Padding{Buckets: []int{64, 256}}
+		ipv4Packet := []byte{
+			// IPv4 header.
+			0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1, 2, 3,
+		}
+		e.Write(ipv4Packet)
+		e.Close()
+		frame := e.ReadEncryptedSIGFrame(1500)
+
+		assert.EqualValues(t, []byte{
+			// SIG frame header.
+			0, 1, 0, 0, 0, 0x90, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+		}, frame[:hdrLen])
+
+		decrypted, err := Decrypt(frame[hdrLen:], testAESKey)
+		assert.NoError(t, err)
+		assert.Len(t, decrypted, 64)
+		n, dummy, err := unpad(decrypted)
+		assert.NoError(t, err)
+		assert.False(t, dummy)
+		assert.EqualValues(t, ipv4Packet, decrypted[:n])
+	})
+
+	t.Run("dummy frame", func(t *testing.T) {
+		e := newEncoder(FrameTypeIP, 1, 2, StaticKey(testAESKey))
+		e.cover = true
+		e.ring.WriteCover()
+		frame := e.ReadEncryptedSIGFrame(1500)
+
+		assert.EqualValues(t, []byte{
+			// SIG frame header, without packet start.
+			0, 1, 0xff, 0xff, 0, 0x80, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+		}, frame[:hdrLen])
+
+		decrypted, err := Decrypt(frame[hdrLen:], testAESKey)
+		assert.NoError(t, err)
+		// Dummy frames take the largest size.
+		assert.Len(t, decrypted, e.maxMessageLength+padTrailerLen-hdrLen)
+		n, dummy, err := unpad(decrypted)
+		assert.NoError(t, err)
+		assert.True(t, dummy)
+		assert.Zero(t, n)
+
+		// Requests for dummy frames are ignored while there is data to send.
+		e.Write([]byte{0x40, 0, 0, 20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+		e.ring.WriteCover()
+		e.Write([]byte{0x40, 0, 0, 20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+		e.Close()
+		frame = e.ReadEncryptedSIGFrame(1500)
+		decrypted, err = Decrypt(frame[hdrLen:], testAESKey)
+		assert.NoError(t, err)
+		n, dummy, err = unpad(decrypted)
+		assert.NoError(t, err)
+		assert.False(t, dummy)
+		assert.Equal(t, 40, n)
+		assert.Nil(t, e.ReadEncryptedSIGFrame(1500))
+	})
+
 	// t.Run("simple IPv6 packet", func(t *testing.T) {
 	// 	e := newEncoder(1, 2, 1500)
 	// 	e.Write([]byte{
@@ -6,6 +6,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"io"
+
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 // Encrypt takes a plaintext string and a key to return its encrypted form
@@ -52,7 +54,8 @@ func Decrypt(encrypted []byte, key string) ([]byte, error) {
 
 	nonceSize := aesGCM.NonceSize()
 	if len(encrypted) < nonceSize {
-		return nil, err
+		return nil, serrors.New("ciphertext too short", "length", len(encrypted),
+			"nonce_size", nonceSize)
 	}
 
 	nonce, ciphertext := encrypted[:nonceSize], encrypted[nonceSize:]
@@ -0,0 +1,172 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+)
+
+var (
+	// errNoEPIC indicates that a share that must arrive on an EPIC path did
+	// not.
+	errNoEPIC = serrors.New("share not on EPIC path")
+	// errEPICUnauthenticated indicates that the destination validation field
+	// of a share on an EPIC path could not be verified.
+	errEPICUnauthenticated = serrors.New("EPIC destination validation failed")
+)
+
+// epicPath returns the path with its SCION dataplane path replaced by an EPIC
+// path. The EPIC authenticators are taken from the path metadata. The packet
+// ID of every share carries the destination validation field computed with
+// the key of the remote gateway, see destinationVF.
+func epicPath(path snet.Path, keys Keys) (snet.Path, error) {
+	epicKeys, ok := keys.(EPICKeys)
+	if !ok {
+		return nil, serrors.New("EPIC requires keys derived from DRKey")
+	}
+	scion, ok := path.Dataplane().(snetpath.SCION)
+	if !ok {
+		return nil, serrors.New("EPIC requires a SCION path",
+			"type", common.TypeOf(path.Dataplane()))
+	}
+	meta := path.Metadata()
+	if meta == nil {
+		return nil, serrors.New("EPIC requires path metadata")
+	}
+	dp, err := snetpath.NewEPICDataplanePath(scion, meta.EpicAuths)
+	if err != nil {
+		return nil, err
+	}
+	dp.Counter = func(pktID epic.PktID, s *slayers.SCION, timestamp uint32) (uint32, error) {
+		key, err := epicKeys.EPICKey(time.Now())
+		if err != nil {
+			return 0, err
+		}
+		return destinationVF(key, pktID, s, timestamp)
+	}
+	return snetpath.Path{
+		Dst:           path.Destination(),
+		DataplanePath: dp,
+		NextHop:       path.UnderlayNextHop(),
+		Meta:          *meta,
+	}, nil
+}
+
+// destinationVF returns the destination validation field of a share on an
+// EPIC path. It is the EPIC MAC of the share with a zero packet counter, keyed
+// with the key shared by the two gateways, and it is sent as the packet
+// counter. The hop validation fields can only be verified by the routers of
+// the last two ASes, which derive them from the hop authenticators. The
+// destination validation field lets the remote gateway verify that the share
+// was sent by the local gateway, and as the hop validation fields cover the
+// packet ID, the routers also drop shares whose destination validation field
+// was modified.
+func destinationVF(key []byte, pktID epic.PktID, s *slayers.SCION,
+	timestamp uint32) (uint32, error) {
+
+	pktID.Counter = 0
+	mac, err := libepic.CalcMac(key, pktID, s, timestamp, nil)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(mac), nil
+}
+
+// checkEPIC checks that a share arrived on an EPIC path if required, and that
+// a share on an EPIC path is fresh and was sent by the remote gateway. The
+// destination validation field of the share is recomputed with the keys of
+// the remote gateway from the source address and the payload length of the
+// share, which are covered by it. The reply path of the share is replaced by
+// the reversed SCION path, because the EPIC header of the share cannot be
+// reused for replies.
+func checkEPIC(src *snet.UDPAddr, payloadLen int, required bool, keys KeyFactory,
+	now time.Time) error {
+
+	var p *epic.Path
+	if reply, ok := src.Path.(snet.RawReplyPath); ok {
+		p, _ = reply.Path.(*epic.Path)
+	}
+	if p == nil {
+		if required {
+			return errNoEPIC
+		}
+		return nil
+	}
+	if p.ScionPath == nil {
+		return serrors.New("EPIC path without SCION path")
+	}
+	// The path is reversed, so the first info field is the one the EPIC
+	// timestamp of the remote gateway is relative to.
+	info, err := p.ScionPath.GetInfoField(0)
+	if err != nil {
+		return err
+	}
+	if err := libepic.VerifyTimestamp(time.Unix(int64(info.Timestamp), 0),
+		p.PktID.Timestamp, now); err != nil {
+		return err
+	}
+	if err := verifyDestinationVF(src, payloadLen, p.PktID, info.Timestamp, keys,
+		now); err != nil {
+		return err
+	}
+	src.Path = snet.RawReplyPath{Path: p.ScionPath}
+	return nil
+}
+
+// verifyDestinationVF verifies the destination validation field in the packet
+// ID of a share from src.
+func verifyDestinationVF(src *snet.UDPAddr, payloadLen int, pktID epic.PktID,
+	timestamp uint32, keys KeyFactory, now time.Time) error {
+
+	var epicKeys EPICKeys
+	if keys != nil {
+		epicKeys, _ = keys.Keys(src.IA, src.Host.IP).(EPICKeys)
+	}
+	if epicKeys == nil {
+		return serrors.WithCtx(errEPICUnauthenticated, "cause", "no keys derived from DRKey")
+	}
+	candidates, err := epicKeys.EPICVerificationKeys(now)
+	if err != nil {
+		return serrors.Wrap(errEPICUnauthenticated, err)
+	}
+	// The source address and the payload length are covered by the
+	// validation field. The payload of the SCION packet is the UDP datagram.
+	s := &slayers.SCION{}
+	s.SrcIA, s.PayloadLen = src.IA, uint16(payloadLen+udpHdrLen)
+	if err := s.SetSrcAddr(&net.IPAddr{IP: src.Host.IP}); err != nil {
+		return err
+	}
+	for _, key := range candidates {
+		dvf, err := destinationVF(key, pktID, s, timestamp)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeEq(int32(dvf), int32(pktID.Counter)) == 1 {
+			return nil
+		}
+	}
+	return errEPICUnauthenticated
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestEPICPath(t *testing.T) {
+	auth := make([]byte, 16)
+	scionPath := snetpath.Path{
+		Dst:           xtest.MustParseIA("1-ff00:0:300"),
+		DataplanePath: snetpath.SCION{Raw: []byte{1, 2, 3}},
+		Meta:          snet.PathMetadata{MTU: 1400},
+	}
+	keys := newTestEPICKeys(1)
+
+	_, err := epicPath(scionPath, keys)
+	assert.Error(t, err, "missing authenticators")
+
+	scionPath.Meta.EpicAuths = snet.EpicAuths{AuthPHVF: auth, AuthLHVF: auth}
+	_, err = epicPath(scionPath, StaticKey(testAESKey))
+	assert.Error(t, err, "keys not derived from DRKey")
+
+	p, err := epicPath(scionPath, keys)
+	require.NoError(t, err)
+	require.IsType(t, &snetpath.EPIC{}, p.Dataplane())
+	assert.Equal(t, scionPath.Meta, *p.Metadata())
+	l, ok := dataplanePathLen(p.Dataplane())
+	assert.True(t, ok)
+	assert.Equal(t, 3+epic.MetadataLen, l)
+
+	// The packet counter of a share is its destination validation field.
+	infoTS := uint32(time.Now().Add(-time.Minute).Unix())
+	scionPath.DataplanePath = snetpath.SCION{Raw: testSCIONPath(t, infoTS)}
+	p, err = epicPath(scionPath, keys)
+	require.NoError(t, err)
+	s := testSCIONHeader(t, 100)
+	require.NoError(t, p.Dataplane().SetPath(s))
+	pktID := s.Path.(*epic.Path).PktID
+	dvf, err := destinationVF(keys.keys[0], pktID, s, infoTS)
+	require.NoError(t, err)
+	assert.Equal(t, dvf, pktID.Counter)
+}
+
+func TestCheckEPIC(t *testing.T) {
+	now := time.Now()
+	infoTS := now.Add(-time.Minute)
+	keys := newTestEPICKeys(1, 2)
+	// newSrc returns the source of a share with the payload length sent at
+	// the given time with the key.
+	newSrc := func(sent time.Time, payloadLen int, key []byte) *snet.UDPAddr {
+		ts, err := libepic.CreateTimestamp(infoTS, sent)
+		require.NoError(t, err)
+		s := testSCIONHeader(t, payloadLen)
+		pktID := epic.PktID{Timestamp: ts}
+		pktID.Counter, err = destinationVF(key, pktID, s, uint32(infoTS.Unix()))
+		require.NoError(t, err)
+		raw := &scion.Raw{}
+		require.NoError(t, raw.DecodeFromBytes(testSCIONPath(t, uint32(infoTS.Unix()))))
+		return &snet.UDPAddr{
+			IA:   s.SrcIA,
+			Host: &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 30056},
+			Path: snet.RawReplyPath{Path: &epic.Path{
+				PktID:     pktID,
+				ScionPath: raw,
+			}},
+		}
+	}
+
+	src := newSrc(now, 100, keys.keys[0])
+	require.NoError(t, checkEPIC(src, 100, true, keys, now))
+	assert.IsType(t, &scion.Raw{}, src.Path.(snet.RawReplyPath).Path,
+		"reply path is plain SCION")
+	assert.NoError(t, checkEPIC(newSrc(now, 100, keys.keys[1]), 100, true, keys, now),
+		"previous key")
+
+	assert.Error(t, checkEPIC(newSrc(now.Add(-10*time.Second), 100, keys.keys[0]), 100,
+		false, keys, now), "stale share")
+	assert.ErrorIs(t, checkEPIC(newSrc(now, 100, make([]byte, 16)), 100, true, keys, now),
+		errEPICUnauthenticated, "wrong key")
+	assert.ErrorIs(t, checkEPIC(newSrc(now, 100, keys.keys[0]), 101, true, keys, now),
+		errEPICUnauthenticated, "wrong payload length")
+	assert.ErrorIs(t, checkEPIC(newSrc(now, 100, keys.keys[0]), 100, true,
+		StaticKey(testAESKey), now), errEPICUnauthenticated, "keys not derived from DRKey")
+
+	other := &snet.UDPAddr{Path: snet.RawReplyPath{Path: &scion.Raw{}}}
+	assert.NoError(t, checkEPIC(other, 100, false, keys, now))
+	assert.ErrorIs(t, checkEPIC(other, 100, true, keys, now), errNoEPIC,
+		"share not on EPIC path")
+}
+
+// testSCIONPath returns a raw SCION path with one segment of two hops.
+func testSCIONPath(t *testing.T, infoTS uint32) []byte {
+	d := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []path.InfoField{{Timestamp: infoTS}},
+		HopFields:  []path.HopField{{}, {}},
+	}
+	buf := make([]byte, d.Len())
+	require.NoError(t, d.SerializeTo(buf))
+	return buf
+}
+
+// testSCIONHeader returns the SCION header of a share with the payload length
+// sent by the local gateway.
+func testSCIONHeader(t *testing.T, payloadLen int) *slayers.SCION {
+	s := &slayers.SCION{}
+	s.SrcIA, s.PayloadLen = xtest.MustParseIA("1-ff00:0:110"), uint16(payloadLen+udpHdrLen)
+	require.NoError(t, s.SetSrcAddr(&net.IPAddr{IP: net.IP{192, 0, 2, 1}}))
+	return s
+}
+
+// testEPICKeys are static keys that also provide EPIC keys. The shares are
+// sent with the first key, and verified with all the keys.
+type testEPICKeys struct {
+	StaticKey
+	keys [][]byte
+}
+
+func newTestEPICKeys(seeds ...byte) testEPICKeys {
+	k := testEPICKeys{StaticKey: StaticKey(testAESKey)}
+	for _, seed := range seeds {
+		key := make([]byte, 16)
+		key[0] = seed
+		k.keys = append(k.keys, key)
+	}
+	return k
+}
+
+func (k testEPICKeys) EPICKey(time.Time) ([]byte, error) {
+	return k.keys[0], nil
+}
+
+func (k testEPICKeys) EPICVerificationKeys(time.Time) ([][]byte, error) {
+	return k.keys, nil
+}
+
+func (k testEPICKeys) Keys(addr.IA, net.IP) Keys {
+	return k
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+// EthernetRoutingTableReader is the routing table used by the Ethernet
+// forwarder.
+type EthernetRoutingTableReader interface {
+	control.RoutingTableReader
+	control.EthernetRoutingTableReader
+}
+
+// EthernetForwarder reads Ethernet frames from the reader, routes them according to a routing
+// table and dispatches them to a session.
+//
+// Frames that carry IPv4 or IPv6 packets, possibly behind VLAN tags, are routed
+// based on the IP header, like in the IPForwarder. All other frames, e.g., ARP,
+// are routed to RemoteIA.
+type EthernetForwarder struct {
+	// Reader is the source of Ethernet frames. It must not be nil.
+	//
+	// Each read should yield a whole frame.
+	Reader io.Reader
+	// RoutingTable is used to decide where frames should be sent. It must not be nil.
+	RoutingTable EthernetRoutingTableReader
+	// RemoteIA is the remote ISD-AS frames that do not carry IP packets are
+	// sent to.
+	RemoteIA addr.IA
+	// Metrics is used by the forwarder to report information about internal operation.
+	// If a metric is not initialized, it is not reported.
+	Metrics IPForwarderMetrics
+}
+
+// Run forwards frames from the reader based on the routing table.
+func (f *EthernetForwarder) Run(ctx context.Context) error {
+	logger := log.FromCtx(ctx)
+	if err := f.validate(); err != nil {
+		return err
+	}
+	f.initMetrics()
+	for {
+		buf := make([]byte, common.MaxMTU)
+
+		length, err := f.Reader.Read(buf)
+		if err != nil {
+			metrics.CounterInc(f.Metrics.ReceiveLocalErrors)
+			return serrors.WrapStr("read device error", err)
+		}
+		metrics.CounterInc(f.Metrics.IPPktsLocalRecv)
+		metrics.CounterAdd(f.Metrics.IPPktBytesLocalRecv, float64(length))
+
+		packet := gopacket.NewPacket(buf[:length], layers.LayerTypeEthernet, decodeOptions)
+		eth := packet.Layer(layers.LayerTypeEthernet)
+		if eth == nil {
+			metrics.CounterInc(f.Metrics.IPPktsInvalid)
+			logger.Debug("forwarder: failed to parse Ethernet frame", "length", length)
+			continue
+		}
+
+		var session control.PktWriter
+		switch ip := packet.NetworkLayer().(type) {
+		case *layers.IPv4:
+			session = f.RoutingTable.RouteIPv4(*ip)
+		case *layers.IPv6:
+			session = f.RoutingTable.RouteIPv6(*ip)
+		default:
+			session = f.RoutingTable.RouteEthernet(f.RemoteIA, eth)
+		}
+
+		if session == nil {
+			metrics.CounterInc(f.Metrics.IPPktsNoRoute)
+			continue
+		}
+
+		session.Write(packet)
+	}
+}
+
+func (f *EthernetForwarder) validate() error {
+	if f.Reader == nil {
+		return serrors.New("frame reader must not be nil")
+	}
+	if f.RoutingTable == nil {
+		return serrors.New("routing table must not be nil")
+	}
+	return nil
+}
+
+func (f *EthernetForwarder) initMetrics() {
+	if f.Metrics.IPPktsInvalid != nil {
+		f.Metrics.IPPktsInvalid.Add(0)
+	}
+	if f.Metrics.ReceiveLocalErrors != nil {
+		f.Metrics.ReceiveLocalErrors.Add(0)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/mocks/io/mock_io"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/control/mock_control"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+)
+
+func TestEthernetForwarderRun(t *testing.T) {
+	t.Run("nil routing table", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		forwarder := &dataplane.EthernetForwarder{
+			Reader: mock_io.NewMockReader(ctrl),
+		}
+		err := forwarder.Run(context.Background())
+		require.Error(t, err)
+	})
+
+	t.Run("successful run", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		remoteIA := xtest.MustParseIA("1-ff00:0:110")
+		reader := mock_io.NewMockReader(ctrl)
+		rt := dataplane.NewRoutingTable([]*control.RoutingChain{
+			{
+				RemoteIA:        remoteIA,
+				Prefixes:        []*net.IPNet{xtest.MustParseCIDR(t, "10.0.0.0/8")},
+				TrafficMatchers: []control.TrafficMatcher{{ID: 1, Matcher: pktcls.CondTrue}},
+			},
+			{
+				RemoteIA:        xtest.MustParseIA("1-ff00:0:111"),
+				Prefixes:        []*net.IPNet{xtest.MustParseCIDR(t, "192.168.0.0/16")},
+				TrafficMatchers: []control.TrafficMatcher{{ID: 2, Matcher: pktcls.CondTrue}},
+			},
+		})
+		art := &dataplane.AtomicRoutingTable{}
+		art.SetRoutingTable(rt)
+
+		sessionOne := mock_control.NewMockPktWriter(ctrl)
+		rt.SetSession(1, sessionOne)
+		sessionTwo := mock_control.NewMockPktWriter(ctrl)
+		rt.SetSession(2, sessionTwo)
+
+		// IP packets are routed on the IP header, also behind VLAN tags.
+		ipv4Frame := newEthernetFrame(t, 10, &layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			Length:   20,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IP{10, 0, 0, 2},
+			DstIP:    net.IP{192, 168, 0, 1},
+		})
+		reader.EXPECT().Read(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, error) { return copy(b, ipv4Frame.Data()), nil },
+		)
+		sessionTwo.EXPECT().Write(Packet(ipv4Frame))
+
+		// Other frames are routed to the remote IA of the forwarder.
+		arpFrame := newEthernetFrame(t, 0, &layers.ARP{
+			AddrType:          layers.LinkTypeEthernet,
+			Protocol:          layers.EthernetTypeIPv4,
+			HwAddressSize:     6,
+			ProtAddressSize:   4,
+			Operation:         layers.ARPRequest,
+			SourceHwAddress:   []byte{0, 1, 2, 3, 4, 5},
+			SourceProtAddress: []byte{10, 0, 0, 2},
+			DstHwAddress:      []byte{0, 0, 0, 0, 0, 0},
+			DstProtAddress:    []byte{10, 0, 0, 1},
+		})
+		reader.EXPECT().Read(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, error) { return copy(b, arpFrame.Data()), nil },
+		)
+		sessionOne.EXPECT().Write(Packet(arpFrame))
+
+		brokenFrame := []byte{1, 3, 3, 7}
+		reader.EXPECT().Read(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, error) { return copy(b, brokenFrame), nil },
+		)
+
+		// Force the forwarder to shut down.
+		errDone := serrors.New("done")
+		reader.EXPECT().Read(gomock.Any()).Return(0, errDone)
+
+		forwarder := &dataplane.EthernetForwarder{
+			Reader:       reader,
+			RoutingTable: art,
+			RemoteIA:     remoteIA,
+		}
+
+		done := make(chan struct{})
+		go func() {
+			err := forwarder.Run(context.Background())
+			require.True(t, errors.Is(err, errDone), err)
+			close(done)
+		}()
+
+		xtest.AssertReadReturnsBefore(t, done, time.Second)
+	})
+}
+
+// newEthernetFrame returns an Ethernet frame carrying the payload. If vlan is
+// not 0, the frame is tagged with the VLAN identifier.
+func newEthernetFrame(t *testing.T, vlan uint16,
+	payload gopacket.SerializableLayer) gopacket.Packet {
+
+	eth := &layers.Ethernet{
+		SrcMAC: net.HardwareAddr{0, 1, 2, 3, 4, 5},
+		DstMAC: net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	next := payload.LayerType()
+	ethType := layers.EthernetTypeIPv4
+	if next == layers.LayerTypeARP {
+		ethType = layers.EthernetTypeARP
+	}
+	toSerialize := []gopacket.SerializableLayer{eth}
+	if vlan != 0 {
+		eth.EthernetType = layers.EthernetTypeDot1Q
+		toSerialize = append(toSerialize, &layers.Dot1Q{
+			VLANIdentifier: vlan,
+			Type:           ethType,
+		})
+	} else {
+		eth.EthernetType = ethType
+	}
+	toSerialize = append(toSerialize, payload)
+
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{}, toSerialize...)
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeEthernet, gopacket.DecodeOptions{
+		NoCopy: true,
+		Lazy:   true,
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/snet"
 )
 
 const (
@@ -56,8 +57,8 @@ type frameBuf struct {
 	// Total length of the frame (including 16-byte header).
 	frameLen int
 	// Start of the fragment that starts a new packet. 0 means that there
-	// is no such fragment. This points to the start of the header of the packet,
-	// i.e., the 2-byte packet len preceding the packet header is not included.
+	// is no such fragment. For Ethernet frames, this points to the 2-byte
+	// length preceding the frame.
 	frag0Start int
 	// Whether fragment 0 has been processed already when reassembling.
 	frag0Processed bool
@@ -68,13 +69,23 @@ type frameBuf struct {
 	fragNProcessed bool
 	// Whether all packets completely contained in the frame have been processed.
 	completePktsProcessed bool
-	// The packet len of the packet that starts at fragment0. Has no meaning
-	// if there is no such fragment.
+	// The packet len of the packet that starts at fragment0, including the
+	// length field of Ethernet frames. Has no meaning if there is no such
+	// fragment.
 	pktLen int
 	// The raw bytes buffer for the frame.
 	raw []byte
 	// The sender object for the frame.
 	snd ingressSender
+	// The shares the frame was combined from. It is empty if the frame was
+	// sent in one piece.
+	shares []shareSource
+}
+
+// shareSource is the share index and the source address of a share.
+type shareSource struct {
+	index uint8
+	src   *snet.UDPAddr
 }
 
 func newFrameBuf() *frameBuf {
@@ -95,6 +106,7 @@ func (fb *frameBuf) Reset() {
 	fb.completePktsProcessed = false
 	fb.pktLen = 0
 	fb.snd = nil
+	fb.shares = fb.shares[:0]
 }
 
 // Release reset the FrameBuf and releases it back to the ringbuf (if set).
@@ -117,28 +129,10 @@ func (fb *frameBuf) ProcessCompletePkts(ctx context.Context) {
 	offset := fb.index + sigHdrSize
 	var pktLen int
 	for offset < fb.frameLen {
-		// Make sure that the frame contains the entire IPv4 or IPv6 header.
-		// Get the payload length.
-		ipVersion := fb.raw[offset] >> 4
-		switch ipVersion {
-		case 4:
-			if fb.frameLen-offset < 20 {
-				fb.completePktsProcessed = true
-				return
-			}
-			pktLen = int(binary.BigEndian.Uint16(fb.raw[offset+2 : offset+4]))
-			if pktLen < 20 {
-				fb.completePktsProcessed = true
-				return
-			}
-		case 6:
-			if fb.frameLen-offset < 40 {
-				fb.completePktsProcessed = true
-				return
-			}
-			pktLen = int(binary.BigEndian.Uint16(fb.raw[offset+4 : offset+6]))
-			pktLen += 40
-		default:
+		// Make sure that the frame contains the entire packet header and get the
+		// packet length.
+		var ok bool
+		if pktLen, ok = fb.pktLenAt(offset); !ok {
 			fb.completePktsProcessed = true
 			return
 		}
@@ -147,7 +141,7 @@ func (fb *frameBuf) ProcessCompletePkts(ctx context.Context) {
 			break
 		}
 		// We got everything for the packet. Write it out to the wire.
-		if err := fb.snd.send(rawPkt[:pktLen]); err != nil {
+		if err := fb.snd.send(rawPkt[fb.pktStart():pktLen]); err != nil {
 			logger.Error("Unable to send packet", "err", err)
 		}
 		offset += pktLen
@@ -161,6 +155,51 @@ func (fb *frameBuf) ProcessCompletePkts(ctx context.Context) {
 	fb.frag0Processed = fb.frag0Start == 0
 }
 
+// pktLenAt returns the length of the packet starting at offset, including the
+// length field preceding Ethernet frames. It returns false if the header of the
+// packet is not contained in the frame or is invalid.
+func (fb *frameBuf) pktLenAt(offset int) (int, bool) {
+	if fb.raw[versionPos] == FrameTypeEthernet {
+		if fb.frameLen-offset < ethLenFieldLen {
+			return 0, false
+		}
+		pktLen := int(binary.BigEndian.Uint16(fb.raw[offset : offset+ethLenFieldLen]))
+		if pktLen < ethMinLen {
+			return 0, false
+		}
+		return ethLenFieldLen + pktLen, true
+	}
+	ipVersion := fb.raw[offset] >> 4
+	switch ipVersion {
+	case 4:
+		if fb.frameLen-offset < 20 {
+			return 0, false
+		}
+		pktLen := int(binary.BigEndian.Uint16(fb.raw[offset+2 : offset+4]))
+		if pktLen < 20 {
+			return 0, false
+		}
+		return pktLen, true
+	case 6:
+		if fb.frameLen-offset < 40 {
+			return 0, false
+		}
+		return 40 + int(binary.BigEndian.Uint16(fb.raw[offset+4:offset+6])), true
+	default:
+		return 0, false
+	}
+}
+
+// pktStart returns the offset of the packet data relative to the start of a
+// packet in the frame, i.e., the length of the framing that precedes the
+// packet.
+func (fb *frameBuf) pktStart() int {
+	if fb.raw[versionPos] == FrameTypeEthernet {
+		return ethLenFieldLen
+	}
+	return 0
+}
+
 // Processed returns true if all fragments in the frame have been processed,
 func (fb *frameBuf) Processed() bool {
 	return (fb.completePktsProcessed && fb.fragNProcessed &&
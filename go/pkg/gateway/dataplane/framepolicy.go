@@ -0,0 +1,166 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+// FramePolicies determines which frames are accepted from the remote gateways.
+// The frame header is not authenticated, so the mode and the threshold of a
+// frame are not taken from its header. Instead, a frame is only accepted if
+// the session policies for the ISD-AS of the remote gateway configure the
+// class of its stream with the same mode and threshold. The frames of a
+// remote gateway whose ISD-AS has no session policy are only accepted if they
+// are secret shared with the default threshold, i.e., unencrypted frames are
+// never accepted from it. If all the session policies for the ISD-AS enable
+// EPIC, the frames must also arrive on EPIC paths.
+//
+// FramePolicies is safe for concurrent use.
+type FramePolicies struct {
+	defaultT uint8
+	// policies holds the *frameRules built from the session policies that
+	// were set last.
+	policies atomic.Value
+}
+
+// frameRules are the frame policies and the ISD-ASes that must send on EPIC
+// paths.
+type frameRules struct {
+	formats map[addr.IA]framePolicy
+	epic    map[addr.IA]bool
+}
+
+// NewFramePolicies creates the frame policies for a gateway that secret shares
+// the packets that match no class with threshold t.
+func NewFramePolicies(t int) *FramePolicies {
+	p := &FramePolicies{defaultT: uint8(t)}
+	p.policies.Store(&frameRules{})
+	return p
+}
+
+// Update sets the session policies the frames are checked against.
+func (p *FramePolicies) Update(policies control.SessionPolicies) {
+	m := make(map[addr.IA]framePolicy)
+	epic := make(map[addr.IA]bool)
+	for _, sp := range policies {
+		fp, ok := m[sp.IA]
+		if !ok {
+			fp = make(framePolicy)
+			m[sp.IA] = fp
+			epic[sp.IA] = true
+		}
+		epic[sp.IA] = epic[sp.IA] && sp.EPIC
+		for i, class := range sp.Classes {
+			if i >= control.MaxClasses {
+				break
+			}
+			fp.add(uint8(i), p.format(class.Type, class.T))
+		}
+		fp.add(uint8(min(len(sp.Classes), control.MaxClasses)),
+			frameFormat{mode: frameModeShared, threshold: p.defaultT})
+	}
+	p.policies.Store(&frameRules{formats: m, epic: epic})
+}
+
+// Run updates the frame policies with the session policies received on the
+// channel until the context is canceled.
+func (p *FramePolicies) Run(ctx context.Context, policies <-chan control.SessionPolicies) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sp := <-policies:
+			p.Update(sp)
+		}
+	}
+}
+
+func (p *FramePolicies) format(action control.ClassActionType, t int) frameFormat {
+	switch action {
+	case control.ClassActionEncrypt:
+		return frameFormat{mode: frameModeEncrypted}
+	case control.ClassActionBypass:
+		return frameFormat{mode: frameModePlain}
+	default:
+		if t == 0 {
+			return frameFormat{mode: frameModeShared, threshold: p.defaultT}
+		}
+		return frameFormat{mode: frameModeShared, threshold: uint8(t)}
+	}
+}
+
+// accepts returns whether a frame of the given class, mode and threshold is
+// accepted from a remote gateway in the ISD-AS. The threshold is ignored for
+// frames that are not secret shared.
+func (p *FramePolicies) accepts(ia addr.IA, class, mode, threshold uint8) bool {
+	if mode != frameModeShared {
+		threshold = 0
+	}
+	format := frameFormat{mode: mode, threshold: threshold}
+	fp, ok := p.rules().formats[ia]
+	if !ok {
+		return format == frameFormat{mode: frameModeShared, threshold: p.defaultT}
+	}
+	for _, f := range fp[class] {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresEPIC returns whether the frames of a remote gateway in the ISD-AS
+// must arrive on EPIC paths.
+func (p *FramePolicies) requiresEPIC(ia addr.IA) bool {
+	return p.rules().epic[ia]
+}
+
+func (p *FramePolicies) rules() *frameRules {
+	return p.policies.Load().(*frameRules)
+}
+
+// framePolicy contains the formats of the frames a remote gateway is
+// configured to send, by the class index of the stream. There can be more
+// than one format per class index if there are several session policies for
+// the ISD-AS.
+type framePolicy map[uint8][]frameFormat
+
+func (fp framePolicy) add(class uint8, f frameFormat) {
+	for _, other := range fp[class] {
+		if other == f {
+			return
+		}
+	}
+	fp[class] = append(fp[class], f)
+}
+
+// frameFormat is the mode and the threshold of a frame. The threshold is zero
+// for frames that are not secret shared.
+type frameFormat struct {
+	mode      uint8
+	threshold uint8
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestFramePoliciesAccepts(t *testing.T) {
+	configured := xtest.MustParseIA("1-ff00:0:300")
+	other := xtest.MustParseIA("1-ff00:0:301")
+	bypass := mustClassAction(t, "dscp=0x8", "bypass")
+	shared, err := control.NewClassAction("dscp=0x2", "secret-share", 1, 3)
+	require.NoError(t, err)
+
+	p := NewFramePolicies(2)
+	p.Update(control.SessionPolicies{{IA: configured, Classes: []control.ClassAction{
+		bypass, shared,
+	}}})
+
+	testCases := map[string]struct {
+		IA        addr.IA
+		Class     uint8
+		Mode      uint8
+		Threshold uint8
+		Accepted  bool
+	}{
+		"bypass class": {
+			IA: configured, Class: 0, Mode: frameModePlain, Accepted: true,
+		},
+		"bypass class encrypted": {
+			IA: configured, Class: 0, Mode: frameModeEncrypted,
+		},
+		"shared class": {
+			IA: configured, Class: 1, Mode: frameModeShared, Threshold: 1, Accepted: true,
+		},
+		"shared class with lower threshold": {
+			IA: configured, Class: 2, Mode: frameModeShared, Threshold: 1,
+		},
+		"shared class plain": {
+			IA: configured, Class: 1, Mode: frameModePlain,
+		},
+		"default class": {
+			IA: configured, Class: 2, Mode: frameModeShared, Threshold: 2, Accepted: true,
+		},
+		"unknown class": {
+			IA: configured, Class: 3, Mode: frameModeShared, Threshold: 2,
+		},
+		"unconfigured IA": {
+			IA: other, Class: 5, Mode: frameModeShared, Threshold: 2, Accepted: true,
+		},
+		"unconfigured IA with lower threshold": {
+			IA: other, Class: 0, Mode: frameModeShared, Threshold: 1,
+		},
+		"unconfigured IA plain": {
+			IA: other, Class: 0, Mode: frameModePlain,
+		},
+		"unconfigured IA encrypted": {
+			IA: other, Class: 0, Mode: frameModeEncrypted,
+		},
+		"probe": {
+			IA: configured, Class: 0, Mode: frameModeProbe,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Accepted, p.accepts(tc.IA, tc.Class, tc.Mode, tc.Threshold))
+		})
+	}
+
+	t.Run("EPIC", func(t *testing.T) {
+		p := NewFramePolicies(2)
+		p.Update(control.SessionPolicies{
+			{IA: configured, EPIC: true},
+			{IA: configured, ID: 1, EPIC: true},
+			{IA: other, EPIC: true},
+			{IA: other, ID: 1},
+		})
+		assert.True(t, p.requiresEPIC(configured))
+		assert.False(t, p.requiresEPIC(other), "not all session policies enable EPIC")
+		assert.False(t, p.requiresEPIC(xtest.MustParseIA("1-ff00:0:302")))
+	})
+	t.Run("update removes IA", func(t *testing.T) {
+		p := NewFramePolicies(2)
+		p.Update(control.SessionPolicies{{IA: other, Classes: []control.ClassAction{bypass}}})
+		assert.True(t, p.accepts(other, 0, frameModePlain, 0))
+		p.Update(nil)
+		assert.False(t, p.accepts(other, 0, frameModePlain, 0))
+	})
+}
+
+func TestDecoderRejectsSpoofedHeader(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	simpleIp4Packet := []byte{0x40, 0, 0, 28, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		17, 18, 19, 20, 21, 22, 23, 24}
+
+	t.Run("plain frame", func(t *testing.T) {
+		mt := &MockTun{}
+		discarded := metrics.NewTestCounter()
+		w := newWorker(addr, 1, NewFramePolicies(2), mt,
+			IngressMetrics{FramesDiscarded: discarded}, StaticKey(testAESKey))
+		header := []byte{0, 1, 0, 0, 0, frameModePlain << 4, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0}
+		SendFrame(t, w, append(header, simpleIp4Packet...))
+		assert.Empty(t, mt.packets)
+		assert.Equal(t, float64(1),
+			metrics.CounterValue(discarded.With("reason", "policy")))
+	})
+	// sendShares sends the first two shares of the packet with threshold 2 in
+	// the header.
+	sendShares := func(t *testing.T, w *worker) {
+		encrypted, err := Encrypt(simpleIp4Packet, testAESKey)
+		require.NoError(t, err)
+		shares, err := Split(encrypted, 3, 2)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			header := []byte{0, 1, 0, 0, 2, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, byte(i)}
+			SendFrame(t, w, append(header, shares[i]...))
+		}
+	}
+	t.Run("lowered threshold", func(t *testing.T) {
+		mt := &MockTun{}
+		w := newWorker(addr, 1, NewFramePolicies(3), mt, IngressMetrics{},
+			StaticKey(testAESKey))
+		sendShares(t, w)
+		assert.Empty(t, mt.packets)
+	})
+	t.Run("configured threshold", func(t *testing.T) {
+		mt := &MockTun{}
+		w := newWorker(addr, 1, NewFramePolicies(2), mt, IngressMetrics{},
+			StaticKey(testAESKey))
+		sendShares(t, w)
+		assert.Equal(t, [][]byte{simpleIp4Packet}, mt.packets)
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// minIPv4MTU is the smallest MTU every IPv4 link must support (RFC 791).
+	minIPv4MTU = 68
+	// minIPv6MTU is the smallest MTU every IPv6 link must support (RFC 8200).
+	minIPv6MTU = 1280
+	// tooBigRate is the number of ICMP errors per second a session sends for
+	// packets that exceed the MTU.
+	tooBigRate = 100
+	// tooBigBurst is the number of ICMP errors a session sends in a burst.
+	tooBigBurst = 10
+	// icmpTTL is the TTL, or hop limit, of the ICMP errors.
+	icmpTTL = 64
+)
+
+// packetTooBig returns the ICMP error that tells the sender of the packet to
+// send packets of at most mtu bytes. That is an ICMPv4 Fragmentation Needed
+// error for IPv4 packets with the DF flag set, and an ICMPv6 Packet Too Big
+// error for IPv6 packets. It returns false if the packet must not be answered,
+// because it may be fragmented or because mtu is below the minimum MTU of the
+// IP version.
+//
+// The ICMP error is sent from the destination of the packet, which is routed
+// via the gateway, so that the error passes reverse path filters.
+func packetTooBig(packet gopacket.Packet, mtu int) ([]byte, bool) {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		if ip.Flags&layers.IPv4DontFragment == 0 || mtu < minIPv4MTU {
+			return nil, false
+		}
+		// The error quotes the IP header and the first 8 bytes of the payload.
+		quoted := packet.Data()
+		if l := int(ip.IHL)*4 + 8; l < len(quoted) {
+			quoted = quoted[:l]
+		}
+		reply := &layers.IPv4{
+			Version:  4,
+			TTL:      icmpTTL,
+			Protocol: layers.IPProtocolICMPv4,
+			SrcIP:    ip.DstIP,
+			DstIP:    ip.SrcIP,
+		}
+		icmp := &layers.ICMPv4{
+			TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+				layers.ICMPv4CodeFragmentationNeeded),
+			// The next-hop MTU is carried in the lower half of the otherwise
+			// unused field, which gopacket calls sequence number.
+			Seq: uint16(mtu),
+		}
+		if err := gopacket.SerializeLayers(buf, opts, reply, icmp,
+			gopacket.Payload(quoted)); err != nil {
+			return nil, false
+		}
+	case *layers.IPv6:
+		if mtu < minIPv6MTU {
+			return nil, false
+		}
+		// The error quotes as much of the packet as fits into the minimum MTU.
+		quoted := packet.Data()
+		if l := minIPv6MTU - 40 - 8; l < len(quoted) {
+			quoted = quoted[:l]
+		}
+		reply := &layers.IPv6{
+			Version:    6,
+			HopLimit:   icmpTTL,
+			NextHeader: layers.IPProtocolICMPv6,
+			SrcIP:      ip.DstIP,
+			DstIP:      ip.SrcIP,
+		}
+		icmp := &layers.ICMPv6{
+			TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+		}
+		if err := icmp.SetNetworkLayerForChecksum(reply); err != nil {
+			return nil, false
+		}
+		body := make([]byte, 4+len(quoted))
+		binary.BigEndian.PutUint32(body, uint32(mtu))
+		copy(body[4:], quoted)
+		if err := gopacket.SerializeLayers(buf, opts, reply, icmp,
+			gopacket.Payload(body)); err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketTooBig(t *testing.T) {
+	t.Run("IPv4 with DF", func(t *testing.T) {
+		pkt := newLargeIPv4Packet(t, 1500, layers.IPv4DontFragment)
+		raw, ok := packetTooBig(pkt, 1000)
+		require.True(t, ok)
+		reply := gopacket.NewPacket(raw, layers.LayerTypeIPv4, gopacket.Default)
+		require.Nil(t, reply.ErrorLayer())
+		ip := reply.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		assert.Equal(t, net.IP{10, 1, 0, 1}, ip.SrcIP.To4())
+		assert.Equal(t, net.IP{10, 0, 0, 1}, ip.DstIP.To4())
+		icmp := reply.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4)
+		assert.Equal(t, layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+			layers.ICMPv4CodeFragmentationNeeded), icmp.TypeCode)
+		assert.Equal(t, uint16(1000), icmp.Seq)
+		// The IP header and 8 bytes of the payload are quoted.
+		assert.Equal(t, pkt.Data()[:28], icmp.Payload)
+	})
+
+	t.Run("IPv4 without DF", func(t *testing.T) {
+		_, ok := packetTooBig(newLargeIPv4Packet(t, 1500, 0), 1000)
+		assert.False(t, ok)
+	})
+
+	t.Run("IPv4 below minimum MTU", func(t *testing.T) {
+		_, ok := packetTooBig(newLargeIPv4Packet(t, 1500, layers.IPv4DontFragment), 60)
+		assert.False(t, ok)
+	})
+
+	t.Run("IPv6", func(t *testing.T) {
+		pkt := newLargeIPv6Packet(t, 2000)
+		raw, ok := packetTooBig(pkt, 1400)
+		require.True(t, ok)
+		assert.Len(t, raw, minIPv6MTU)
+		reply := gopacket.NewPacket(raw, layers.LayerTypeIPv6, gopacket.Default)
+		require.Nil(t, reply.ErrorLayer())
+		ip := reply.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+		assert.Equal(t, net.ParseIP("2001:db8::2"), ip.SrcIP)
+		assert.Equal(t, net.ParseIP("2001:db8::1"), ip.DstIP)
+		icmp := reply.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6)
+		assert.Equal(t, layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+			icmp.TypeCode)
+		assert.Equal(t, uint32(1400), binary.BigEndian.Uint32(icmp.Payload[:4]))
+		assert.Equal(t, pkt.Data()[:len(icmp.Payload)-4], icmp.Payload[4:])
+	})
+
+	t.Run("IPv6 below minimum MTU", func(t *testing.T) {
+		_, ok := packetTooBig(newLargeIPv6Packet(t, 2000), 1200)
+		assert.False(t, ok)
+	})
+}
+
+func newLargeIPv4Packet(t *testing.T, size int, flags layers.IPv4Flag) gopacket.Packet {
+	t.Helper()
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Flags:    flags,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{10, 0, 0, 1},
+		DstIP:    net.IP{10, 1, 0, 1},
+	}
+	udp := &layers.UDP{SrcPort: 1000, DstPort: 53}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, udp,
+		gopacket.Payload(make([]byte, size-28))))
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+}
+
+func newLargeIPv6Packet(t *testing.T, size int) gopacket.Packet {
+	t.Helper()
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		NextHeader: layers.IPProtocolUDP,
+		SrcIP:      net.ParseIP("2001:db8::1"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+	}
+	udp := &layers.UDP{SrcPort: 1000, DstPort: 53}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, udp,
+		gopacket.Payload(make([]byte, size-48))))
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv6, gopacket.Default)
+}
@@ -17,12 +17,16 @@ package dataplane
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -39,6 +43,12 @@ type ReadConn interface {
 	ReadFrom(b []byte) (int, net.Addr, error)
 }
 
+// writeConn is implemented by the connections the ingress server can answer
+// path MTU probes on.
+type writeConn interface {
+	WriteTo(b []byte, addr net.Addr) (int, error)
+}
+
 // IngressMetrics are used to report traffic and error statistics for ingress traffic.
 type IngressMetrics struct {
 	// IPPktBytesRecv is the total IP packets bytes received.
@@ -59,23 +69,97 @@ type IngressMetrics struct {
 	SendLocalError metrics.Counter
 	// ReceiveExternalError is the error count when reading frames from the external network.
 	ReceiveExternalError metrics.Counter
+	// SharesRecv is the total shares count received. The worker adds the
+	// "share_index" label.
+	SharesRecv metrics.Counter
+	// SharesLost is the total count of shares that were not received. A share
+	// is considered lost if no share with its index arrived for a share group
+	// before the group was cleaned up. The decoder adds the "share_index" label.
+	SharesLost metrics.Counter
+	// ShareGroupsCombined is the total number of share groups combined into frames.
+	ShareGroupsCombined metrics.Counter
+	// ShareGroupsExpired is the total number of share groups that expired with
+	// fewer than T shares.
+	ShareGroupsExpired metrics.Counter
+	// DecryptErrors is the total number of combined frames that failed to decrypt.
+	DecryptErrors metrics.Counter
+	// CombineDuration is the time between receiving the first share of a group
+	// and combining the group, in seconds.
+	CombineDuration metrics.Histogram
+}
+
+// IngressClass delivers the decoded packets that match a traffic class to a
+// dedicated device.
+type IngressClass struct {
+	// IAs are the remote IAs the class applies to. If empty, the class applies
+	// to all remote IAs.
+	IAs []addr.IA
+	// Matcher is the traffic class packets must match. It must not be nil.
+	Matcher pktcls.Cond
+	// DeviceManager provides the device matching packets are delivered to.
+	DeviceManager control.DeviceManager
+}
+
+func (c IngressClass) appliesTo(ia addr.IA) bool {
+	if len(c.IAs) == 0 {
+		return true
+	}
+	for _, other := range c.IAs {
+		if other.Equal(ia) {
+			return true
+		}
+	}
+	return false
 }
 
 // IngressServer reads new encapsulated packets, classifies the packet by
 // source ISD-AS -> source host Addr -> Sess ID and hands it off to the
 // appropriate Worker, starting a new one if none currently exists.
+//
+// The ingress servers of several gateway instances of an AS do not share any
+// state. A worker can start reassembling a stream at any frame, so an instance
+// can take over the streams of another one. However, the shares of a frame
+// are only combined if they all arrive at the same instance. The sessions
+// guarantee this by sending all the shares of a frame to the same remote
+// gateway.
 type IngressServer struct {
-	Conn          ReadConn
+	Conn ReadConn
+	// DeviceManager provides the device decoded packets are delivered to if
+	// they do not match any of the Classes.
 	DeviceManager control.DeviceManager
-	Metrics       IngressMetrics
+	// Classes are evaluated in order for every decoded packet, and the packet
+	// is delivered to the device of the first matching class.
+	Classes []IngressClass
+	// FrameType is the type of the frames that are accepted. Frames of other
+	// types are discarded. The decoded packets are delivered to the devices
+	// as packets of this type, i.e., IP packets or Ethernet frames.
+	FrameType uint8
+	Metrics   IngressMetrics
+	// ReplyPaths, if set, records the reply paths of the received shares per
+	// share index.
+	ReplyPaths *ReplyPaths
+	// Capturer, if set, captures the received shares, the probe replies and
+	// the decoded packets.
+	Capturer *Capturer
 
-	workers        map[string]*worker
+	workers map[string]*worker
+	// NumberOfPathsT is the number of shares needed to decode the frames of
+	// the packets that match no class. It is only used if FramePolicies is
+	// nil.
 	NumberOfPathsT int
-	AESKey         string
+	// FramePolicies determine the modes and thresholds of the frames that are
+	// accepted from the remote gateways. If nil, only frames that are secret
+	// shared with NumberOfPathsT are accepted.
+	FramePolicies *FramePolicies
+	// Keys provide the keys to decrypt the frames of the remote gateways.
+	Keys KeyFactory
 }
 
 func (d *IngressServer) Run(ctx context.Context) error {
 	d.workers = make(map[string]*worker)
+	if d.FramePolicies == nil {
+		d.FramePolicies = NewFramePolicies(d.NumberOfPathsT)
+	}
 	return d.read(ctx)
 }
 
@@ -105,11 +189,40 @@ func (d *IngressServer) read(ctx context.Context) error {
 						return serrors.New("frame too short",
 							"expected", sigHdrSize, "actual", read)
 					}
-					if frame.raw[0] != 0 {
+					if frame.raw[versionPos] != d.FrameType {
 						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
 							"remote_isd_as", v.IA.String(), "reason", "invalid"))
-						return serrors.New("unsupported SIG protocol version",
-							"supported", 0, "actual", frame.raw[0])
+						logger.Debug("IngressServer: Discarding frame of unsupported type",
+							"remote", v, "supported", d.FrameType, "actual",
+							frame.raw[versionPos])
+						frame.Release()
+						frames[i] = nil
+						continue
+					}
+					err := checkEPIC(v, read, d.FramePolicies.requiresEPIC(v.IA), d.Keys,
+						time.Now())
+					if err != nil {
+						reason := "stale"
+						switch {
+						case errors.Is(err, errNoEPIC):
+							reason = "policy"
+						case errors.Is(err, errEPICUnauthenticated):
+							reason = "unauthenticated"
+						}
+						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
+							"remote_isd_as", v.IA.String(), "reason", reason))
+						logger.Debug("IngressServer: Discarding frame failing the EPIC check",
+							"remote", v, "err", err)
+						frame.Release()
+						frames[i] = nil
+						continue
+					}
+					d.Capturer.share(CaptureIngress, v.IA, capturePeer(v), frame.raw[:read])
+					if frameMode(frame) == frameModeProbe {
+						d.replyProbe(ctx, frame.raw[:read], v)
+						frame.Release()
+						frames[i] = nil
+						continue
 					}
 					frame.frameLen = read
 					frame.sessId = frame.raw[1]
@@ -132,6 +245,21 @@ func (d *IngressServer) read(ctx context.Context) error {
 	}
 }
 
+// replyProbe answers the path MTU probe on the path it arrived on. Probes are
+// not answered if the connection cannot be written to.
+func (d *IngressServer) replyProbe(ctx context.Context, probe []byte, src *snet.UDPAddr) {
+	conn, ok := d.Conn.(writeConn)
+	if !ok {
+		return
+	}
+	reply := newProbeReply(probe)
+	d.Capturer.share(CaptureEgress, src.IA, capturePeer(src), reply)
+	if _, err := conn.WriteTo(reply, src); err != nil {
+		log.FromCtx(ctx).Debug("IngressServer: Unable to reply to probe",
+			"remote", src, "err", err)
+	}
+}
+
 // dispatch dispatches a frame to the corresponding worker, spawning one if none
 // exist yet. Dispatching is done based on source ISD-AS -> source host Addr -> Sess Id.
 func (d *IngressServer) dispatch(ctx context.Context, frame *shareBuf, src *snet.UDPAddr) {
@@ -148,9 +276,24 @@ func (d *IngressServer) dispatch(ctx context.Context, frame *shareBuf, src *snet
 				"err", err, "isd_as", src.IA)
 			return
 		}
-		// Handle will be cleaned up when worker goroutine finishes.
+		classes, err := d.classWriters(ctx, src.IA)
+		if err != nil {
+			logger.Info("Unable to get class device handle for ingress dispatch, "+
+				"dropping packet", "err", err, "isd_as", src.IA)
+			if err := handle.Close(); err != nil {
+				logger.Info("Encountered error when closing device handle in ingress dispatch",
+					"err", err)
+			}
+			return
+		}
+		// Handles will be cleaned up when worker goroutine finishes.
 
-		worker = newWorker(src, frame.sessId, d.NumberOfPathsT, handle, metrics, d.AESKey)
+		worker = newWorker(src, frame.sessId, d.FramePolicies, handle, metrics,
+			d.Keys.Keys(src.IA, src.Host.IP))
+		worker.classes = classes
+		worker.frameType = d.FrameType
+		worker.replyPaths = d.ReplyPaths
+		worker.capturer = d.Capturer
 		d.workers[dispatchStr] = worker
 		go func() {
 			defer log.HandlePanic()
@@ -159,14 +302,41 @@ func (d *IngressServer) dispatch(ctx context.Context, frame *shareBuf, src *snet
 					logger.Info("Encountered error when closing device handle in ingress dispatch",
 						"err", err)
 				}
+				for _, c := range classes {
+					if err := c.tunIO.Close(); err != nil {
+						logger.Info("Encountered error when closing class device handle in "+
+							"ingress dispatch", "err", err)
+					}
+				}
 			}()
 			worker.Run(ctx)
 		}()
 	}
 	worker.markedForCleanup = false
+	frame.src = src
 	worker.Ring.Write(ringbuf.EntryList{frame}, true)
 }
 
+// classWriters returns the class writers for the classes that apply to the
+// remote IA. If an error is returned, no device handles are held.
+func (d *IngressServer) classWriters(ctx context.Context, ia addr.IA) ([]classWriter, error) {
+	var writers []classWriter
+	for i, c := range d.Classes {
+		if !c.appliesTo(ia) {
+			continue
+		}
+		handle, err := c.DeviceManager.Get(ctx, ia)
+		if err != nil {
+			for _, w := range writers {
+				w.tunIO.Close()
+			}
+			return nil, serrors.WrapStr("getting device handle", err, "class", i)
+		}
+		writers = append(writers, classWriter{cond: c.Matcher, tunIO: handle})
+	}
+	return writers, nil
+}
+
 func createWorkerMetrics(in IngressMetrics, remoteIALabel string) IngressMetrics {
 	labels := []string{"remote_isd_as", remoteIALabel}
 	return IngressMetrics{
@@ -178,11 +348,20 @@ func createWorkerMetrics(in IngressMetrics, remoteIALabel string) IngressMetrics
 		FramesRecv:          metrics.CounterWith(in.FramesRecv, labels...),
 		FramesDiscarded:     metrics.CounterWith(in.FramesDiscarded, labels...),
 		SendLocalError:      in.SendLocalError,
+		SharesRecv:          metrics.CounterWith(in.SharesRecv, labels...),
+		SharesLost:          metrics.CounterWith(in.SharesLost, labels...),
+		ShareGroupsCombined: metrics.CounterWith(in.ShareGroupsCombined, labels...),
+		ShareGroupsExpired:  metrics.CounterWith(in.ShareGroupsExpired, labels...),
+		DecryptErrors:       metrics.CounterWith(in.DecryptErrors, labels...),
+		CombineDuration:     metrics.HistogramWith(in.CombineDuration, labels...),
 	}
 }
 
 // cleanup periodically stops and releases idle workers.
 func (d *IngressServer) cleanup() {
+	if d.ReplyPaths != nil {
+		d.ReplyPaths.Cleanup()
+	}
 	for key := range d.workers {
 		worker := d.workers[key]
 		if worker.markedForCleanup {
@@ -197,8 +376,39 @@ func (d *IngressServer) cleanup() {
 	}
 }
 
+// indexCounters caches the counters labeled with a share index. It is not
+// safe for concurrent use.
+type indexCounters struct {
+	base     metrics.Counter
+	counters map[uint8]metrics.Counter
+}
+
+func newIndexCounters(base metrics.Counter) *indexCounters {
+	return &indexCounters{base: base, counters: make(map[uint8]metrics.Counter)}
+}
+
+// get returns the counter for the share index, or nil if the base counter is
+// nil.
+func (c *indexCounters) get(index uint8) metrics.Counter {
+	if c.base == nil {
+		return nil
+	}
+	counter, ok := c.counters[index]
+	if !ok {
+		counter = c.base.With("share_index", strconv.Itoa(int(index)))
+		c.counters[index] = counter
+	}
+	return counter
+}
+
 func increaseCounterMetric(m metrics.Counter, amount float64) {
 	if m != nil {
 		m.Add(amount)
 	}
 }
+
+func setGaugeMetric(m metrics.Gauge, value float64) {
+	if m != nil {
+		m.Set(value)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestIngressServerProbeReply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:110"),
+		Host: &net.UDPAddr{IP: net.IP{192, 168, 1, 1}, Port: 30256},
+	}
+	probe := newProbe(FrameTypeIP, 3, 42, 1000)
+	conn := mock_net.NewMockPacketConn(ctrl)
+	gomock.InOrder(
+		conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, net.Addr, error) {
+				return copy(b, probe), src, nil
+			}),
+		// A frame that is too short makes the server return.
+		conn.EXPECT().ReadFrom(gomock.Any()).Return(1, src, nil),
+	)
+	conn.EXPECT().WriteTo(gomock.Any(), src).DoAndReturn(
+		func(b []byte, _ net.Addr) (int, error) {
+			id, size, ok := parseProbeReply(b)
+			assert.True(t, ok)
+			assert.Equal(t, uint64(42), id)
+			assert.Equal(t, 1000, size)
+			assert.Equal(t, uint8(3), b[sessPos])
+			return len(b), nil
+		})
+
+	server := &IngressServer{Conn: conn}
+	// No worker is started for the probe, so no device manager is needed.
+	assert.Error(t, server.Run(context.Background()))
+}
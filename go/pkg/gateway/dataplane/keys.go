@@ -0,0 +1,71 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// Keys provides the hex encoded AES keys of the frames exchanged with a remote
+// gateway. The keys are looked up for every frame, so implementations must not
+// block. Implementations must be safe for concurrent use.
+type Keys interface {
+	// EncryptionKey returns the key to encrypt the frames sent to the remote
+	// gateway at the given time.
+	EncryptionKey(now time.Time) (string, error)
+	// DecryptionKeys returns the keys to try to decrypt the frames received
+	// from the remote gateway at the given time, the most likely one first.
+	DecryptionKeys(now time.Time) ([]string, error)
+}
+
+// EPICKeys provides the keys of the destination validation fields of the
+// shares exchanged with a remote gateway on EPIC paths. Like Keys, they are
+// looked up for every share. Only keys derived from DRKey provide them.
+type EPICKeys interface {
+	// EPICKey returns the key of the shares sent to the remote gateway at the
+	// given time.
+	EPICKey(now time.Time) ([]byte, error)
+	// EPICVerificationKeys returns the keys to try to verify the shares
+	// received from the remote gateway at the given time, the most likely
+	// one first.
+	EPICVerificationKeys(now time.Time) ([][]byte, error)
+}
+
+// KeyFactory creates the keys of the remote gateways. For the sessions,
+// remoteIP is the address the frames are sent to. For the ingress workers, it
+// is the address the frames are received from.
+type KeyFactory interface {
+	Keys(remoteIA addr.IA, remoteIP net.IP) Keys
+}
+
+// StaticKey is a pre-shared key, which is used for all remote gateways and in
+// both directions.
+type StaticKey string
+
+func (k StaticKey) EncryptionKey(time.Time) (string, error) {
+	return string(k), nil
+}
+
+func (k StaticKey) DecryptionKeys(time.Time) ([]string, error) {
+	return []string{string(k)}, nil
+}
+
+// Keys implements KeyFactory.
+func (k StaticKey) Keys(addr.IA, net.IP) Keys {
+	return k
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// The payload of a frame with the padded flag set ends with a 2-byte trailer,
+// which is encrypted together with the rest of the payload:
+//
+//  0                   1
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |D|        Padding length       |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The padding length is the number of bytes added to the payload, including
+// the trailer itself. The padding bytes are zero and precede the trailer. The
+// D flag marks dummy frames, which carry no data and are discarded by the
+// receiver.
+
+const (
+	// padTrailerLen is the length of the trailer of padded payloads, in bytes.
+	padTrailerLen = 2
+	// padDummy is the flag in the trailer of dummy frames.
+	padDummy = 0x8000
+	// maxPadLen is the largest padding length the trailer can carry.
+	maxPadLen = 0x7fff
+)
+
+// padSize returns the size a payload of payloadLen bytes is padded to. It is
+// the smallest bucket that fits the payload and the trailer, or max if no such
+// bucket exists. Without buckets, the payload is padded to max.
+func padSize(payloadLen int, buckets []int, max int) int {
+	for _, b := range buckets {
+		if b > max {
+			break
+		}
+		if b >= payloadLen+padTrailerLen {
+			return b
+		}
+	}
+	return max
+}
+
+// padFrame pads the payload of the frame to size bytes, including the
+// trailer. If the payload does not leave room for the trailer within size,
+// only the trailer is added.
+func padFrame(frame []byte, size int, dummy bool) []byte {
+	padLen := size - (len(frame) - hdrLen)
+	if padLen < padTrailerLen {
+		padLen = padTrailerLen
+	}
+	if padLen > maxPadLen {
+		padLen = maxPadLen
+	}
+	padded := make([]byte, len(frame)+padLen)
+	copy(padded, frame)
+	trailer := uint16(padLen)
+	if dummy {
+		trailer |= padDummy
+	}
+	binary.BigEndian.PutUint16(padded[len(padded)-padTrailerLen:], trailer)
+	return padded
+}
+
+// unpad returns the length of the payload without the padding, and whether
+// the payload belongs to a dummy frame.
+func unpad(payload []byte) (int, bool, error) {
+	if len(payload) < padTrailerLen {
+		return 0, false, serrors.New("payload too short for padding trailer",
+			"length", len(payload))
+	}
+	trailer := binary.BigEndian.Uint16(payload[len(payload)-padTrailerLen:])
+	padLen := int(trailer &^ padDummy)
+	if padLen < padTrailerLen || padLen > len(payload) {
+		return 0, false, serrors.New("invalid padding length",
+			"padding", padLen, "length", len(payload))
+	}
+	return len(payload) - padLen, trailer&padDummy != 0, nil
+}
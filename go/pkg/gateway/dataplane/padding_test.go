@@ -0,0 +1,83 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPadSize(t *testing.T) {
+	buckets := []int{128, 512, 1024}
+	testCases := map[string]struct {
+		PayloadLen int
+		Buckets    []int
+		Expected   int
+	}{
+		"smallest bucket":         {PayloadLen: 10, Buckets: buckets, Expected: 128},
+		"exact fit":               {PayloadLen: 126, Buckets: buckets, Expected: 128},
+		"no room for the trailer": {PayloadLen: 127, Buckets: buckets, Expected: 512},
+		"bucket above max":        {PayloadLen: 600, Buckets: buckets, Expected: 1000},
+		"larger than all buckets": {PayloadLen: 1100, Buckets: buckets, Expected: 1000},
+		"no buckets":              {PayloadLen: 10, Expected: 1000},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, padSize(tc.PayloadLen, tc.Buckets, 1000))
+		})
+	}
+}
+
+func TestPadding(t *testing.T) {
+	header := make([]byte, hdrLen)
+	payload := []byte{1, 2, 3, 4, 5}
+
+	t.Run("padded to size", func(t *testing.T) {
+		frame := padFrame(append(header, payload...), 64, false)
+		assert.Len(t, frame, hdrLen+64)
+		n, dummy, err := unpad(frame[hdrLen:])
+		assert.NoError(t, err)
+		assert.False(t, dummy)
+		assert.Equal(t, payload, frame[hdrLen:hdrLen+n])
+	})
+
+	t.Run("trailer only", func(t *testing.T) {
+		frame := padFrame(append(header, payload...), 0, false)
+		assert.Len(t, frame, hdrLen+len(payload)+padTrailerLen)
+		n, dummy, err := unpad(frame[hdrLen:])
+		assert.NoError(t, err)
+		assert.False(t, dummy)
+		assert.Equal(t, len(payload), n)
+	})
+
+	t.Run("dummy", func(t *testing.T) {
+		frame := padFrame(header, 64, true)
+		assert.Len(t, frame, hdrLen+64)
+		n, dummy, err := unpad(frame[hdrLen:])
+		assert.NoError(t, err)
+		assert.True(t, dummy)
+		assert.Zero(t, n)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := unpad([]byte{0})
+		assert.Error(t, err)
+		_, _, err = unpad([]byte{1, 2, 0, 5})
+		assert.Error(t, err)
+		_, _, err = unpad([]byte{1, 2, 0, 1})
+		assert.Error(t, err)
+	})
+}
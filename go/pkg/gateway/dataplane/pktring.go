@@ -14,15 +14,30 @@
 
 package dataplane
 
-import "github.com/scionproto/scion/go/lib/ringbuf"
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+)
 
 const (
 	batchSize = 32
 	ringSize  = 64
 )
 
+// queuedPkt is a packet in the packet ring.
+type queuedPkt struct {
+	pkt []byte
+	// enqueued is the time the packet was written to the ring.
+	enqueued time.Time
+	// cover marks a request for a dummy frame. It carries no packet.
+	cover bool
+}
+
 // pktRing reads entries from a ringbuffer in batches but hands them to
-// the client one by one.
+// the client one by one. If the ring has CoDel enabled, packets that spent too
+// long in the ring are dropped.
 type pktRing struct {
 	// ring is the undelying ringbuffer.
 	ring *ringbuf.Ring
@@ -30,42 +45,83 @@ type pktRing struct {
 	storage [batchSize]ringbuf.Entry
 	// entries are the buffered entries. A slice on top of the storage.
 	entries []ringbuf.Entry
+	// codel, if set, decides which packets are dropped when the queue builds
+	// up.
+	codel *codel
+	// dropped counts the packets dropped because the ring was full or by
+	// CoDel.
+	dropped metrics.Counter
 }
 
-// newPktRing creates a new packet ring.
-func newPktRing() *pktRing {
+// newPktRing creates a new packet ring without queue management. Packets are
+// only dropped if the ring is full.
+func newPktRing(dropped metrics.Counter) *pktRing {
 	ring := ringbuf.New(ringSize, nil, "egress")
-	return &pktRing{ring: ring}
+	return &pktRing{ring: ring, dropped: dropped}
+}
+
+// newCodelPktRing creates a new packet ring that drops the packets according
+// to CoDel.
+func newCodelPktRing(dropped metrics.Counter) *pktRing {
+	pr := newPktRing(dropped)
+	pr.codel = newCodel()
+	return pr
 }
 
 // Write writes one packet to the ringbuffer.
 // Returns 1 if successful, 0 if the call would block or -1 if the ringbuf was closed.
+// If the call would block, the packet is dropped.
 func (pr *pktRing) Write(pkt []byte, block bool) int {
-	n, _ := pr.ring.Write(ringbuf.EntryList{pkt}, block)
+	n, _ := pr.ring.Write(ringbuf.EntryList{queuedPkt{pkt: pkt, enqueued: time.Now()}}, block)
+	if n == 0 {
+		increaseCounterMetric(pr.dropped, 1)
+	}
 	return n
 }
 
+// WriteCover requests a dummy frame from the reader of the ring. The request
+// is not queued if the ring is full, since frames are then sent anyway.
+// Returns false if the ringbuf was closed.
+func (pr *pktRing) WriteCover() bool {
+	n, _ := pr.ring.Write(ringbuf.EntryList{queuedPkt{cover: true}}, false)
+	return n != -1
+}
+
 // Read returns next packet from the ringbuffer.
 // Returns 1 if successful, 0 if the call would block or -1 if the ringbuf was closed.
+// A successful read that returns a nil packet is a request for a dummy frame.
 func (pr *pktRing) Read(block bool) ([]byte, int) {
-	if len(pr.entries) == 0 {
-		pr.entries = pr.storage[:]
-		n, _ := pr.ring.Read(pr.entries, block)
-		if n == -1 {
-			// Ringbuffer closed.
-			pr.entries = pr.storage[:0]
-			return nil, -1
+	for {
+		if len(pr.entries) == 0 {
+			pr.entries = pr.storage[:]
+			n, _ := pr.ring.Read(pr.entries, block)
+			if n == -1 {
+				// Ringbuffer closed.
+				pr.entries = pr.storage[:0]
+				return nil, -1
+			}
+			if n == 0 {
+				// There's no data in the ringbuffer.
+				pr.entries = pr.storage[:0]
+				return nil, 0
+			}
+			pr.entries = pr.storage[:n]
+		}
+		qp := pr.entries[0].(queuedPkt)
+		pr.entries = pr.entries[1:]
+		if qp.cover {
+			return nil, 1
+		}
+		if pr.codel == nil {
+			return qp.pkt, 1
 		}
-		if n == 0 {
-			// There's no data in the ringbuffer.
-			pr.entries = pr.storage[:0]
-			return nil, 0
+		now := time.Now()
+		if pr.codel.Drop(now.Sub(qp.enqueued), now) {
+			increaseCounterMetric(pr.dropped, 1)
+			continue
 		}
-		pr.entries = pr.storage[:n]
+		return qp.pkt, 1
 	}
-	pkt := pr.entries[0].([]byte)
-	pr.entries = pr.entries[1:]
-	return pkt, 1
 }
 
 // Close closes the ring. This causes the Read function to return nil.
@@ -16,13 +16,16 @@ package dataplane
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/metrics"
 )
 
 func TestPktReader(t *testing.T) {
 	raw := make([]byte, 10)
-	p := newPktRing()
+	p := newPktRing(nil)
 
 	// If the ring is empty, nil is returned.
 	pkt, n := p.Read(false)
@@ -70,3 +73,45 @@ func TestPktReader(t *testing.T) {
 	assert.Equal(t, -1, n)
 	assert.Nil(t, pkt)
 }
+
+func TestPktRingDrops(t *testing.T) {
+	raw := make([]byte, 10)
+
+	t.Run("full", func(t *testing.T) {
+		dropped := metrics.NewTestCounter()
+		p := newPktRing(dropped)
+		for i := 0; i < ringSize; i++ {
+			assert.Equal(t, 1, p.Write(raw, false))
+		}
+		assert.Equal(t, 0, p.Write(raw, false))
+		assert.Equal(t, float64(1), metrics.CounterValue(dropped))
+	})
+
+	t.Run("without CoDel", func(t *testing.T) {
+		p := newPktRing(nil)
+		assert.Equal(t, 1, p.Write(raw, false))
+		assert.Equal(t, 1, p.Write(raw, false))
+		time.Sleep(2 * codelTarget)
+		// Packets are never dropped for their queueing delay.
+		for i := 0; i < 2; i++ {
+			pkt, n := p.Read(false)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, raw, pkt)
+		}
+	})
+
+	t.Run("CoDel", func(t *testing.T) {
+		dropped := metrics.NewTestCounter()
+		p := newCodelPktRing(dropped)
+		// Simulate a standing queue that persisted for an interval.
+		p.codel.firstAbove = time.Now().Add(-time.Millisecond)
+		assert.Equal(t, 1, p.Write(raw, false))
+		assert.Equal(t, 1, p.Write(raw, false))
+		time.Sleep(2 * codelTarget)
+		// The first packet is dropped when entering the dropping state.
+		pkt, n := p.Read(false)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, raw, pkt)
+		assert.Equal(t, float64(1), metrics.CounterValue(dropped))
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// The MTU of a path is discovered with probe frames, in the spirit of
+// packetization layer path MTU discovery (RFC 8899). A probe frame carries the
+// probe ID in the sequence number and is padded with zeros to the probed size.
+// The probe replies are not authenticated, hence the probe IDs are random, such
+// that an off-path attacker cannot forge the replies to raise the MTU.
+// The remote gateway answers each probe with a probe reply that carries the
+// same probe ID and the size of the received probe as a 2-byte big-endian
+// integer. Probes that are not answered within pmtuProbeTimeout are lost.
+//
+// The prober of a path starts with the MTU from the path metadata. Only if a
+// probe of that size is lost pmtuMaxProbes times in a row, the prober searches
+// for the MTU between the largest confirmed size and the smallest lost size.
+// Every pmtuRaiseInterval, the prober confirms the MTU in use and then
+// repeats the search from the MTU of the path metadata, so that the MTU of a
+// path can shrink and grow again.
+
+const (
+	// pmtuProbeTimeout is the time after which a probe is considered lost.
+	pmtuProbeTimeout = time.Second
+	// pmtuMaxProbes is the number of probes of a size that must be lost before
+	// the size is considered too big.
+	pmtuMaxProbes = 3
+	// pmtuSearchGranularity is the accuracy of the search, in bytes.
+	pmtuSearchGranularity = 8
+	// pmtuRaiseInterval is the time after which the search is repeated.
+	pmtuRaiseInterval = 10 * time.Minute
+	// pmtuTickInterval is the interval at which the session checks the probers
+	// of its paths.
+	pmtuTickInterval = pmtuProbeTimeout / 4
+	// probeReplyLen is the length of a probe reply frame, in bytes.
+	probeReplyLen = hdrLen + 2
+)
+
+// pmtuProber discovers the MTU of one path. The MTU is the size of the frames
+// including the frame header, but excluding the SCION and UDP headers.
+//
+// pmtuProber is safe for concurrent use.
+type pmtuProber struct {
+	mtx sync.Mutex
+	// max is the MTU from the path metadata, which is the upper bound of the
+	// search.
+	max int
+	// mtu is the MTU in use.
+	mtu int
+	// lo is the largest size confirmed by a probe reply, or minMTU.
+	lo int
+	// hi is the smallest size that was found to be too big, or max+1.
+	hi int
+	// verify is set if the next probe confirms lo.
+	verify bool
+	// size is the size of the outstanding probe, or zero if there is none.
+	size int
+	// id is the ID of the outstanding probe.
+	id uint64
+	// sent is the time the outstanding probe was sent.
+	sent time.Time
+	// losses is the number of probes of the current size that were lost.
+	losses int
+	// done is the time the last search completed.
+	done time.Time
+}
+
+func newPMTUProber(max int) *pmtuProber {
+	return &pmtuProber{
+		max: max,
+		mtu: max,
+		lo:  minMTU,
+		hi:  max + 1,
+	}
+}
+
+// MTU returns the MTU of the path.
+func (p *pmtuProber) MTU() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.mtu
+}
+
+// Tick returns the size of the probe to send, or zero if no probe is due. If a
+// probe is due, it is registered as outstanding with the given ID.
+func (p *pmtuProber) Tick(now time.Time, id uint64) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.size != 0 {
+		if now.Sub(p.sent) < pmtuProbeTimeout {
+			return 0
+		}
+		p.losses++
+		if p.losses < pmtuMaxProbes {
+			p.id, p.sent = id, now
+			return p.size
+		}
+		p.lost(now)
+	}
+	if !p.verify && p.hi-p.lo <= pmtuSearchGranularity {
+		if now.Sub(p.done) < pmtuRaiseInterval {
+			return 0
+		}
+		p.hi = p.max + 1
+		p.verify = p.lo < p.max
+	}
+	switch {
+	case p.verify:
+		p.size = p.lo
+	case p.hi > p.max:
+		p.size = p.max
+	default:
+		p.size = (p.lo + p.hi) / 2
+	}
+	p.id, p.sent = id, now
+	return p.size
+}
+
+// lost records that the outstanding probe is too big.
+func (p *pmtuProber) lost(now time.Time) {
+	p.hi = p.size
+	if p.lo >= p.size {
+		p.lo = minMTU
+	}
+	if p.mtu >= p.size {
+		p.mtu = p.lo
+	}
+	p.size, p.losses, p.verify = 0, 0, false
+	if p.hi-p.lo <= pmtuSearchGranularity {
+		p.done = now
+	}
+}
+
+// Reply records the reply to the probe with the given ID. It returns false if
+// the ID does not belong to the outstanding probe.
+func (p *pmtuProber) Reply(id uint64, now time.Time) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.size == 0 || id != p.id {
+		return false
+	}
+	if p.size > p.lo {
+		p.lo = p.size
+	}
+	if p.size > p.mtu {
+		p.mtu = p.size
+	}
+	p.size, p.losses, p.verify = 0, 0, false
+	if p.hi-p.lo <= pmtuSearchGranularity {
+		p.done = now
+	}
+	return true
+}
+
+// newProbeID returns a random probe ID.
+func newProbeID() (uint64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// newProbe returns a probe frame of the given size.
+func newProbe(frameType, sessionID uint8, id uint64, size int) []byte {
+	frame := make([]byte, size)
+	frame[versionPos] = frameType
+	frame[sessPos] = sessionID
+	frame[modePos] = frameModeProbe << 4
+	binary.BigEndian.PutUint64(frame[seqPos:seqPos+8], id)
+	return frame
+}
+
+// newProbeReply returns the reply to the probe frame.
+func newProbeReply(probe []byte) []byte {
+	reply := make([]byte, probeReplyLen)
+	copy(reply, probe[:hdrLen])
+	reply[modePos] = frameModeProbeReply << 4
+	binary.BigEndian.PutUint16(reply[hdrLen:], uint16(len(probe)))
+	return reply
+}
+
+// parseProbeReply returns the probe ID and the probe size of the probe reply
+// frame. It returns false if the frame is not a probe reply.
+func parseProbeReply(frame []byte) (uint64, int, bool) {
+	if len(frame) < probeReplyLen || frame[modePos]>>4 != frameModeProbeReply {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint64(frame[seqPos : seqPos+8]),
+		int(binary.BigEndian.Uint16(frame[hdrLen:])), true
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runProber drives the prober over a path that passes probes of at most mtu
+// bytes, for the given duration.
+func runProber(p *pmtuProber, mtu int, start time.Time, d time.Duration) time.Time {
+	now := start
+	var id uint64
+	for ; now.Sub(start) < d; now = now.Add(pmtuTickInterval) {
+		id++
+		if size := p.Tick(now, id); size != 0 && size <= mtu {
+			p.Reply(id, now)
+		}
+	}
+	return now
+}
+
+func TestPMTUProber(t *testing.T) {
+	start := time.Now()
+
+	t.Run("metadata MTU is confirmed", func(t *testing.T) {
+		p := newPMTUProber(1400)
+		assert.Equal(t, 1400, p.MTU())
+		assert.Equal(t, 1400, p.Tick(start, 1))
+		assert.True(t, p.Reply(1, start))
+		assert.Equal(t, 1400, p.MTU())
+		// No more probes until the search is repeated.
+		assert.Zero(t, p.Tick(start.Add(time.Minute), 2))
+		assert.Equal(t, 1400, p.Tick(start.Add(pmtuRaiseInterval), 3))
+	})
+
+	t.Run("smaller MTU is found", func(t *testing.T) {
+		p := newPMTUProber(1400)
+		// The metadata MTU is used until its probes are lost.
+		now := runProber(p, 1000, start, (pmtuMaxProbes-1)*pmtuProbeTimeout)
+		assert.Equal(t, 1400, p.MTU())
+		now = runProber(p, 1000, now, time.Minute)
+		assert.LessOrEqual(t, p.MTU(), 1000)
+		assert.Greater(t, p.MTU(), 1000-pmtuSearchGranularity)
+
+		// The search is repeated after some time and finds a larger MTU.
+		now = runProber(p, 1200, now, pmtuRaiseInterval+time.Minute)
+		assert.LessOrEqual(t, p.MTU(), 1200)
+		assert.Greater(t, p.MTU(), 1200-pmtuSearchGranularity)
+
+		// A smaller MTU is found as well.
+		now = runProber(p, 900, now, pmtuRaiseInterval+time.Minute)
+		assert.LessOrEqual(t, p.MTU(), 900)
+		assert.Greater(t, p.MTU(), 900-pmtuSearchGranularity)
+
+		// The metadata MTU is used again once it is confirmed.
+		runProber(p, 1400, now, pmtuRaiseInterval+time.Minute)
+		assert.Equal(t, 1400, p.MTU())
+	})
+
+	t.Run("late replies are ignored", func(t *testing.T) {
+		p := newPMTUProber(1400)
+		assert.Equal(t, 1400, p.Tick(start, 1))
+		assert.Equal(t, 1400, p.Tick(start.Add(pmtuProbeTimeout), 2))
+		assert.False(t, p.Reply(1, start.Add(pmtuProbeTimeout)))
+		assert.True(t, p.Reply(2, start.Add(pmtuProbeTimeout)))
+	})
+}
+
+func TestProbeFrames(t *testing.T) {
+	probe := newProbe(FrameTypeIP, 7, 42, 500)
+	assert.Len(t, probe, 500)
+	assert.Equal(t, uint8(7), probe[sessPos])
+	assert.Equal(t, frameModeProbe, probe[modePos]>>4)
+	_, _, ok := parseProbeReply(probe)
+	assert.False(t, ok)
+
+	reply := newProbeReply(probe)
+	assert.Len(t, reply, probeReplyLen)
+	assert.Equal(t, uint8(7), reply[sessPos])
+	id, size, ok := parseProbeReply(reply)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), id)
+	assert.Equal(t, 500, size)
+}
+
+func TestNewProbeID(t *testing.T) {
+	ids := make(map[uint64]bool)
+	for i := 0; i < 100; i++ {
+		id, err := newProbeID()
+		require.NoError(t, err)
+		ids[id] = true
+	}
+	assert.Len(t, ids, 100)
+}
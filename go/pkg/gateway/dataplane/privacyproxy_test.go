@@ -12,10 +12,12 @@ import (
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
 func TestEncryptionAndDecryption(t *testing.T) {
@@ -52,7 +54,7 @@ func TestThreePathsEncryptionWithRandomData(t *testing.T) {
 	}
 
 	mt := &MockTun{}
-	w := newWorker(addr, 1, 2, mt, IngressMetrics{}, testAESKey)
+	w := newWorker(addr, 1, NewFramePolicies(2), mt, IngressMetrics{}, StaticKey(testAESKey))
 
 	// create a list of randomly generated gopackets and send them
 	packets := make([]gopacket.Packet, numPackets)
@@ -93,7 +95,7 @@ func TestChangingPaths(t *testing.T) {
 	}
 
 	mt := &MockTun{}
-	w := newWorker(addr, 1, 2, mt, IngressMetrics{}, testAESKey)
+	w := newWorker(addr, 1, NewFramePolicies(2), mt, IngressMetrics{}, StaticKey(testAESKey))
 
 	// create a list of randomly generated gopackets and send them
 	packets := make([]gopacket.Packet, 2*numPackets)
@@ -178,7 +180,160 @@ func TestChangingPaths(t *testing.T) {
 // 	sess.Close()
 // }
 
+func TestClassActions(t *testing.T) {
+	fmt.Println("[Running Test]: privacyproxy_test.go->TestClassActions")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	random := rand.New(rand.NewSource(42))
+	frameChan := make(chan ([]byte))
+	classes := []control.ClassAction{
+		mustClassAction(t, "dscp=0x8", "bypass"),
+		mustClassAction(t, "dscp=0x2", "encrypt"),
+	}
+	sess := newMockSession(ctrl, frameChan, classes, nil, nil)
+	defer sess.Close()
+
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	// The receiving gateway is configured with the same classes.
+	policies := NewFramePolicies(2)
+	policies.Update(control.SessionPolicies{{IA: addr.IA, Classes: classes}})
+	mt := &MockTun{}
+	w := newWorker(addr, 1, policies, mt, IngressMetrics{}, StaticKey(testAESKey))
+
+	testCases := map[string]struct {
+		DSCP   uint8
+		Mode   uint8
+		Frames int
+	}{
+		"bypass":       {DSCP: 0x8, Mode: frameModePlain, Frames: 1},
+		"encrypt":      {DSCP: 0x2, Mode: frameModeEncrypted, Frames: 1},
+		"secret-share": {DSCP: 0x0, Mode: frameModeShared, Frames: 3},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mt.packets = nil
+			packet := generatePacketWithDSCP(random, 50, tc.DSCP)
+			sess.Write(packet)
+
+			frames := collectFrames(frameChan)
+			assert.Equal(t, tc.Frames, len(frames))
+			for _, frame := range frames {
+				assert.Equal(t, tc.Mode, frame[modePos]>>4)
+				payload := frame[hdrLen:]
+				if tc.Mode == frameModePlain {
+					assert.Contains(t, string(payload), string(packet.Data()))
+				} else {
+					assert.NotContains(t, string(payload), string(packet.Data()))
+				}
+				SendFrame(t, w, frame)
+			}
+			assert.Equal(t, [][]byte{packet.Data()}, mt.packets)
+		})
+	}
+}
+
+func TestPaddingAndCoverTraffic(t *testing.T) {
+	fmt.Println("[Running Test]: privacyproxy_test.go->TestPaddingAndCoverTraffic")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	random := rand.New(rand.NewSource(42))
+	frameChan := make(chan ([]byte))
+	sess := newMockSession(ctrl, frameChan, nil,
+		&control.Padding{Buckets: []int{128}},
+		&control.CoverTraffic{Mode: control.CoverTrafficConstant, Rate: 20})
+	defer sess.Close()
+
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	mt := &MockTun{}
+	w := newWorker(addr, 1, NewFramePolicies(2), mt, IngressMetrics{}, StaticKey(testAESKey))
+
+	packet := generateRandomPayloadPacket(random, 50)
+	sess.Write(packet)
+	frames := collectFramesFor(frameChan, 500*time.Millisecond)
+
+	// The data frame is split into 3 shares. While idle, the session keeps
+	// sending dummy frames.
+	require.Greater(t, len(frames), 3)
+	sizes := map[int]bool{}
+	for _, frame := range frames {
+		assert.Equal(t, paddedFlag, frame[modePos]&paddedFlag)
+		assert.NotContains(t, string(frame[hdrLen:]), string(packet.Data()))
+		sizes[len(frame)] = true
+		SendFrame(t, w, frame)
+	}
+	// The frames are either padded to the bucket or to the largest size.
+	assert.LessOrEqual(t, len(sizes), 2)
+	// Shares carry a tag byte, and the encryption adds a 12-byte nonce and a
+	// 16-byte tag.
+	assert.True(t, sizes[hdrLen+1+12+16+128])
+	// Only the packet is delivered, the dummy frames are discarded.
+	assert.Equal(t, [][]byte{packet.Data()}, mt.packets)
+}
+
+// TestIngressTakeover checks that a gateway instance can take over a stream
+// from another instance without sharing any state with it.
+func TestIngressTakeover(t *testing.T) {
+	fmt.Println("[Running Test]: privacyproxy_test.go->TestIngressTakeover")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	random := rand.New(rand.NewSource(42))
+	frameChan := make(chan ([]byte))
+	sess := createMockSession(ctrl, frameChan)
+	defer sess.Close()
+
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	first, second := &MockTun{}, &MockTun{}
+	instances := []*worker{
+		newWorker(addr, 1, NewFramePolicies(2), first, IngressMetrics{}, StaticKey(testAESKey)),
+		newWorker(addr, 1, NewFramePolicies(2), second, IngressMetrics{}, StaticKey(testAESKey)),
+	}
+
+	packets := make([]gopacket.Packet, 4)
+	for i := range packets {
+		packets[i] = generateRandomPayloadPacket(random, 50)
+		sess.Write(packets[i])
+		frames := collectFrames(frameChan)
+		require.Len(t, frames, 3)
+		// The first half of the stream arrives at the first instance, the
+		// second half at the second instance. One share of every frame is
+		// lost, the remaining shares are enough for the threshold.
+		for _, frame := range frames[:2] {
+			SendFrame(t, instances[i*len(instances)/len(packets)], frame)
+		}
+	}
+	assert.Equal(t, [][]byte{packets[0].Data(), packets[1].Data()}, first.packets)
+	assert.Equal(t, [][]byte{packets[2].Data(), packets[3].Data()}, second.packets)
+}
+
 func createMockSession(ctrl *gomock.Controller, frameChan chan []byte) *Session {
+	return newMockSession(ctrl, frameChan, nil, nil, nil)
+}
+
+func newMockSession(ctrl *gomock.Controller, frameChan chan []byte,
+	classes []control.ClassAction, padding *control.Padding,
+	coverTraffic *control.CoverTraffic) *Session {
+
 	conn := mock_net.NewMockPacketConn(ctrl)
 	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
 	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -187,7 +342,8 @@ func createMockSession(ctrl *gomock.Controller, frameChan chan []byte) *Session
 			return 0, nil
 		}).AnyTimes()
 
-	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 3, testAESKey)
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 3, StaticKey(testAESKey),
+		SessionOptions{Classes: classes, Padding: padding, CoverTraffic: coverTraffic})
 
 	sess.SetPaths([]snet.Path{
 		createMockPath(ctrl, 300),
@@ -221,6 +377,49 @@ func generateRandomPayloadPacket(r *rand.Rand, payloadSize int) gopacket.Packet
 	return pkt
 }
 
+// generatePacketWithDSCP creates a packet like generateRandomPayloadPacket with
+// the DSCP field set.
+func generatePacketWithDSCP(r *rand.Rand, payloadSize int, dscp uint8) gopacket.Packet {
+	bytes := generateRandomPayloadPacket(r, payloadSize).Data()
+	bytes[1] = dscp << 2
+	return gopacket.NewPacket(bytes, layers.LayerTypeIPv4, gopacket.Default)
+}
+
+func mustClassAction(t *testing.T, trafficClass, action string) control.ClassAction {
+	c, err := control.NewClassAction(trafficClass, action, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// collectFrames returns the frames sent until no frame is sent for 500ms.
+func collectFrames(frameChan chan []byte) [][]byte {
+	var frames [][]byte
+	for {
+		select {
+		case frame := <-frameChan:
+			frames = append(frames, frame)
+		case <-time.After(500 * time.Millisecond):
+			return frames
+		}
+	}
+}
+
+// collectFramesFor returns the frames sent within the duration.
+func collectFramesFor(frameChan chan []byte, d time.Duration) [][]byte {
+	var frames [][]byte
+	deadline := time.After(d)
+	for {
+		select {
+		case frame := <-frameChan:
+			frames = append(frames, frame)
+		case <-deadline:
+			return frames
+		}
+	}
+}
+
 func waitFramesProxyTest(t *testing.T, frameChan chan []byte, e *worker) {
 Top:
 	for {
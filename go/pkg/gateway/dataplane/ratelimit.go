@@ -0,0 +1,45 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+const (
+	// defaultBurstDuration is the time worth of traffic at the configured rate
+	// that the token bucket holds if no burst size is configured.
+	defaultBurstDuration = 100 * time.Millisecond
+	// minBurst is the smallest burst size in bytes. It allows for the largest
+	// possible IP packet to pass.
+	minBurst = 65535
+)
+
+// newTokenBucket creates a full token bucket for the rate in bits per second
+// and the burst size in bytes. Tokens are bytes. If burst is zero, a default
+// burst size is used.
+func newTokenBucket(rate, burst uint64, now time.Time) *tokenbucket.Bucket {
+	bytesPerSecond := float64(rate) / 8
+	b := float64(burst)
+	if b == 0 {
+		b = bytesPerSecond * defaultBurstDuration.Seconds()
+		if b < minBurst {
+			b = minBurst
+		}
+	}
+	return tokenbucket.New(bytesPerSecond, b, now)
+}
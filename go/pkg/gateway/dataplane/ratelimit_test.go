@@ -0,0 +1,44 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenBucket(t *testing.T) {
+	now := time.Now()
+
+	t.Run("burst", func(t *testing.T) {
+		// 8000 bit/s is 1000 bytes per second.
+		b := newTokenBucket(8000, 1500, now)
+		assert.True(t, b.Allow(1500, now))
+		assert.False(t, b.Allow(1, now))
+		assert.True(t, b.Allow(1000, now.Add(time.Second)))
+		assert.False(t, b.Allow(1, now.Add(time.Second)))
+	})
+
+	t.Run("default burst", func(t *testing.T) {
+		b := newTokenBucket(8000, 0, now)
+		assert.False(t, b.Allow(minBurst+1, now))
+		assert.True(t, b.Allow(minBurst, now))
+		b = newTokenBucket(8e9, 0, now)
+		assert.False(t, b.Allow(1e8+1, now))
+		assert.True(t, b.Allow(1e8, now))
+	})
+}
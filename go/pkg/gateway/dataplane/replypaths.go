@@ -0,0 +1,103 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// replyPathTTL is the time after which a reply path that was not refreshed
+	// by a received share is no longer used.
+	replyPathTTL = 10 * time.Second
+)
+
+// ReplyPaths keeps track of the paths on which the shares of the remote
+// gateways arrive. Because a remote gateway sends all the shares with the same
+// share index on the same path, the reversed path of the last received share
+// with a given index is the reply path for that index. Only the shares of the
+// frames that were combined and decrypted successfully are considered, such
+// that spoofed shares cannot redirect the replies. Sending the replies on
+// these paths makes them follow the disjoint paths of the remote gateway back.
+//
+// ReplyPaths is safe for concurrent use.
+type ReplyPaths struct {
+	mtx   sync.RWMutex
+	paths map[replyPathKey]replyPath
+}
+
+// replyPathKey identifies the reply path of a share index of a remote gateway.
+// The port is not part of the key, because the remote gateway sends the shares
+// from a different port than it receives them on.
+type replyPathKey struct {
+	ia    addr.IA
+	ip    string
+	index uint8
+}
+
+type replyPath struct {
+	path    snet.DataplanePath
+	nextHop *net.UDPAddr
+	updated time.Time
+}
+
+// NewReplyPaths creates an empty set of reply paths.
+func NewReplyPaths() *ReplyPaths {
+	return &ReplyPaths{paths: make(map[replyPathKey]replyPath)}
+}
+
+// Update records the reply path of the share with the given index received
+// from src.
+func (r *ReplyPaths) Update(src *snet.UDPAddr, index uint8) {
+	if src == nil || src.Host == nil || src.Path == nil {
+		return
+	}
+	key := replyPathKey{ia: src.IA, ip: src.Host.IP.String(), index: index}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.paths[key] = replyPath{path: src.Path, nextHop: src.NextHop, updated: time.Now()}
+}
+
+// Get returns the reply path and the next hop for the share with the given
+// index sent to the remote gateway. It returns false if no share with the
+// index was received from the remote gateway recently.
+func (r *ReplyPaths) Get(ia addr.IA, ip net.IP,
+	index uint8) (snet.DataplanePath, *net.UDPAddr, bool) {
+
+	key := replyPathKey{ia: ia, ip: ip.String(), index: index}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	p, ok := r.paths[key]
+	if !ok || time.Since(p.updated) > replyPathTTL {
+		return nil, nil, false
+	}
+	return p.path, p.nextHop, true
+}
+
+// Cleanup removes the reply paths that expired.
+func (r *ReplyPaths) Cleanup() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for key, p := range r.paths {
+		if time.Since(p.updated) > replyPathTTL {
+			delete(r.paths, key)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestReplyPaths(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:300")
+	src := &snet.UDPAddr{
+		IA:      ia,
+		Host:    &net.UDPAddr{IP: net.IP{192, 168, 1, 1}, Port: 40000},
+		Path:    snetpath.SCION{Raw: []byte{1}},
+		NextHop: &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 30041},
+	}
+
+	// sendShares sends the shares with the given indices from src, with the
+	// next hop 10.0.0.<index+1>.
+	sendShares := func(t *testing.T, w *worker, shares [][]byte, seq byte, indices ...int) {
+		for _, i := range indices {
+			f := newShareBuf()
+			copy(f.raw, []byte{0, 1, 0xff, 0xff, 2, 0, 0, 1, 0, 0, 0, 0, 0, 0, seq, byte(i)})
+			copy(f.raw[hdrLen:], shares[i])
+			f.frameLen = hdrLen + len(shares[i])
+			f.src = src.Copy()
+			f.src.NextHop = &net.UDPAddr{IP: net.IP{10, 0, 0, byte(i + 1)}, Port: 30041}
+			w.processFrame(context.Background(), f)
+		}
+	}
+	pkt := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+
+	t.Run("learned from the combined shares", func(t *testing.T) {
+		rp := NewReplyPaths()
+		w := newWorker(src, 1, NewFramePolicies(2), &MockTun{}, IngressMetrics{},
+			StaticKey(testAESKey))
+		w.replyPaths = rp
+		encrypted, err := Encrypt(pkt, testAESKey)
+		require.NoError(t, err)
+		shares, err := Split(encrypted, 3, 2)
+		require.NoError(t, err)
+		sendShares(t, w, shares, 1, 0, 1)
+		// A share that arrives after the group was combined is not trusted.
+		sendShares(t, w, shares, 1, 2)
+
+		// The gateway receives the shares on a different port.
+		for i := 0; i < 2; i++ {
+			path, nextHop, ok := rp.Get(ia, net.IP{192, 168, 1, 1}, uint8(i))
+			assert.True(t, ok)
+			assert.Equal(t, src.Path, path)
+			assert.Equal(t, net.IP{10, 0, 0, byte(i + 1)}, nextHop.IP)
+		}
+		_, _, ok := rp.Get(ia, net.IP{192, 168, 1, 1}, 2)
+		assert.False(t, ok)
+		_, _, ok = rp.Get(ia, net.IP{192, 168, 1, 2}, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("not learned from unauthenticated shares", func(t *testing.T) {
+		rp := NewReplyPaths()
+		w := newWorker(src, 1, NewFramePolicies(2), &MockTun{}, IngressMetrics{},
+			StaticKey(testAESKey))
+		w.replyPaths = rp
+		// The shares combine, but the frame fails to decrypt.
+		shares, err := Split(pkt, 3, 2)
+		require.NoError(t, err)
+		sendShares(t, w, shares, 1, 0, 1)
+		// A single share does not combine.
+		sendShares(t, w, shares, 2, 2)
+		assert.Empty(t, rp.paths)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		rp := NewReplyPaths()
+		rp.Update(src, 0)
+		for key, p := range rp.paths {
+			p.updated = time.Now().Add(-2 * replyPathTTL)
+			rp.paths[key] = p
+		}
+		_, _, ok := rp.Get(ia, src.Host.IP, 0)
+		assert.False(t, ok)
+		rp.Cleanup()
+		assert.Empty(t, rp.paths)
+	})
+}
@@ -0,0 +1,328 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+)
+
+const (
+	// reserveInterval is the interval in which the session tries to set up
+	// reservations for the share indices that have none.
+	reserveInterval = 30 * time.Second
+	// reserveTimeout is the timeout for setting up and tearing down
+	// reservations.
+	reserveTimeout = 10 * time.Second
+)
+
+// Reservation is a bandwidth reservation the shares of a session are sent
+// over, e.g., a COLIBRI E2E reservation. The path of the reservation changes
+// when the reservation is renewed.
+type Reservation interface {
+	// Path returns the current path of the reservation. If the metadata of
+	// the path has no MTU, the MTU of the best-effort path with the same share
+	// index is assumed.
+	Path() snet.Path
+	// Close tears down the reservation.
+	Close(ctx context.Context) error
+}
+
+// ReservationEvents are called when a reservation is renewed or expires.
+type ReservationEvents struct {
+	// Renewed is called after the reservation was renewed.
+	Renewed func(Reservation)
+	// Failed is called after the renewal of the reservation failed. The
+	// reservation is not renewed anymore and expires.
+	Failed func(Reservation)
+}
+
+// Reserver sets up the reservations of a session to the remote gateway.
+type Reserver interface {
+	// Reserve sets up a reservation whose path shares no interface with the
+	// paths to avoid, i.e., with the paths of the other share indices. The
+	// path of the reservation must satisfy the path policy of the session.
+	Reserve(ctx context.Context, avoid []snet.Path,
+		events ReservationEvents) (Reservation, error)
+}
+
+// reservedSender sends the shares of one share index over a reservation.
+type reservedSender struct {
+	rsv    Reservation
+	sender *sender
+}
+
+// shareSender returns the sender of the share with the index. The reservation
+// of the index is used if there is one. It must be called with the mutex held.
+func (s *Session) shareSender(index int) *sender {
+	if index < len(s.reserved) && s.reserved[index] != nil {
+		return s.reserved[index].sender
+	}
+	return s.senders[index]
+}
+
+// allSenders returns the senders on the best-effort paths followed by the
+// senders on the reservations. It must be called with the mutex held.
+func (s *Session) allSenders() []*sender {
+	senders := append([]*sender(nil), s.senders...)
+	for _, r := range s.reserved {
+		if r != nil {
+			senders = append(senders, r.sender)
+		}
+	}
+	return senders
+}
+
+// runReservations sets up the missing reservations periodically, until the
+// session is closed. The reservations are torn down when the session is
+// closed.
+func (s *Session) runReservations() {
+	ticker := time.NewTicker(reserveInterval)
+	defer ticker.Stop()
+	for {
+		s.reserve()
+		select {
+		case <-s.closed:
+			s.mutex.Lock()
+			reserved := s.reserved
+			s.reserved = nil
+			s.mutex.Unlock()
+			for _, r := range reserved {
+				if r != nil {
+					s.releaseReservation(r.rsv)
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reserve sets up reservations for the share indices that have none, one
+// after the other, so that each reservation avoids the paths of the other
+// share indices.
+func (s *Session) reserve() {
+	s.mutex.Lock()
+	count := len(s.senders)
+	s.mutex.Unlock()
+	for index := 0; index < count; index++ {
+		s.mutex.Lock()
+		if s.hasReservation(index) || index >= len(s.senders) {
+			s.mutex.Unlock()
+			continue
+		}
+		avoid := s.otherPaths(index)
+		s.mutex.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), reserveTimeout)
+		rsv, err := s.Reserver.Reserve(ctx, avoid, ReservationEvents{
+			Renewed: s.reservationRenewed,
+			Failed:  s.reservationFailed,
+		})
+		cancel()
+		if err != nil {
+			log.Debug("Unable to set up reservation, using best-effort path",
+				"share_index", index, "err", err)
+			continue
+		}
+
+		s.mutex.Lock()
+		if !s.addReservation(index, rsv) {
+			go s.releaseReservation(rsv)
+		}
+		s.updateMTU()
+		s.mutex.Unlock()
+	}
+}
+
+// hasReservation returns whether the share index has a reservation. It must
+// be called with the mutex held.
+func (s *Session) hasReservation(index int) bool {
+	return index < len(s.reserved) && s.reserved[index] != nil
+}
+
+// otherPaths returns the paths the shares of the other share indices are sent
+// on. It must be called with the mutex held.
+func (s *Session) otherPaths(index int) []snet.Path {
+	var paths []snet.Path
+	for i := range s.senders {
+		if i != index {
+			paths = append(paths, s.shareSender(i).path)
+		}
+	}
+	return paths
+}
+
+// reservationUsable returns whether the path of the reservation satisfies the
+// path policy of the session and shares no interface with the paths of the
+// other share indices. It must be called with the mutex held.
+func (s *Session) reservationUsable(index int, path snet.Path) bool {
+	if s.PathPolicy != nil && len(s.PathPolicy.Filter([]snet.Path{path})) == 0 {
+		return false
+	}
+	used := make(map[snet.PathInterface]struct{})
+	for _, other := range s.otherPaths(index) {
+		for _, intf := range pathInterfaces(other) {
+			used[intf] = struct{}{}
+		}
+	}
+	for _, intf := range pathInterfaces(path) {
+		if _, ok := used[intf]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func pathInterfaces(path snet.Path) []snet.PathInterface {
+	if meta := path.Metadata(); meta != nil {
+		return meta.Interfaces
+	}
+	return nil
+}
+
+// addReservation assigns the reservation to the share index. It returns false
+// if the reservation cannot be used, e.g., because the paths of the other
+// share indices changed in the meantime. It must be called with the mutex
+// held.
+func (s *Session) addReservation(index int, rsv Reservation) bool {
+	select {
+	case <-s.closed:
+		return false
+	default:
+	}
+	if index >= len(s.senders) || s.hasReservation(index) {
+		return false
+	}
+	path := s.reservedPath(index, rsv)
+	if !s.reservationUsable(index, path) {
+		log.Debug("Reservation not usable", "share_index", index)
+		return false
+	}
+	snd, err := s.newReservedSender(path)
+	if err != nil {
+		log.Debug("Unable to use reservation", "err", err)
+		return false
+	}
+	for len(s.reserved) <= index {
+		s.reserved = append(s.reserved, nil)
+	}
+	s.reserved[index] = &reservedSender{rsv: rsv, sender: snd}
+	return true
+}
+
+// newReservedSender creates the sender for the path of a reservation. It must
+// be called with the mutex held.
+func (s *Session) newReservedSender(path snet.Path) (*sender, error) {
+	snd, err := newSender(
+		s.SessionID,
+		s.DataPlaneConn,
+		path,
+		s.GatewayAddr,
+		s.PathStatsPublisher,
+		s.Metrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if s.PMTUDiscovery {
+		snd.pmtu = newPMTUProber(snd.maxMTU)
+	}
+	snd.capturer = s.Capturer
+	return snd, nil
+}
+
+// reservedPath returns the path of the reservation. If the reservation does
+// not know the MTU of its path, the MTU of the best-effort path of the share
+// index is used. It must be called with the mutex held.
+func (s *Session) reservedPath(index int, rsv Reservation) snet.Path {
+	path := rsv.Path()
+	var meta snet.PathMetadata
+	if m := path.Metadata(); m != nil {
+		meta = *m
+	}
+	if meta.MTU != 0 || index >= len(s.senders) {
+		return path
+	}
+	if m := s.senders[index].path.Metadata(); m != nil {
+		meta.MTU = m.MTU
+	}
+	return snetpath.Path{
+		Dst:           path.Destination(),
+		DataplanePath: path.Dataplane(),
+		NextHop:       path.UnderlayNextHop(),
+		Meta:          meta,
+	}
+}
+
+// reservationRenewed switches the sender of the reservation to the renewed
+// path. If the renewed path no longer satisfies the path policy or shares an
+// interface with the paths of the other share indices, the share index falls
+// back to its best-effort path and the reservation is torn down.
+func (s *Session) reservationRenewed(rsv Reservation) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, r := range s.reserved {
+		if r != nil && r.rsv == rsv {
+			path := s.reservedPath(i, rsv)
+			if !s.reservationUsable(i, path) {
+				log.Debug("Renewed reservation not usable, using best-effort path",
+					"share_index", i)
+				s.dropReservation(i)
+				return
+			}
+			r.sender.SetPath(path)
+			return
+		}
+	}
+}
+
+// reservationFailed falls back to the best-effort path for the share index of
+// the reservation. A new reservation is set up for the index later on.
+func (s *Session) reservationFailed(rsv Reservation) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, r := range s.reserved {
+		if r != nil && r.rsv == rsv {
+			log.Debug("Reservation expires, using best-effort path", "share_index", i)
+			s.dropReservation(i)
+			return
+		}
+	}
+}
+
+// dropReservation falls back to the best-effort path for the share index and
+// tears down its reservation. It must be called with the mutex held.
+func (s *Session) dropReservation(index int) {
+	r := s.reserved[index]
+	r.sender.Close()
+	s.reserved[index] = nil
+	s.updateMTU()
+	go s.releaseReservation(r.rsv)
+}
+
+// releaseReservation tears down the reservation.
+func (s *Session) releaseReservation(rsv Reservation) {
+	defer log.HandlePanic()
+	ctx, cancel := context.WithTimeout(context.Background(), reserveTimeout)
+	defer cancel()
+	if err := rsv.Close(ctx); err != nil {
+		log.Debug("Unable to tear down reservation", "err", err)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSessionReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type sent struct {
+		shareIndex byte
+		nextHop    *net.UDPAddr
+	}
+	sentChan := make(chan sent, 100)
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(f []byte, a net.Addr) (int, error) {
+			sentChan <- sent{shareIndex: f[seqPos+7], nextHop: a.(*snet.UDPAddr).NextHop}
+			return len(f), nil
+		}).AnyTimes()
+	// nextHops sends a packet and returns the next hops of its shares, by
+	// share index.
+	nextHops := func(sess *Session) map[byte]*net.UDPAddr {
+		sendPacketsWithZeroPayload(t, sess, 22, 1)
+		hops := make(map[byte]*net.UDPAddr)
+		for len(hops) < 2 {
+			select {
+			case s := <-sentChan:
+				hops[s.shareIndex] = s.nextHop
+			case <-time.After(time.Second):
+				require.FailNow(t, "shares not sent")
+			}
+		}
+		return hops
+	}
+
+	ia := xtest.MustParseIA("1-ff00:0:300")
+	// bestEffort returns a best-effort path that leaves the local AS on the
+	// interface.
+	bestEffort := func(mtu uint16, intf common.IFIDType) snet.Path {
+		return snetpath.Path{
+			Dst:           ia,
+			DataplanePath: snetpath.SCION{Raw: []byte{}},
+			Meta: snet.PathMetadata{
+				MTU:        mtu,
+				Interfaces: []snet.PathInterface{{IA: ia, ID: intf}},
+			},
+		}
+	}
+
+	rsv := &testReservation{
+		nextHop:    &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 1000},
+		interfaces: []snet.PathInterface{{IA: ia, ID: 1}},
+	}
+	reserver := &testReserver{}
+	reserver.add(rsv)
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 2, StaticKey(testAESKey),
+		SessionOptions{})
+	sess.Reserver = reserver
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{bestEffort(1000, 1), bestEffort(1001, 2)}))
+	require.Eventually(t, func() bool {
+		sess.mutex.Lock()
+		defer sess.mutex.Unlock()
+		return sess.hasReservation(0)
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(reserver.avoided()) == 2 },
+		time.Second, 10*time.Millisecond)
+
+	// Each reservation avoids the paths of the other share index.
+	avoided := reserver.avoided()
+	assert.Equal(t, []snet.PathInterface{{IA: ia, ID: 2}}, pathInterfaces(avoided[0][0]))
+	assert.Equal(t, []snet.PathInterface{{IA: ia, ID: 1}}, pathInterfaces(avoided[1][0]))
+
+	// The reservation knows no MTU, so it takes the MTU of the best-effort
+	// path of its share index.
+	sess.mutex.Lock()
+	assert.Equal(t, sess.senders[0].maxMTU, sess.reserved[0].sender.maxMTU)
+	sess.mutex.Unlock()
+
+	// The first share is sent over the reservation, the second one on the
+	// best-effort path, because no second reservation was admitted.
+	hops := nextHops(sess)
+	assert.Equal(t, rsv.nextHop, hops[0])
+	assert.Nil(t, hops[1])
+
+	// Renewals switch to the renewed path.
+	renewed := &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 2000}
+	rsv.setNextHop(renewed)
+	reserver.events.Renewed(rsv)
+	assert.Equal(t, renewed, nextHops(sess)[0])
+
+	// Renewed paths that share an interface with the path of the other share
+	// index fall back to the best-effort path.
+	rsv.setInterfaces([]snet.PathInterface{{IA: ia, ID: 2}})
+	reserver.events.Renewed(rsv)
+	assert.Nil(t, nextHops(sess)[0])
+	require.Eventually(t, rsv.isClosed, time.Second, 10*time.Millisecond)
+
+	// Failed renewals fall back to the best-effort path.
+	rsv = &testReservation{nextHop: &net.UDPAddr{IP: net.IP{10, 0, 0, 2}, Port: 1000}}
+	reserver.add(rsv)
+	sess.reserve()
+	assert.Equal(t, rsv.nextHop, nextHops(sess)[0])
+	reserver.events.Failed(rsv)
+	assert.Nil(t, nextHops(sess)[0])
+	require.Eventually(t, rsv.isClosed, time.Second, 10*time.Millisecond)
+
+	// Reservations that do not satisfy the path policy are not used.
+	rsv = &testReservation{nextHop: &net.UDPAddr{IP: net.IP{10, 0, 0, 3}, Port: 1000}}
+	reserver.add(rsv)
+	sess.mutex.Lock()
+	sess.PathPolicy = rejectPolicy{}
+	sess.mutex.Unlock()
+	sess.reserve()
+	assert.Nil(t, nextHops(sess)[0])
+	require.Eventually(t, rsv.isClosed, time.Second, 10*time.Millisecond)
+}
+
+// testReserver returns the added reservations, one per call.
+type testReserver struct {
+	mtx    sync.Mutex
+	rsvs   []Reservation
+	avoid  [][]snet.Path
+	events ReservationEvents
+}
+
+func (r *testReserver) Reserve(_ context.Context, avoid []snet.Path,
+	events ReservationEvents) (Reservation, error) {
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.avoid, r.events = append(r.avoid, avoid), events
+	if len(r.rsvs) == 0 {
+		return nil, serrors.New("no reservation")
+	}
+	rsv := r.rsvs[0]
+	r.rsvs = r.rsvs[1:]
+	return rsv, nil
+}
+
+func (r *testReserver) add(rsv Reservation) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.rsvs = append(r.rsvs, rsv)
+}
+
+// avoided returns the paths to avoid, per call.
+func (r *testReserver) avoided() [][]snet.Path {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([][]snet.Path(nil), r.avoid...)
+}
+
+type testReservation struct {
+	mtx        sync.Mutex
+	nextHop    *net.UDPAddr
+	interfaces []snet.PathInterface
+	closed     bool
+}
+
+func (r *testReservation) Path() snet.Path {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return snetpath.Path{
+		Dst:           xtest.MustParseIA("1-ff00:0:300"),
+		DataplanePath: snetpath.SCION{Raw: []byte{}},
+		NextHop:       r.nextHop,
+		Meta:          snet.PathMetadata{Interfaces: r.interfaces},
+	}
+}
+
+func (r *testReservation) Close(context.Context) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.closed = true
+	return nil
+}
+
+func (r *testReservation) setNextHop(nextHop *net.UDPAddr) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.nextHop = nextHop
+}
+
+func (r *testReservation) setInterfaces(interfaces []snet.PathInterface) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.interfaces = interfaces
+}
+
+func (r *testReservation) isClosed() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.closed
+}
+
+// rejectPolicy is a path policy that rejects all paths.
+type rejectPolicy struct{}
+
+func (rejectPolicy) Filter([]snet.Path) []snet.Path {
+	return nil
+}
@@ -203,7 +203,7 @@ func (l *reassemblyList) collectAndWrite(ctx context.Context) {
 			"expected", pktLen, "have", l.buf.Len())
 	} else {
 		// Write the packet to the wire.
-		if err := l.snd.send(l.buf.Bytes()); err != nil {
+		if err := l.snd.send(l.buf.Bytes()[startFrame.pktStart():]); err != nil {
 			logger.Error("Unable to send reassembled packet", "err", err)
 		}
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
@@ -68,6 +69,9 @@ type RoutingTable struct {
 	indexToSubEntry map[int]*subEntry
 	indexToEntries  map[int][]*entry
 	table           []*entry
+	// iaToEntry contains the traffic matchers of the routing chains per remote
+	// ISD-AS. It is used to route frames that do not carry IP packets.
+	iaToEntry map[addr.IA]*entry
 }
 
 // NewRoutingTable creates a new routing table and initializes it with the given
@@ -75,8 +79,22 @@ type RoutingTable struct {
 func NewRoutingTable(chains []*control.RoutingChain) *RoutingTable {
 	indexToSubEntry := make(map[int]*subEntry)
 	indexToEntries := make(map[int][]*entry)
+	iaToEntry := make(map[addr.IA]*entry)
 	var table []*entry
 	for _, chain := range chains {
+		iaEntry, ok := iaToEntry[chain.RemoteIA]
+		if !ok {
+			iaEntry = &entry{}
+			iaToEntry[chain.RemoteIA] = iaEntry
+		}
+		for _, tm := range chain.TrafficMatchers {
+			se, ok := indexToSubEntry[tm.ID]
+			if !ok {
+				se = &subEntry{Class: tm.Matcher, Session: nil}
+				indexToSubEntry[tm.ID] = se
+			}
+			iaEntry.Table = append(iaEntry.Table, se)
+		}
 		for _, prefix := range chain.Prefixes {
 			e := &entry{
 				Prefix: prefix,
@@ -98,6 +116,7 @@ func NewRoutingTable(chains []*control.RoutingChain) *RoutingTable {
 		indexToSubEntry: indexToSubEntry,
 		indexToEntries:  indexToEntries,
 		table:           table,
+		iaToEntry:       iaToEntry,
 	}
 }
 
@@ -128,6 +147,19 @@ func (rt *RoutingTable) RouteIPv6(pkt layers.IPv6) control.PktWriter {
 	return rt.route(pkt.DstIP, &pkt)
 }
 
+// RouteEthernet returns the session an Ethernet frame to the remote ISD-AS
+// should be routed on. The traffic classes of the routing chains for the remote
+// ISD-AS are evaluated in order on pkt, and the session for the first match is
+// returned. If the remote ISD-AS is not known or no traffic class is matched,
+// routing will return `nil`.
+func (rt *RoutingTable) RouteEthernet(ia addr.IA, pkt gopacket.Layer) control.PktWriter {
+	e, ok := rt.iaToEntry[ia]
+	if !ok {
+		return nil
+	}
+	return e.route(pkt)
+}
+
 func (rt *RoutingTable) route(dst net.IP, pkt gopacket.Layer) control.PktWriter {
 	var ret control.PktWriter
 	highestMask := 0
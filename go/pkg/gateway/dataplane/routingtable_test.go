@@ -300,6 +300,38 @@ func TestRoutingTableAddClearSession(t *testing.T) {
 	}
 }
 
+func TestRoutingTableRouteEthernet(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	rt := dataplane.NewRoutingTable([]*control.RoutingChain{
+		{
+			RemoteIA: ia110,
+			Prefixes: []*net.IPNet{xtest.MustParseCIDR(t, "192.168.100.0/24")},
+			TrafficMatchers: []control.TrafficMatcher{
+				{ID: 1, Matcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e})},
+				{ID: 2, Matcher: pktcls.CondTrue},
+			},
+		},
+		{
+			RemoteIA: ia111,
+			Prefixes: []*net.IPNet{xtest.MustParseCIDR(t, "192.168.101.0/24")},
+			TrafficMatchers: []control.TrafficMatcher{
+				{ID: 3, Matcher: pktcls.CondTrue},
+			},
+		},
+	})
+	require.NoError(t, rt.SetSession(1, testPktWriter{ID: 1}))
+	require.NoError(t, rt.SetSession(2, testPktWriter{ID: 2}))
+
+	arp := &layers.Ethernet{EthernetType: layers.EthernetTypeARP}
+	assert.Equal(t, testPktWriter{ID: 2}, rt.RouteEthernet(ia110, arp))
+	assert.Nil(t, rt.RouteEthernet(ia111, arp), "session not set")
+	assert.Nil(t, rt.RouteEthernet(xtest.MustParseIA("1-ff00:0:112"), arp), "unknown IA")
+
+	require.NoError(t, rt.SetSession(3, testPktWriter{ID: 3}))
+	assert.Equal(t, testPktWriter{ID: 3}, rt.RouteEthernet(ia111, arp))
+}
+
 type testPktWriter struct {
 	ID int
 }
@@ -16,12 +16,14 @@ package dataplane
 
 import (
 	"net"
+	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/snet"
 	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 )
@@ -38,43 +40,61 @@ type sender struct {
 	// ring is the ring buffer containing encrypted shares to be sent
 	ring               *pktRing
 	conn               net.PacketConn
-	address            net.Addr
 	pathStatsPublisher PathStatsPublisher
 	path               snet.Path
 	pathFingerprint    snet.PathFingerprint
 	metrics            SessionMetrics
+	// sharesSent counts the shares sent via the path of the sender, per share
+	// index.
+	sharesSent *indexCounters
+	// pathLen is the length of the dataplane path, in bytes.
+	pathLen int
+	// maxMTU is the MTU of the path according to the path metadata, excluding
+	// the SCION and UDP headers.
+	maxMTU int
+	// pmtu, if set, discovers the MTU of the path.
+	pmtu *pmtuProber
+	// capturer, if set, captures the shares sent by the sender.
+	capturer *Capturer
+
+	// addrMtx protects address and replyAddr.
+	addrMtx sync.Mutex
+	// address is the address of the remote gateway via the path of the
+	// sender.
+	address *snet.UDPAddr
+	// replyAddr, if set, is used instead of address. It carries the reply path
+	// of the shares the remote gateway sends with the same share index.
+	replyAddr *snet.UDPAddr
 }
 
 func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	gatewayAddr net.UDPAddr, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics) (*sender, error) {
+	sessMetrics SessionMetrics) (*sender, error) {
 
 	// MTU must account for the size of the SCION header.
 	localAddr := conn.LocalAddr().(*net.UDPAddr)
 	addrLen := addr.IABytes*2 + len(localAddr.IP) + len(gatewayAddr.IP)
-	scionPath, ok := path.Dataplane().(snetpath.SCION)
+	pathLen, ok := dataplanePathLen(path.Dataplane())
 	if !ok {
-		return nil, serrors.New("not a scion path", "type", common.TypeOf(path.Dataplane()))
+		return nil, serrors.New("unsupported path type", "type", common.TypeOf(path.Dataplane()))
 	}
-	pathLen := len(scionPath.Raw)
 	mtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
 	if mtu < minMTU {
 		return nil, serrors.New("insufficient MTU", "mtu", mtu, "minMTU", minMTU)
 	}
 
+	fingerprint := snet.Fingerprint(path)
 	c := &sender{
-		ring: newPktRing(),
-		conn: conn,
-		address: &snet.UDPAddr{
-			IA:      path.Destination(),
-			Path:    path.Dataplane(),
-			NextHop: path.UnderlayNextHop(),
-			Host:    &gatewayAddr,
-		},
+		ring:               newPktRing(sessMetrics.FramesQueueDropped),
+		conn:               conn,
+		address:            pathAddr(path, &gatewayAddr),
 		pathStatsPublisher: pathStatsPublisher,
 		path:               path,
-		pathFingerprint:    snet.Fingerprint(path),
-		metrics:            metrics,
+		pathFingerprint:    fingerprint,
+		metrics:            sessMetrics,
+		sharesSent:         newIndexCounters(sessMetrics.SharesSent),
+		pathLen:            pathLen,
+		maxMTU:             mtu,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -89,12 +109,96 @@ func (c *sender) Close() {
 	c.ring.Close()
 }
 
-// Write sends the packet to the remote gateway in asynchronous manner.
-func (c *sender) Write(pkt []byte) {
-	increaseCounterMetric(c.metrics.IPPktsSent, 1)
-	increaseCounterMetric(c.metrics.IPPktBytesSent, float64(len(pkt)))
+// Write sends the share to the remote gateway in asynchronous manner.
+func (c *sender) Write(share []byte) {
+	c.ring.Write(share, false)
+}
+
+// MTU returns the MTU of the path of the sender, excluding the SCION and UDP
+// headers. It is the discovered MTU if path MTU discovery is enabled.
+func (c *sender) MTU() int {
+	if c.pmtu != nil {
+		return c.pmtu.MTU()
+	}
+	return c.maxMTU
+}
+
+// WriteProbe sends the path MTU probe immediately. Probes are always sent on
+// the path of the sender, because that is the path they probe.
+func (c *sender) WriteProbe(probe []byte) error {
+	c.addrMtx.Lock()
+	address := c.address
+	c.addrMtx.Unlock()
+	_, err := c.conn.WriteTo(probe, address)
+	return err
+}
+
+// SetPath replaces the path of the sender with a path that traverses the same
+// links, e.g., the renewed path of a reservation. The shares that are already
+// queued are sent on the new path.
+func (c *sender) SetPath(path snet.Path) {
+	c.addrMtx.Lock()
+	defer c.addrMtx.Unlock()
+	c.address = pathAddr(path, c.address.Host)
+}
+
+// pathAddr returns the address of the gateway via the path.
+func pathAddr(path snet.Path, gatewayAddr *net.UDPAddr) *snet.UDPAddr {
+	return &snet.UDPAddr{
+		IA:      path.Destination(),
+		Path:    path.Dataplane(),
+		NextHop: path.UnderlayNextHop(),
+		Host:    gatewayAddr,
+	}
+}
+
+// SetReplyPath sets the reply path the shares are sent on instead of the path
+// of the sender. If ok is false, or the reply path is longer than the path of
+// the sender and thus might not fit the MTU, the path of the sender is used.
+// Senders on EPIC paths always use their path, as reply paths are plain SCION
+// paths.
+func (c *sender) SetReplyPath(path snet.DataplanePath, nextHop *net.UDPAddr, ok bool) {
+	c.addrMtx.Lock()
+	defer c.addrMtx.Unlock()
+	c.replyAddr = nil
+	if _, isEPIC := c.address.Path.(*snetpath.EPIC); isEPIC {
+		return
+	}
+	if l, known := dataplanePathLen(path); ok && known && l <= c.pathLen {
+		c.replyAddr = &snet.UDPAddr{
+			IA:      c.address.IA,
+			Path:    path,
+			NextHop: nextHop,
+			Host:    c.address.Host,
+		}
+	}
+}
 
-	c.ring.Write(pkt, false)
+// destination returns the address the shares are sent to.
+func (c *sender) destination() net.Addr {
+	c.addrMtx.Lock()
+	defer c.addrMtx.Unlock()
+	if c.replyAddr != nil {
+		return c.replyAddr
+	}
+	return c.address
+}
+
+// dataplanePathLen returns the length of the path, in bytes. It returns false
+// if the length of the path type is not known.
+func dataplanePathLen(path snet.DataplanePath) (int, bool) {
+	switch p := path.(type) {
+	case snet.RawReplyPath:
+		return p.Path.Len(), true
+	case snetpath.SCION:
+		return len(p.Raw), true
+	case snetpath.Colibri:
+		return p.Len(), true
+	case *snetpath.EPIC:
+		return len(p.SCION) + epic.MetadataLen, true
+	default:
+		return 0, false
+	}
 }
 
 func (c *sender) run() {
@@ -106,12 +210,18 @@ func (c *sender) run() {
 			// Sender was closed and all the buffered frames were sent.
 			break
 		}
-		_, err := c.conn.WriteTo(frame, c.address)
+		_, err := c.conn.WriteTo(frame, c.destination())
 		if err != nil {
 			increaseCounterMetric(c.metrics.SendExternalErrors, 1)
 			continue
 		}
+		c.capturer.share(CaptureEgress, c.path.Destination(), c.pathFingerprint, frame)
 		increaseCounterMetric(c.metrics.FramesSent, 1)
+		// Frames sent in one piece do not carry a share index.
+		if (frame[modePos]&^paddedFlag)>>4 == frameModeShared {
+			// The share index is the last byte of the sequence number.
+			increaseCounterMetric(c.sharesSent.get(frame[hdrLen-1]), 1)
+		}
 		increaseCounterMetric(c.metrics.FrameBytesSent, float64(len(frame)))
 
 		if c.pathStatsPublisher != nil {
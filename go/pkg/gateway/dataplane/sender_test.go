@@ -16,13 +16,20 @@ package dataplane
 
 import (
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 
 	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func expectFrames(conn *mock_net.MockPacketConn) *gomock.Call {
@@ -123,3 +130,36 @@ func TestSender(t *testing.T) {
 	// 	})
 	// }
 }
+
+func TestSenderReplyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+	gatewayAddr := net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 30041}
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(xtest.MustParseIA("1-ff00:0:300")).AnyTimes()
+	path.EXPECT().Metadata().Return(&snet.PathMetadata{MTU: 1400}).AnyTimes()
+	path.EXPECT().Dataplane().Return(snetpath.SCION{Raw: make([]byte, 24)}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().Return(nil).AnyTimes()
+	c, err := newSender(1, conn, path, gatewayAddr, nil, SessionMetrics{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, c.address, c.destination())
+
+	nextHop := &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 30041}
+	c.SetReplyPath(snetpath.SCION{Raw: make([]byte, 24)}, nextHop, true)
+	dst := c.destination().(*snet.UDPAddr)
+	assert.Equal(t, nextHop, dst.NextHop)
+	assert.Equal(t, gatewayAddr.String(), dst.Host.String())
+
+	// Longer reply paths might not fit the MTU.
+	c.SetReplyPath(snetpath.SCION{Raw: make([]byte, 48)}, nextHop, true)
+	assert.Equal(t, c.address, c.destination())
+
+	c.SetReplyPath(snetpath.SCION{Raw: make([]byte, 24)}, nextHop, true)
+	c.SetReplyPath(nil, nil, false)
+	assert.Equal(t, c.address, c.destination())
+}
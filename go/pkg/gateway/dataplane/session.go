@@ -15,22 +15,26 @@
 package dataplane
 
 import (
+	"errors"
 	"fmt"
 	"hash/crc64"
+	"io"
 	"net"
-	"sort"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/gopacket"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
-	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
-	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
 var (
@@ -54,6 +58,35 @@ type SessionMetrics struct {
 	FrameBytesSent metrics.Counter
 	// SendExternalError is the error count when sending frames to the external network.
 	SendExternalErrors metrics.Counter
+	// SharesSent is the shares count sent. Each sender adds the "share_index"
+	// label with the share index of the shares it sends.
+	SharesSent metrics.Counter
+	// SharesThreshold is the number of shares needed to reconstruct a frame (T).
+	SharesThreshold metrics.Gauge
+	// Shares is the number of shares each frame is split into (N).
+	Shares metrics.Gauge
+	// IPPktsRateLimited is the IP packets count dropped because the rate limit
+	// of the session or the traffic class was exceeded. The session adds the
+	// "class" label with the class action the packet matched.
+	IPPktsRateLimited metrics.Counter
+	// IPPktsQueueDropped is the IP packets count dropped by CoDel because they
+	// were queued too long, or because the queue was full. The session adds
+	// the "class" label with the class action the packet matched.
+	IPPktsQueueDropped metrics.Counter
+	// FramesQueueDropped is the frames count dropped because the queue of
+	// their path was full, or because no path was available. It includes the
+	// shares of secret-shared frames.
+	FramesQueueDropped metrics.Counter
+	// CoverFramesSent is the dummy frames count sent as cover traffic.
+	CoverFramesSent metrics.Counter
+	// IPPktsTooBig is the IP packets count dropped because they do not fit
+	// into a single frame. The senders of the packets are notified with ICMP
+	// errors.
+	IPPktsTooBig metrics.Counter
+	// FramesNoKey is the frames count dropped because no encryption key was
+	// available, e.g., because the DRKey of the current epoch was not fetched
+	// yet.
+	FramesNoKey metrics.Counter
 }
 
 type Session struct {
@@ -62,34 +95,217 @@ type Session struct {
 	DataPlaneConn      net.PacketConn
 	PathStatsPublisher PathStatsPublisher
 	Metrics            SessionMetrics
-	mutex              sync.Mutex
-	// senders is a list of currently used senders.
+	// ReplyPaths, if set, provides the paths on which the shares of the remote
+	// gateway arrive. The share with index i is then sent on the reply path of
+	// the shares with index i, instead of the path of the i-th sender.
+	ReplyPaths *ReplyPaths
+	// PMTUDiscovery enables the discovery of the MTU of the paths with probes.
+	// The replies to the probes are read from DataPlaneConn. It must be set
+	// before the paths are set.
+	PMTUDiscovery bool
+	// TooBigWriter, if set, receives the ICMP errors for the IP packets that
+	// do not fit into a single frame. Such packets are then dropped instead of
+	// being split across frames, unless the IP version does not allow for
+	// the error. The writer is closed when the session is closed.
+	TooBigWriter io.WriteCloser
+	// Capturer, if set, captures the packets written to the session as well
+	// as the shares and probes the session exchanges. They are captured as
+	// traffic of RemoteIA. The capturer must be set before the paths are set.
+	Capturer *Capturer
+	RemoteIA addr.IA
+	// Reserver, if set, sets up a reservation for each share index. The
+	// shares are sent over the reservation of their index, and on the
+	// best-effort path of the index while it has none, e.g., because the
+	// admission failed or the renewal of the reservation failed. It must be
+	// set before the paths are set.
+	Reserver Reserver
+	// PathPolicy, if set, is the path policy of the session. The paths of the
+	// reservations must satisfy it, also after a renewal. It must be set
+	// before the paths are set.
+	PathPolicy policies.PathPolicy
+	// EPIC sends the shares on EPIC paths instead of SCION paths, so that the
+	// routers on the last hops and the remote gateway authenticate the source
+	// of every share. Paths without EPIC authenticators in their metadata are
+	// rejected, and the keys of the session must provide EPICKeys.
+	// Reservations are not affected. It must be set before the paths are set.
+	EPIC bool
+
+	mutex sync.Mutex
+	// senders is a list of currently used senders. The share with index i is
+	// sent by the i-th sender, unless it is sent over a reservation.
 	senders []*sender
-	// encoder is the encoder that transforms IP packets into SIG frames
-	encoder *encoder
+	// reserved holds the senders on the reservations, by share index. Indices
+	// without reservation are nil.
+	reserved []*reservedSender
+	// reserving is set once the reservations are set up.
+	reserving bool
+	// pipelines are checked in order for each written packet. The packet is
+	// handled by the first pipeline whose condition matches. The last pipeline
+	// is the default pipeline that matches all packets.
+	pipelines []*pipeline
+	// limiter, if set, limits the rate of all the packets of the session.
+	limiter *tokenbucket.Bucket
+	// cover, if set, sends dummy frames on the default pipeline.
+	cover *coverTraffic
+	// tooBigLimiter limits the rate of the ICMP errors written to TooBigWriter.
+	tooBigLimiter *tokenbucket.Bucket
+	// frameType is the type of the frames sent by the session.
+	frameType uint8
+	// keys are the keys of the frames and of the EPIC shares sent to the
+	// remote gateway.
+	keys Keys
+	// pmtuRunning is set once the path MTU discovery runs.
+	pmtuRunning bool
+	// closed is closed when the session is closed.
+	closed chan struct{}
+	// pathsChanged is closed and replaced whenever the paths or the MTU of
+	// the session change, such that the pipelines waiting for paths wake up.
+	pathsChanged chan struct{}
 	// mtu is the minimal MTU of all paths
 	mtu            int
 	numberOfPathsT int
 	numberOfPathsN int
 }
 
+// pipeline handles the packets of one traffic class of a session.
+type pipeline struct {
+	// cond selects the packets handled by the pipeline. It is nil for the
+	// default pipeline.
+	cond pktcls.Cond
+	// action determines how the frames of the pipeline are sent.
+	action control.ClassActionType
+	// t is the number of shares needed to reconstruct a secret-shared frame.
+	t int
+	// n is the number of shares a secret-shared frame is split into.
+	n int
+	// encoder is the encoder that transforms IP packets or Ethernet frames into SIG frames
+	encoder *encoder
+	// limiter, if set, limits the rate of the packets of the pipeline.
+	limiter *tokenbucket.Bucket
+	// rateLimited counts the packets of the pipeline dropped by a rate limiter.
+	rateLimited metrics.Counter
+}
+
+// paths returns the number of paths the pipeline needs to send frames.
+func (p *pipeline) paths() int {
+	if p.action == control.ClassActionSecretShare {
+		return p.n
+	}
+	return 1
+}
+
+// SessionOptions determine how a session sends the packets written to it.
+// The zero value sends IP packets, and secret-shares all of them.
+type SessionOptions struct {
+	// FrameType is the type of the frames sent by the session.
+	FrameType uint8
+	// Classes are the traffic classes of the session. Packets matching one of
+	// the classes are handled as specified by the first matching class.
+	Classes []control.ClassAction
+	// RateLimit, if set, limits the rate of all the packets of the session,
+	// in addition to the rate limits of the classes.
+	RateLimit *control.RateLimit
+	// Padding, if set, pads the payloads of all the encrypted frames.
+	Padding *control.Padding
+	// CoverTraffic, if set, sends dummy frames that are secret-shared like the
+	// packets that match no class.
+	CoverTraffic *control.CoverTraffic
+}
+
+// NewSession creates a new session. Packets that match none of the classes in
+// the options are secret-shared over numberOfPathsN paths, numberOfPathsT of
+// which are needed to reconstruct them. Secret-shared classes with T and N set
+// to zero use the same defaults.
 func NewSession(sessionId uint8, gatewayAddr net.UDPAddr,
 	dataPlaneConn net.PacketConn, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics, numberOfPathsT int, numberOfPathsN int, aesKey string) *Session {
+	sessMetrics SessionMetrics, numberOfPathsT int, numberOfPathsN int, keys Keys,
+	opts SessionOptions) *Session {
 	sess := &Session{
 		SessionID:          sessionId,
 		GatewayAddr:        gatewayAddr,
 		DataPlaneConn:      dataPlaneConn,
 		PathStatsPublisher: pathStatsPublisher,
-		Metrics:            metrics,
+		Metrics:            sessMetrics,
 		numberOfPathsT:     numberOfPathsT,
 		numberOfPathsN:     numberOfPathsN,
-		encoder:            newEncoder(sessionId, NewStreamID(), aesKey),
+		limiter:            newLimiter(opts.RateLimit),
+		// One token per ICMP error.
+		tooBigLimiter: tokenbucket.New(tooBigRate, tooBigBurst, time.Now()),
+		frameType:     opts.FrameType,
+		keys:          keys,
+		closed:        make(chan struct{}),
+		pathsChanged:  make(chan struct{}),
+	}
+	// Each pipeline uses its own stream, such that the remote gateway
+	// reassembles the frames of the pipelines independently. The pipeline
+	// index goes into the upper 4 bits of the 20-bit stream ID, hence there
+	// can be at most control.MaxClasses classes besides the default pipeline.
+	// The session policies are validated when they are parsed, the excess
+	// classes are ignored here.
+	classes := opts.Classes
+	if len(classes) > control.MaxClasses {
+		log.Error("Ignoring excess classes", "classes", len(classes),
+			"max", control.MaxClasses)
+		classes = classes[:control.MaxClasses]
+	}
+	streamID := NewStreamID()
+	newPipeline := func(label string, cond pktcls.Cond, action control.ClassActionType,
+		t, n int, rateLimit *control.RateLimit) {
+
+		if t == 0 || n == 0 {
+			t, n = numberOfPathsT, numberOfPathsN
+		}
+		enc := newEncoder(opts.FrameType, sessionId,
+			streamID|uint32(len(sess.pipelines))<<streamClassShift, keys)
+		enc.noKey = sessMetrics.FramesNoKey
+		enc.ring.dropped = metrics.CounterWith(sessMetrics.IPPktsQueueDropped, "class", label)
+		switch action {
+		case control.ClassActionEncrypt:
+			enc.mode = frameModeEncrypted
+			enc.padding = opts.Padding
+		case control.ClassActionBypass:
+			enc.mode = frameModePlain
+		default:
+			enc.mode = frameModeShared
+			enc.threshold = uint8(t)
+			enc.padding = opts.Padding
+		}
+		sess.pipelines = append(sess.pipelines, &pipeline{
+			cond:        cond,
+			action:      action,
+			t:           t,
+			n:           n,
+			encoder:     enc,
+			limiter:     newLimiter(rateLimit),
+			rateLimited: metrics.CounterWith(sessMetrics.IPPktsRateLimited, "class", label),
+		})
+	}
+	for _, class := range classes {
+		newPipeline(class.String(), class.TrafficMatcher, class.Type, class.T, class.N,
+			class.RateLimit)
+	}
+	newPipeline("default", nil, control.ClassActionSecretShare, numberOfPathsT, numberOfPathsN,
+		nil)
+	if opts.CoverTraffic != nil {
+		enc := sess.pipelines[len(sess.pipelines)-1].encoder
+		enc.cover = true
+		enc.coverSent = sessMetrics.CoverFramesSent
+		sess.cover = newCoverTraffic(opts.CoverTraffic, enc)
+		go func() {
+			defer log.HandlePanic()
+			sess.cover.Run()
+		}()
+	}
+
+	setGaugeMetric(sessMetrics.SharesThreshold, float64(numberOfPathsT))
+	setGaugeMetric(sessMetrics.Shares, float64(numberOfPathsN))
+	for _, p := range sess.pipelines {
+		p := p
+		go func() {
+			defer log.HandlePanic()
+			sess.run(p)
+		}()
 	}
-	go func() {
-		defer log.HandlePanic()
-		sess.run()
-	}()
 	return sess
 }
 
@@ -97,17 +313,82 @@ func NewSession(sessionId uint8, gatewayAddr net.UDPAddr,
 // soon as forwarding goroutines are signaled to shut down (never blocks).
 func (s *Session) Close() {
 	s.mutex.Lock()
-	for _, snd := range s.senders {
+	defer s.mutex.Unlock()
+	select {
+	case <-s.closed:
+		return
+	default:
+	}
+	close(s.closed)
+	for _, snd := range s.allSenders() {
 		snd.Close()
 	}
-	s.encoder.Close()
-	s.mutex.Unlock()
+	for _, p := range s.pipelines {
+		p.encoder.Close()
+	}
+	if s.TooBigWriter != nil {
+		if err := s.TooBigWriter.Close(); err != nil {
+			log.Debug("Encountered error when closing ICMP writer", "err", err)
+		}
+	}
 }
 
 // Write encodes the packet and sends it to the network.
 // The packet may be silently dropped.
+// The packet is dropped if it exceeds the rate limit of its traffic class or
+// of the session. If TooBigWriter is set, IP packets that do not fit into a
+// single frame are dropped and answered with an ICMP error.
 func (s *Session) Write(packet gopacket.Packet) {
-	s.encoder.Write(packet.Data())
+	p := s.pipeline(packet)
+	size := len(packet.Data())
+	now := time.Now()
+	if s.TooBigWriter != nil && s.frameType == FrameTypeIP {
+		if mtu := p.encoder.maxPacketLen(s.frameMTU()); size > mtu && s.tooBig(packet, mtu, now) {
+			return
+		}
+	}
+	// The tokens are only taken if both the class and the session limit
+	// allow the packet, such that dropped packets do not use up the rate of
+	// either.
+	if !tokenbucket.AllowBoth(p.limiter, s.limiter, size, now) {
+		increaseCounterMetric(p.rateLimited, 1)
+		return
+	}
+	increaseCounterMetric(s.Metrics.IPPktsSent, 1)
+	increaseCounterMetric(s.Metrics.IPPktBytesSent, float64(size))
+	s.Capturer.packet(CaptureEgress, s.RemoteIA, s.frameType, packet.Data())
+	p.encoder.Write(packet.Data())
+}
+
+// tooBig answers the packet that does not fit into a single frame with an
+// ICMP error. It returns false if the packet cannot be answered.
+func (s *Session) tooBig(packet gopacket.Packet, mtu int, now time.Time) bool {
+	icmp, ok := packetTooBig(packet, mtu)
+	if !ok {
+		return false
+	}
+	increaseCounterMetric(s.Metrics.IPPktsTooBig, 1)
+	if !s.tooBigLimiter.Allow(1, now) {
+		return true
+	}
+	if _, err := s.TooBigWriter.Write(icmp); err != nil {
+		log.Debug("Encountered error when writing ICMP error", "err", err)
+	}
+	return true
+}
+
+// pipeline returns the pipeline handling the packet.
+func (s *Session) pipeline(packet gopacket.Packet) *pipeline {
+	network := packet.NetworkLayer()
+	for _, p := range s.pipelines {
+		if p.cond == nil {
+			return p
+		}
+		if network != nil && p.cond.Eval(network) {
+			return p
+		}
+	}
+	return s.pipelines[len(s.pipelines)-1]
 }
 
 func (s *Session) String() string {
@@ -131,11 +412,17 @@ func (s *Session) String() string {
 // could cause packets to be delivered out of order. Using new sender with new stream
 // ID causes creation of new reassemby queue on the remote side, thus avoiding the
 // reordering issues.
+//
+// The share index of a path is kept for as long as the path is used, i.e., the
+// shares with the same index are always sent on the same path. The remote
+// gateway relies on this to attribute the shares to paths. New paths take the
+// share indices that became free.
 func (s *Session) SetPaths(paths []snet.Path) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	created := make([]*sender, 0, len(paths))
+	newSenders := make([]*sender, len(paths))
+	var created, pending []*sender
 	reused := make(map[*sender]bool, len(s.senders))
 	for _, existingSender := range s.senders {
 		reused[existingSender] = false
@@ -144,11 +431,27 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 	for _, path := range paths {
 		// Find out whether we already have a sender for this path.
 		// Keep using old senders whenever possible.
-		if existingSender, ok := findSenderWithPath(s.senders, path); ok {
+		// If the path is listed more than once, each occurrence gets its own sender.
+		if index, ok := findSenderWithPath(s.senders, path); ok && !reused[s.senders[index]] {
+			existingSender := s.senders[index]
 			reused[existingSender] = true
+			if index < len(newSenders) && newSenders[index] == nil {
+				newSenders[index] = existingSender
+			} else {
+				pending = append(pending, existingSender)
+			}
 			continue
 		}
 
+		if s.EPIC {
+			var err error
+			if path, err = epicPath(path, s.keys); err != nil {
+				for _, createdSender := range created {
+					createdSender.Close()
+				}
+				return serrors.WrapStr("creating EPIC path", err)
+			}
+		}
 		newSender, err := newSender(
 			s.SessionID,
 			s.DataPlaneConn,
@@ -164,83 +467,267 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			}
 			return err
 		}
+		if s.PMTUDiscovery {
+			newSender.pmtu = newPMTUProber(newSender.maxMTU)
+		}
+		newSender.capturer = s.Capturer
 		created = append(created, newSender)
+		pending = append(pending, newSender)
 	}
 
-	newSenders := created
 	for existingSender, reuse := range reused {
 		if !reuse {
 			existingSender.Close()
-			continue
 		}
-		newSenders = append(newSenders, existingSender)
 	}
 
-	// Sort the paths to get a minimal amount of consistency,
-	// at least in the case when new paths are the same as old paths.
-	sort.Slice(newSenders, func(x, y int) bool {
-		return strings.Compare(string(newSenders[x].pathFingerprint),
-			string(newSenders[y].pathFingerprint)) == -1
-	})
+	// Assign the free share indices to the new paths.
+	for i := range newSenders {
+		if newSenders[i] == nil {
+			newSenders[i], pending = pending[0], pending[1:]
+		}
+	}
 	s.senders = newSenders
 
-	// Re-compute MTU after selecting the paths
-	lowestMtu := 65535
-	for _, path := range paths {
-
-		// MTU must account for the size of the SCION header.
-		localAddr := s.DataPlaneConn.LocalAddr().(*net.UDPAddr)
-		addrLen := addr.IABytes*2 + len(localAddr.IP) + len(s.GatewayAddr.IP)
-		scionPath, _ := path.Dataplane().(snetpath.SCION)
-		pathLen := len(scionPath.Raw)
-
-		pathMtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
-		if pathMtu < lowestMtu {
-			lowestMtu = pathMtu
+	// Reservations of share indices that are not used anymore are released.
+	for i := len(newSenders); i < len(s.reserved); i++ {
+		if r := s.reserved[i]; r != nil {
+			r.sender.Close()
+			go s.releaseReservation(r.rsv)
 		}
 	}
-
-	if lowestMtu != s.mtu {
-		s.mtu = lowestMtu
+	if len(s.reserved) > len(newSenders) {
+		s.reserved = s.reserved[:len(newSenders)]
 	}
 
+	// Re-compute MTU after selecting the paths
+	s.updateMTU()
+
+	if s.PMTUDiscovery && !s.pmtuRunning {
+		s.pmtuRunning = true
+		go func() {
+			defer log.HandlePanic()
+			s.runPMTU()
+		}()
+	}
+	if s.Reserver != nil && !s.reserving {
+		s.reserving = true
+		go func() {
+			defer log.HandlePanic()
+			s.runReservations()
+		}()
+	}
 	return nil
 }
 
-func (s *Session) run() {
+// updateMTU sets the MTU of the session to the minimal MTU of the paths of
+// the senders, including the senders on reservations. The MTU of a path
+// accounts for the size of the SCION header. It must be called with the mutex
+// held. The pipelines waiting for paths are woken up.
+func (s *Session) updateMTU() {
+	lowestMtu := 65535
+	for _, snd := range s.allSenders() {
+		if pathMtu := snd.MTU(); pathMtu < lowestMtu {
+			lowestMtu = pathMtu
+		}
+	}
+	s.mtu = lowestMtu
+	close(s.pathsChanged)
+	s.pathsChanged = make(chan struct{})
+}
 
-	fmt.Println("Session is running. T=", s.numberOfPathsT, "N=", s.numberOfPathsN)
+// frameMTU returns the MTU of the session.
+func (s *Session) frameMTU() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.mtu
+}
 
+// runPMTU sends the path MTU probes of the senders and reads the probe
+// replies, until the session is closed. Errors are logged and the probing is
+// retried after pmtuTickInterval.
+func (s *Session) runPMTU() {
+	buf := make([]byte, common.MaxMTU)
+	var lastTick time.Time
 	for {
-
-		startTime := time.Now()
-		for len(s.senders) < s.numberOfPathsN || s.mtu == 0 {
-			if time.Since(startTime) > time.Second*5 {
-				panic("There less than T paths available after 5 second wait.")
+		select {
+		case <-s.closed:
+			return
+		default:
+		}
+		now := time.Now()
+		if now.Sub(lastTick) >= pmtuTickInterval {
+			s.sendProbes(now)
+			lastTick = now
+		}
+		if err := s.DataPlaneConn.SetReadDeadline(now.Add(pmtuTickInterval)); err != nil {
+			log.Info("Unable to set deadline for probe replies, retrying", "err", err)
+			s.waitPMTURetry()
+			continue
+		}
+		n, src, err := s.DataPlaneConn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			var opErr *snet.OpError
+			if (errors.As(err, &netErr) && netErr.Timeout()) || errors.As(err, &opErr) {
+				continue
 			}
+			log.Info("Unable to read probe replies, retrying", "err", err)
+			s.waitPMTURetry()
+			continue
 		}
+		s.Capturer.share(CaptureIngress, s.RemoteIA, capturePeer(src), buf[:n])
+		s.handleProbeReply(buf[:n], time.Now())
+	}
+}
+
+// waitPMTURetry waits for pmtuTickInterval, or until the session is closed.
+func (s *Session) waitPMTURetry() {
+	select {
+	case <-s.closed:
+	case <-time.After(pmtuTickInterval):
+	}
+}
 
-		// Get the SIG frame, then apply SSS to the content.
-		sigFrame := s.encoder.ReadEncryptedSIGFrame(s.mtu)
-		if sigFrame == nil {
-			// sender was closed and all the buffered frames were sent.
+// sendProbes sends the path MTU probes that are due. The probes are written
+// after releasing the mutex, such that a slow network does not block the
+// session.
+func (s *Session) sendProbes(now time.Time) {
+	type pendingProbe struct {
+		snd   *sender
+		probe []byte
+	}
+	var pending []pendingProbe
+	s.mutex.Lock()
+	for _, snd := range s.allSenders() {
+		if snd.pmtu == nil {
+			continue
+		}
+		id, err := newProbeID()
+		if err != nil {
+			log.Info("Unable to create path MTU probe ID", "err", err)
 			break
 		}
+		size := snd.pmtu.Tick(now, id)
+		if size == 0 {
+			continue
+		}
+		probe := newProbe(s.frameType, s.SessionID, id, size)
+		pending = append(pending, pendingProbe{snd: snd, probe: probe})
+	}
+	// Lost probes can lower the MTU.
+	s.updateMTU()
+	s.mutex.Unlock()
+
+	for _, p := range pending {
+		s.Capturer.share(CaptureEgress, s.RemoteIA, p.snd.pathFingerprint, p.probe)
+		if err := p.snd.WriteProbe(p.probe); err != nil {
+			log.Debug("Unable to send path MTU probe", "path", p.snd.pathFingerprint,
+				"size", len(p.probe), "err", err)
+		}
+	}
+}
+
+// handleProbeReply passes the probe reply to the sender that sent the probe.
+func (s *Session) handleProbeReply(frame []byte, now time.Time) {
+	id, _, ok := parseProbeReply(frame)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, snd := range s.allSenders() {
+		if snd.pmtu != nil && snd.pmtu.Reply(id, now) {
+			s.updateMTU()
+			return
+		}
+	}
+}
 
-		err := s.splitAndSend(sigFrame, s.numberOfPathsN, s.numberOfPathsT)
+func (s *Session) run(p *pipeline) {
+	log.Debug("Session pipeline running", "action", p.action, "t", p.t, "n", p.n)
+	for {
+		mtu, ok := s.waitPaths(p.paths())
+		if !ok {
+			return
+		}
+		var err error
+		switch p.action {
+		case control.ClassActionSecretShare:
+			// Get the SIG frame, then apply SSS to the content.
+			sigFrame := p.encoder.ReadEncryptedSIGFrame(mtu)
+			if sigFrame == nil {
+				// sender was closed and all the buffered frames were sent.
+				return
+			}
+			err = s.splitAndSend(sigFrame, p.n, p.t)
+		case control.ClassActionEncrypt:
+			sigFrame := p.encoder.ReadEncryptedSIGFrame(mtu)
+			if sigFrame == nil {
+				return
+			}
+			s.sendWhole(sigFrame)
+		default:
+			sigFrame := p.encoder.ReadPlainSIGFrame(mtu)
+			if sigFrame == nil {
+				return
+			}
+			s.sendWhole(sigFrame)
+		}
 		if err != nil {
 			panic(err)
 		}
+		if s.cover != nil {
+			s.cover.FrameSent(time.Now())
+		}
+
+	}
+}
+
+// waitPaths waits until the session has at least n paths, and returns the
+// MTU of the session. It returns false if the session is closed while
+// waiting.
+func (s *Session) waitPaths(n int) (int, bool) {
+	for {
+		s.mutex.Lock()
+		mtu, paths, changed := s.mtu, len(s.senders), s.pathsChanged
+		s.mutex.Unlock()
+		if paths >= n && mtu != 0 {
+			return mtu, true
+		}
+		select {
+		case <-s.closed:
+			return 0, false
+		case <-changed:
+		}
+	}
+}
 
+// sendWhole sends the frame in one piece on the path of the first sender,
+// which is the best path of the session, or over the reservation of the first
+// share index.
+func (s *Session) sendWhole(frame []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.senders) == 0 {
+		increaseCounterMetric(s.Metrics.FramesQueueDropped, 1)
+		return
 	}
+	s.shareSender(0).Write(frame)
 }
 
 func (s *Session) splitAndSend(frame []byte, N, T int) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	if T > N || N > 255 || T < 1 || N < 1 || len(s.senders) < N {
+	if T > N || N > 255 || T < 1 || N < 1 {
 		panic("Invalid N or T")
 	}
+	// The paths may have been reduced since the pipeline waited for them. All
+	// the shares of the frame are dropped then.
+	if len(s.senders) < N {
+		increaseCounterMetric(s.Metrics.FramesQueueDropped, float64(N))
+		return nil
+	}
 
 	// split the frame into N shares
 	shares, err := Split(frame[hdrLen:], N, T)
@@ -260,21 +747,37 @@ func (s *Session) splitAndSend(frame []byte, N, T int) error {
 		copy(encryptedFrames[i][hdrLen:], shares[i])
 	}
 
-	// write the encrypted frames to the respective senders
-	for pathID, sender := range s.senders {
+	// write the encrypted frames to the respective senders. The shares sent
+	// over reservations stay on the reserved path.
+	for pathID := 0; pathID < N; pathID++ {
+		sender := s.shareSender(pathID)
+		if s.ReplyPaths != nil && sender == s.senders[pathID] {
+			sender.SetReplyPath(s.ReplyPaths.Get(sender.path.Destination(),
+				s.GatewayAddr.IP, uint8(pathID)))
+		}
 		sender.Write(encryptedFrames[pathID])
 	}
 
 	return nil
 }
 
-func findSenderWithPath(senders []*sender, path snet.Path) (*sender, bool) {
-	for _, s := range senders {
+// newLimiter returns the token bucket for the rate limit, or nil if there is
+// no rate limit.
+func newLimiter(rateLimit *control.RateLimit) *tokenbucket.Bucket {
+	if rateLimit == nil {
+		return nil
+	}
+	return newTokenBucket(rateLimit.Rate, rateLimit.Burst, time.Now())
+}
+
+// findSenderWithPath returns the index of the sender that uses the path.
+func findSenderWithPath(senders []*sender, path snet.Path) (int, bool) {
+	for i, s := range senders {
 		if pathsEqual(path, s.path) {
-			return s, true
+			return i, true
 		}
 	}
-	return nil, false
+	return 0, false
 }
 
 func pathsEqual(x, y snet.Path) bool {
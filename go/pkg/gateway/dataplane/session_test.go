@@ -16,35 +16,75 @@ package dataplane
 
 import (
 	"fmt"
+	"io"
+	"math/rand"
 	"net"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/mock_snet"
 	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
-// TODO: reimplement this test
-// func TestNoPath(t *testing.T) {
-// 	fmt.Println("[Running Test]: session_test.go->TestNoPath")
+func TestNoPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan ([]byte), 100)
+	sess := createSession(t, ctrl, frameChan, 2, 2)
+	defer sess.Close()
+	sendPacketsWithZeroPayload(t, sess, 22, 10)
+	// No path was set. Make sure that no frames are generated.
+	select {
+	case <-frameChan:
+		t.Fatal("frame sent without paths")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The pipelines wait for the paths, the buffered packets are sent once
+	// they are set.
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 200),
+		createMockPath(ctrl, 201),
+	}))
+	select {
+	case <-frameChan:
+	case <-time.After(time.Second):
+		t.Fatal("no frame sent after setting the paths")
+	}
+}
 
-// 	ctrl := gomock.NewController(t)
-// 	defer ctrl.Finish()
+func TestSplitAndSendTooFewPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-// 	frameChan := make(chan ([]byte))
-// 	sess := createSession(t, ctrl, frameChan, 0)
-// 	// sendPacketsWithZeroPayload(t, sess, 22, 10)
-// 	// No path was set. Make sure that no frames are generated.
-// 	waitFrames(t, frameChan, 0, 0)
-// 	sess.Close()
-// }
+	frameChan := make(chan ([]byte), 100)
+	sess := createSession(t, ctrl, frameChan, 2, 3)
+	defer sess.Close()
+	sess.Metrics.FramesQueueDropped = metrics.NewTestCounter()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 200),
+		createMockPath(ctrl, 201),
+	}))
+
+	// The paths were reduced after the pipeline waited for three paths.
+	require.NoError(t, sess.splitAndSend(make([]byte, hdrLen+10), 3, 2))
+	assert.Equal(t, float64(3), metrics.CounterValue(sess.Metrics.FramesQueueDropped))
+	assert.Empty(t, frameChan)
+}
 
 func TestTwoPaths(t *testing.T) {
 	fmt.Println("[Running Test]: session_test.go->TestTwoPaths")
@@ -138,6 +178,322 @@ func TestNoLeak(t *testing.T) {
 	// sess.Close()
 }
 
+func TestSessionMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan ([]byte))
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(f []byte, _ interface{}) (int, error) {
+			frameChan <- f
+			return 0, nil
+		}).AnyTimes()
+	sessMetrics := SessionMetrics{
+		IPPktsSent:      metrics.NewTestCounter(),
+		IPPktBytesSent:  metrics.NewTestCounter(),
+		FramesSent:      metrics.NewTestCounter(),
+		SharesSent:      metrics.NewTestCounter(),
+		SharesThreshold: metrics.NewTestGauge(),
+		Shares:          metrics.NewTestGauge(),
+	}
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, sessMetrics, 2, 3, StaticKey(testAESKey),
+		SessionOptions{})
+	defer sess.Close()
+	assert.Equal(t, float64(2), metrics.GaugeValue(sessMetrics.SharesThreshold))
+	assert.Equal(t, float64(3), metrics.GaugeValue(sessMetrics.Shares))
+
+	path := createMockPath(ctrl, 600)
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		path,
+		createMockPath(ctrl, 601),
+		createMockPath(ctrl, 602),
+	}))
+	sendPacketsWithZeroPayload(t, sess, 22, 10)
+
+	var shares int
+Top:
+	for {
+		select {
+		case <-frameChan:
+			shares++
+		case <-time.After(500 * time.Millisecond):
+			break Top
+		}
+	}
+	// Packets are counted once, not once per share.
+	assert.Equal(t, float64(10), metrics.CounterValue(sessMetrics.IPPktsSent))
+	assert.Equal(t, float64(10*42), metrics.CounterValue(sessMetrics.IPPktBytesSent))
+	assert.Zero(t, shares%3)
+	assert.Equal(t, float64(shares), metrics.CounterValue(sessMetrics.FramesSent))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, float64(shares/3), metrics.CounterValue(
+			sessMetrics.SharesSent.With("share_index", strconv.Itoa(i))), "share index %d", i)
+	}
+}
+
+func TestSessionRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	sessMetrics := SessionMetrics{
+		IPPktsSent:        metrics.NewTestCounter(),
+		IPPktsRateLimited: metrics.NewTestCounter(),
+	}
+	class, err := control.NewClassAction("dscp=0x8", "encrypt", 0, 0)
+	require.NoError(t, err)
+	// Two 62 byte packets fit into the burst of the class.
+	class.RateLimit = &control.RateLimit{Rate: 8, Burst: 130}
+	// Ten 42 byte packets fit into the burst of the session.
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, sessMetrics, 2, 3, StaticKey(testAESKey),
+		SessionOptions{
+			Classes:   []control.ClassAction{class},
+			RateLimit: &control.RateLimit{Rate: 8, Burst: 550},
+		})
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 600),
+		createMockPath(ctrl, 601),
+		createMockPath(ctrl, 602),
+	}))
+
+	for i := 0; i < 5; i++ {
+		sess.Write(generatePacketWithDSCP(rand.New(rand.NewSource(42)), 42, 0x8))
+	}
+	sendPacketsWithZeroPayload(t, sess, 22, 15)
+
+	assert.Equal(t, float64(12), metrics.CounterValue(sessMetrics.IPPktsSent))
+	assert.Equal(t, float64(3), metrics.CounterValue(
+		sessMetrics.IPPktsRateLimited.With("class", class.String())))
+	// The session has 550-2*62=426 bytes left, i.e., 10 packets.
+	assert.Equal(t, float64(5), metrics.CounterValue(
+		sessMetrics.IPPktsRateLimited.With("class", "default")))
+}
+
+func TestSetPathsKeepsShareIndices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sess := createSession(t, ctrl, make(chan []byte), 2, 3)
+	defer sess.Close()
+	// The MTU is used to differentiate the paths.
+	a, b, c, d := createMockPath(ctrl, 600), createMockPath(ctrl, 601),
+		createMockPath(ctrl, 602), createMockPath(ctrl, 603)
+	mtus := func() []uint16 {
+		sess.mutex.Lock()
+		defer sess.mutex.Unlock()
+		var mtus []uint16
+		for _, snd := range sess.senders {
+			mtus = append(mtus, snd.path.Metadata().MTU)
+		}
+		return mtus
+	}
+
+	require.NoError(t, sess.SetPaths([]snet.Path{c, a, b}))
+	assert.Equal(t, []uint16{602, 600, 601}, mtus())
+
+	// The remaining paths keep their share index, the new path takes the free one.
+	require.NoError(t, sess.SetPaths([]snet.Path{d, b, c}))
+	assert.Equal(t, []uint16{602, 603, 601}, mtus())
+
+	// Fewer paths move the paths with an index that is no longer used.
+	require.NoError(t, sess.SetPaths([]snet.Path{b, d}))
+	assert.Equal(t, []uint16{601, 603}, mtus())
+}
+
+func TestSessionTooBig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan []byte, 100)
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(f []byte, _ interface{}) (int, error) {
+			frameChan <- f
+			return 0, nil
+		}).AnyTimes()
+	sessMetrics := SessionMetrics{
+		IPPktsSent:   metrics.NewTestCounter(),
+		IPPktsTooBig: metrics.NewTestCounter(),
+	}
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, sessMetrics, 2, 2, StaticKey(testAESKey),
+		SessionOptions{})
+	tun := &MockTun{}
+	sess.TooBigWriter = tun
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 1000),
+		createMockPath(ctrl, 1001),
+	}))
+	mtu := sess.pipelines[0].encoder.maxPacketLen(sess.frameMTU())
+
+	// Packets that fit into a frame are sent.
+	sess.Write(newLargeIPv4Packet(t, mtu, layers.IPv4DontFragment))
+	assert.Empty(t, tun.packets)
+
+	// Larger packets are answered with an ICMP error.
+	sess.Write(newLargeIPv4Packet(t, mtu+1, layers.IPv4DontFragment))
+	require.Len(t, tun.packets, 1)
+	reply := gopacket.NewPacket(tun.packets[0], layers.LayerTypeIPv4, gopacket.Default)
+	icmp, ok := reply.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4)
+	require.True(t, ok)
+	assert.Equal(t, uint16(mtu), icmp.Seq)
+
+	// Larger packets that may be fragmented are still split across frames.
+	sess.Write(newLargeIPv4Packet(t, mtu+1, 0))
+	assert.Len(t, tun.packets, 1)
+
+	assert.Equal(t, float64(2), metrics.CounterValue(sessMetrics.IPPktsSent))
+	assert.Equal(t, float64(1), metrics.CounterValue(sessMetrics.IPPktsTooBig))
+}
+
+func TestSessionPMTUDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := newProbeConn(500)
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 2, StaticKey(testAESKey),
+		SessionOptions{})
+	sess.PMTUDiscovery = true
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 600),
+		createMockPath(ctrl, 601),
+	}))
+	maxMTU := sess.frameMTU()
+
+	// The probes of the metadata MTU are lost, the session falls back to the
+	// smallest MTU until the search confirms a larger one.
+	require.Eventually(t, func() bool {
+		return sess.frameMTU() < maxMTU
+	}, pmtuMaxProbes*pmtuProbeTimeout+time.Second, pmtuTickInterval)
+	require.Eventually(t, func() bool {
+		mtu := sess.frameMTU()
+		return mtu > minMTU && mtu <= 500
+	}, 2*time.Second, pmtuTickInterval/2)
+}
+
+func TestSessionPMTUDiscoveryReadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := newProbeConn(500)
+	conn.readErrs = 2
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 2, StaticKey(testAESKey),
+		SessionOptions{})
+	sess.PMTUDiscovery = true
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 600),
+		createMockPath(ctrl, 601),
+	}))
+
+	// The discovery continues after the read errors.
+	require.Eventually(t, func() bool {
+		mtu := sess.frameMTU()
+		return mtu > minMTU && mtu <= 500
+	}, pmtuMaxProbes*pmtuProbeTimeout+3*time.Second, pmtuTickInterval/2)
+}
+
+func TestSessionSendProbesUnlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := newProbeConn(500)
+	conn.writing = make(chan struct{}, 10)
+	conn.unblock = make(chan struct{})
+	sess := NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, 2, 2, StaticKey(testAESKey),
+		SessionOptions{})
+	defer sess.Close()
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 600),
+		createMockPath(ctrl, 601),
+	}))
+	for _, snd := range sess.senders {
+		snd.pmtu = newPMTUProber(snd.maxMTU)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sess.sendProbes(time.Now())
+	}()
+	<-conn.writing
+	// The session can be used while a probe is being written.
+	assert.NotZero(t, sess.frameMTU())
+	close(conn.unblock)
+	<-done
+}
+
+// probeConn is a connection on which probes of at most mtu bytes are answered.
+type probeConn struct {
+	net.PacketConn
+	mtu      int
+	replies  chan []byte
+	mtx      sync.Mutex
+	deadline time.Time
+	// readErrs is the number of reads that fail before replies are read.
+	readErrs int
+	// writing, if set, is notified when a probe is written. The write then
+	// blocks until unblock is closed.
+	writing chan struct{}
+	unblock chan struct{}
+}
+
+func newProbeConn(mtu int) *probeConn {
+	return &probeConn{mtu: mtu, replies: make(chan []byte, 100)}
+}
+
+func (c *probeConn) LocalAddr() net.Addr {
+	return &net.UDPAddr{IP: net.IP{192, 168, 1, 1}}
+}
+
+func (c *probeConn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	if c.writing != nil && b[modePos]>>4 == frameModeProbe {
+		c.writing <- struct{}{}
+		<-c.unblock
+	}
+	if b[modePos]>>4 == frameModeProbe && len(b) <= c.mtu {
+		c.replies <- newProbeReply(b)
+	}
+	return len(b), nil
+}
+
+func (c *probeConn) SetReadDeadline(t time.Time) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.deadline = t
+	return nil
+}
+
+func (c *probeConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	c.mtx.Lock()
+	deadline := c.deadline
+	if c.readErrs > 0 {
+		c.readErrs--
+		c.mtx.Unlock()
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	c.mtx.Unlock()
+	select {
+	case reply := <-c.replies:
+		return copy(b, reply), nil, nil
+	case <-time.After(time.Until(deadline)):
+		return 0, nil, timeoutError{}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
 func createSession(t *testing.T, ctrl *gomock.Controller, frameChan chan []byte, T int, N int) *Session {
 	conn := mock_net.NewMockPacketConn(ctrl)
 	conn.EXPECT().LocalAddr().Return(&net.UDPAddr{IP: net.IP{192, 168, 1, 1}}).AnyTimes()
@@ -146,7 +502,8 @@ func createSession(t *testing.T, ctrl *gomock.Controller, frameChan chan []byte,
 			frameChan <- f
 			return 0, nil
 		}).AnyTimes()
-	return NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, T, N, testAESKey)
+	return NewSession(22, net.UDPAddr{}, conn, nil, SessionMetrics{}, T, N, StaticKey(testAESKey),
+		SessionOptions{})
 }
 
 func sendPacketsWithZeroPayload(t *testing.T, sess *Session, payloadSize int, pktCount int) {
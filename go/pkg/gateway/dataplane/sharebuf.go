@@ -1,6 +1,9 @@
 package dataplane
 
-import "github.com/scionproto/scion/go/lib/ringbuf"
+import (
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/snet"
+)
 
 const (
 	// shareBufCap is the size of a preallocated frame buffer.
@@ -42,6 +45,8 @@ type shareBuf struct {
 	raw []byte
 	// The sender object for the frame.
 	snd ingressSender
+	// The source address of the share, including the reply path.
+	src *snet.UDPAddr
 }
 
 func newShareBuf() *shareBuf {
@@ -55,6 +60,7 @@ func (sb *shareBuf) Reset() {
 	sb.seqNr = 0
 	sb.frameLen = 0
 	sb.snd = nil
+	sb.src = nil
 }
 
 func (sb *shareBuf) Release() {
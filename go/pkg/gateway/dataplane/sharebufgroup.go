@@ -3,6 +3,8 @@ package dataplane
 import (
 	"container/list"
 	"context"
+	"encoding/binary"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
@@ -19,12 +21,32 @@ type shareBufGroup struct {
 	isCombined bool
 	// Is the group marked for cleanup in the decoder
 	isMarkedForCleanup bool
+	// The time the first share of the group was received
+	created time.Time
+	// The indices of the shares received for the group, including the ones
+	// received after the group was combined
+	received shareIndexSet
 }
 
 func GetPathIndex(sb *shareBuf) uint8 {
 	return uint8(sb.seqNr & 0xff)
 }
 
+// frameMode returns the mode of the frame the share belongs to.
+func frameMode(sb *shareBuf) uint8 {
+	return (sb.raw[modePos] &^ paddedFlag) >> 4
+}
+
+// frameStream returns the 20-bit stream ID of the frame the share belongs to.
+func frameStream(sb *shareBuf) uint32 {
+	return binary.BigEndian.Uint32(sb.raw[streamPos:streamPos+4]) & 0xfffff
+}
+
+// streamClass returns the class index carried in the stream ID.
+func streamClass(stream uint32) uint8 {
+	return uint8(stream >> streamClassShift)
+}
+
 func NewShareBufGroup(sb *shareBuf, numPaths uint8) *shareBufGroup {
 	groupSeqNr := sb.seqNr >> 8
 	pathIndex := GetPathIndex(sb)
@@ -38,6 +60,7 @@ func NewShareBufGroup(sb *shareBuf, numPaths uint8) *shareBufGroup {
 		shares:             list.New(),
 		isCombined:         false,
 		isMarkedForCleanup: false,
+		created:            time.Now(),
 	}
 	sbg.Insert(sb)
 	return sbg
@@ -50,6 +73,7 @@ func (sbg *shareBufGroup) Release() {
 }
 
 func (sbg *shareBufGroup) Insert(sb *shareBuf) {
+	sbg.received.add(GetPathIndex(sb))
 	sbg.shares.PushBack(sb)
 }
 
@@ -67,9 +91,11 @@ func (sbg *shareBufGroup) TryAndCombine(ctx context.Context) *frameBuf {
 
 	// Extract shares from the group into a slice
 	shares := make([][]byte, sbg.numPaths)
+	sources := make([]shareSource, 0, sbg.numPaths)
 	for i, e := 0, sbg.shares.Front(); e != nil && i < int(sbg.numPaths); i, e = i+1, e.Next() {
 		sb := e.Value.(*shareBuf)
 		shares[i] = sb.raw[hdrLen:sb.frameLen]
+		sources = append(sources, shareSource{index: GetPathIndex(sb), src: sb.src})
 	}
 
 	// Combine the shares
@@ -96,6 +122,7 @@ func (sbg *shareBufGroup) TryAndCombine(ctx context.Context) *frameBuf {
 	combinedFrame.frameLen = len(output) + hdrLen
 	combinedFrame.fragNProcessed = combinedFrame.index == 0
 	combinedFrame.completePktsProcessed = combinedFrame.index == 0xffff
+	combinedFrame.shares = append(combinedFrame.shares, sources...)
 
 	sbg.isCombined = true
 	sbg.Release()
@@ -22,7 +22,12 @@ import (
 	"io"
 	"time"
 
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -41,6 +46,12 @@ type ingressSender interface {
 	send([]byte) error
 }
 
+// classWriter delivers the packets matching cond to tunIO.
+type classWriter struct {
+	cond  pktcls.Cond
+	tunIO io.WriteCloser
+}
+
 // worker handles decapsulation of SIG frames.
 type worker struct {
 	Remote           *snet.UDPAddr
@@ -50,20 +61,41 @@ type worker struct {
 	rlists           map[int]*reassemblyList
 	markedForCleanup bool
 	tunIO            io.WriteCloser
-	decoder          *Decoder
+	// classes are checked in order before delivering a packet to tunIO. The
+	// packet is delivered to the device of the first matching class instead.
+	classes []classWriter
+	// frameType is the type of the frames handled by the worker. It determines
+	// how the packets are decoded for matching the classes.
+	frameType uint8
+	decoder   *Decoder
+	// sharesRecv caches the received shares counters per share index.
+	sharesRecv *indexCounters
+	// replyPaths, if set, records the reply path of every received share.
+	replyPaths *ReplyPaths
+	// coverDiscarded counts the discarded dummy frames.
+	coverDiscarded metrics.Counter
+	// paddingInvalid counts the frames discarded because of invalid padding.
+	paddingInvalid metrics.Counter
+	// capturer, if set, captures the decoded packets.
+	capturer *Capturer
 }
 
-func newWorker(remote *snet.UDPAddr, sessID uint8, numberOfPathsT int,
-	tunIO io.WriteCloser, metrics IngressMetrics, aesKey string) *worker {
+func newWorker(remote *snet.UDPAddr, sessID uint8, policies *FramePolicies,
+	tunIO io.WriteCloser, ingressMetrics IngressMetrics, keys Keys) *worker {
 
 	worker := &worker{
-		Remote:  remote,
-		SessID:  sessID,
-		Ring:    ringbuf.New(64, nil, fmt.Sprintf("ingress_%s_%d", remote.IA, sessID)),
-		rlists:  make(map[int]*reassemblyList),
-		tunIO:   tunIO,
-		Metrics: metrics,
-		decoder: newDecoder(numberOfPathsT, aesKey),
+		Remote:     remote,
+		SessID:     sessID,
+		Ring:       ringbuf.New(64, nil, fmt.Sprintf("ingress_%s_%d", remote.IA, sessID)),
+		rlists:     make(map[int]*reassemblyList),
+		tunIO:      tunIO,
+		Metrics:    ingressMetrics,
+		decoder:    newDecoder(remote.IA, policies, keys, ingressMetrics),
+		sharesRecv: newIndexCounters(ingressMetrics.SharesRecv),
+		coverDiscarded: metrics.CounterWith(ingressMetrics.FramesDiscarded,
+			"reason", "cover"),
+		paddingInvalid: metrics.CounterWith(ingressMetrics.FramesDiscarded,
+			"reason", "invalid"),
 	}
 
 	return worker
@@ -107,6 +139,11 @@ func (w *worker) processFrame(ctx context.Context, frame *shareBuf) {
 	epoch := int(binary.BigEndian.Uint32(frame.raw[4:8]) & 0xfffff)
 	seqNr := binary.BigEndian.Uint64(frame.raw[8:16])
 	frame.seqNr = seqNr
+	// Frames sent in one piece do not carry a share index.
+	if frameMode(frame) == frameModeShared {
+		shareIndex := GetPathIndex(frame)
+		increaseCounterMetric(w.sharesRecv.get(shareIndex), 1)
+	}
 
 	// Add frame to a decoder structure
 	decodedFrame := w.decoder.Insert(ctx, frame)
@@ -114,6 +151,16 @@ func (w *worker) processFrame(ctx context.Context, frame *shareBuf) {
 	if decodedFrame == nil {
 		return
 	}
+	// Only the shares of a frame that was combined and authenticated are
+	// trusted to carry valid reply paths.
+	if w.replyPaths != nil {
+		for _, s := range decodedFrame.shares {
+			w.replyPaths.Update(s.src, s.index)
+		}
+	}
+	if decodedFrame.raw[modePos]&paddedFlag != 0 && !w.unpad(decodedFrame) {
+		return
+	}
 	// build frame
 	index := int(binary.BigEndian.Uint16(decodedFrame.raw[2:4]))
 	decodedFrame.index = index
@@ -129,6 +176,25 @@ func (w *worker) processFrame(ctx context.Context, frame *shareBuf) {
 	rlist.Insert(ctx, decodedFrame)
 }
 
+// unpad removes the padding from the payload of the frame. It returns false,
+// and releases the frame, if the frame is a dummy frame or the padding is
+// invalid. Dummy frames are only sent between packets, so discarding them
+// does not break the reassembly of the stream.
+func (w *worker) unpad(frame *frameBuf) bool {
+	n, dummy, err := unpad(frame.raw[hdrLen:frame.frameLen])
+	switch {
+	case err != nil:
+		increaseCounterMetric(w.paddingInvalid, 1)
+	case dummy:
+		increaseCounterMetric(w.coverDiscarded, 1)
+	default:
+		frame.frameLen = hdrLen + n
+		return true
+	}
+	frame.Release()
+	return false
+}
+
 func (w *worker) getRlist(epoch int) *reassemblyList {
 	rlist, ok := w.rlists[epoch]
 	if !ok {
@@ -160,7 +226,8 @@ func (w *worker) cleanup() {
 }
 
 func (w *worker) send(packet []byte) error {
-	bytesWritten, err := w.tunIO.Write(packet)
+	w.capturer.packet(CaptureIngress, w.Remote.IA, w.frameType, packet)
+	bytesWritten, err := w.device(packet).Write(packet)
 	if err != nil {
 		increaseCounterMetric(w.Metrics.SendLocalError, 1)
 		return serrors.New("Unable to write to internal ingress", "err", err, "length", len(packet))
@@ -176,6 +243,34 @@ func (w *worker) send(packet []byte) error {
 	return nil
 }
 
+// device returns the device the packet is delivered to.
+func (w *worker) device(packet []byte) io.Writer {
+	if len(w.classes) == 0 || len(packet) == 0 {
+		return w.tunIO
+	}
+	var decoded gopacket.Packet
+	switch {
+	case w.frameType == FrameTypeEthernet:
+		decoded = gopacket.NewPacket(packet, layers.LayerTypeEthernet, decodeOptions)
+	case packet[0]>>4 == 4:
+		decoded = gopacket.NewPacket(packet, layers.LayerTypeIPv4, decodeOptions)
+	case packet[0]>>4 == 6:
+		decoded = gopacket.NewPacket(packet, layers.LayerTypeIPv6, decodeOptions)
+	default:
+		return w.tunIO
+	}
+	network := decoded.NetworkLayer()
+	if network == nil {
+		return w.tunIO
+	}
+	for _, c := range w.classes {
+		if c.cond.Eval(network) {
+			return c.tunIO
+		}
+	}
+	return w.tunIO
+}
+
 func (w *worker) adjustCtx(ctx context.Context) (context.Context, log.Logger) {
 	return log.WithLabels(ctx, "ingress", w.Remote.String(), "sessId", w.SessID)
 }
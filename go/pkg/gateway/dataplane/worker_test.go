@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -76,7 +77,8 @@ func EncryptAndSendFrame(t *testing.T, w *worker, packet []byte, seqNumber int)
 	shares, _ := Split(encrypted, N, T)
 
 	for i := 0; i < N; i++ {
-		sigHeader := []byte{0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, byte(seqNumber), byte(i)}
+		sigHeader := []byte{0, 1, 0, 0, byte(T), 0, 0, 1, 0, 0, 0, 0, 0, 0, byte(seqNumber),
+			byte(i)}
 		SendFrame(t, w, append(sigHeader, shares[i]...))
 	}
 }
@@ -89,6 +91,7 @@ func EncryptAndSendFrameWithHeader(t *testing.T, w *worker, packet []byte, sigHe
 	shares, _ := Split(encrypted, N, T)
 
 	for i := 0; i < N; i++ {
+		sigHeader[thresholdPos] = byte(T)
 		sigHeader[14] = byte(seqNumber)
 		sigHeader[15] = byte(i)
 		SendFrame(t, w, append(sigHeader, shares[i]...))
@@ -106,7 +109,7 @@ func TestParsing(t *testing.T) {
 		},
 	}
 	mt := &MockTun{}
-	w := newWorker(addr, 1, 2, mt, IngressMetrics{}, testAESKey)
+	w := newWorker(addr, 1, NewFramePolicies(2), mt, IngressMetrics{}, StaticKey(testAESKey))
 
 	simpleIp4Packet := []byte{0x40, 0, 0, 28, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 17, 18, 19, 20, 21, 22, 23, 24}
 
@@ -331,3 +334,88 @@ func TestParsing(t *testing.T) {
 
 	mt.AssertDone(t)
 }
+
+func TestClassDelivery(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	defaultTun := &MockTun{}
+	classTun := &MockTun{}
+	w := newWorker(addr, 1, NewFramePolicies(2), defaultTun, IngressMetrics{},
+		StaticKey(testAESKey))
+	w.classes = []classWriter{{
+		cond:  pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+		tunIO: classTun,
+	}}
+
+	// IPv4 packet with DSCP 0x2e (ToS 0xb8).
+	expedited := []byte{
+		0x45, 0xb8, 0, 28, 0, 0, 0, 0, 64, 17, 0, 0, 192, 0, 2, 1, 192, 0, 2, 2,
+		1, 2, 3, 4, 5, 6, 7, 8,
+	}
+	// IPv4 packet with DSCP 0.
+	bestEffort := []byte{
+		0x45, 0, 0, 28, 0, 0, 0, 0, 64, 17, 0, 0, 192, 0, 2, 1, 192, 0, 2, 2,
+		1, 2, 3, 4, 5, 6, 7, 8,
+	}
+
+	EncryptAndSendFrame(t, w, expedited, 0)
+	EncryptAndSendFrame(t, w, bestEffort, 1)
+	assert.Equal(t, [][]byte{expedited}, classTun.packets)
+	assert.Equal(t, [][]byte{bestEffort}, defaultTun.packets)
+}
+
+func TestEthernetDelivery(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	defaultTun := &MockTun{}
+	classTun := &MockTun{}
+	w := newWorker(addr, 1, NewFramePolicies(2), defaultTun, IngressMetrics{},
+		StaticKey(testAESKey))
+	w.frameType = FrameTypeEthernet
+	w.classes = []classWriter{{
+		cond:  pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+		tunIO: classTun,
+	}}
+
+	// VLAN-tagged ARP frame.
+	arp := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 1, 2, 3, 4, 5, 0x81, 0x00, 0, 10, 0x08, 0x06,
+		0, 1, 8, 0, 6, 4, 0, 1, 0, 1, 2, 3, 4, 5, 192, 0, 2, 1,
+		0, 0, 0, 0, 0, 0, 192, 0, 2, 2,
+	}
+	// VLAN-tagged IPv4 packet with DSCP 0x2e (ToS 0xb8).
+	expedited := []byte{
+		0, 1, 2, 3, 4, 5, 0, 1, 2, 3, 4, 6, 0x81, 0x00, 0, 10, 0x08, 0x00,
+		0x45, 0xb8, 0, 28, 0, 0, 0, 0, 64, 17, 0, 0, 192, 0, 2, 1, 192, 0, 2, 2,
+		1, 2, 3, 4, 5, 6, 7, 8,
+	}
+	header := func(index uint16) []byte {
+		return []byte{FrameTypeEthernet, 1, byte(index >> 8), byte(index), 0, 0, 0, 1,
+			0, 0, 0, 0, 0, 0, 0, 0}
+	}
+	withLen := func(frame []byte) []byte {
+		return append([]byte{0, byte(len(frame))}, frame...)
+	}
+
+	// Two complete frames in a single SIG frame.
+	EncryptAndSendFrameWithHeader(t, w, append(withLen(arp), withLen(expedited)...),
+		header(0), 0)
+	assert.Equal(t, [][]byte{arp}, defaultTun.packets)
+	assert.Equal(t, [][]byte{expedited}, classTun.packets)
+
+	// A frame spread over two SIG frames.
+	pkt := withLen(arp)
+	EncryptAndSendFrameWithHeader(t, w, pkt[:20], header(0), 1)
+	EncryptAndSendFrameWithHeader(t, w, pkt[20:], header(0xffff), 2)
+	assert.Equal(t, [][]byte{arp, arp}, defaultTun.packets)
+}
@@ -0,0 +1,317 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"crypto/aes"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+)
+
+const (
+	// drkeyFetchTimeout bounds the time to fetch a key from the daemon.
+	drkeyFetchTimeout = 10 * time.Second
+	// drkeyRetryInterval is the minimal time between two fetches of the same
+	// key, e.g., after a fetch failed.
+	drkeyRetryInterval = time.Second
+	// drkeyPrefetch is the time before the end of an epoch at which the key of
+	// the next epoch is fetched.
+	drkeyPrefetch = time.Minute
+	// drkeyClockSkew is the clock skew tolerated between the gateways. Close
+	// to the end of an epoch, the frames are also decrypted with the key of
+	// the adjacent epoch.
+	drkeyClockSkew = 5 * time.Second
+)
+
+// epicKeyLabel is encrypted with a 4SP key to derive the key of the EPIC
+// destination validation fields, such that the same key is not used for the
+// frames and the validation fields.
+var epicKeyLabel = [aes.BlockSize]byte{'4', 'S', 'P', ' ', 'E', 'P', 'I', 'C'}
+
+// DRKeyFactory creates keys that are derived from DRKey for the 4SP protocol.
+// The frames sent from the local gateway at EgressIP to a remote gateway at
+// IP B in AS Y are encrypted with the Host-Host key from LocalIA:EgressIP to
+// Y:B. The frames received from a remote gateway at IP A in AS X are
+// decrypted with the Host-Host key from X:A to LocalIA:IngressIP. Both
+// gateways can thus compute the keys without a handshake, and the keys change
+// with the DRKey epochs. The keys of the EPIC destination validation fields
+// are derived from the same Host-Host keys.
+//
+// The keys of a remote gateway are created once and shared by all its users,
+// such that every key is only fetched once.
+type DRKeyFactory struct {
+	// Fetcher fetches the keys, typically the SCION Daemon.
+	Fetcher   drkey.Fetcher
+	LocalIA   addr.IA
+	EgressIP  net.IP
+	IngressIP net.IP
+
+	mtx  sync.Mutex
+	keys map[drkeyRemote]*drkeyKeys
+}
+
+// drkeyRemote identifies a remote gateway.
+type drkeyRemote struct {
+	ia addr.IA
+	ip [net.IPv6len]byte
+}
+
+// Keys implements dataplane.KeyFactory.
+func (f *DRKeyFactory) Keys(remoteIA addr.IA, remoteIP net.IP) dataplane.Keys {
+	remote := drkeyRemote{ia: remoteIA}
+	copy(remote.ip[:], remoteIP.To16())
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if keys, ok := f.keys[remote]; ok {
+		return keys
+	}
+	if f.keys == nil {
+		f.keys = make(map[drkeyRemote]*drkeyKeys)
+	}
+	keys := f.newKeys(remoteIA, remoteIP)
+	f.keys[remote] = keys
+	return keys
+}
+
+func (f *DRKeyFactory) newKeys(remoteIA addr.IA, remoteIP net.IP) *drkeyKeys {
+	return &drkeyKeys{
+		egress: &drkeyCache{
+			fetcher: f.Fetcher,
+			srcIA:   f.LocalIA,
+			srcHost: f.EgressIP.String(),
+			dstIA:   remoteIA,
+			dstHost: remoteIP.String(),
+		},
+		ingress: &drkeyCache{
+			fetcher: f.Fetcher,
+			srcIA:   remoteIA,
+			srcHost: remoteIP.String(),
+			dstIA:   f.LocalIA,
+			dstHost: f.IngressIP.String(),
+		},
+	}
+}
+
+var _ dataplane.EPICKeys = (*drkeyKeys)(nil)
+
+// drkeyKeys are the keys of the frames exchanged with a remote gateway.
+type drkeyKeys struct {
+	egress  *drkeyCache
+	ingress *drkeyCache
+}
+
+func (k *drkeyKeys) EncryptionKey(now time.Time) (string, error) {
+	key, ok := k.egress.get(now)
+	if !ok {
+		return "", serrors.New("DRKey not available", "src_isd_as", k.egress.srcIA,
+			"dst_isd_as", k.egress.dstIA, "time", now)
+	}
+	return hex.EncodeToString(key.Key[:]), nil
+}
+
+// DecryptionKeys returns the key of the current epoch, and the key of the
+// adjacent epoch if the sending gateway might be in it because of clock skew.
+func (k *drkeyKeys) DecryptionKeys(now time.Time) ([]string, error) {
+	ingress, err := k.ingressKeys(now)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(ingress))
+	for _, key := range ingress {
+		keys = append(keys, hex.EncodeToString(key[:]))
+	}
+	return keys, nil
+}
+
+// EPICKey returns the key of the destination validation fields of the shares
+// sent at the given time.
+func (k *drkeyKeys) EPICKey(now time.Time) ([]byte, error) {
+	key, ok := k.egress.get(now)
+	if !ok {
+		return nil, serrors.New("DRKey not available", "src_isd_as", k.egress.srcIA,
+			"dst_isd_as", k.egress.dstIA, "time", now)
+	}
+	return deriveEPICKey(key.Key)
+}
+
+// EPICVerificationKeys returns the keys of the destination validation fields
+// of the epochs DecryptionKeys returns the keys of.
+func (k *drkeyKeys) EPICVerificationKeys(now time.Time) ([][]byte, error) {
+	ingress, err := k.ingressKeys(now)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, 0, len(ingress))
+	for _, key := range ingress {
+		epicKey, err := deriveEPICKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, epicKey)
+	}
+	return keys, nil
+}
+
+// ingressKeys returns the key of the current epoch, and the key of the
+// adjacent epoch if the sending gateway might be in it because of clock skew.
+func (k *drkeyKeys) ingressKeys(now time.Time) ([]drkey.Key, error) {
+	var keys []drkey.Key
+	var epochs []drkey.Epoch
+	for _, t := range []time.Time{now, now.Add(-drkeyClockSkew), now.Add(drkeyClockSkew)} {
+		key, ok := k.ingress.get(t)
+		if !ok || containsEpoch(epochs, key.Epoch) {
+			continue
+		}
+		epochs = append(epochs, key.Epoch)
+		keys = append(keys, key.Key)
+	}
+	if len(keys) == 0 {
+		return nil, serrors.New("DRKey not available", "src_isd_as", k.ingress.srcIA,
+			"dst_isd_as", k.ingress.dstIA, "time", now)
+	}
+	return keys, nil
+}
+
+// deriveEPICKey derives the key of the EPIC destination validation fields from
+// the 4SP key.
+func deriveEPICKey(key drkey.Key) ([]byte, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	epicKey := make([]byte, aes.BlockSize)
+	block.Encrypt(epicKey, epicKeyLabel[:])
+	return epicKey, nil
+}
+
+func containsEpoch(epochs []drkey.Epoch, epoch drkey.Epoch) bool {
+	for _, e := range epochs {
+		if e.Equal(epoch) {
+			return true
+		}
+	}
+	return false
+}
+
+// drkeyCache caches the Host-Host keys between two hosts. The keys are
+// fetched in the background, such that looking them up never blocks.
+type drkeyCache struct {
+	fetcher          drkey.Fetcher
+	srcIA, dstIA     addr.IA
+	srcHost, dstHost string
+
+	mtx  sync.Mutex
+	keys []drkey.HostHostKey
+	// fetching is set while a key is fetched.
+	fetching bool
+	// lastFetch is the time the last fetch was started.
+	lastFetch time.Time
+}
+
+// get returns the key of the epoch that contains t. If the key is not cached,
+// it is fetched in the background and false is returned. The key of the next
+// epoch is fetched ahead of time.
+func (c *drkeyCache) get(t time.Time) (drkey.HostHostKey, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var found *drkey.HostHostKey
+	for i := range c.keys {
+		if c.keys[i].Epoch.Contains(t) {
+			found = &c.keys[i]
+			break
+		}
+	}
+	if found == nil {
+		c.fetch(t)
+		return drkey.HostHostKey{}, false
+	}
+	if next := found.Epoch.NotAfter.Add(time.Second); next.Sub(t) < drkeyPrefetch {
+		if !c.cached(next) {
+			c.fetch(next)
+		}
+	}
+	return *found, true
+}
+
+// cached returns whether the key of the epoch that contains t is cached. It
+// must be called with the lock held.
+func (c *drkeyCache) cached(t time.Time) bool {
+	for _, key := range c.keys {
+		if key.Epoch.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch fetches the key of the epoch that contains t in the background,
+// unless a fetch is already ongoing or the last one was started too recently.
+// It must be called with the lock held.
+func (c *drkeyCache) fetch(t time.Time) {
+	now := time.Now()
+	if c.fetching || now.Sub(c.lastFetch) < drkeyRetryInterval {
+		return
+	}
+	c.fetching, c.lastFetch = true, now
+	meta := drkey.HostHostMeta{
+		Lvl2Meta: drkey.Lvl2Meta{
+			ProtoId:  drkey.FourSP,
+			Validity: t,
+			SrcIA:    c.srcIA,
+			DstIA:    c.dstIA,
+		},
+		SrcHost: c.srcHost,
+		DstHost: c.dstHost,
+	}
+	go func() {
+		defer log.HandlePanic()
+		ctx, cancel := context.WithTimeout(context.Background(), drkeyFetchTimeout)
+		defer cancel()
+		key, err := c.fetcher.DRKeyGetHostHostKey(ctx, meta)
+
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		c.fetching = false
+		if err != nil {
+			log.Info("Failed to fetch DRKey", "src_isd_as", c.srcIA, "src_host", c.srcHost,
+				"dst_isd_as", c.dstIA, "dst_host", c.dstHost, "err", err)
+			return
+		}
+		c.add(key, time.Now())
+	}()
+}
+
+// add caches the key and removes the keys of the epochs that ended. It must be
+// called with the lock held.
+func (c *drkeyCache) add(key drkey.HostHostKey, now time.Time) {
+	keys := c.keys[:0]
+	for _, k := range c.keys {
+		if k.Epoch.NotAfter.Add(drkeyClockSkew).After(now) && !k.Epoch.Equal(key.Epoch) {
+			keys = append(keys, k)
+		}
+	}
+	c.keys = append(keys, key)
+}
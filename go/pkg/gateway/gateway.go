@@ -29,6 +29,7 @@ import (
 	"inet.af/netaddr"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/daemon"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
@@ -42,6 +43,7 @@ import (
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/gateway/config"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
@@ -70,34 +72,98 @@ type DataplaneSessionFactory struct {
 	Metrics            dataplane.SessionMetrics
 	NumberOfPathsN     int
 	NumberOfPathsT     int
-	AESKey             string
+	// Keys provide the keys to encrypt the frames sent to the remote gateways.
+	Keys dataplane.KeyFactory
+	// FrameType is the type of the frames sent by the sessions.
+	FrameType uint8
+	// ReplyPaths, if set, makes the sessions send the shares on the reply paths
+	// of the shares received from the remote gateways.
+	ReplyPaths *dataplane.ReplyPaths
+	// PMTUDiscovery makes the sessions discover the MTU of their paths. IP
+	// packets that do not fit into a single frame are then answered with ICMP
+	// errors written to the device of the remote IA, which DeviceManager must
+	// provide.
+	PMTUDiscovery bool
+	DeviceManager control.DeviceManager
+	// Capturer, if set, captures the traffic of the sessions.
+	Capturer *dataplane.Capturer
+	// ColibriBandwidth, if set, makes the sessions send their shares over
+	// COLIBRI E2E reservations of this bandwidth class, one per share index,
+	// which are set up with Daemon from LocalIP in LocalIA.
+	ColibriBandwidth reservation.BWCls
+	Daemon           daemon.Connector
+	LocalIA          addr.IA
+	LocalIP          net.IP
 }
 
-func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
-	remoteIA addr.IA, remoteAddr net.Addr) control.DataplaneSession {
+func (dpf DataplaneSessionFactory) New(config *control.SessionConfig) control.DataplaneSession {
+	remoteIA, remoteAddr := config.IA, config.Gateway.Data
 
 	conn, err := dpf.PacketConnFactory.New()
 	if err != nil {
 		panic(err)
 	}
-	labels := []string{"remote_isd_as", remoteIA.String(), "policy_id", strconv.Itoa(policyID)}
+	labels := []string{"remote_isd_as", remoteIA.String(),
+		"policy_id", strconv.Itoa(config.PolicyID)}
 	metrics := dataplane.SessionMetrics{
 		IPPktBytesSent:     metrics.CounterWith(dpf.Metrics.IPPktBytesSent, labels...),
 		IPPktsSent:         metrics.CounterWith(dpf.Metrics.IPPktsSent, labels...),
 		FrameBytesSent:     metrics.CounterWith(dpf.Metrics.FrameBytesSent, labels...),
 		FramesSent:         metrics.CounterWith(dpf.Metrics.FramesSent, labels...),
 		SendExternalErrors: dpf.Metrics.SendExternalErrors,
+		SharesSent:         metrics.CounterWith(dpf.Metrics.SharesSent, labels...),
+		SharesThreshold:    metrics.GaugeWith(dpf.Metrics.SharesThreshold, labels...),
+		Shares:             metrics.GaugeWith(dpf.Metrics.Shares, labels...),
+		IPPktsRateLimited:  metrics.CounterWith(dpf.Metrics.IPPktsRateLimited, labels...),
+		IPPktsQueueDropped: metrics.CounterWith(dpf.Metrics.IPPktsQueueDropped, labels...),
+		FramesQueueDropped: metrics.CounterWith(dpf.Metrics.FramesQueueDropped, labels...),
+		CoverFramesSent:    metrics.CounterWith(dpf.Metrics.CoverFramesSent, labels...),
+		IPPktsTooBig:       metrics.CounterWith(dpf.Metrics.IPPktsTooBig, labels...),
+		FramesNoKey:        metrics.CounterWith(dpf.Metrics.FramesNoKey, labels...),
 	}
 	sess := dataplane.NewSession(
-		id,
-		*remoteAddr.(*net.UDPAddr),
+		config.ID,
+		*remoteAddr,
 		conn,
 		dpf.PathStatsPublisher,
 		metrics,
 		dpf.NumberOfPathsT,
 		dpf.NumberOfPathsN,
-		dpf.AESKey,
+		dpf.Keys.Keys(remoteIA, remoteAddr.IP),
+		dataplane.SessionOptions{
+			FrameType:    dpf.FrameType,
+			Classes:      config.Classes,
+			RateLimit:    config.RateLimit,
+			Padding:      config.Padding,
+			CoverTraffic: config.CoverTraffic,
+		},
 	)
+	sess.ReplyPaths = dpf.ReplyPaths
+	sess.Capturer = dpf.Capturer
+	sess.RemoteIA = remoteIA
+	sess.EPIC = config.EPIC
+	sess.PathPolicy = config.PathPolicy
+	if dpf.ColibriBandwidth != 0 {
+		sess.Reserver = &ColibriReserver{
+			Daemon:     dpf.Daemon,
+			LocalIA:    dpf.LocalIA,
+			LocalIP:    dpf.LocalIP,
+			RemoteIA:   remoteIA,
+			RemoteIP:   remoteAddr.IP,
+			Bandwidth:  dpf.ColibriBandwidth,
+			PathPolicy: config.PathPolicy,
+		}
+	}
+	if dpf.PMTUDiscovery {
+		sess.PMTUDiscovery = true
+		handle, err := dpf.DeviceManager.Get(context.Background(), remoteIA)
+		if err != nil {
+			log.Info("Unable to get device handle for ICMP errors", "err", err,
+				"isd_as", remoteIA)
+		} else {
+			sess.TooBigWriter = handle
+		}
+	}
 	return sess
 }
 
@@ -211,6 +277,10 @@ type Gateway struct {
 
 	// Dispatcher is the API of the SCION Dispatcher on the local host.
 	Dispatcher reliable.Dispatcher
+	// EndhostPorts is the end host port range from the topology of the local
+	// AS. If it is not empty, the data and probe connections bind their ports
+	// directly instead of registering with the Dispatcher.
+	EndhostPorts underlay.PortRange
 
 	// Daemon is the API of the SCION Daemon.
 	Daemon daemon.Connector
@@ -221,6 +291,13 @@ type Gateway struct {
 	RouteSourceIPv6 net.IP
 	// TunnelName is the device name for the Linux global tunnel device.
 	TunnelName string
+	// TunnelDevices are additional tunnel devices that remote IAs and traffic
+	// classes are mapped to. Traffic that is not mapped uses the global tunnel
+	// device.
+	TunnelDevices []config.TunnelDevice
+	// TunnelMode is the tunnel mode, either config.TunnelModeIP or
+	// config.TunnelModeEthernet. If empty, config.TunnelModeIP is used.
+	TunnelMode string
 
 	// RoutingTableReader is used for routing the packets.
 	RoutingTableReader control.RoutingTableReader
@@ -241,6 +318,21 @@ type Gateway struct {
 	NumberOfPathsN int
 	NumberOfPathsT int
 	AESKey         string
+	// DRKey makes the gateway derive the keys of the frames from DRKey, instead
+	// of using AESKey for all remote gateways.
+	DRKey bool
+	// FollowReplyPaths makes the shares sent to a remote gateway follow the
+	// paths on which the shares of the remote gateway arrive.
+	FollowReplyPaths bool
+	// PMTUDiscovery makes the sessions discover the MTU of their paths and
+	// answer IP packets that exceed it with ICMP errors.
+	PMTUDiscovery bool
+	// Capturer, if set, captures the packets and shares of the dataplane on
+	// demand.
+	Capturer *dataplane.Capturer
+	// ColibriBandwidth, if set, is the bandwidth class of the COLIBRI E2E
+	// reservations the shares are sent over.
+	ColibriBandwidth reservation.BWCls
 }
 
 func (g *Gateway) Run(ctx context.Context) error {
@@ -269,21 +361,33 @@ func (g *Gateway) Run(ctx context.Context) error {
 		tunnelName = "tun0"
 	}
 
+	frameType := dataplane.FrameTypeIP
+	var deviceOptions []xnet.DeviceOption
+	if g.TunnelMode == config.TunnelModeEthernet {
+		frameType = dataplane.FrameTypeEthernet
+		deviceOptions = append(deviceOptions, xnet.WithTAP())
+	}
+
 	tunnelReader := TunnelReader{
-		DeviceOpener: xnet.UseNameResolver(
-			routemgr.FixedTunnelName(tunnelName),
-			xnet.OpenerWithOptions(ctx),
-		),
-		Router:  g.RoutingTableReader,
-		Metrics: fwMetrics,
+		Router:    g.RoutingTableReader,
+		FrameType: frameType,
+		Metrics:   fwMetrics,
+	}
+	openDevice := func(name string, options ...xnet.DeviceOption) (control.Device, error) {
+		return xnet.OpenerWithOptions(ctx, options...).Open(name)
 	}
-	deviceManager := &routemgr.SingleDeviceManager{
-		DeviceOpener: tunnelReader.GetDeviceOpenerWithAsyncReader(ctx),
+	deviceManager, ingressClasses, err := tunnelDevices(ctx, tunnelNamer(tunnelName, frameType),
+		g.TunnelDevices, openDevice, deviceOptions, tunnelReader)
+	if err != nil {
+		return serrors.WrapStr("setting up tunnel devices", err)
 	}
 
 	logger.Debug("Egress started")
 
-	routePublisherFactory := createRouteManager(ctx, deviceManager)
+	// Devices in ethernet mode are bridged rather than routed, so no routes
+	// are installed on them.
+	routePublisherFactory := createRouteManager(ctx, deviceManager,
+		frameType == dataplane.FrameTypeEthernet)
 
 	// *************************************************************************
 	// Initialize base SCION network information: IA + Dispatcher connectivity
@@ -383,12 +487,21 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	legacySessionPolicyAdapter := &control.LegacySessionPolicyAdapter{}
 
-	// We know we have two subscribers, so we initialize the subscriptions right from the start.
-	// Once subscribed, publish immediately.
+	// We know we have three subscribers, so we initialize the subscriptions right from the
+	// start. Once subscribed, publish immediately.
 	configPublisher := &control.ConfigPublisher{}
 	remoteIAsChannel := configPublisher.SubscribeRemoteIAs()
 	sessionPoliciesChannel := configPublisher.SubscribeSessionPolicies()
 
+	// The ingress only accepts the frames of the remote gateways in the modes
+	// and with the thresholds that the session policies configure.
+	framePolicies := dataplane.NewFramePolicies(g.NumberOfPathsT)
+	framePoliciesChannel := configPublisher.SubscribeSessionPolicies()
+	go func() {
+		defer log.HandlePanic()
+		framePolicies.Run(ctx, framePoliciesChannel)
+	}()
+
 	configLoader := config.Loader{
 		SessionPoliciesFile: g.TrafficPolicyFile,
 		RoutingPolicyFile:   g.RoutingPolicyFile,
@@ -463,19 +576,38 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	// scionNetwork is the network for all SCION connections, with the exception of the QUIC server
 	// connection.
+	// Forward revocations to Daemon
+	scmpHandler := snet.DefaultSCMPHandler{
+		RevocationHandler: revocationHandler,
+		SCMPErrors:        g.Metrics.SCMPErrors,
+	}
 	scionNetwork := &snet.SCIONNetwork{
 		LocalIA: localIA,
 		Dispatcher: &snet.DefaultPacketDispatcherService{
 			// Enable transparent reconnections to the dispatcher
-			Dispatcher: reconnectingDispatcher,
-			// Forward revocations to Daemon
-			SCMPHandler: snet.DefaultSCMPHandler{
-				RevocationHandler: revocationHandler,
-				SCMPErrors:        g.Metrics.SCMPErrors,
-			},
+			Dispatcher:             reconnectingDispatcher,
+			SCMPHandler:            scmpHandler,
 			SCIONPacketConnMetrics: g.Metrics.SCIONPacketConnMetrics,
 		},
-		Metrics: g.Metrics.SCIONNetworkMetrics,
+		Metrics:      g.Metrics.SCIONNetworkMetrics,
+		EndhostPorts: g.EndhostPorts,
+	}
+	// dataNetwork is the network for the data and probe connections. If the
+	// local AS has an end host port range, they bypass the dispatcher.
+	dataNetwork := scionNetwork
+	if !g.EndhostPorts.Empty() {
+		dataNetwork = &snet.SCIONNetwork{
+			LocalIA: localIA,
+			Dispatcher: &snet.DirectPacketDispatcherService{
+				Ports:                  g.EndhostPorts,
+				SCMPHandler:            scmpHandler,
+				SCIONPacketConnMetrics: g.Metrics.SCIONPacketConnMetrics,
+			},
+			Metrics:      g.Metrics.SCIONNetworkMetrics,
+			EndhostPorts: g.EndhostPorts,
+		}
+		logger.Info("Binding data and probe ports without the dispatcher",
+			"endhost_ports", g.EndhostPorts)
 	}
 
 	// Initialize the UDP/SCION QUIC conn for outgoing Gateway Discovery RPCs and outgoing Prefix
@@ -566,7 +698,8 @@ func (g *Gateway) Run(ctx context.Context) error {
 			SCMPHandler:            ignoreSCMP{},
 			SCIONPacketConnMetrics: g.Metrics.SCIONPacketConnMetrics,
 		},
-		Metrics: g.Metrics.SCIONNetworkMetrics,
+		Metrics:      g.Metrics.SCIONNetworkMetrics,
+		EndhostPorts: g.EndhostPorts,
 	}
 	serverConn, err := scionNetworkNoSCMP.Listen(
 		context.TODO(),
@@ -617,7 +750,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 	// received from the session monitors of the remote gateway.
 	// *********************************************************************************
 
-	probeConn, err := scionNetwork.Listen(context.TODO(), "udp", g.ProbeServerAddr, addr.SvcNone)
+	probeConn, err := dataNetwork.Listen(context.TODO(), "udp", g.ProbeServerAddr, addr.SvcNone)
 	if err != nil {
 		return serrors.WrapStr("creating server probe conn", err)
 	}
@@ -632,8 +765,22 @@ func (g *Gateway) Run(ctx context.Context) error {
 	}()
 
 	// Start dataplane ingress
-	if err := StartIngress(ctx, scionNetwork, g.DataServerAddr, deviceManager,
-		g.Metrics, g.NumberOfPathsT, g.AESKey); err != nil {
+	var replyPaths *dataplane.ReplyPaths
+	if g.FollowReplyPaths {
+		replyPaths = dataplane.NewReplyPaths()
+	}
+	var keys dataplane.KeyFactory = dataplane.StaticKey(g.AESKey)
+	if g.DRKey {
+		keys = &DRKeyFactory{
+			Fetcher:   g.Daemon,
+			LocalIA:   localIA,
+			EgressIP:  g.DataClientIP,
+			IngressIP: g.DataServerAddr.IP,
+		}
+		logger.Info("Deriving frame keys from DRKey")
+	}
+	if err := StartIngress(ctx, dataNetwork, g.DataServerAddr, deviceManager, ingressClasses,
+		g.Metrics, framePolicies, keys, frameType, replyPaths, g.Capturer); err != nil {
 
 		return err
 	}
@@ -674,19 +821,28 @@ func (g *Gateway) Run(ctx context.Context) error {
 		EngineFactory: &control.DefaultEngineFactory{
 			PathMonitor: pathMonitor,
 			ProbeConnFactory: PacketConnFactory{
-				Network: scionNetwork,
+				Network: dataNetwork,
 				Addr:    &net.UDPAddr{IP: g.ProbeClientIP},
 			},
 			DeviceManager: deviceManager,
 			DataplaneSessionFactory: DataplaneSessionFactory{
 				PacketConnFactory: PacketConnFactory{
-					Network: scionNetwork,
+					Network: dataNetwork,
 					Addr:    &net.UDPAddr{IP: g.DataClientIP},
 				},
-				Metrics:        CreateSessionMetrics(g.Metrics),
-				NumberOfPathsN: g.NumberOfPathsN,
-				NumberOfPathsT: g.NumberOfPathsT,
-				AESKey:         g.AESKey,
+				Metrics:          CreateSessionMetrics(g.Metrics),
+				NumberOfPathsN:   g.NumberOfPathsN,
+				NumberOfPathsT:   g.NumberOfPathsT,
+				Keys:             keys,
+				FrameType:        frameType,
+				ReplyPaths:       replyPaths,
+				PMTUDiscovery:    g.PMTUDiscovery,
+				DeviceManager:    deviceManager,
+				Capturer:         g.Capturer,
+				ColibriBandwidth: g.ColibriBandwidth,
+				Daemon:           g.Daemon,
+				LocalIA:          localIA,
+				LocalIP:          g.DataClientIP,
 			},
 			Metrics: CreateEngineMetrics(g.Metrics),
 		},
@@ -803,11 +959,19 @@ func CreateIngressMetrics(m *Metrics) dataplane.IngressMetrics {
 		FramesDiscarded:      metrics.NewPromCounter(m.FramesDiscardedTotal),
 		SendLocalError:       metrics.NewPromCounter(m.SendLocalErrorsTotal),
 		ReceiveExternalError: metrics.NewPromCounter(m.ReceiveExternalErrorsTotal),
+		SharesRecv:           metrics.NewPromCounter(m.SharesReceivedTotal),
+		SharesLost:           metrics.NewPromCounter(m.SharesLostTotal),
+		ShareGroupsCombined:  metrics.NewPromCounter(m.ShareGroupsCombinedTotal),
+		ShareGroupsExpired:   metrics.NewPromCounter(m.ShareGroupsExpiredTotal),
+		DecryptErrors:        metrics.NewPromCounter(m.DecryptErrorsTotal),
+		CombineDuration:      metrics.NewPromHistogram(m.ShareCombineDuration),
 	}
 }
 
 func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
-	deviceManager control.DeviceManager, metrics *Metrics, numberOfPathsT int, aesKey string) error {
+	deviceManager control.DeviceManager, classes []dataplane.IngressClass, metrics *Metrics,
+	framePolicies *dataplane.FramePolicies, keys dataplane.KeyFactory, frameType uint8,
+	replyPaths *dataplane.ReplyPaths, capturer *dataplane.Capturer) error {
 
 	logger := log.FromCtx(ctx)
 	dataplaneServerConn, err := scionNetwork.Listen(
@@ -821,11 +985,15 @@ func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr
 	}
 	ingressMetrics := CreateIngressMetrics(metrics)
 	ingressServer := &dataplane.IngressServer{
-		Conn:           dataplaneServerConn,
-		DeviceManager:  deviceManager,
-		Metrics:        ingressMetrics,
-		NumberOfPathsT: numberOfPathsT,
-		AESKey:         aesKey,
+		Conn:          dataplaneServerConn,
+		DeviceManager: deviceManager,
+		Classes:       classes,
+		Metrics:       ingressMetrics,
+		FramePolicies: framePolicies,
+		Keys:          keys,
+		FrameType:     frameType,
+		ReplyPaths:    replyPaths,
+		Capturer:      capturer,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -847,6 +1015,15 @@ func CreateSessionMetrics(m *Metrics) dataplane.SessionMetrics {
 		FrameBytesSent:     metrics.NewPromCounter(m.FrameBytesSentTotal),
 		FramesSent:         metrics.NewPromCounter(m.FramesSentTotal),
 		SendExternalErrors: metrics.NewPromCounter(m.SendExternalErrorsTotal),
+		SharesSent:         metrics.NewPromCounter(m.SharesSentTotal),
+		SharesThreshold:    metrics.NewPromGauge(m.SessionSharesThreshold),
+		Shares:             metrics.NewPromGauge(m.SessionShares),
+		IPPktsRateLimited:  metrics.NewPromCounter(m.IPPktsRateLimitedTotal),
+		IPPktsQueueDropped: metrics.NewPromCounter(m.IPPktsQueueDroppedTotal),
+		FramesQueueDropped: metrics.NewPromCounter(m.FramesQueueDroppedTotal),
+		CoverFramesSent:    metrics.NewPromCounter(m.CoverFramesSentTotal),
+		IPPktsTooBig:       metrics.NewPromCounter(m.IPPktsTooBigTotal),
+		FramesNoKey:        metrics.NewPromCounter(m.FramesNoKeyTotal),
 	}
 }
 
@@ -863,8 +1040,12 @@ func CreateEngineMetrics(m *Metrics) control.EngineMetrics {
 	}
 }
 
-func createRouteManager(ctx context.Context,
-	deviceManager control.DeviceManager) control.PublisherFactory {
+func createRouteManager(ctx context.Context, deviceManager control.DeviceManager,
+	dummyRouting bool) control.PublisherFactory {
+
+	if dummyRouting {
+		return &routemgr.Dummy{}
+	}
 
 	linux := &routemgr.Linux{DeviceManager: deviceManager}
 	go func() {
@@ -874,10 +1055,88 @@ func createRouteManager(ctx context.Context,
 	return linux
 }
 
+// tunnelNamer returns the namer for the devices of the remote IAs that are not
+// mapped to a configured tunnel device. In IP mode, they share the device with
+// the default name. In Ethernet mode, the frames that do not carry IP packets
+// are sent to the remote IA of the device they are read from, so each remote
+// IA gets its own device.
+func tunnelNamer(defaultName string, frameType uint8) func(addr.IA) string {
+	if frameType == dataplane.FrameTypeEthernet {
+		return routemgr.Base32TunnelName
+	}
+	return routemgr.FixedTunnelName(defaultName)
+}
+
+// tunnelDevices creates the device manager for the remote IAs and the ingress
+// classes for the configured tunnel devices. Remote IAs that are not mapped to
+// a device use the device named by fallback. The devices are opened with
+// openDevice, and the base options are applied to all of them.
+func tunnelDevices(ctx context.Context, fallback func(addr.IA) string,
+	devices []config.TunnelDevice,
+	openDevice func(string, ...xnet.DeviceOption) (control.Device, error),
+	base []xnet.DeviceOption,
+	reader TunnelReader) (control.DeviceManager, []dataplane.IngressClass, error) {
+
+	vrfs := make(map[string]xnet.DeviceOption)
+	names := make(map[addr.IA]string)
+	for _, d := range devices {
+		if d.VRF != "" {
+			vrfs[d.Name] = xnet.WithVRF(d.VRF, d.VRFTable)
+		}
+		if d.TrafficClass != "" {
+			continue
+		}
+		for _, ia := range d.RemoteIAs {
+			names[ia] = d.Name
+		}
+	}
+	opener := xnet.DeviceOpenerFunc(func(name string) (control.Device, error) {
+		options := append([]xnet.DeviceOption{}, base...)
+		if vrf, ok := vrfs[name]; ok {
+			options = append(options, vrf)
+		}
+		return openDevice(name, options...)
+	})
+
+	namer := routemgr.MappedTunnelName(names, fallback)
+	iaReader := reader
+	iaReader.DeviceOpener = xnet.UseNameResolver(namer, opener)
+	deviceManager := &routemgr.NamedDeviceManager{
+		Namer:        namer,
+		DeviceOpener: iaReader.GetDeviceOpenerWithAsyncReader(ctx),
+	}
+
+	var classes []dataplane.IngressClass
+	for _, d := range devices {
+		matcher, err := d.Matcher()
+		if err != nil {
+			return nil, nil, err
+		}
+		if matcher == nil {
+			continue
+		}
+		classReader := reader
+		classReader.DeviceOpener = xnet.UseNameResolver(
+			routemgr.FixedTunnelName(d.Name), opener)
+		classes = append(classes, dataplane.IngressClass{
+			IAs:     d.RemoteIAs,
+			Matcher: matcher,
+			DeviceManager: &routemgr.SingleDeviceManager{
+				DeviceOpener: classReader.GetDeviceOpenerWithAsyncReader(ctx),
+			},
+		})
+	}
+	return deviceManager, classes, nil
+}
+
 type TunnelReader struct {
 	DeviceOpener control.DeviceOpener
 	Router       control.RoutingTableReader
-	Metrics      dataplane.IPForwarderMetrics
+	// FrameType is the type of the frames read from the devices. For
+	// dataplane.FrameTypeEthernet, Router must implement
+	// dataplane.EthernetRoutingTableReader.
+	FrameType uint8
+	Metrics   dataplane.IPForwarderMetrics
 }
 
 func (r *TunnelReader) GetDeviceOpenerWithAsyncReader(ctx context.Context) control.DeviceOpener {
@@ -888,11 +1147,24 @@ func (r *TunnelReader) GetDeviceOpenerWithAsyncReader(ctx context.Context) contr
 			return nil, serrors.WrapStr("opening device", err)
 		}
 
-		forwarder := &dataplane.IPForwarder{
+		var forwarder interface{ Run(context.Context) error } = &dataplane.IPForwarder{
 			Reader:       handle,
 			RoutingTable: r.Router,
 			Metrics:      r.Metrics,
 		}
+		if r.FrameType == dataplane.FrameTypeEthernet {
+			router, ok := r.Router.(dataplane.EthernetRoutingTableReader)
+			if !ok {
+				handle.Close()
+				return nil, serrors.New("routing table does not support Ethernet frames")
+			}
+			forwarder = &dataplane.EthernetForwarder{
+				Reader:       handle,
+				RoutingTable: router,
+				RemoteIA:     ia,
+				Metrics:      r.Metrics,
+			}
+		}
 
 		go func() {
 			defer log.HandlePanic()
@@ -0,0 +1,192 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/routemgr"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/config"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+	"github.com/scionproto/scion/go/pkg/gateway/xnet"
+)
+
+func TestTunnelDevicesEthernet(t *testing.T) {
+	ia1 := xtest.MustParseIA("1-ff00:0:110")
+	ia2 := xtest.MustParseIA("1-ff00:0:111")
+	arp := arpFrame(t)
+
+	testCases := map[string]struct {
+		FrameType uint8
+		Devices   []config.TunnelDevice
+		Expected  []string
+	}{
+		"ip mode shares the default device": {
+			FrameType: dataplane.FrameTypeIP,
+			Expected:  []string{"sig"},
+		},
+		"ethernet mode opens a device per IA": {
+			FrameType: dataplane.FrameTypeEthernet,
+			Expected:  []string{routemgr.Base32TunnelName(ia1), routemgr.Base32TunnelName(ia2)},
+		},
+		"ethernet mode with mapped device": {
+			FrameType: dataplane.FrameTypeEthernet,
+			Devices:   []config.TunnelDevice{{Name: "sig-a", RemoteIAs: []addr.IA{ia1}}},
+			Expected:  []string{"sig-a", routemgr.Base32TunnelName(ia2)},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var mtx sync.Mutex
+			var opened []string
+			open := func(name string, _ ...xnet.DeviceOption) (control.Device, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				opened = append(opened, name)
+				return newFakeDevice(arp), nil
+			}
+			router := &ethernetRouter{routed: make(chan addr.IA, 2)}
+			dm, _, err := tunnelDevices(ctx, tunnelNamer("sig", tc.FrameType), tc.Devices,
+				open, nil, TunnelReader{Router: router, FrameType: tc.FrameType})
+			require.NoError(t, err)
+
+			for _, ia := range []addr.IA{ia1, ia2} {
+				h, err := dm.Get(ctx, ia)
+				require.NoError(t, err)
+				defer h.Close()
+			}
+			mtx.Lock()
+			assert.ElementsMatch(t, tc.Expected, opened)
+			mtx.Unlock()
+			if tc.FrameType != dataplane.FrameTypeEthernet {
+				return
+			}
+
+			// The ARP frame read from each device is sent to the IA of the
+			// device.
+			var routed []addr.IA
+			for i := 0; i < 2; i++ {
+				select {
+				case ia := <-router.routed:
+					routed = append(routed, ia)
+				case <-time.After(time.Second):
+					t.Fatal("frame not routed")
+				}
+			}
+			assert.ElementsMatch(t, []addr.IA{ia1, ia2}, routed)
+		})
+	}
+}
+
+func arpFrame(t *testing.T) []byte {
+	mac := net.HardwareAddr{0x02, 0, 0, 0, 0, 1}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       mac,
+			DstMAC:       layers.EthernetBroadcast,
+			EthernetType: layers.EthernetTypeARP,
+		},
+		&layers.ARP{
+			AddrType:          layers.LinkTypeEthernet,
+			Protocol:          layers.EthernetTypeIPv4,
+			HwAddressSize:     6,
+			ProtAddressSize:   4,
+			Operation:         layers.ARPRequest,
+			SourceHwAddress:   mac,
+			SourceProtAddress: []byte{192, 0, 2, 1},
+			DstHwAddress:      make([]byte, 6),
+			DstProtAddress:    []byte{192, 0, 2, 2},
+		},
+	)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// fakeDevice yields the frame on the first read, and blocks subsequent reads
+// until it is closed.
+type fakeDevice struct {
+	frames chan []byte
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeDevice(frame []byte) *fakeDevice {
+	d := &fakeDevice{frames: make(chan []byte, 1), closed: make(chan struct{})}
+	d.frames <- frame
+	return d
+}
+
+func (d *fakeDevice) Read(b []byte) (int, error) {
+	select {
+	case f := <-d.frames:
+		return copy(b, f), nil
+	case <-d.closed:
+		return 0, io.EOF
+	}
+}
+
+func (d *fakeDevice) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (d *fakeDevice) Close() error {
+	d.once.Do(func() { close(d.closed) })
+	return nil
+}
+
+func (d *fakeDevice) AddRoute(context.Context, *control.Route) error {
+	return nil
+}
+
+func (d *fakeDevice) DeleteRoute(context.Context, *control.Route) error {
+	return nil
+}
+
+// ethernetRouter records the remote IAs of the routed Ethernet frames.
+type ethernetRouter struct {
+	routed chan addr.IA
+}
+
+func (r *ethernetRouter) RouteIPv4(layers.IPv4) control.PktWriter {
+	return nil
+}
+
+func (r *ethernetRouter) RouteIPv6(layers.IPv6) control.PktWriter {
+	return nil
+}
+
+func (r *ethernetRouter) RouteEthernet(ia addr.IA, _ gopacket.Layer) control.PktWriter {
+	r.routed <- ia
+	return nil
+}
@@ -116,6 +116,86 @@ var (
 		Help:   "Total number of errors when receiving IP packets from the network (LAN).",
 		Labels: []string{"isd_as"},
 	}
+	IPPktsRateLimitedTotalMeta = MetricMeta{
+		Name: "gateway_ippkts_rate_limited_total",
+		Help: "Total number of IP packets dropped because the rate limit of the session or " +
+			"the traffic class was exceeded.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id", "class"},
+	}
+	IPPktsQueueDroppedTotalMeta = MetricMeta{
+		Name: "gateway_ippkts_queue_dropped_total",
+		Help: "Total number of IP packets dropped by CoDel because they were queued too long, " +
+			"or because the queue was full.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id", "class"},
+	}
+	FramesQueueDroppedTotalMeta = MetricMeta{
+		Name: "gateway_frames_queue_dropped_total",
+		Help: "Total number of frames and shares dropped because the queue of their path " +
+			"was full or no path was available.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
+	CoverFramesSentTotalMeta = MetricMeta{
+		Name:   "gateway_cover_frames_sent_total",
+		Help:   "Total number of dummy frames sent as cover traffic to remote gateways.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
+	IPPktsTooBigTotalMeta = MetricMeta{
+		Name: "gateway_ippkts_too_big_total",
+		Help: "Total number of IP packets dropped because they exceed the path MTU and " +
+			"answered with ICMP errors.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
+	FramesNoKeyTotalMeta = MetricMeta{
+		Name:   "gateway_frames_no_key_total",
+		Help:   "Total number of frames dropped because no encryption key was available.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
+	SharesSentTotalMeta = MetricMeta{
+		Name:   "gateway_shares_sent_total",
+		Help:   "Total number of secret shares sent to remote gateways per share index.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id", "share_index"},
+	}
+	SharesReceivedTotalMeta = MetricMeta{
+		Name:   "gateway_shares_received_total",
+		Help:   "Total number of secret shares received from remote gateways per share index.",
+		Labels: []string{"isd_as", "remote_isd_as", "share_index"},
+	}
+	SharesLostTotalMeta = MetricMeta{
+		Name:   "gateway_shares_lost_total",
+		Help:   "Total number of secret shares from remote gateways lost per share index.",
+		Labels: []string{"isd_as", "remote_isd_as", "share_index"},
+	}
+	ShareGroupsCombinedTotalMeta = MetricMeta{
+		Name:   "gateway_share_groups_combined_total",
+		Help:   "Total number of share groups combined into frames.",
+		Labels: []string{"isd_as", "remote_isd_as"},
+	}
+	ShareGroupsExpiredTotalMeta = MetricMeta{
+		Name:   "gateway_share_groups_expired_total",
+		Help:   "Total number of share groups expired with fewer than T shares.",
+		Labels: []string{"isd_as", "remote_isd_as"},
+	}
+	DecryptErrorsTotalMeta = MetricMeta{
+		Name:   "gateway_decrypt_errors_total",
+		Help:   "Total number of combined frames that failed to decrypt.",
+		Labels: []string{"isd_as", "remote_isd_as"},
+	}
+	ShareCombineDurationMeta = MetricMeta{
+		Name: "gateway_share_combine_duration_seconds",
+		Help: "Time between receiving the first share of a group and combining the group " +
+			"into a frame.",
+		Labels: []string{"isd_as", "remote_isd_as"},
+	}
+	SessionSharesThresholdMeta = MetricMeta{
+		Name:   "gateway_session_shares_threshold",
+		Help:   "Number of shares (T) needed to reconstruct a frame of the session.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
+	SessionSharesMeta = MetricMeta{
+		Name:   "gateway_session_shares",
+		Help:   "Number of shares (N) each frame of the session is split into.",
+		Labels: []string{"isd_as", "remote_isd_as", "policy_id"},
+	}
 	PathsMonitoredMeta = MetricMeta{
 		Name:   "gateway_paths_monitored",
 		Help:   "Total number of paths being monitored by the gateway.",
@@ -214,6 +294,18 @@ func (mm *MetricMeta) NewGaugeVec() *prometheus.GaugeVec {
 	)
 }
 
+// NewHistogramVec creates a histogram vector with the given buckets.
+func (mm *MetricMeta) NewHistogramVec(buckets []float64) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    mm.Name,
+			Help:    mm.Help,
+			Buckets: buckets,
+		},
+		mm.Labels,
+	)
+}
+
 // Metrics defines the metrics exported by the gateway.
 type Metrics struct {
 	// Traffic Metrics
@@ -237,6 +329,23 @@ type Metrics struct {
 	SendLocalErrorsTotal       *prometheus.CounterVec
 	ReceiveExternalErrorsTotal *prometheus.CounterVec
 	ReceiveLocalErrorsTotal    *prometheus.CounterVec
+	IPPktsRateLimitedTotal     *prometheus.CounterVec
+	IPPktsQueueDroppedTotal    *prometheus.CounterVec
+	FramesQueueDroppedTotal    *prometheus.CounterVec
+	CoverFramesSentTotal       *prometheus.CounterVec
+	IPPktsTooBigTotal          *prometheus.CounterVec
+	FramesNoKeyTotal           *prometheus.CounterVec
+
+	// Secret Sharing Metrics
+	SharesSentTotal          *prometheus.CounterVec
+	SharesReceivedTotal      *prometheus.CounterVec
+	SharesLostTotal          *prometheus.CounterVec
+	ShareGroupsCombinedTotal *prometheus.CounterVec
+	ShareGroupsExpiredTotal  *prometheus.CounterVec
+	DecryptErrorsTotal       *prometheus.CounterVec
+	ShareCombineDuration     *prometheus.HistogramVec
+	SessionSharesThreshold   *prometheus.GaugeVec
+	SessionShares            *prometheus.GaugeVec
 
 	// Path Monitoring Metrics
 	PathsMonitored        *prometheus.GaugeVec
@@ -307,6 +416,37 @@ func NewMetrics(ia addr.IA) *Metrics {
 			NewCounterVec().MustCurryWith(labels),
 		ReceiveLocalErrorsTotal: ReceiveLocalErrorsTotalMeta.
 			NewCounterVec().MustCurryWith(labels),
+		IPPktsRateLimitedTotal: IPPktsRateLimitedTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		IPPktsQueueDroppedTotal: IPPktsQueueDroppedTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		FramesQueueDroppedTotal: FramesQueueDroppedTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		CoverFramesSentTotal: CoverFramesSentTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		IPPktsTooBigTotal: IPPktsTooBigTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		FramesNoKeyTotal: FramesNoKeyTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		SharesSentTotal: SharesSentTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		SharesReceivedTotal: SharesReceivedTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		SharesLostTotal: SharesLostTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		ShareGroupsCombinedTotal: ShareGroupsCombinedTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		ShareGroupsExpiredTotal: ShareGroupsExpiredTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		DecryptErrorsTotal: DecryptErrorsTotalMeta.
+			NewCounterVec().MustCurryWith(labels),
+		ShareCombineDuration: ShareCombineDurationMeta.
+			NewHistogramVec(prometheus.ExponentialBuckets(0.0001, 2, 16)).
+			MustCurryWith(labels).(*prometheus.HistogramVec),
+		SessionSharesThreshold: SessionSharesThresholdMeta.
+			NewGaugeVec().MustCurryWith(labels),
+		SessionShares: SessionSharesMeta.
+			NewGaugeVec().MustCurryWith(labels),
 		PathsMonitored: PathsMonitoredMeta.
 			NewGaugeVec().MustCurryWith(labels),
 		PathProbesSent: PathProbesSentMeta.
@@ -150,13 +150,7 @@ func BuildGraphAndFindPaths(paths []snet.Path, numberOfPaths int) []snet.Path {
 		return prevSelectedOriginalPaths
 	}
 
-	// Build the graph
-	g := NewGraph(pathsEdgeReprs)
-	sourceNode := pathsEdgeReprs[0][0].Source
-	destinationNode := pathsEdgeReprs[0][len(pathsEdgeReprs[0])-1].Target
-
-	// Find paths
-	selectedPaths := g.FindPathsGreedy(sourceNode, destinationNode, numberOfPaths)
+	selectedPaths := findPathsGreedy(pathsEdgeReprs, numberOfPaths)
 
 	// Match the selectedPaths back to the original paths
 	returnOriginalPaths := make([]snet.Path, 0, len(selectedPaths))
@@ -180,6 +174,33 @@ func BuildGraphAndFindPaths(paths []snet.Path, numberOfPaths int) []snet.Path {
 	return returnOriginalPaths
 }
 
+// FindPaths selects numberOfPaths paths greedily, like BuildGraphAndFindPaths.
+// Unlike the latter, it neither caches nor prints the selection, so it can be
+// called concurrently.
+func FindPaths(paths []snet.Path, numberOfPaths int) []snet.Path {
+	if len(paths) == 0 {
+		return nil
+	}
+	pathsEdgeReprs := make([][]Edge, len(paths))
+	for i, path := range paths {
+		pathsEdgeReprs[i] = pathToEdgeRepresentation(path)
+	}
+	selected := make([]snet.Path, 0, numberOfPaths)
+	for _, p := range findPathsGreedy(pathsEdgeReprs, numberOfPaths) {
+		selected = append(selected, matchPathWithOriginalPaths(p, paths))
+	}
+	return selected
+}
+
+// findPathsGreedy builds the graph of the paths and finds numberOfPaths paths
+// in it.
+func findPathsGreedy(pathsEdgeReprs [][]Edge, numberOfPaths int) [][]Edge {
+	g := NewGraph(pathsEdgeReprs)
+	sourceNode := pathsEdgeReprs[0][0].Source
+	destinationNode := pathsEdgeReprs[0][len(pathsEdgeReprs[0])-1].Target
+	return g.FindPathsGreedy(sourceNode, destinationNode, numberOfPaths)
+}
+
 func isSamePathSet(paths1, paths2 [][]Edge) bool {
 
 	if paths1 == nil || paths2 == nil {
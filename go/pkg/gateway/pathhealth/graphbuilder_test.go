@@ -3,6 +3,7 @@ package pathhealth_test
 import (
 	"testing"
 
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +36,15 @@ func TestGraphBuilder(t *testing.T) {
 	assert.InDelta(t, 0.109, proba, 0.0001) // Use assert.InDelta for floating-point comparison with a tolerance of 0.001
 
 }
+
+func TestFindPaths(t *testing.T) {
+	paths := []snet.Path{
+		newSelectorTestPath("1-ff00:0:120", 1),
+		newSelectorTestPath("1-ff00:0:120", 2),
+		newSelectorTestPath("1-ff00:0:121", 3),
+	}
+	selected := pathhealth.FindPaths(paths, 2)
+	assert.Equal(t, []snet.Path{paths[0], paths[2]}, selected, "paths via different ASes")
+	assert.Equal(t, selected, pathhealth.FindPaths(paths, 2), "same selection on repeated calls")
+	assert.Empty(t, pathhealth.FindPaths(nil, 2))
+}
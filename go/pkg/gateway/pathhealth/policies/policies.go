@@ -24,6 +24,12 @@ type PathPolicy interface {
 	Filter(paths []snet.Path) []snet.Path
 }
 
+// SetPolicy selects a set of paths that satisfies constraints over the whole
+// set.
+type SetPolicy interface {
+	Select(paths []snet.Path, n int) []snet.Path
+}
+
 type PerfPolicy interface {
 	// Better is a function that takes two paths and decides whether the first
 	// one is "better" according to the policy.
@@ -55,6 +61,9 @@ type Stats struct {
 type Policies struct {
 	// Path policies are used to determine which paths are eligible and which are not.
 	PathPolicy PathPolicy
+	// SetPolicy is used to determine which sets of paths can be used together.
+	// If set to nil, any set of eligible paths can be used.
+	SetPolicy SetPolicy
 	// PerfPolicy determines how to select a path if there are several eligible
 	// ones. If set to nil, arbitrary path is chosen.
 	PerfPolicy PerfPolicy
@@ -27,6 +27,13 @@ const (
 	deadInfo = "dead (probes are not passing through)"
 	// rejectedInfo is a string to log about paths rejected by path policies.
 	rejectedInfo = "rejected by path policy"
+	// setRejectedInfo is a string to log if no set of paths satisfies the set
+	// policy.
+	setRejectedInfo = "no set of %d link-disjoint paths satisfies the set policy"
+
+	// maxDisjointSets is the maximum number of sets of link-disjoint paths that
+	// are evaluated against the set policy before giving up.
+	maxDisjointSets = 10000
 )
 
 // PathPolicy filters the set of paths.
@@ -34,10 +41,22 @@ type PathPolicy interface {
 	Filter(paths []snet.Path) []snet.Path
 }
 
+// SetPolicy selects a set of paths that satisfies constraints over the whole
+// set.
+type SetPolicy interface {
+	// Select returns n paths out of the candidates, preferring the candidates
+	// that come first. It returns nil if no set satisfies the policy.
+	Select(paths []snet.Path, n int) []snet.Path
+}
+
 // FilteringPathSelector selects the best paths from a filtered set of paths.
 type FilteringPathSelector struct {
 	// PathPolicy is used to determine which paths are eligible and which are not.
 	PathPolicy PathPolicy
+	// SetPolicy is used to determine which sets of paths can be used together.
+	// If it is set, only sets of pairwise link-disjoint paths are considered.
+	// If it is nil, any set of paths is accepted.
+	SetPolicy SetPolicy
 	// RevocationStore keeps track of the revocations.
 	RevocationStore
 	// PathCount is the max number of paths to return to the user. Defaults to 1.
@@ -136,6 +155,14 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 		paths = append(paths, allowed[i].Path)
 	}
 	selectedPaths := BuildGraphAndFindPaths(paths, pathCount)
+	if f.SetPolicy != nil {
+		selectedPaths = selectDisjointSet(f.SetPolicy, setCandidates(selectedPaths, paths),
+			pathCount)
+		if selectedPaths == nil {
+			info = append(info, fmt.Sprintf(setRejectedInfo, pathCount))
+			selectedPaths = make([]snet.Path, 0)
+		}
+	}
 
 	return Selection{
 		Paths:         selectedPaths,
@@ -146,6 +173,100 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 	}
 }
 
+// setCandidates returns all paths, ordered such that the selected paths come
+// first, followed by the remaining paths in their original order.
+func setCandidates(selected, paths []snet.Path) []snet.Path {
+	candidates := make([]snet.Path, 0, len(paths))
+	candidates = append(candidates, selected...)
+	isSelected := make(map[snet.PathFingerprint]struct{}, len(selected))
+	for _, path := range selected {
+		isSelected[snet.Fingerprint(path)] = struct{}{}
+	}
+	for _, path := range paths {
+		if _, ok := isSelected[snet.Fingerprint(path)]; !ok {
+			candidates = append(candidates, path)
+		}
+	}
+	return candidates
+}
+
+// selectDisjointSet returns the first set of n pairwise link-disjoint paths
+// that satisfies the set policy. The sets are considered in lexicographic
+// order of the indices of their paths, i.e., paths that come first are
+// preferred. It returns nil if no set satisfies the policy, or if none was
+// found after evaluating a bounded number of sets.
+func selectDisjointSet(policy SetPolicy, paths []snet.Path, n int) []snet.Path {
+	if n <= 0 || len(paths) < n {
+		return nil
+	}
+	links := make([][]pathLink, 0, len(paths))
+	for _, path := range paths {
+		links = append(links, pathLinks(path))
+	}
+	used := make(map[pathLink]struct{})
+	selected := make([]snet.Path, 0, n)
+	budget := maxDisjointSets
+	var search func(start int) []snet.Path
+	search = func(start int) []snet.Path {
+		if len(selected) == n {
+			budget--
+			return policy.Select(selected, n)
+		}
+		for i := start; i <= len(paths)-(n-len(selected)) && budget > 0; i++ {
+			if !disjoint(used, links[i]) {
+				continue
+			}
+			for _, l := range links[i] {
+				used[l] = struct{}{}
+			}
+			selected = append(selected, paths[i])
+			if result := search(i + 1); result != nil {
+				return result
+			}
+			selected = selected[:len(selected)-1]
+			for _, l := range links[i] {
+				delete(used, l)
+			}
+		}
+		return nil
+	}
+	return search(0)
+}
+
+// pathLink is an inter-AS link, identified by the interfaces at both ends in
+// the order they are traversed.
+type pathLink struct {
+	from, to snet.PathInterface
+}
+
+// pathLinks returns the inter-AS links of the path. Paths without interface
+// metadata have no links.
+func pathLinks(path snet.Path) []pathLink {
+	meta := path.Metadata()
+	if meta == nil {
+		return nil
+	}
+	links := make([]pathLink, 0, len(meta.Interfaces)/2)
+	for i := 0; i+1 < len(meta.Interfaces); i += 2 {
+		links = append(links, pathLink{from: meta.Interfaces[i], to: meta.Interfaces[i+1]})
+	}
+	return links
+}
+
+// disjoint returns whether none of the links is used.
+func disjoint(used map[pathLink]struct{}, links []pathLink) bool {
+	for _, l := range links {
+		if _, ok := used[l]; ok {
+			return false
+		}
+		// The link can be traversed in both directions.
+		if _, ok := used[pathLink{from: l.to, to: l.from}]; ok {
+			return false
+		}
+	}
+	return true
+}
+
 // isPathAllowed returns true is path is allowed by the policy.
 func isPathAllowed(policy PathPolicy, path snet.Path) bool {
 	if policy == nil {
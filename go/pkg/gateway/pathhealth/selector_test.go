@@ -0,0 +1,151 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
+)
+
+func TestFilteringPathSelectorSetPolicy(t *testing.T) {
+	paths := []snet.Path{
+		newSelectorTestPath("1-ff00:0:120", 1),
+		newSelectorTestPath("1-ff00:0:121", 2),
+		newSelectorTestPath("1-ff00:0:122", 3),
+	}
+	selectables := func(paths []snet.Path) []pathhealth.Selectable {
+		result := make([]pathhealth.Selectable, 0, len(paths))
+		for _, path := range paths {
+			result = append(result, testSelectable{path: path})
+		}
+		return result
+	}
+
+	t.Run("set policy selects paths", func(t *testing.T) {
+		setPolicy := &testSetPolicy{accepted: paths[1:]}
+		selector := pathhealth.FilteringPathSelector{
+			SetPolicy:       setPolicy,
+			RevocationStore: &pathhealth.MemoryRevocationStore{},
+			PathCount:       2,
+		}
+		sel := selector.Select(selectables(paths), pathhealth.FingerprintSet{})
+		assert.ElementsMatch(t, paths[1:], sel.Paths)
+		assert.Equal(t, 2, setPolicy.n)
+		for _, set := range setPolicy.evaluated {
+			assert.Len(t, set, 2)
+		}
+	})
+	t.Run("no set satisfies the set policy", func(t *testing.T) {
+		selector := pathhealth.FilteringPathSelector{
+			SetPolicy:       &testSetPolicy{},
+			RevocationStore: &pathhealth.MemoryRevocationStore{},
+			PathCount:       2,
+		}
+		sel := selector.Select(selectables(paths), pathhealth.FingerprintSet{})
+		assert.Empty(t, sel.Paths)
+		assert.NotNil(t, sel.Paths)
+		assert.Equal(t, 3, sel.PathsAlive)
+		assert.Contains(t, sel.Info, "set policy")
+	})
+	t.Run("only satisfying set overlaps", func(t *testing.T) {
+		// overlapping leaves the source AS on the same link as paths[0].
+		src, dst := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:130")
+		transit := xtest.MustParseIA("1-ff00:0:120")
+		overlapping := snetpath.Path{
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: transit, ID: 11},
+					{IA: transit, ID: 41},
+					{IA: dst, ID: 51},
+				},
+			},
+		}
+		setPolicy := &testSetPolicy{accepted: []snet.Path{paths[0], overlapping}}
+		selector := pathhealth.FilteringPathSelector{
+			SetPolicy:       setPolicy,
+			RevocationStore: &pathhealth.MemoryRevocationStore{},
+			PathCount:       2,
+		}
+		sel := selector.Select(selectables([]snet.Path{paths[0], overlapping}),
+			pathhealth.FingerprintSet{})
+		assert.Empty(t, sel.Paths)
+		assert.NotNil(t, sel.Paths)
+		assert.Empty(t, setPolicy.evaluated)
+		assert.Contains(t, sel.Info, "link-disjoint")
+	})
+}
+
+type testSelectable struct {
+	path snet.Path
+}
+
+func (s testSelectable) Path() snet.Path {
+	return s.path
+}
+
+func (s testSelectable) State() pathhealth.State {
+	return pathhealth.State{IsAlive: true}
+}
+
+// testSetPolicy accepts the set that contains the accepted paths, and records
+// the sets it evaluated.
+type testSetPolicy struct {
+	accepted  []snet.Path
+	evaluated [][]snet.Path
+	n         int
+}
+
+func (p *testSetPolicy) Select(paths []snet.Path, n int) []snet.Path {
+	p.evaluated = append(p.evaluated, append([]snet.Path(nil), paths...))
+	p.n = n
+	if len(paths) != len(p.accepted) {
+		return nil
+	}
+	for _, accepted := range p.accepted {
+		var found bool
+		for _, path := range paths {
+			found = found || snet.Fingerprint(path) == snet.Fingerprint(accepted)
+		}
+		if !found {
+			return nil
+		}
+	}
+	return paths
+}
+
+func newSelectorTestPath(transit string, id int) snet.Path {
+	src, dst := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:130")
+	ia := xtest.MustParseIA(transit)
+	return snetpath.Path{
+		Dst: dst,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: src, ID: common.IFIDType(id)},
+				{IA: ia, ID: common.IFIDType(10 + id)},
+				{IA: ia, ID: common.IFIDType(20 + id)},
+				{IA: dst, ID: common.IFIDType(30 + id)},
+			},
+		},
+	}
+}
@@ -41,6 +41,7 @@ func (pm *PathMonitor) Register(
 
 	reg := pm.Monitor.Register(remote, &pathhealth.FilteringPathSelector{
 		PathPolicy:      policies.PathPolicy,
+		SetPolicy:       policies.SetPolicy,
 		PathCount:       pm.NumberOfPathsN,
 		RevocationStore: pm.revStore,
 	})
@@ -21,6 +21,7 @@ import (
 	"context"
 	"io"
 	"net"
+	"sync"
 
 	"github.com/songgao/water"
 	"github.com/vishvananda/netlink"
@@ -40,10 +41,14 @@ const (
 	SIGTxQlen    = 1000
 )
 
-// connectTun creates (or opens) interface name, and then sets its state to up
-func connectTun(name string) (netlink.Link, io.ReadWriteCloser, error) {
+// connectTun creates (or opens) interface name, and then sets its state to up.
+// The interface is of type tap if deviceType is water.TAP, and of type tun
+// otherwise.
+func connectTun(name string, deviceType water.DeviceType) (netlink.Link, io.ReadWriteCloser,
+	error) {
+
 	tun, err := water.New(water.Config{
-		DeviceType:             water.TUN,
+		DeviceType:             deviceType,
 		PlatformSpecificParams: water.PlatformSpecificParams{Name: name}})
 	if err != nil {
 		return nil, nil, err
@@ -123,31 +128,144 @@ func open(ctx context.Context, name string, options ...DeviceOption) (control.De
 		logger.Debug("Successfully opened tun device", "name", name)
 		return &deviceHandle{
 			link:            link,
+			table:           int(o.vrfTable),
 			ReadWriteCloser: &errorReadWriteCloser{},
 		}, nil
 	}
 
-	link, rwc, err := connectTun(name)
+	var deviceType water.DeviceType = water.TUN
+	if o.tap {
+		deviceType = water.TAP
+	}
+	link, rwc, err := connectTun(name, deviceType)
 	if err != nil {
 		logger.Debug("Failed to open tun device", "name", name, "err", err)
 		return nil, err
 	}
 	logger.Debug("Successfully opened tun device", "name", name)
 
+	var vrf *vrfRef
+	if o.vrf != "" {
+		if vrf, err = enslaveToVRF(link, o.vrf, o.vrfTable); err != nil {
+			logger.Debug("Failed to add tun device to VRF", "name", name, "vrf", o.vrf,
+				"err", err)
+			rwc.Close()
+			return nil, err
+		}
+		logger.Debug("Successfully added tun device to VRF", "name", name, "vrf", o.vrf)
+	}
+
 	return &deviceHandle{
 		link:            link,
+		table:           int(o.vrfTable),
+		vrf:             vrf,
 		ReadWriteCloser: rwc,
 	}, nil
 }
 
+// createdVRFs counts, by name, the devices enslaved to the VRF devices that
+// were created by this process. A VRF device is deleted once the last of them
+// is closed.
+var createdVRFs = struct {
+	sync.Mutex
+	devices map[string]int
+}{devices: make(map[string]int)}
+
+// vrfRef is the reference of a device to a VRF device created by this process.
+type vrfRef struct {
+	name string
+	once sync.Once
+}
+
+// release drops the reference, and deletes the VRF device if it was the last
+// one. It is safe to call release more than once.
+func (r *vrfRef) release() {
+	r.once.Do(func() {
+		createdVRFs.Lock()
+		defer createdVRFs.Unlock()
+		createdVRFs.devices[r.name]--
+		if createdVRFs.devices[r.name] > 0 {
+			return
+		}
+		delete(createdVRFs.devices, r.name)
+		if link, err := netlink.LinkByName(r.name); err == nil {
+			netlink.LinkDel(link)
+		}
+	})
+}
+
+// enslaveToVRF makes link a slave of the VRF device with the given name. If
+// the VRF device does not exist, it is created with the given routing table.
+// The returned reference is nil if the VRF device was not created by this
+// process.
+func enslaveToVRF(link netlink.Link, name string, table uint32) (*vrfRef, error) {
+	createdVRFs.Lock()
+	defer createdVRFs.Unlock()
+
+	vrf, created, err := openVRF(name, table)
+	if err != nil {
+		return nil, err
+	}
+	if err := netlink.LinkSetMasterByIndex(link, vrf.Attrs().Index); err != nil {
+		// Do not leave behind a VRF device that nothing is enslaved to.
+		if created {
+			netlink.LinkDel(vrf)
+		}
+		return nil, serrors.WrapStr("unable to add device to VRF", err,
+			"name", link.Attrs().Name, "vrf", name)
+	}
+	if _, ok := createdVRFs.devices[name]; !ok && !created {
+		return nil, nil
+	}
+	createdVRFs.devices[name]++
+	return &vrfRef{name: name}, nil
+}
+
+// openVRF returns the VRF device with the given name, creating it if
+// necessary, and whether it was created. An existing VRF device must use the
+// given routing table.
+func openVRF(name string, table uint32) (netlink.Link, bool, error) {
+	link, err := netlink.LinkByName(name)
+	if err == nil {
+		vrf, ok := link.(*netlink.Vrf)
+		if !ok {
+			return nil, false, serrors.New("existing device is not a VRF", "name", name,
+				"type", link.Type())
+		}
+		if vrf.Table != table {
+			return nil, false, serrors.New("existing VRF uses different routing table",
+				"name", name, "expected", table, "actual", vrf.Table)
+		}
+		return vrf, false, nil
+	}
+	vrf := &netlink.Vrf{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		Table:     table,
+	}
+	if err := netlink.LinkAdd(vrf); err != nil {
+		return nil, false, serrors.WrapStr("unable to create VRF device", err, "name", name)
+	}
+	if err := netlink.LinkSetUp(vrf); err != nil {
+		netlink.LinkDel(vrf)
+		return nil, false, serrors.WrapStr("unable to set VRF device Up", err, "name", name)
+	}
+	return vrf, true, nil
+}
+
 type deviceHandle struct {
 	link netlink.Link
+	// table is the routing table routes are added to. If 0, the main table is
+	// used.
+	table int
+	// vrf is the reference to the VRF device the device is enslaved to, if
+	// the VRF device was created by this process.
+	vrf *vrfRef
 	io.ReadWriteCloser
 }
 
 func (h deviceHandle) AddRoute(ctx context.Context, r *control.Route) error {
 	logger := log.FromCtx(ctx)
-	err := addRoute(0, h.link, r.Prefix, r.Source)
+	err := addRoute(h.table, h.link, r.Prefix, r.Source)
 	if err != nil {
 		logger.Debug("Failed to add route", "tun", h.link.Attrs().Name, "route", r, "err", err)
 		return err
@@ -158,7 +276,7 @@ func (h deviceHandle) AddRoute(ctx context.Context, r *control.Route) error {
 
 func (h deviceHandle) DeleteRoute(ctx context.Context, r *control.Route) error {
 	logger := log.FromCtx(ctx)
-	err := deleteRoute(0, h.link, r.Prefix, r.Source)
+	err := deleteRoute(h.table, h.link, r.Prefix, r.Source)
 	if err != nil {
 		logger.Debug("Failed to delete route", "tun", h.link.Attrs().Name, "route", r, "err", err)
 		return err
@@ -168,7 +286,11 @@ func (h deviceHandle) DeleteRoute(ctx context.Context, r *control.Route) error {
 }
 
 func (h deviceHandle) Close() error {
-	return h.ReadWriteCloser.Close()
+	err := h.ReadWriteCloser.Close()
+	if h.vrf != nil {
+		h.vrf.release()
+	}
+	return err
 }
 
 func addRoute(rTable int, link netlink.Link, dest *net.IPNet, src net.IP) error {
@@ -207,6 +329,9 @@ func deleteRoute(rTable int, link netlink.Link, dest *net.IPNet, src net.IP) err
 
 type deviceOptions struct {
 	routingOnlyNoCreate bool
+	vrf                 string
+	vrfTable            uint32
+	tap                 bool
 }
 
 type DeviceOption func(*deviceOptions)
@@ -229,6 +354,27 @@ func WithRoutingOnlyNoCreate() DeviceOption {
 	}
 }
 
+// WithVRF signals to add the device to the Linux VRF with the given name, and to
+// install routes in the VRF routing table. The VRF device is created if it
+// does not exist, and then deleted again when the last device opened in it is
+// closed. For devices opened with WithRoutingOnlyNoCreate, the device
+// is expected to already be part of the VRF and only the routing table is
+// used.
+func WithVRF(name string, table uint32) DeviceOption {
+	return func(o *deviceOptions) {
+		o.vrf = name
+		o.vrfTable = table
+	}
+}
+
+// WithTAP signals to create a device of type tap instead of tun. Reading from
+// and writing to the device yields Ethernet frames instead of IP packets.
+func WithTAP() DeviceOption {
+	return func(o *deviceOptions) {
+		o.tap = true
+	}
+}
+
 type errorReadWriteCloser struct{}
 
 func (*errorReadWriteCloser) Read(b []byte) (int, error) {
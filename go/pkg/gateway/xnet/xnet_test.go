@@ -0,0 +1,164 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xnet_test
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/xnet"
+)
+
+// inNetNS runs f in a fresh network namespace. The test is skipped if the
+// namespace cannot be created, e.g., because the test does not run as root.
+func inNetNS(t *testing.T, f func(t *testing.T)) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	orig, err := netns.Get()
+	require.NoError(t, err)
+	defer orig.Close()
+	ns, err := netns.New()
+	if err != nil {
+		t.Skipf("unable to create network namespace: %v", err)
+	}
+	defer ns.Close()
+	defer netns.Set(orig)
+	f(t)
+}
+
+func TestOpen(t *testing.T) {
+	inNetNS(t, func(t *testing.T) {
+		dev, err := xnet.OpenerWithOptions(context.Background()).Open("tun-test")
+		if err != nil {
+			t.Skipf("unable to create TUN device: %v", err)
+		}
+		defer dev.Close()
+
+		link, err := netlink.LinkByName("tun-test")
+		require.NoError(t, err)
+		assert.Equal(t, 0, link.Attrs().MasterIndex)
+
+		route := &control.Route{Prefix: mustParseCIDR(t, "192.0.2.0/24")}
+		require.NoError(t, dev.AddRoute(context.Background(), route))
+		routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4,
+			&netlink.Route{LinkIndex: link.Attrs().Index}, netlink.RT_FILTER_OIF)
+		require.NoError(t, err)
+		assert.Len(t, routes, 1)
+		require.NoError(t, dev.DeleteRoute(context.Background(), route))
+	})
+}
+
+func TestOpenWithTAP(t *testing.T) {
+	inNetNS(t, func(t *testing.T) {
+		dev, err := xnet.OpenerWithOptions(context.Background(), xnet.WithTAP()).Open("tap-test")
+		if err != nil {
+			t.Skipf("unable to create TAP device: %v", err)
+		}
+		defer dev.Close()
+
+		link, err := netlink.LinkByName("tap-test")
+		require.NoError(t, err)
+		assert.Equal(t, "ether", link.Attrs().EncapType)
+	})
+}
+
+func TestOpenWithVRF(t *testing.T) {
+	inNetNS(t, func(t *testing.T) {
+		opener := xnet.OpenerWithOptions(context.Background(), xnet.WithVRF("vrf-test", 100))
+		dev, err := opener.Open("tun-a")
+		if err != nil {
+			t.Skipf("unable to create TUN device in VRF: %v", err)
+		}
+		defer dev.Close()
+
+		vrf, err := netlink.LinkByName("vrf-test")
+		require.NoError(t, err)
+		require.IsType(t, &netlink.Vrf{}, vrf)
+		assert.Equal(t, uint32(100), vrf.(*netlink.Vrf).Table)
+
+		link, err := netlink.LinkByName("tun-a")
+		require.NoError(t, err)
+		assert.Equal(t, vrf.Attrs().Index, link.Attrs().MasterIndex)
+
+		route := &control.Route{Prefix: mustParseCIDR(t, "192.0.2.0/24")}
+		require.NoError(t, dev.AddRoute(context.Background(), route))
+		routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4,
+			&netlink.Route{Table: 100}, netlink.RT_FILTER_TABLE)
+		require.NoError(t, err)
+		var found bool
+		for _, r := range routes {
+			if r.Dst != nil && r.Dst.String() == "192.0.2.0/24" {
+				found = true
+			}
+		}
+		assert.True(t, found, "route not found in VRF table")
+
+		// The subtests below are not run with t.Run, as that would run them on
+		// a goroutine outside of the network namespace.
+
+		// A second device shares the VRF.
+		devB, err := opener.Open("tun-b")
+		require.NoError(t, err)
+		link, err = netlink.LinkByName("tun-b")
+		require.NoError(t, err)
+		assert.Equal(t, vrf.Attrs().Index, link.Attrs().MasterIndex)
+
+		// A VRF with a different table is rejected.
+		_, err = xnet.OpenerWithOptions(context.Background(),
+			xnet.WithVRF("vrf-test", 200)).Open("tun-c")
+		assert.Error(t, err)
+
+		// The VRF is deleted with the last device.
+		require.NoError(t, dev.Close())
+		_, err = netlink.LinkByName("vrf-test")
+		assert.NoError(t, err, "VRF deleted while a device is enslaved")
+		require.NoError(t, devB.Close())
+		_, err = netlink.LinkByName("vrf-test")
+		assert.Error(t, err, "VRF not deleted")
+	})
+}
+
+func TestOpenWithExistingVRF(t *testing.T) {
+	inNetNS(t, func(t *testing.T) {
+		vrf := &netlink.Vrf{LinkAttrs: netlink.LinkAttrs{Name: "vrf-test"}, Table: 100}
+		if err := netlink.LinkAdd(vrf); err != nil {
+			t.Skipf("unable to create VRF device: %v", err)
+		}
+		dev, err := xnet.OpenerWithOptions(context.Background(),
+			xnet.WithVRF("vrf-test", 100)).Open("tun-a")
+		if err != nil {
+			t.Skipf("unable to create TUN device in VRF: %v", err)
+		}
+		require.NoError(t, dev.Close())
+
+		_, err = netlink.LinkByName("vrf-test")
+		assert.NoError(t, err, "VRF that existed before deleted")
+	})
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
@@ -148,6 +148,68 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetDrops lists the recently dropped packets.
+func (s *Server) GetDrops(w http.ResponseWriter, r *http.Request, params GetDropsParams) {
+	droppedPackets, err := s.Dataplane.ListDroppedPackets()
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting dropped packets",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	drops := make([]DroppedPacket, 0, len(droppedPackets))
+	for _, pkt := range droppedPackets {
+		if params.Reason != nil && string(*params.Reason) != pkt.Reason {
+			continue
+		}
+		drop := DroppedPacket{
+			InterfaceId: int(pkt.Interface),
+			Length:      pkt.Length,
+			Reason:      DropReason(pkt.Reason),
+			Time:        pkt.Time,
+		}
+		if pkt.Error != "" {
+			drop.Error = api.StringRef(pkt.Error)
+		}
+		if pkt.PathType != "" {
+			drop.PathType = api.StringRef(pkt.PathType)
+		}
+		if !pkt.SrcIA.IsZero() {
+			srcIA := IsdAs(pkt.SrcIA.String())
+			drop.SrcIsdAs = &srcIA
+		}
+		if !pkt.DstIA.IsZero() {
+			dstIA := IsdAs(pkt.DstIA.String())
+			drop.DstIsdAs = &dstIA
+		}
+		if pkt.SrcHost != "" {
+			drop.SrcHost = api.StringRef(pkt.SrcHost)
+		}
+		if pkt.DstHost != "" {
+			drop.DstHost = api.StringRef(pkt.DstHost)
+		}
+		drops = append(drops, drop)
+	}
+
+	rep := DropsResponse{
+		Drops: drops,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
 // Error creates an detailed error response.
 func Error(w http.ResponseWriter, p Problem) {
 	w.Header().Set("Content-Type", "application/problem+json")
@@ -118,6 +118,36 @@ func TestAPI(t *testing.T) {
 			ResponseFile: "testdata/interfaces-sibling-error.json",
 			Status:       500,
 		},
+		"drops": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().ListDroppedPackets().Return(
+					createDroppedPackets(), nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/drops",
+			ResponseFile: "testdata/drops.json",
+			Status:       200,
+		},
+		"drops by reason": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().ListDroppedPackets().Return(
+					createDroppedPackets(), nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/drops?reason=invalid_mac",
+			ResponseFile: "testdata/drops-reason.json",
+			Status:       200,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -270,3 +300,26 @@ func createSiblingIntfs(t *testing.T) []control.SiblingInterface {
 		},
 	}
 }
+
+func createDroppedPackets() []control.DroppedPacket {
+	return []control.DroppedPacket{
+		{
+			Time:      time.Date(2021, 7, 1, 12, 0, 1, 0, time.UTC),
+			Interface: 1,
+			Reason:    "invalid_mac",
+			Error:     "invalid MAC",
+			SrcIA:     xtest.MustParseIA("1-ff00:0:111"),
+			DstIA:     xtest.MustParseIA("1-ff00:0:110"),
+			SrcHost:   "10.0.0.1",
+			DstHost:   "10.0.0.2",
+			PathType:  "SCION (1)",
+			Length:    1200,
+		},
+		{
+			Time:      time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC),
+			Interface: 0,
+			Reason:    "queue_full",
+			Length:    12,
+		},
+	}
+}
@@ -18,45 +18,53 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ33PbNhL+VzBoH5op9cNOem305thOq5kk1lj29KH1eSBiRaIGARYAZet8+t9vFiAp",
-	"/rLs5K7tXJ9skQD2w7ffLnbBRxrrLNcKlLN09kgN2FwrC/7HO8Yv4fcCrMNfsVYOlP+X5bkUMXNCq8lv",
-	"Vit8ZuMUMob/fW1gTWf0q8l+6Ul4aydLxxRnhp8bow3d7XYR5WBjI3JcjM7QJjGlUXxbTvRw3p/hn9zo",
-	"HIwTASMHKwzw20wokRXZrXu4FcqB2TBZvm4sfpUCKQeSahRZgbsHUMQZpmwmrBVaEb0m796fEdyz0ZLk",
-	"LL4DZ4lLmSMuBYIQmNOGBPt2TK5SYcmGyQKIsITxDWK0wInTfkYOYCKS6nvYgPFPWOwKJvdAChwtLLE5",
-	"xGItgJPVljh2J1Tix2fswSPX69IqH5WbGbmHUb0MU9wPD1j02v8wkGkHntnWRAMxiA3sQfhZYxpReGBZ",
-	"LoHO6PF0mlkaUbfN8ad1RqiEes85iJHa26yQTuRSgBkmXRXZCgyCaTGZFdaRFfrElkxxiCUzQByyaSE4",
-	"g1nC9b1CjoHURveY1zoQih6r5ghLYibjQjIXiCwhbis2W/QoSLQTfmhLBnuRbAOkPj2va2JwcAIGmQHF",
-	"VhJ4n4y54mXgoOn7FFwKxgMXlpSzvAdjrdYiKQxwolWw7cGsWdy270wBNYSV1hKYQgiVq+vIKF39mVFR",
-	"zuKHwgFdtbUOMmJTXUhObJHn2rjng6KUJcYGPhKBHWjJfe3TgYq35BsxhnHUxjoKWGrgr2rkTwJGJHEM",
-	"uUO2KyRSx0yW23iR/BsU09kvB/PQE5Gyl8kBb91E1AnngbwTXJiwDJPkvTb3zHCU81kdEpVqaoUx1ZZN",
-	"uQm9+g1ihzKZV2/7qXW15s+lc0zJu4jWJm7FgOaXp/OLT3sYRHBQDhOceT6Q/CzF5K1o4uzrl3FuwFrc",
-	"cjWFdO1WqVAXrmOaHr09Hh/944fx8fh49vpoOp0OpTsFIklX2jxHSk3pp2qC14r0TrGpyJ9b4INQd5fN",
-	"8f4c9OpxxbMnLA78eHXtJznm4CXWln5gV9Mttzb230QTeZlUpjr7HPRfQ9HBQ/O9h1TDQwfV+qnhi7Zq",
-	"SyX0ZXJ9tpjMF6RQHIxk26Zk0GgHyxfoQ1h+GyqVg+qw/MT2qQ5zoxp+g6VqrxjqXU2D4rkWylW7kELd",
-	"HY5ze1mWeH3q6mXDLweZfbHW0UZplBnDtl5+YiWFSm6/YN1lmHpg+d2eoGpHRArrkKWQzPEYLSGQBoQh",
-	"crxPZo9Nj4/W6+l0Np0dHaGzc+ZQyHRG//nrr/zb0Te/sNF6Onp783gUvdnNXj0e79qPXv0bx31N9yjn",
-	"y7PRyZLM6+w3pKFe6CMoVWSokdOLy3Ma0dOf5h/OaEQXJ5fnn67wn/PzS9TLHnw1ZHD5ZZUUqnWvFzSi",
-	"Zxc/f2ov4h/3V9DJB9iA7KtHVo/bYfdBJ4n3iX8d1VY5rIrEZ4i1xse+IWgBKN8cPnfDsjcDTl0YvZKQ",
-	"DbUMjokBpCckLTKGJQ/jvjSAh1wyFc7SsiiPQ70gLNFxXBgDan+w5MFgXWSkIPN1IXEGCrIsa6pRqM4E",
-	"S2/GNyLkvlTf4+Dc6BiAj8nPRjgHeIKTc5VIYVM/q8aHdS+oRCgAYyNS2IJJuSVKO2ILgcUsjlCYVSFO",
-	"lfAVjmN3kGrJwVi/Go728SL+Bbyd9U61UmVhgaU5c2zFLBAnMqxKCzeYBJV1TA0d0yfk+nJODKwhsBZo",
-	"qqLBenJqlp9kNyIwTsZYjzPuix9G1oYlGajGYoZoQ2yxGuXMpXUDVrlnm8OYfGRb7DyKshhtOMhoXaZT",
-	"YetJIpxMVhcmBhJr3jkgJuXASVxzNvKS/srpO1Aj1PIIHTfy7I0Ce2ttMubojBZGjGpmhmjF47Www7XP",
-	"T1dXCxIGeGQkAYXdadlAYrdqRCIUsWCw9wzt0iEJt/b23fR1RMtinM6+e/s2omWRSmdH0+lQ1VamvL4C",
-	"bKoNijPLmNn24sY75q8W/RKMj8drxTZMSLQ55JDwAHe4ZoVEH7KVLtxsJZm6o9FLtF8o8XsBctsNgiYf",
-	"RCscENTnb2AeXIO3jeDAycliPiYXea4bnVUVSaxslcnl+9PR9z9Mv4+I8NlJgfC9p4FYZxkoHuausMOu",
-	"gHrCka9QYzhNWMiRo9odXMcFBl+wo7QhidQr75Kwv7o7b7n5ZcHzGSHSORbKeKmkOHQ+1IXycENcdp+t",
-	"64BCIXeKrLYOrN9YqMfK9rLsdw3kBiyWM9W+nY619Ak0LPHN4uz6VbvwlGwLxnMtbC3qxg0GszWkc/Sb",
-	"AkdytpWacTIi8wX5CRgHQ0bk+qz60WL56M33x0Ox2qu0ni4L/5Lubl61c516PdR4f3gzV9LzN2vlBoh/",
-	"sr/rdHQBSLOJK0vs+cESu8tjX2X/fff0v+6Z2pfVPcRQPW4L1o8mGVjLkucTVV33dqzvdmVp3D9FF/M6",
-	"p4atXdb9ctUQ+QekOspOFnMa0Q0YG1aYjqfjI9ygzkGxXNAZfT2ejo9Dn5P6zU3CVRL+m4C/9A9X3kKr",
-	"Oacz+iO40zAian80OJ5OO18L8Mya5JKJzneCLjG9bwHLIo7BWqyhLyrjCPtNMDGkkxrKpPHxwn9HCDUH",
-	"ndGFEVVqvrr4+KFzZ7YWMlyUscSif/Bw1Ire4BqTyiFPMTIPHcv/Fx/vmBUxwa3hSYsc5CwB4suZuuww",
-	"WvryEeXk+xNrD7DUbPdLrjpNobCuIeD9jFAZMQO9C/Dmxd0A8Y3c8wz9X/7xauAOZcBLfm963dvauOGq",
-	"J+CUddC3nweranQHsMzVhklRf1Ebd1z/pBsarm1c3HnvSp1M6lb/qUCobwn+QG/UNv60SPkRsGtoX2f0",
-	"IiCieTFAyrJDil//nebbP4WP6hKmaT+cQM4UsPtbeWn5Ei/5Kb4dxuePtDCSzmjqXD6bTB5Tbd1u9phr",
-	"43YTlovJ5ggPUGYE9jyeIxzS7v98Qeofowa06bx+PX3z5hhZuKnh9CqHDZitSxG4L7pCR9bPIxFVLKtK",
-	"6ep6tLvYqd8qnv0EHkJruNqWi5WZvLlUyczuZvefAAAA//9UPMQGEyAAAA==",
+	"H4sIAAAAAAAC/9xaW3PbNhb+Kxi0D8mUuthJN43eHNtpNZPGGl+mM9t6NRBxRKIGARYA5Wi9+u87uBDi",
+	"TbbT3bS7nTyEIoGDD9+54JwDP+BUFqUUIIzGswesQJdSaHA/3hF6Cb9VoI39lUphQLhHUpacpcQwKSa/",
+	"ainsO53mUBD79LWCNZ7hryZ70RP/VU+uDBGUKHqulFR4t9slmIJOFSutMDyzayIVFrVfw0QH5/2Z/a9U",
+	"sgRlmMdIQTMFdFkwwYqqWJpPSyYMqA3h4XND+HUOKAxE9Si0AnMPIJBRROiCac2kQHKN3r0/Q3bPSnJU",
+	"kvQOjEYmJwaZHJCFQIxUyK+vx+g6ZxptCK8AMY0I3ViMGigy0s0oAVSCcnkPG1DuDUlNRfgeSGVHM410",
+	"CSlbM6BotUWG3DGRufEF+eSQy3VYlY7CZkbm0yiKIYK64R6LXLsfCgppwDHbmqggBbaBPQg3a4wTDJ9I",
+	"UXLAM3w8nRYaJ9hsS/tTG8VEhp3mDKSW2mVRccNKzkANky6qYgXKgmkxWVTaoJXViQ5MUUg5UYCMZVOD",
+	"VwbRiMp7YTkGFBfdY15LT6jVWD2HaZQSnlacGE9kgLit2WzRIyCThrmhLTPYG8nWQ+rT8yoSYwdnoCwz",
+	"IMiKA+2TMRc0OI5d+j4Hk4NywJlGYZbTYCrFmmWVAoqk8Gs7MGuSttc3qoIIYSUlByIshFrV0TOCqj/T",
+	"K8Is+pg7WFVttYEC6VxWnCJdlaVU5mmnCGZZAij7inl2oGXua7sTEOkWvWBjGCdtrCOPJQJ/GZEfBGyR",
+	"pCmUxrJdI+EyJTxs41nm36AYz35+NA4d8JS9mTyirdsEG2YckHeMMuXFEI7eS3VPFLXmfBZdoraaaGFE",
+	"tM0mbEKufoXUWDM5U7K8BBKCeN8wlPuG7vMtIoFGdG8dUsmyBJpY51RgtW2Dl0BmP4eTFfAYgGRlQC3D",
+	"tGV5Z/TSSEM4KsAolrY5Z2JDOKPLgqRDgefMS1k4OANngjbLXGozvCMK2jDhGbKjEKFUgdY1Ur/JNp6j",
+	"6dj+Ox6MgtosmaZLop86/Oaanmg7BdzpN4jOffJ2mhJ3IlhMlrbx0OJRuUtGhwXGEY3NOQ1Gz5YiQdMY",
+	"RN14Qfh+YouKo6Fwx0FkJh9e339rk2sNZbU1oNuij6fTIeklMfnSvx5awH7Zi+8u1dbj1en84iN6cfRy",
+	"iEsV3eAxJTYcxqYnKn3E1LSsVAqfa2VHQ+DsQp9pZYYVhyhjRc8cgmd2Y9/x0Wj6ZjQ9uj46nk2ns+n0",
+	"7zjBa6kKYvAMU2Jg5NZ5Kj6GQS17jZRHC2pEu5ManPOFBkJ/FtcR5WBQ05chlR2ID/azfWAGCv0che+D",
+	"zS4uR5Qi2/454GTfDoCa11vvA1qt6VMobPL7pL97845jEKMgDFszUC21DqYstd8vWRNn33YadlxPQd11",
+	"WzG/Y+Nvj8dHf/tufDw+nr06mk6nQ8YugGX5SqqnSImUfqwnOG1wF9x1zsqnBHxg4u6yOd5VHO6cNtWT",
+	"tYwd+OP1jZtkiIHnrHblBnatpuMWcf9NNIkzk3qpzj4H9dfwJq+h+V5DoqEh/Ji1fmzoom21wRL6ZnJz",
+	"tpjMF6gSFBQn227o62D5HfbxWYGwS7Wfm0T4DZbqvdqkqmvTIGgpmTD1LjgTd+NHmXskAkWxzw9DUWw/",
+	"BCVYsxVnIlv+DrlXfuoj4nd7guodIc60sSz5tNkWLAECakAYIsfpZPbQ1PhovbYny+zoyCq7JMaAEniG",
+	"//HLL/Sb0YufyWg9Hb29fThKXu9mLx+Od+1XL/9lx32N9yjnV2ejkys0j9FvyIZ6rm9BiaqwNnJ6cXmO",
+	"E3z6w/zDGU7w4uTy/OO1fTg/v7T2sgdfDxkUf1UHhVruzQIn+Ozip49tITeLQQky+wAb4H3r4fXrttt9",
+	"kFnmdOI+J3FVCqsqcxFiLXGdfN620+217EPoOI4XO3SyLZRccSiGmjOGsAGkJyivCiKQAkJdEQafSk5C",
+	"Th7aH6mvzJhGMk0rpUDsD5bSLxjLuRx4ua64ncFlLCDrUdY6M9vkIHTDfOzL5b0dXCqZAtAx+kkxY0Ag",
+	"JtC5yDjTuZsV8dnkGETGBIDSCap0RTjfIiEN0hUzQN0IYaMqpLlgrpY05A5yySko7aTZ0c5f2D+7edap",
+	"FCKUcLYJQgxZEe3zNIpkZYZTf22IGDqmT9DN5RwpWINnzdNUe4N25ESWD7KbIBhnY5ttEerKTILWimQF",
+	"iIYwhaRCulqNXOZtZFOAy8zH6EeyRSvw3a22gpSUIZwyHSeFGjKkzqmknQNiEgZO0sjZyJn0V0begRhZ",
+	"Wx5Zxbm8lI48ezFjrRQbRWYGE21DTKWHc58frq8XyA9wyFAGAlTdYbKwpWIZE0iDsl0+X1M9ZsKtvX07",
+	"fZXg0PbAs2/fvk1waAfg2dFwbRRCXt8CdC6VNc6iIGrb8xunmD/b6K9AOX+8EWRDGLdrDilkX/mtScWt",
+	"DslKVma24kTc4eQ5tl8J9lsFfNt1giYfSAq+ra3P9bo/mQZvG0aBopPFfIwuylI2eli1J5HQlESX709H",
+	"b76bvkkQc9FJAHNdPgWpLAoQ1M9dAaJQA3WEW758jmEkIj5GjqI6qEwr63x+HSEVyrhcOZX4/cUSvqXm",
+	"5znPZ7hI51gI/lKb4tD5EBPl4dZj6PO1Gq+VYPsugduYz8dCIy90FhWUCjQIE9VpZCq5C6BexIvF2c3L",
+	"duLJyRaU45rpaNSNXjHREdK51ZsAg0qy5ZJQNELzBfoBCAWFRujmrP7R7mO8fnM85Ku9TOtwWvinVHfz",
+	"MKabr/sc74sXc4Gev1gpN0D8wfquU9F5IM0iLqTY80dT7C6PfSv7z6un/3bN1L4W7CE+0C91o1EBWpPs",
+	"6UAV897O6rtdSI37p+hiHmOq39plrJfrgsi9QPVRdrKY4wRvQGkvwbfzdgmWJQhSMjzDr0If2SZMbnMT",
+	"37S3j5nvZvvLRSbFnOIZ/h7MqR+RtK9nj6fTzr2sPbMmJSescyPbJaZ363pVpSlobXPoi3pxC/v1dHrI",
+	"TiKUSeOa2EoOOQee4YVidWi+vvjxQ+d2Ys24v5Igmbb6sYejFPjWypjEFl2gpFPpMG0QQdrFoXZHNdzx",
+	"3IMC1+EWhm+HO4eJey6kNmEgkgLQmiltxuiiLbMWUFuDJkV9zZEg2IDaIjGyrWfhLrruoDTuZqRxi3fP",
+	"TO7kLI2y0cGBZyKzFPQU7hqYzkgUKcCAsgx1WbiwCYstxA9svw2a6QB47KISnuHfKlBbnGBBCsCzfS/2",
+	"eTf5zT747vZJ0/z9fzLQbuYOGK+zBrmO+61zhIYBHwASsqFvPg9QXe4OQJn7e6v6LxjGHYf4UCurZ5kB",
+	"c8MdQiPZeUMdng7Fh7mv3/+/osM7olmKmPB5p40IJckAueQ+JuH28laH4Oqqda0Pxox28+tw4NiH8/0M",
+	"7zZEQe/ivdnGHiC+cRJ/MQ8Y6Cg+4gbdrf0v+0EXa0O1jTa20y6X2SQ2vg45QuyZfUFtxDX+ME/5HmwN",
+	"3W7u9TwgwWU1QMpVhxQn/52k2z+Ej7ol2Vzf52NGVbD7S2np6jlaclNcc8if6JXieIZzY8rZZPJgL4p3",
+	"s4dSKrObkJJNNvYueEMUsx0Ax9H+srnuhrjyzL22NiBV5/Or6evXx5aF2winl0fbBMbkFrgrQXx/oh9H",
+	"YqrAGpcFzxPWO5ujLH/O9eWcOspsRm27w67hstoGUOFEaIoJDO9ud/8eABVsEHrTKAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
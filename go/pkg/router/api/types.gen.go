@@ -3,6 +3,10 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package api
 
+import (
+	"time"
+)
+
 // Defines values for LinkRelationship.
 const (
 	LinkRelationshipCHILD LinkRelationship = "CHILD"
@@ -45,6 +49,43 @@ type BFD struct {
 	RequiredMinimumReceive string `json:"required_minimum_receive"`
 }
 
+// The reason why a packet was dropped, as reported in the reason label of the router_dropped_pkts_total metric.
+type DropReason string
+
+// DroppedPacket defines model for DroppedPacket.
+type DroppedPacket struct {
+	// The destination host address of the packet.
+	DstHost  *string `json:"dst_host,omitempty"`
+	DstIsdAs *IsdAs  `json:"dst_isd_as,omitempty"`
+
+	// The error that caused the drop.
+	Error *string `json:"error,omitempty"`
+
+	// The interface the packet was received on, 0 for the internal interface.
+	InterfaceId int `json:"interface_id"`
+
+	// The length of the packet in bytes.
+	Length int `json:"length"`
+
+	// The type of the path of the packet.
+	PathType *string `json:"path_type,omitempty"`
+
+	// The reason why a packet was dropped, as reported in the reason label of the router_dropped_pkts_total metric.
+	Reason DropReason `json:"reason"`
+
+	// The source host address of the packet.
+	SrcHost  *string `json:"src_host,omitempty"`
+	SrcIsdAs *IsdAs  `json:"src_isd_as,omitempty"`
+
+	// The time the packet was dropped.
+	Time time.Time `json:"time"`
+}
+
+// DropsResponse defines model for DropsResponse.
+type DropsResponse struct {
+	Drops []DroppedPacket `json:"drops"`
+}
+
 // Interface defines model for Interface.
 type Interface struct {
 	Bfd BFD `json:"bfd"`
@@ -143,6 +184,12 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest StandardError
 
+// GetDropsParams defines parameters for GetDrops.
+type GetDropsParams struct {
+	// Only list the packets that were dropped for this reason.
+	Reason *DropReason `json:"reason,omitempty"`
+}
+
 // SetLogLevelJSONBody defines parameters for SetLogLevel.
 type SetLogLevelJSONBody LogLevel
 
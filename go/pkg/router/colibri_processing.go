@@ -33,6 +33,8 @@ import (
 type colibriPacketProcessor struct {
 	// d is a reference to the dataplane instance that initiated this processor.
 	d *DataPlane
+	// state is the forwarding state the packet is processed with.
+	state *forwardingState
 	// ingressID is the interface ID this packet came in, determined from the
 	// socket.
 	ingressID uint16
@@ -41,6 +43,8 @@ type colibriPacketProcessor struct {
 	rawPkt []byte
 	// scionLayer is the SCION gopacket layer (common/address header).
 	scionLayer slayers.SCION
+	// lastLayer is the last decoded layer before the L4 header.
+	lastLayer gopacket.DecodingLayer
 	// buffer is the buffer that can be used to serialize gopacket layers.
 	buffer gopacket.SerializeBuffer
 
@@ -110,7 +114,7 @@ func (c *colibriPacketProcessor) basicValidation() (processResult, error) {
 	// Correct ingress interface (if we received the packet on the local interface, we do not
 	// need to check the interface in the hop field)
 	if c.ingressID != 0 && c.ingressID != c.colibriPathMinimal.CurrHopField.IngressId {
-		return processResult{}, serrors.New("invalid ingress identifier",
+		return processResult{}, serrors.WithCtx(invalidIngress,
 			"receivedOn", c.ingressID,
 			"HopFieldIngressId", c.colibriPathMinimal.CurrHopField.IngressId)
 	}
@@ -118,7 +122,7 @@ func (c *colibriPacketProcessor) basicValidation() (processResult, error) {
 	if (!R && !C && c.scionLayer.PayloadLen != c.colibriPathMinimal.InfoField.OrigPayLen) ||
 		(int(c.scionLayer.PayloadLen) != len(c.scionLayer.Payload)) {
 
-		return processResult{}, serrors.New("packet length validation failed",
+		return processResult{}, serrors.WrapStr("packet length validation failed", malformedPacket,
 			"scion", c.scionLayer.PayloadLen, "colibri", c.colibriPathMinimal.InfoField.OrigPayLen,
 			"actual", len(c.scionLayer.Payload))
 	}
@@ -139,7 +143,7 @@ func (c *colibriPacketProcessor) basicValidation() (processResult, error) {
 	expTick := c.colibriPathMinimal.InfoField.ExpTick
 	notExpired := libcolibri.VerifyExpirationTick(expTick)
 	if !notExpired {
-		return processResult{}, serrors.New("packet expired")
+		return processResult{}, expiredPacket
 	}
 
 	// Packet freshness
@@ -147,7 +151,8 @@ func (c *colibriPacketProcessor) basicValidation() (processResult, error) {
 		timestamp := c.colibriPathMinimal.PacketTimestamp
 		isFresh := libcolibri.VerifyTimestamp(expTick, timestamp, time.Now())
 		if !isFresh {
-			return processResult{}, serrors.New("verification of packet timestamp failed")
+			return processResult{}, serrors.WrapStr("verification of packet timestamp failed",
+				expiredPacket)
 		}
 	}
 
@@ -166,11 +171,22 @@ func (c *colibriPacketProcessor) basicValidation() (processResult, error) {
 }
 
 func (c *colibriPacketProcessor) cryptographicValidation() (processResult, error) {
-	privateKey := c.d.colibriKey
+	privateKey := c.state.colibriKey
 	colHeader := c.colibriPathMinimal
 	err := libcolibri.VerifyMAC(privateKey, colHeader.PacketTimestamp, colHeader.InfoField,
 		colHeader.CurrHopField, &c.scionLayer)
-	return processResult{}, err
+	// During a key rollover, the MACs computed with the previous key are valid
+	// as well.
+	if err != nil && c.state.secondaryColibriKey != nil &&
+		libcolibri.VerifyMAC(c.state.secondaryColibriKey, colHeader.PacketTimestamp,
+			colHeader.InfoField, colHeader.CurrHopField, &c.scionLayer) == nil {
+
+		err = nil
+	}
+	if err != nil {
+		return processResult{}, serrors.Wrap(invalidMAC, err)
+	}
+	return processResult{}, nil
 }
 
 func (c *colibriPacketProcessor) forward() (processResult, error) {
@@ -212,7 +228,7 @@ func (c *colibriPacketProcessor) forward() (processResult, error) {
 }
 
 func (c *colibriPacketProcessor) canForwardLocally(egressId uint16) (BatchConn, bool) {
-	conn, ok := c.d.external[egressId]
+	conn, ok := c.state.external[egressId]
 	return conn, ok
 }
 
@@ -234,8 +250,8 @@ func (c *colibriPacketProcessor) forwardToLocalEgress(egressId uint16,
 
 func (c *colibriPacketProcessor) forwardToRemoteEgress(egressId uint16) (processResult, error) {
 	// AS transit: the packet will leave the AS from another border router.
-	if a, ok := c.d.internalNextHops[egressId]; ok {
-		return processResult{OutConn: c.d.internal, OutAddr: a, OutPkt: c.rawPkt}, nil
+	if a, ok := c.state.internalNextHops[egressId]; ok {
+		return processResult{OutConn: c.d.internalConn(a), OutAddr: a, OutPkt: c.rawPkt}, nil
 	} else {
 		return processResult{}, serrors.New("no remote border router with this egress id",
 			"egressId", egressId)
@@ -244,11 +260,11 @@ func (c *colibriPacketProcessor) forwardToRemoteEgress(egressId uint16) (process
 
 func (c *colibriPacketProcessor) forwardToLocalAS() (processResult, error) {
 	// Inbound: packet destined to a host in the local IA.
-	a, err := c.d.resolveLocalDst(c.scionLayer)
+	a, err := c.d.resolveLocalDst(c.scionLayer, c.lastLayer)
 	if err != nil {
 		return processResult{}, err
 	}
-	return processResult{OutConn: c.d.internal, OutAddr: a, OutPkt: c.rawPkt}, nil
+	return processResult{OutConn: c.d.internalConn(a), OutAddr: a, OutPkt: c.rawPkt}, nil
 }
 
 func (c *colibriPacketProcessor) forwardToColibriSvc() (processResult, error) {
@@ -273,5 +289,5 @@ func (c *colibriPacketProcessor) forwardToColibriSvc() (processResult, error) {
 		return processResult{}, err
 	}
 
-	return processResult{OutConn: c.d.internal, OutAddr: a, OutPkt: c.rawPkt}, nil
+	return processResult{OutConn: c.d.internalConn(a), OutAddr: a, OutPkt: c.rawPkt}, nil
 }
@@ -18,14 +18,41 @@ package config
 
 import (
 	"io"
+	"runtime"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/router/control"
 )
 
-const idSample = "router-1"
+const (
+	idSample = "router-1"
+
+	// DefaultBatchSize is the default maximal number of packets read or written
+	// in a single system call.
+	DefaultBatchSize = 64
+	// DefaultDropTraceSampling is the default sampling of the dropped packets
+	// that are kept in the drop trace.
+	DefaultDropTraceSampling = 100
+	// DefaultConfigWatchInterval is the default interval at which the
+	// topology and the master keys are checked for changes.
+	DefaultConfigWatchInterval = 10 * time.Second
+	// DefaultSCMPErrorRate is the default number of SCMP error messages per
+	// second and type that the router sends.
+	DefaultSCMPErrorRate = 100
+	// DefaultSCMPTracerouteRate is the default number of traceroute replies
+	// per second that the router sends.
+	DefaultSCMPTracerouteRate = 100
+	// DefaultSCMPDestinationRate is the default number of SCMP messages per
+	// second that the router sends to the same destination.
+	DefaultSCMPDestinationRate = 10
+)
 
 type Config struct {
 	General  env.General  `toml:"general,omitempty"`
@@ -33,6 +60,7 @@ type Config struct {
 	Logging  log.Config   `toml:"log,omitempty"`
 	Metrics  env.Metrics  `toml:"metrics,omitempty"`
 	API      api.Config   `toml:"api,omitempty"`
+	Router   RouterConfig `toml:"router,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -42,6 +70,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Router,
 	)
 }
 
@@ -52,6 +81,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Router,
 	)
 }
 
@@ -62,5 +92,205 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.API,
+		&cfg.Router,
 	)
 }
+
+const routerSample = `
+# The number of goroutines that process the packets. The packets are dispatched
+# to them by flow, such that the packets of a flow are forwarded in order.
+# (default 0, i.e., the number of CPUs the router may use)
+num_processors = 0
+# The maximal number of packets that are read from or written to a socket in a
+# single system call. (default 64)
+batch_size = 64
+# The sampling of the dropped packets that are kept in the drop trace, which is
+# served by the /drops endpoint of the API, i.e., every n-th packet dropped for
+# the same reason is kept. (default 100)
+drop_trace_sampling = 100
+# The JSON file with the rules of the packet filter, which drops the matching
+# received packets. The file is reloaded on SIGHUP. (default "", i.e., no
+# filter)
+filter_file = ""
+# The interval at which the topology file and the master keys in the config
+# directory are checked for changes. The router is reconfigured without a
+# restart if they changed, or on SIGHUP. (default 10s)
+config_watch_interval = "10s"
+`
+
+// RouterConfig is the configuration of the packet processing.
+type RouterConfig struct {
+	// NumProcessors is the number of goroutines that process the packets.
+	NumProcessors int `toml:"num_processors,omitempty"`
+	// BatchSize is the maximal number of packets read or written in a single
+	// system call.
+	BatchSize int `toml:"batch_size,omitempty"`
+	// DropTraceSampling is the sampling of the dropped packets that are kept in
+	// the drop trace.
+	DropTraceSampling int `toml:"drop_trace_sampling,omitempty"`
+	// FilterFile is the file with the rules of the packet filter.
+	FilterFile string `toml:"filter_file,omitempty"`
+	// ConfigWatchInterval is the interval at which the topology and the master
+	// keys are checked for changes.
+	ConfigWatchInterval util.DurWrap `toml:"config_watch_interval,omitempty"`
+	// SCMP is the configuration of the SCMP messages sent by the router.
+	SCMP SCMPConfig `toml:"scmp,omitempty"`
+}
+
+func (cfg *RouterConfig) InitDefaults() {
+	if cfg.NumProcessors == 0 {
+		cfg.NumProcessors = runtime.GOMAXPROCS(0)
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.DropTraceSampling == 0 {
+		cfg.DropTraceSampling = DefaultDropTraceSampling
+	}
+	if cfg.ConfigWatchInterval.Duration == 0 {
+		cfg.ConfigWatchInterval.Duration = DefaultConfigWatchInterval
+	}
+	config.InitAll(&cfg.SCMP)
+}
+
+func (cfg *RouterConfig) Validate() error {
+	if cfg.NumProcessors < 0 {
+		return serrors.New("num_processors must not be negative", "value", cfg.NumProcessors)
+	}
+	if cfg.BatchSize < 0 {
+		return serrors.New("batch_size must not be negative", "value", cfg.BatchSize)
+	}
+	if cfg.DropTraceSampling < 0 {
+		return serrors.New("drop_trace_sampling must not be negative",
+			"value", cfg.DropTraceSampling)
+	}
+	if cfg.ConfigWatchInterval.Duration <= 0 {
+		return serrors.New("config_watch_interval must be positive",
+			"value", cfg.ConfigWatchInterval)
+	}
+	return config.ValidateAll(&cfg.SCMP)
+}
+
+func (cfg *RouterConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, routerSample)
+	config.WriteSample(dst, path, ctx, &cfg.SCMP)
+}
+
+func (cfg *RouterConfig) ConfigName() string {
+	return "router"
+}
+
+const scmpSample = `
+# The number of SCMP error messages per second that the router sends, for
+# every SCMP type separately. A negative value disables the limit. (default 100)
+error_rate = 100
+# The number of SCMP error messages of a type that the router sends at once.
+# (default error_rate)
+error_burst = 100
+# The number of traceroute replies per second that the router sends. A negative
+# value disables the limit. (default 100)
+traceroute_rate = 100
+# The number of traceroute replies that the router sends at once.
+# (default traceroute_rate)
+traceroute_burst = 100
+# The number of SCMP messages per second that the router sends to the same
+# ISD-AS and host, i.e., for the packets from the same source. A negative value
+# disables the limit. (default 10)
+destination_rate = 10
+# The number of SCMP messages that the router sends at once to the same ISD-AS
+# and host. (default destination_rate)
+destination_burst = 10
+`
+
+// SCMPConfig is the configuration of the rate limits on the SCMP messages sent
+// by the router.
+type SCMPConfig struct {
+	// ErrorRate is the number of SCMP error messages per second and type.
+	ErrorRate int `toml:"error_rate,omitempty"`
+	// ErrorBurst is the number of SCMP error messages of a type sent at once.
+	ErrorBurst int `toml:"error_burst,omitempty"`
+	// TracerouteRate is the number of traceroute replies per second.
+	TracerouteRate int `toml:"traceroute_rate,omitempty"`
+	// TracerouteBurst is the number of traceroute replies sent at once.
+	TracerouteBurst int `toml:"traceroute_burst,omitempty"`
+	// DestinationRate is the number of SCMP messages per second and
+	// destination.
+	DestinationRate int `toml:"destination_rate,omitempty"`
+	// DestinationBurst is the number of SCMP messages sent at once to a
+	// destination.
+	DestinationBurst int `toml:"destination_burst,omitempty"`
+}
+
+func (cfg *SCMPConfig) InitDefaults() {
+	initRateLimit(&cfg.ErrorRate, &cfg.ErrorBurst, DefaultSCMPErrorRate)
+	initRateLimit(&cfg.TracerouteRate, &cfg.TracerouteBurst, DefaultSCMPTracerouteRate)
+	initRateLimit(&cfg.DestinationRate, &cfg.DestinationBurst, DefaultSCMPDestinationRate)
+}
+
+// initRateLimit sets the rate to the default if it is not set, and the burst
+// to the rate, i.e., to one second worth of messages, if it is not set.
+func initRateLimit(rate, burst *int, defaultRate int) {
+	if *rate == 0 {
+		*rate = defaultRate
+	}
+	if *burst == 0 && *rate > 0 {
+		*burst = *rate
+	}
+}
+
+func (cfg *SCMPConfig) Validate() error {
+	if cfg.ErrorBurst < 0 {
+		return serrors.New("error_burst must not be negative", "value", cfg.ErrorBurst)
+	}
+	if cfg.TracerouteBurst < 0 {
+		return serrors.New("traceroute_burst must not be negative",
+			"value", cfg.TracerouteBurst)
+	}
+	if cfg.DestinationBurst < 0 {
+		return serrors.New("destination_burst must not be negative",
+			"value", cfg.DestinationBurst)
+	}
+	return nil
+}
+
+func (cfg *SCMPConfig) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, scmpSample)
+}
+
+func (cfg *SCMPConfig) ConfigName() string {
+	return "scmp"
+}
+
+// RateLimits returns the rate limits for the router control.
+func (cfg *SCMPConfig) RateLimits() control.SCMPRateLimits {
+	limits := control.SCMPRateLimits{
+		Types: make(map[slayers.SCMPType]control.SCMPRateLimit),
+	}
+	if cfg.ErrorRate > 0 {
+		for _, t := range []slayers.SCMPType{
+			slayers.SCMPTypeDestinationUnreachable,
+			slayers.SCMPTypePacketTooBig,
+			slayers.SCMPTypeParameterProblem,
+			slayers.SCMPTypeExternalInterfaceDown,
+			slayers.SCMPTypeInternalConnectivityDown,
+		} {
+			limits.Types[t] = control.SCMPRateLimit{
+				Rate:  float64(cfg.ErrorRate),
+				Burst: cfg.ErrorBurst,
+			}
+		}
+	}
+	if cfg.TracerouteRate > 0 {
+		limits.Types[slayers.SCMPTypeTracerouteReply] = control.SCMPRateLimit{
+			Rate:  float64(cfg.TracerouteRate),
+			Burst: cfg.TracerouteBurst,
+		}
+	}
+	if cfg.DestinationRate > 0 {
+		limits.Destination = control.SCMPRateLimit{
+			Rate:  float64(cfg.DestinationRate),
+			Burst: cfg.DestinationBurst,
+		}
+	}
+	return limits
+}
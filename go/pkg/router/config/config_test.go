@@ -17,14 +17,17 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/pkg/api/apitest"
 	"github.com/scionproto/scion/go/pkg/router/config"
+	"github.com/scionproto/scion/go/pkg/router/control"
 )
 
 func TestConfigSample(t *testing.T) {
@@ -42,10 +45,66 @@ func InitTestConfig(cfg *config.Config) {
 	apitest.InitConfig(&cfg.API)
 	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
 	logtest.InitTestLogging(&cfg.Logging)
+	cfg.Router.NumProcessors = 3
+	cfg.Router.BatchSize = 8
+	cfg.Router.DropTraceSampling = 5
+	cfg.Router.FilterFile = "filter.json"
+	cfg.Router.ConfigWatchInterval.Duration = time.Minute
+	cfg.Router.SCMP.ErrorRate = 1
+	cfg.Router.SCMP.DestinationBurst = 3
 }
 
 func CheckTestConfig(t *testing.T, cfg *config.Config, id string) {
 	apitest.CheckConfig(t, &cfg.API)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	assert.Zero(t, cfg.Router.NumProcessors)
+	assert.Equal(t, config.DefaultBatchSize, cfg.Router.BatchSize)
+	assert.Equal(t, config.DefaultDropTraceSampling, cfg.Router.DropTraceSampling)
+	assert.Empty(t, cfg.Router.FilterFile)
+	assert.Equal(t, config.DefaultConfigWatchInterval, cfg.Router.ConfigWatchInterval.Duration)
+	assert.Equal(t, config.DefaultSCMPErrorRate, cfg.Router.SCMP.ErrorRate)
+	assert.Equal(t, config.DefaultSCMPErrorRate, cfg.Router.SCMP.ErrorBurst)
+	assert.Equal(t, config.DefaultSCMPTracerouteRate, cfg.Router.SCMP.TracerouteRate)
+	assert.Equal(t, config.DefaultSCMPTracerouteRate, cfg.Router.SCMP.TracerouteBurst)
+	assert.Equal(t, config.DefaultSCMPDestinationRate, cfg.Router.SCMP.DestinationRate)
+	assert.Equal(t, config.DefaultSCMPDestinationRate, cfg.Router.SCMP.DestinationBurst)
+}
+
+func TestRouterConfigDefaults(t *testing.T) {
+	var cfg config.RouterConfig
+	cfg.InitDefaults()
+	assert.Positive(t, cfg.NumProcessors)
+	assert.Equal(t, config.DefaultBatchSize, cfg.BatchSize)
+	assert.Equal(t, config.DefaultDropTraceSampling, cfg.DropTraceSampling)
+	assert.Equal(t, config.DefaultConfigWatchInterval, cfg.ConfigWatchInterval.Duration)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.BatchSize = -1
+	assert.Error(t, cfg.Validate())
+}
+
+func TestSCMPConfigRateLimits(t *testing.T) {
+	cfg := config.SCMPConfig{
+		ErrorRate:        3,
+		TracerouteRate:   -1,
+		DestinationRate:  5,
+		DestinationBurst: 20,
+	}
+	cfg.InitDefaults()
+	assert.NoError(t, cfg.Validate())
+
+	limits := cfg.RateLimits()
+	errorLimit := control.SCMPRateLimit{Rate: 3, Burst: 3}
+	assert.Equal(t, map[slayers.SCMPType]control.SCMPRateLimit{
+		slayers.SCMPTypeDestinationUnreachable:   errorLimit,
+		slayers.SCMPTypePacketTooBig:             errorLimit,
+		slayers.SCMPTypeParameterProblem:         errorLimit,
+		slayers.SCMPTypeExternalInterfaceDown:    errorLimit,
+		slayers.SCMPTypeInternalConnectivityDown: errorLimit,
+	}, limits.Types)
+	assert.Equal(t, control.SCMPRateLimit{Rate: 5, Burst: 20}, limits.Destination)
+
+	cfg.ErrorBurst = -1
+	assert.Error(t, cfg.Validate())
 }
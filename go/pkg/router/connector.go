@@ -23,6 +23,7 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 	"github.com/scionproto/scion/go/lib/underlay/conn"
 	"github.com/scionproto/scion/go/pkg/router/control"
 )
@@ -40,6 +41,11 @@ type Connector struct {
 	internalInterfaces []control.InternalInterface
 	externalInterfaces map[uint16]control.ExternalInterface
 	siblingInterfaces  map[uint16]control.SiblingInterface
+	// removedConns are the connections of the interfaces that were removed
+	// during the current reconfiguration, by their local and remote address.
+	// They are reused for the interfaces with the same addresses that are
+	// added again, as their addresses can't be bound twice.
+	removedConns map[string]BatchConn
 }
 
 var errMultiIA = serrors.New("different IA not allowed")
@@ -56,11 +62,16 @@ func (c *Connector) CreateIACtx(ia addr.IA) error {
 	return c.DataPlane.SetIA(ia)
 }
 
-// AddInternalInterface adds the internal interface.
-func (c *Connector) AddInternalInterface(ia addr.IA, local net.UDPAddr) error {
+// AddInternalInterface adds the internal interface. If alt is set, the
+// interface is dual-stack, with a second socket bound to alt for the other IP
+// family. The traffic of each family is then sent and received on the socket
+// bound to the configured address of that family.
+func (c *Connector) AddInternalInterface(ia addr.IA, local net.UDPAddr,
+	alt *net.UDPAddr) error {
+
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
-	log.Debug("Adding internal interface", "isd_as", ia, "local", local)
+	log.Debug("Adding internal interface", "isd_as", ia, "local", local, "alt", alt)
 	if !c.ia.Equal(ia) {
 		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
 	}
@@ -69,11 +80,28 @@ func (c *Connector) AddInternalInterface(ia addr.IA, local net.UDPAddr) error {
 	if err != nil {
 		return err
 	}
+	if err := c.DataPlane.AddInternalInterface(connection, local.IP); err != nil {
+		return err
+	}
 	c.internalInterfaces = append(c.internalInterfaces, control.InternalInterface{
 		IA:   ia,
 		Addr: &local,
 	})
-	return c.DataPlane.AddInternalInterface(connection, local.IP)
+	if alt == nil {
+		return nil
+	}
+	altConnection, err := conn.New(alt, nil,
+		&conn.Config{ReceiveBufferSize: receiveBufferSize})
+	if err != nil {
+		return serrors.WrapStr("opening alternative internal socket", err, "alt", alt)
+	}
+	if err := c.DataPlane.AddAltInternalInterface(altConnection, alt.IP); err != nil {
+		if closeErr := altConnection.Close(); closeErr != nil {
+			log.Info("Failed to close connection", "err", closeErr)
+		}
+		return err
+	}
+	return nil
 }
 
 // AddExternalInterface adds a link between the local and remote address.
@@ -129,10 +157,16 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 		return c.DataPlane.AddNextHop(intf, link.Remote.Addr)
 	}
 
-	connection, err := conn.New(link.Local.Addr, link.Remote.Addr,
-		&conn.Config{ReceiveBufferSize: receiveBufferSize})
-	if err != nil {
-		return err
+	connection, ok := c.removedConns[connKey(link)]
+	if ok {
+		delete(c.removedConns, connKey(link))
+	} else {
+		var err error
+		connection, err = conn.New(link.Local.Addr, link.Remote.Addr,
+			&conn.Config{ReceiveBufferSize: receiveBufferSize})
+		if err != nil {
+			return err
+		}
 	}
 	if !link.BFD.Disable {
 		err := c.DataPlane.AddExternalInterfaceBFD(intf, connection, link.Local,
@@ -144,6 +178,63 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 	return c.DataPlane.AddExternalInterface(intf, connection)
 }
 
+// RemoveExternalInterface removes the interface, which was added with
+// AddExternalInterface. This is only possible within Reconfigure.
+func (c *Connector) RemoveExternalInterface(localIfID common.IFIDType) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	intf := uint16(localIfID)
+	log.Debug("Removing external interface", "interface", localIfID)
+
+	connection, err := c.DataPlane.removeInterface(intf)
+	if err != nil {
+		return serrors.WrapStr("removing interface", err, "if_id", localIfID)
+	}
+	if external, ok := c.externalInterfaces[intf]; ok && c.removedConns != nil {
+		c.removedConns[connKey(external.Link)] = connection
+	}
+	delete(c.externalInterfaces, intf)
+	delete(c.siblingInterfaces, intf)
+	return nil
+}
+
+// Reconfigure applies the changes that fn makes to the running dataplane
+// atomically, see DataPlane.Reconfigure. If fn fails, none of the changes are
+// applied.
+func (c *Connector) Reconfigure(fn func() error) error {
+	c.mtx.Lock()
+	externalInterfaces := make(map[uint16]control.ExternalInterface,
+		len(c.externalInterfaces))
+	for k, v := range c.externalInterfaces {
+		externalInterfaces[k] = v
+	}
+	siblingInterfaces := make(map[uint16]control.SiblingInterface, len(c.siblingInterfaces))
+	for k, v := range c.siblingInterfaces {
+		siblingInterfaces[k] = v
+	}
+	c.removedConns = make(map[string]BatchConn)
+	c.mtx.Unlock()
+
+	log.Debug("Reconfiguring dataplane")
+	err := c.DataPlane.Reconfigure(fn)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.removedConns = nil
+	if err != nil {
+		c.externalInterfaces = externalInterfaces
+		c.siblingInterfaces = siblingInterfaces
+		return err
+	}
+	return nil
+}
+
+// connKey identifies the connection of an external interface by its local and
+// remote address.
+func connKey(link control.LinkInfo) string {
+	return link.Local.Addr.String() + " " + link.Remote.Addr.String()
+}
+
 // AddSvc adds the service address for the given ISD-AS.
 func (c *Connector) AddSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
 	c.mtx.Lock()
@@ -166,7 +257,8 @@ func (c *Connector) DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
 	return c.DataPlane.DelSvc(svc, &net.UDPAddr{IP: ip, Port: topology.EndhostPort})
 }
 
-// SetKey sets the key for the given ISD-AS at the given index.
+// SetKey sets the key for the given ISD-AS at the given index. The MACs are
+// verified with the key at index 0, or else with the key at index 1.
 func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
@@ -174,22 +266,54 @@ func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
 	if !c.ia.Equal(ia) {
 		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
 	}
-	if index != 0 {
-		return serrors.New("currently only index 0 key is supported")
+	switch index {
+	case 0:
+		return c.DataPlane.SetKey(key)
+	case 1:
+		return c.DataPlane.SetSecondaryKey(key)
+	default:
+		return serrors.New("only keys with index 0 and 1 are supported", "index", index)
 	}
-	return c.DataPlane.SetKey(key)
 }
 
-// SetColibriKey sets the Colibri key for the given ISD-AS at the given index.
+// SetColibriKey sets the Colibri key for the given ISD-AS at the given index,
+// see SetKey.
 func (c *Connector) SetColibriKey(ia addr.IA, index int, key []byte) error {
 	log.Debug("Setting key", "isd_as", ia, "index", index)
 	if !c.ia.Equal(ia) {
 		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
 	}
-	if index != 0 {
-		return serrors.New("currently only index 0 key is supported")
+	switch index {
+	case 0:
+		return c.DataPlane.SetColibriKey(key)
+	case 1:
+		return c.DataPlane.SetSecondaryColibriKey(key)
+	default:
+		return serrors.New("only keys with index 0 and 1 are supported", "index", index)
 	}
-	return c.DataPlane.SetColibriKey(key)
+}
+
+// SetSCMPRateLimits sets the limits on the SCMP messages sent by the router.
+func (c *Connector) SetSCMPRateLimits(ia addr.IA, limits control.SCMPRateLimits) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting SCMP rate limits", "isd_as", ia)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
+	}
+	return c.DataPlane.SetSCMPRateLimits(limits)
+}
+
+// SetEndhostPorts sets the range of underlay ports to which the end hosts bind
+// directly.
+func (c *Connector) SetEndhostPorts(ia addr.IA, ports underlay.PortRange) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting end host port range", "isd_as", ia, "ports", ports)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
+	}
+	return c.DataPlane.SetEndhostPorts(ports)
 }
 
 func (c *Connector) ListInternalInterfaces() ([]control.InternalInterface, error) {
@@ -225,3 +349,9 @@ func (c *Connector) ListSiblingInterfaces() ([]control.SiblingInterface, error)
 	}
 	return siblingInterfaceList, nil
 }
+
+// ListDroppedPackets returns the sampled packets that were dropped recently,
+// the most recent one first.
+func (c *Connector) ListDroppedPackets() ([]control.DroppedPacket, error) {
+	return c.DataPlane.drops.list(), nil
+}
@@ -15,27 +15,51 @@
 package control
 
 import (
+	"bytes"
 	"net"
+	"reflect"
 	"sort"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 // Dataplane is the interface that a dataplane has to support to be controlled
 // by this controller.
 type Dataplane interface {
 	CreateIACtx(ia addr.IA) error
-	AddInternalInterface(ia addr.IA, local net.UDPAddr) error
+	// AddInternalInterface adds the internal interface with the local
+	// address. If alt is not nil, the interface is dual-stack and alt is its
+	// address in the other IP family.
+	AddInternalInterface(ia addr.IA, local net.UDPAddr, alt *net.UDPAddr) error
 	AddExternalInterface(localIfID common.IFIDType, info LinkInfo, owned bool) error
 	AddSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error
 	DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error
 	SetKey(ia addr.IA, index int, key []byte) error
 	SetColibriKey(ia addr.IA, index int, key []byte) error
+	SetSCMPRateLimits(ia addr.IA, limits SCMPRateLimits) error
+	SetEndhostPorts(ia addr.IA, ports underlay.PortRange) error
+}
+
+// ReconfigurableDataplane is a Dataplane that can be reconfigured while it is
+// running.
+type ReconfigurableDataplane interface {
+	Dataplane
+	// RemoveExternalInterface removes the interface. This is only possible
+	// within Reconfigure.
+	RemoveExternalInterface(localIfID common.IFIDType) error
+	// Reconfigure applies the changes that fn makes with the methods of the
+	// dataplane atomically, i.e., the packets are forwarded either with the
+	// configuration before or after the changes. If fn fails, none of the
+	// changes are applied.
+	Reconfigure(fn func() error) error
 }
 
 // LinkInfo contains the information about a link between an internal and
@@ -55,10 +79,34 @@ type LinkEnd struct {
 	Addr *net.UDPAddr
 }
 
+// SCMPRateLimit is a token bucket limit on the SCMP messages sent by the
+// router.
+type SCMPRateLimit struct {
+	// Rate is the number of messages per second. If it is not positive, the
+	// messages are not limited.
+	Rate float64
+	// Burst is the number of messages that can be sent at once. It is at least
+	// 1.
+	Burst int
+}
+
+// SCMPRateLimits are the limits on the SCMP messages sent by the router, i.e.,
+// on the SCMP errors and on the traceroute replies. A message is only sent if
+// neither the limit of its type nor the limit of its destination is exceeded.
+type SCMPRateLimits struct {
+	// Types are the limits per SCMP type. Types without a limit are not
+	// limited.
+	Types map[slayers.SCMPType]SCMPRateLimit
+	// Destination is the limit per destination ISD-AS and host, i.e., per
+	// source of the packets the messages are sent for.
+	Destination SCMPRateLimit
+}
+
 type ObservableDataplane interface {
 	ListInternalInterfaces() ([]InternalInterface, error)
 	ListExternalInterfaces() ([]ExternalInterface, error)
 	ListSiblingInterfaces() ([]SiblingInterface, error)
+	ListDroppedPackets() ([]DroppedPacket, error)
 }
 
 // InternalInterface represents the internal interface of a router.
@@ -96,6 +144,31 @@ type SiblingInterface struct {
 	State InterfaceState
 }
 
+// DroppedPacket describes a packet that was dropped by the router.
+type DroppedPacket struct {
+	// Time is the time the packet was dropped.
+	Time time.Time
+	// Interface is the interface the packet was received on, 0 for the
+	// internal interface.
+	Interface uint16
+	// Reason is the reason why the packet was dropped, as reported in the
+	// reason label of the dropped packets metric.
+	Reason string
+	// Error is the error that caused the drop, if any.
+	Error string
+	// SrcIA and DstIA are the source and destination ISD-AS of the packet.
+	SrcIA addr.IA
+	DstIA addr.IA
+	// SrcHost and DstHost are the source and destination host addresses of
+	// the packet.
+	SrcHost string
+	DstHost string
+	// PathType is the type of the path of the packet.
+	PathType string
+	// Length is the length of the packet in bytes.
+	Length int
+}
+
 // InterfaceState indicates the state of the interface.
 type InterfaceState string
 
@@ -114,24 +187,24 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	if err := dp.CreateIACtx(cfg.IA); err != nil {
 		return err
 	}
-	// Set Keys
-	// XXX HSR currently only support 1 key, so use Key0
-	// Should it be an error if no key is set?
-	if len(cfg.MasterKeys.Key0) > 0 {
-		key0 := scrypto.DeriveHFMacKey(cfg.MasterKeys.Key0)
-		if err := dp.SetKey(cfg.IA, 0, key0); err != nil {
-			return err
-		}
-
-		keyColibri := scrypto.DeriveColibriMacKey(cfg.MasterKeys.Key0)
-		if err := dp.SetColibriKey(cfg.IA, 0, keyColibri); err != nil {
+	if err := dp.SetSCMPRateLimits(cfg.IA, cfg.SCMPRateLimits); err != nil {
+		return err
+	}
+	if cfg.Topo != nil {
+		if err := dp.SetEndhostPorts(cfg.IA, cfg.Topo.EndhostPorts()); err != nil {
 			return err
 		}
 	}
+	// Set Keys
+	// Should it be an error if no key is set?
+	if err := confKeys(dp, cfg); err != nil {
+		return err
+	}
 	// Add internal interfaces
 	if cfg.BR != nil {
 		if cfg.BR.InternalAddr != nil {
-			if err := dp.AddInternalInterface(cfg.IA, *cfg.BR.InternalAddr); err != nil {
+			err := dp.AddInternalInterface(cfg.IA, *cfg.BR.InternalAddr, cfg.BR.AltInternalAddr)
+			if err != nil {
 				return err
 			}
 		}
@@ -147,21 +220,48 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	return nil
 }
 
-func confExternalInterfaces(dp Dataplane, cfg *Config) error {
-	// Sort out keys/ifids to get deterministic order for unit testing
-	infoMap := cfg.Topo.IFInfoMap()
-	if len(infoMap) == 0 {
-		// nothing to do
-		return nil
+// confKeys sets the keys derived from the master keys. The hop field MACs are
+// verified with the key derived from Key0, or else with the one derived from
+// Key1, which allows to roll over the keys without invalidating the hop fields
+// issued with the previous key.
+func confKeys(dp Dataplane, cfg *Config) error {
+	for i, key := range [][]byte{cfg.MasterKeys.Key0, cfg.MasterKeys.Key1} {
+		if len(key) == 0 {
+			continue
+		}
+		if err := dp.SetKey(cfg.IA, i, scrypto.DeriveHFMacKey(key)); err != nil {
+			return err
+		}
+		if err := dp.SetColibriKey(cfg.IA, i, scrypto.DeriveColibriMacKey(key)); err != nil {
+			return err
+		}
 	}
-	ifids := []common.IFIDType{}
-	for k := range infoMap {
-		ifids = append(ifids, k)
+	return nil
+}
+
+// externalInterface is the configuration of an external interface.
+type externalInterface struct {
+	link  LinkInfo
+	owned bool
+}
+
+func confExternalInterfaces(dp Dataplane, cfg *Config) error {
+	intfs := externalInterfaces(cfg)
+	for _, ifid := range sortedIFIDs(intfs) {
+		intf := intfs[ifid]
+		if err := dp.AddExternalInterface(ifid, intf.link, intf.owned); err != nil {
+			return err
+		}
 	}
-	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
-	// External interfaces
-	for _, ifid := range ifids {
-		iface := infoMap[ifid]
+	return nil
+}
+
+// externalInterfaces returns the configuration of the external interfaces of
+// the AS.
+func externalInterfaces(cfg *Config) map[common.IFIDType]externalInterface {
+	infoMap := cfg.Topo.IFInfoMap()
+	intfs := make(map[common.IFIDType]externalInterface, len(infoMap))
+	for ifid, iface := range infoMap {
 		linkInfo := LinkInfo{
 			Local: LinkEnd{
 				IA:   cfg.IA,
@@ -184,18 +284,27 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 			// When setting up external interfaces that belong to other routers in the AS, they
 			// are basically IP/UDP tunnels between the two border routers, and as such is
 			// configured in the data plane.
-			linkInfo.Local.Addr = snet.CopyUDPAddr(cfg.BR.InternalAddr)
-			linkInfo.Remote.Addr = snet.CopyUDPAddr(iface.InternalAddr)
+			local, remote := siblingAddrs(cfg.BR, iface)
+			linkInfo.Local.Addr = snet.CopyUDPAddr(local)
+			linkInfo.Remote.Addr = snet.CopyUDPAddr(remote)
 			// For internal BFD always use the default configuration, which can be modified with
 			// the env variables.
 			linkInfo.BFD = BFDDefaults
 		}
+		intfs[ifid] = externalInterface{link: linkInfo, owned: owned}
+	}
+	return intfs
+}
 
-		if err := dp.AddExternalInterface(ifid, linkInfo, owned); err != nil {
-			return err
-		}
+// sortedIFIDs returns the sorted interface IDs, to get a deterministic order
+// for unit testing.
+func sortedIFIDs(intfs map[common.IFIDType]externalInterface) []common.IFIDType {
+	ifids := make([]common.IFIDType, 0, len(intfs))
+	for k := range intfs {
+		ifids = append(ifids, k)
 	}
-	return nil
+	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
+	return ifids
 }
 
 var svcTypes = []addr.HostSVC{
@@ -205,12 +314,26 @@ var svcTypes = []addr.HostSVC{
 }
 
 func confServices(dp Dataplane, cfg *Config) error {
+	svcAddrs := services(cfg)
+	for _, svc := range svcTypes {
+		for _, ip := range svcAddrs[svc] {
+			if err := dp.AddSvc(cfg.IA, svc, ip); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// services returns the addresses of the services in the topology.
+func services(cfg *Config) map[addr.HostSVC][]net.IP {
 	if cfg.Topo == nil {
 		// nothing to tdo
 		return nil
 	}
+	svcAddrs := make(map[addr.HostSVC][]net.IP)
 	for _, svc := range svcTypes {
-		addrs, err := cfg.Topo.UnderlayMulticast(svc)
+		addrs, err := cfg.Topo.UnderlayMulticastFor(svc, internalFamily(cfg))
 		if err != nil {
 			// XXX assumption is that any error means there are no addresses for the SVC type
 			continue
@@ -220,7 +343,137 @@ func confServices(dp Dataplane, cfg *Config) error {
 			return addrs[i].IP.String() < addrs[j].IP.String()
 		})
 		for _, a := range addrs {
-			if err := dp.AddSvc(cfg.IA, svc, a.IP); err != nil {
+			svcAddrs[svc] = append(svcAddrs[svc], a.IP)
+		}
+	}
+	return svcAddrs
+}
+
+// internalFamily returns an address in the IP family of the internal
+// interface, or nil if the internal interface is dual-stack.
+func internalFamily(cfg *Config) net.IP {
+	if cfg.BR == nil || cfg.BR.InternalAddr == nil || cfg.BR.AltInternalAddr != nil {
+		return nil
+	}
+	return cfg.BR.InternalAddr.IP
+}
+
+// siblingAddrs returns the local and remote internal addresses of the link to
+// the router that owns the interface. The routers use an IP family that both
+// of them have. If both routers are dual-stack, they use IPv6, such that they
+// agree on the addresses of the link.
+func siblingAddrs(br *topology.BRInfo, iface topology.IFInfo) (*net.UDPAddr, *net.UDPAddr) {
+	locals := []*net.UDPAddr{br.InternalAddr, br.AltInternalAddr}
+	remotes := []*net.UDPAddr{iface.InternalAddr, iface.AltInternalAddr}
+	for _, ipv6 := range []bool{true, false} {
+		local, remote := addrInFamily(locals, ipv6), addrInFamily(remotes, ipv6)
+		if local != nil && remote != nil {
+			return local, remote
+		}
+	}
+	return br.InternalAddr, iface.InternalAddr
+}
+
+func addrInFamily(addrs []*net.UDPAddr, ipv6 bool) *net.UDPAddr {
+	for _, a := range addrs {
+		if a != nil && (a.IP.To4() == nil) == ipv6 {
+			return a
+		}
+	}
+	return nil
+}
+
+// ReconfigDataplane reconfigures the running data-plane, which is configured
+// with prev, with the new configuration. The changes of the external
+// interfaces and of the keys are applied atomically. An interface that
+// changed is removed and added again. The service addresses are updated
+// afterwards. Changes of the ISD-AS, of the internal interface and of the end
+// host port range require a restart of the router.
+func ReconfigDataplane(dp ReconfigurableDataplane, prev, cfg *Config) error {
+	if prev == nil || cfg == nil || prev.BR == nil || cfg.BR == nil {
+		return serrors.New("empty configuration")
+	}
+	if !cfg.IA.Equal(prev.IA) {
+		return serrors.New("changing the ISD-AS requires a restart",
+			"current", prev.IA, "new", cfg.IA)
+	}
+	if !reflect.DeepEqual(cfg.BR.InternalAddr, prev.BR.InternalAddr) ||
+		!reflect.DeepEqual(cfg.BR.AltInternalAddr, prev.BR.AltInternalAddr) {
+
+		return serrors.New("changing the internal interface requires a restart",
+			"current", prev.BR.InternalAddr, "new", cfg.BR.InternalAddr,
+			"current_alt", prev.BR.AltInternalAddr, "new_alt", cfg.BR.AltInternalAddr)
+	}
+	if prev.Topo != nil && cfg.Topo != nil && cfg.Topo.EndhostPorts() != prev.Topo.EndhostPorts() {
+		return serrors.New("changing the end host port range requires a restart",
+			"current", prev.Topo.EndhostPorts(), "new", cfg.Topo.EndhostPorts())
+	}
+	prevIntfs, intfs := externalInterfaces(prev), externalInterfaces(cfg)
+	unchanged := func(ifid common.IFIDType) bool {
+		prevIntf, ok := prevIntfs[ifid]
+		return ok && reflect.DeepEqual(prevIntf, intfs[ifid])
+	}
+	err := dp.Reconfigure(func() error {
+		for _, ifid := range sortedIFIDs(prevIntfs) {
+			if _, ok := intfs[ifid]; ok && unchanged(ifid) {
+				continue
+			}
+			if err := dp.RemoveExternalInterface(ifid); err != nil {
+				return err
+			}
+		}
+		// The keys are set before the interfaces are added, such that the
+		// BFD sessions of the new interfaces use the new keys.
+		if !bytes.Equal(cfg.MasterKeys.Key0, prev.MasterKeys.Key0) ||
+			!bytes.Equal(cfg.MasterKeys.Key1, prev.MasterKeys.Key1) {
+
+			if err := confKeys(dp, cfg); err != nil {
+				return err
+			}
+		}
+		for _, ifid := range sortedIFIDs(intfs) {
+			if unchanged(ifid) {
+				continue
+			}
+			intf := intfs[ifid]
+			if err := dp.AddExternalInterface(ifid, intf.link, intf.owned); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return reconfServices(dp, prev, cfg)
+}
+
+// reconfServices deletes the service addresses of prev that are not in the
+// new configuration, and adds the ones that are new.
+func reconfServices(dp Dataplane, prev, cfg *Config) error {
+	prevAddrs, svcAddrs := services(prev), services(cfg)
+	contains := func(ips []net.IP, ip net.IP) bool {
+		for _, other := range ips {
+			if other.Equal(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, svc := range svcTypes {
+		for _, ip := range prevAddrs[svc] {
+			if contains(svcAddrs[svc], ip) {
+				continue
+			}
+			if err := dp.DelSvc(cfg.IA, svc, ip); err != nil {
+				return err
+			}
+		}
+		for _, ip := range svcAddrs[svc] {
+			if contains(prevAddrs[svc], ip) {
+				continue
+			}
+			if err := dp.AddSvc(cfg.IA, svc, ip); err != nil {
 				return err
 			}
 		}
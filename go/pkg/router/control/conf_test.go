@@ -0,0 +1,170 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+func TestReconfigDataplane(t *testing.T) {
+	prev, err := control.LoadConfig("br1-ff00_0_110-2", "testdata")
+	require.NoError(t, err)
+	cfg, err := control.LoadConfig("br1-ff00_0_110-2", "testdata/reload")
+	require.NoError(t, err)
+
+	t.Run("applies changes", func(t *testing.T) {
+		dp := &recordingDataplane{}
+		require.NoError(t, control.ReconfigDataplane(dp, prev, cfg))
+		assert.Equal(t, []string{
+			"reconfigure",
+			"remove 1",
+			"key 0",
+			"colibri key 0",
+			"key 1",
+			"colibri key 1",
+			"add 3 owned=true",
+			"reconfigured",
+			"add svc CS A (0x0002) 127.0.0.3",
+		}, dp.calls)
+	})
+	t.Run("nothing changed", func(t *testing.T) {
+		dp := &recordingDataplane{}
+		require.NoError(t, control.ReconfigDataplane(dp, prev, prev))
+		assert.Equal(t, []string{"reconfigure", "reconfigured"}, dp.calls)
+	})
+	t.Run("failure skips services", func(t *testing.T) {
+		dp := &recordingDataplane{removeErr: serrors.New("test error")}
+		assert.Error(t, control.ReconfigDataplane(dp, prev, cfg))
+		assert.Equal(t, []string{"reconfigure", "remove 1"}, dp.calls)
+	})
+}
+
+func TestConfigDataplaneDualStack(t *testing.T) {
+	t.Run("dual-stack router", func(t *testing.T) {
+		cfg, err := control.LoadConfig("br1-ff00_0_110-1", "testdata/dualstack")
+		require.NoError(t, err)
+		dp := &recordingDataplane{}
+		require.NoError(t, control.ConfigDataplane(dp, cfg))
+		assert.Equal(t, []string{
+			"add internal 127.0.0.1:50000 alt=[::1]:50000",
+			"add 1 owned=true",
+			"add 2 owned=false local=[::1]:50000 remote=[::1]:50001",
+			"add svc CS A (0x0002) 127.0.0.3",
+			"add svc CS A (0x0002) ::4",
+		}, dp.calls[4:], "calls after setting the keys")
+	})
+	t.Run("IPv6 router", func(t *testing.T) {
+		cfg, err := control.LoadConfig("br1-ff00_0_110-2", "testdata/dualstack")
+		require.NoError(t, err)
+		dp := &recordingDataplane{}
+		require.NoError(t, control.ConfigDataplane(dp, cfg))
+		assert.Equal(t, []string{
+			"add internal [::1]:50001 alt=<nil>",
+			"add 1 owned=false local=[::1]:50001 remote=[::1]:50000",
+			"add 2 owned=true",
+			"add svc CS A (0x0002) ::3",
+			"add svc CS A (0x0002) ::4",
+		}, dp.calls[4:], "calls after setting the keys")
+	})
+}
+
+// recordingDataplane records the calls of the reconfiguration.
+type recordingDataplane struct {
+	calls     []string
+	removeErr error
+}
+
+func (d *recordingDataplane) record(format string, args ...interface{}) {
+	d.calls = append(d.calls, fmt.Sprintf(format, args...))
+}
+
+func (d *recordingDataplane) CreateIACtx(ia addr.IA) error { return nil }
+
+func (d *recordingDataplane) AddInternalInterface(ia addr.IA, local net.UDPAddr,
+	alt *net.UDPAddr) error {
+
+	d.record("add internal %s alt=%s", &local, alt)
+	return nil
+}
+
+func (d *recordingDataplane) AddExternalInterface(ifID common.IFIDType,
+	info control.LinkInfo, owned bool) error {
+
+	if !owned {
+		d.record("add %d owned=false local=%s remote=%s", ifID, info.Local.Addr,
+			info.Remote.Addr)
+		return nil
+	}
+	d.record("add %d owned=true", ifID)
+	return nil
+}
+
+func (d *recordingDataplane) RemoveExternalInterface(ifID common.IFIDType) error {
+	d.record("remove %d", ifID)
+	return d.removeErr
+}
+
+func (d *recordingDataplane) AddSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
+	d.record("add svc %s %s", svc, ip)
+	return nil
+}
+
+func (d *recordingDataplane) DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
+	d.record("del svc %s %s", svc, ip)
+	return nil
+}
+
+func (d *recordingDataplane) SetKey(ia addr.IA, index int, key []byte) error {
+	d.record("key %d", index)
+	return nil
+}
+
+func (d *recordingDataplane) SetColibriKey(ia addr.IA, index int, key []byte) error {
+	d.record("colibri key %d", index)
+	return nil
+}
+
+func (d *recordingDataplane) SetSCMPRateLimits(ia addr.IA,
+	limits control.SCMPRateLimits) error {
+
+	return nil
+}
+
+func (d *recordingDataplane) SetEndhostPorts(ia addr.IA, ports underlay.PortRange) error {
+	if !ports.Empty() {
+		d.record("endhost ports %s", ports)
+	}
+	return nil
+}
+
+func (d *recordingDataplane) Reconfigure(fn func() error) error {
+	d.record("reconfigure")
+	if err := fn(); err != nil {
+		return err
+	}
+	d.record("reconfigured")
+	return nil
+}
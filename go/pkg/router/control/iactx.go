@@ -37,6 +37,8 @@ type Config struct {
 	BR *topology.BRInfo
 	// MasterKeys holds the local AS master keys.
 	MasterKeys keyconf.Master
+	// SCMPRateLimits are the limits on the SCMP messages sent by the router.
+	SCMPRateLimits SCMPRateLimits
 }
 
 // LoadConfig sets up the configuration, loading it from the supplied config directory.
@@ -120,6 +122,27 @@ func (iac *IACtx) Configure() error {
 	return nil
 }
 
+// Reconfigure reconfigures the running dataplane with the new configuration,
+// see ReconfigDataplane. On success, the new configuration becomes the
+// configuration of the context.
+func (iac *IACtx) Reconfigure(cfg *Config) error {
+	if cfg == nil {
+		return serrors.New("empty configuration")
+	}
+	dp, ok := iac.DP.(ReconfigurableDataplane)
+	if !ok {
+		return serrors.New("dataplane does not support reconfiguration")
+	}
+
+	log.Debug("Reconfiguring Dataplane")
+	if err := ReconfigDataplane(dp, iac.Config, cfg); err != nil {
+		return serrors.WrapStr("reconfiguring dataplane", err)
+	}
+	iac.Config = cfg
+	log.Debug("Dataplane reconfigured successfully", "config", cfg)
+	return nil
+}
+
 func dumpConfig(cfg *Config) (string, error) {
 	if cfg == nil {
 		return "", serrors.New("empty configuration")
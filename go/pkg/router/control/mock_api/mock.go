@@ -34,6 +34,21 @@ func (m *MockObservableDataplane) EXPECT() *MockObservableDataplaneMockRecorder
 	return m.recorder
 }
 
+// ListDroppedPackets mocks base method.
+func (m *MockObservableDataplane) ListDroppedPackets() ([]control.DroppedPacket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDroppedPackets")
+	ret0, _ := ret[0].([]control.DroppedPacket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDroppedPackets indicates an expected call of ListDroppedPackets.
+func (mr *MockObservableDataplaneMockRecorder) ListDroppedPackets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDroppedPackets", reflect.TypeOf((*MockObservableDataplane)(nil).ListDroppedPackets))
+}
+
 // ListExternalInterfaces mocks base method.
 func (m *MockObservableDataplane) ListExternalInterfaces() ([]control.ExternalInterface, error) {
 	m.ctrl.T.Helper()
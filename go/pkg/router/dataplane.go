@@ -15,11 +15,11 @@
 package router
 
 import (
-	"bytes"
 	"context"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
@@ -27,7 +27,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/gopacket"
@@ -51,11 +51,12 @@ import (
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	sheader "github.com/scionproto/scion/go/lib/slayers/scion"
 	"github.com/scionproto/scion/go/lib/topology"
-	"github.com/scionproto/scion/go/lib/underlay/conn"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 	underlayconn "github.com/scionproto/scion/go/lib/underlay/conn"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/router/bfd"
 	"github.com/scionproto/scion/go/pkg/router/control"
+	"github.com/scionproto/scion/go/pkg/router/filter"
 )
 
 const (
@@ -93,23 +94,52 @@ type BatchConn interface {
 //
 // XXX(lukedirtwalker): this is still in development and not feature complete.
 // Currently, only the following features are supported:
-//  - initializing connections; MUST be done prior to calling Run
+//  - initializing connections; MUST be done prior to calling Run, or within
+//    Reconfigure once the dataplane is running
 type DataPlane struct {
-	external          map[uint16]BatchConn
-	linkTypes         map[uint16]topology.LinkType
-	neighborIAs       map[uint16]addr.IA
-	internal          BatchConn
-	internalIP        net.IP
-	internalNextHops  map[uint16]*net.UDPAddr
-	svc               *services
-	macFactory        func() hash.Hash
-	colibriKey        cipher.Block
-	bfdSessions       map[uint16]bfdSession
-	localIA           addr.IA
-	mtx               sync.Mutex
-	running           bool
-	Metrics           *Metrics
-	forwardingMetrics map[uint16]forwardingMetrics
+	external            map[uint16]BatchConn
+	linkTypes           map[uint16]topology.LinkType
+	neighborIAs         map[uint16]addr.IA
+	internal            BatchConn
+	internalIP          net.IP
+	internalAlt         BatchConn
+	internalAltIP       net.IP
+	internalNextHops    map[uint16]*net.UDPAddr
+	endhostPorts        underlay.PortRange
+	svc                 *services
+	macFactory          func() hash.Hash
+	secondaryMacFactory func() hash.Hash
+	colibriKey          cipher.Block
+	secondaryColibriKey cipher.Block
+	bfdSessions         map[uint16]bfdSession
+	localIA             addr.IA
+	mtx                 sync.Mutex
+	running             bool
+	reconfiguring       bool
+	Metrics             *Metrics
+
+	// NumProcessors is the number of goroutines that process the packets. If
+	// it is not set, runtime.GOMAXPROCS(0) is used.
+	NumProcessors int
+	// BatchSize is the maximal number of packets read or written in a single
+	// system call. If it is not set, inputBatchCnt is used.
+	BatchSize int
+	// DropTraceSampling is the sampling of the dropped packets that are kept
+	// in the drop trace, i.e., every DropTraceSampling-th packet dropped for
+	// the same reason is kept. If it is not set, defaultDropTraceSampling is
+	// used.
+	DropTraceSampling int
+
+	drops       dropTrace
+	scmpLimiter *scmpRateLimiter
+	// filter holds the *filter.Filter applied to the received packets. It can
+	// be replaced while the dataplane is running.
+	filter atomic.Value
+	// state holds the *forwardingState the packets are processed with once
+	// the dataplane is running.
+	state atomic.Value
+	// pipeline is the packet processing pipeline of the running dataplane.
+	pipeline *pipeline
 }
 
 var (
@@ -124,6 +154,12 @@ var (
 	noBFDSessionFound             = serrors.New("no BFD sessions was found")
 	noBFDSessionConfigured        = serrors.New("no BFD sessions have been configured")
 	errBFDDisabled                = serrors.New("BFD is disabled")
+	malformedPacket               = serrors.New("malformed packet")
+	expiredPacket                 = serrors.New("packet expired")
+	invalidMAC                    = serrors.New("invalid MAC")
+	invalidIngress                = serrors.New("invalid ingress interface")
+	scmpRateLimited               = serrors.New("SCMP message rate limited")
+	filteredPacket                = serrors.New("packet dropped by filter")
 )
 
 type scmpError struct {
@@ -153,39 +189,73 @@ func (d *DataPlane) SetIA(ia addr.IA) error {
 }
 
 // SetKey sets the key used for MAC verification. The key provided here should
-// already be derived as in scrypto.HFMacFactory.
+// already be derived as in scrypto.HFMacFactory. Within Reconfigure, the key
+// can be replaced.
 func (d *DataPlane) SetKey(key []byte) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 	if len(key) == 0 {
 		return emptyValue
 	}
-	if d.macFactory != nil {
+	if d.macFactory != nil && !d.reconfiguring {
 		return alreadySet
 	}
+	macFactory, err := newMacFactory(key)
+	if err != nil {
+		return err
+	}
+	d.macFactory = macFactory
+	return nil
+}
+
+// SetSecondaryKey sets the key the MACs are verified with if they don't
+// match the MACs computed with the key set by SetKey. During a key rollover,
+// it is set to the previous key, such that the hop fields that were issued
+// with the previous key stay valid. Within Reconfigure, the key can be
+// replaced.
+func (d *DataPlane) SetSecondaryKey(key []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.configurable() {
+		return modifyExisting
+	}
+	if len(key) == 0 {
+		return emptyValue
+	}
+	if d.secondaryMacFactory != nil && !d.reconfiguring {
+		return alreadySet
+	}
+	macFactory, err := newMacFactory(key)
+	if err != nil {
+		return err
+	}
+	d.secondaryMacFactory = macFactory
+	return nil
+}
+
+func newMacFactory(key []byte) (func() hash.Hash, error) {
 	// First check for MAC creation errors.
 	if _, err := scrypto.InitMac(key); err != nil {
-		return err
+		return nil, err
 	}
-	d.macFactory = func() hash.Hash {
+	return func() hash.Hash {
 		mac, _ := scrypto.InitMac(key)
 		return mac
-	}
-	return nil
+	}, nil
 }
 
 // SetColibriKey sets the key used for Colibri MAC verification. The key provided here should
-// already be derived as in scrypto.HFMacFactory.
+// already be derived as in scrypto.HFMacFactory. Within Reconfigure, the key can be replaced.
 func (d *DataPlane) SetColibriKey(key []byte) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
-	if d.colibriKey != nil {
+	if d.colibriKey != nil && !d.reconfiguring {
 		return alreadySet
 	}
 
@@ -198,6 +268,65 @@ func (d *DataPlane) SetColibriKey(key []byte) error {
 	return nil
 }
 
+// SetSecondaryColibriKey sets the key the Colibri MACs are verified with if
+// they don't match the MACs computed with the key set by SetColibriKey, see
+// SetSecondaryKey.
+func (d *DataPlane) SetSecondaryColibriKey(key []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.configurable() {
+		return modifyExisting
+	}
+	if d.secondaryColibriKey != nil && !d.reconfiguring {
+		return alreadySet
+	}
+
+	keyColibri, err := libcolibri.InitColibriKey(key)
+	if err != nil {
+		return err
+	}
+
+	d.secondaryColibriKey = keyColibri
+	return nil
+}
+
+// SetSCMPRateLimits sets the limits on the SCMP messages sent by the router.
+// This can only be called on a not yet running dataplane.
+func (d *DataPlane) SetSCMPRateLimits(limits control.SCMPRateLimits) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	var suppressed *prometheus.CounterVec
+	if d.Metrics != nil {
+		suppressed = d.Metrics.SCMPSuppressedTotal
+	}
+	d.scmpLimiter = newSCMPRateLimiter(limits, suppressed)
+	return nil
+}
+
+// SetEndhostPorts sets the range of underlay ports to which the end hosts bind
+// directly. The packets for a SCION/UDP port in the range are delivered to the
+// same underlay port, and all other packets to the dispatcher. This can only be
+// called on a not yet running dataplane.
+func (d *DataPlane) SetEndhostPorts(ports underlay.PortRange) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	d.endhostPorts = ports
+	return nil
+}
+
+// SetFilter sets the filter that is applied to the received packets. Unlike
+// the other settings, the filter can be replaced while the dataplane is
+// running. A nil filter allows all packets.
+func (d *DataPlane) SetFilter(f *filter.Filter) {
+	d.filter.Store(f)
+}
+
 // AddInternalInterface sets the interface the data-plane will use to
 // send/receive traffic in the local AS. This can only be called once; future
 // calls will return an error. This can only be called on a not yet running
@@ -219,13 +348,62 @@ func (d *DataPlane) AddInternalInterface(conn BatchConn, ip net.IP) error {
 	return nil
 }
 
+// AddAltInternalInterface sets the socket of the internal interface for the
+// IP family of ip, which must differ from the family of the address of the
+// internal interface. The traffic to end hosts and routers of that family is
+// then sent and received on this socket. This can only be called once, after
+// AddInternalInterface, on a not yet running dataplane.
+func (d *DataPlane) AddAltInternalInterface(conn BatchConn, ip net.IP) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if conn == nil || ip == nil {
+		return emptyValue
+	}
+	if d.internal == nil {
+		return serrors.New("internal interface not set")
+	}
+	if d.internalAlt != nil {
+		return alreadySet
+	}
+	if (ip.To4() != nil) == (d.internalIP.To4() != nil) {
+		return serrors.New("alternative internal address must be of the other IP family",
+			"internal", d.internalIP, "alt", ip)
+	}
+	d.internalAlt = conn
+	d.internalAltIP = ip
+	return nil
+}
+
+// internalConn returns the socket of the internal interface to send to the
+// underlay address from.
+func (d *DataPlane) internalConn(dst *net.UDPAddr) BatchConn {
+	if d.internalAlt != nil && dst != nil &&
+		(dst.IP.To4() != nil) != (d.internalIP.To4() != nil) {
+		return d.internalAlt
+	}
+	return d.internal
+}
+
+// internalAddr returns the address of the internal interface in the IP family
+// of ip.
+func (d *DataPlane) internalAddr(ip net.IP) net.IP {
+	if d.internalAlt != nil && (ip.To4() != nil) != (d.internalIP.To4() != nil) {
+		return d.internalAltIP
+	}
+	return d.internalIP
+}
+
 // AddExternalInterface adds the inter AS connection for the given interface ID.
 // If a connection for the given ID is already set this method will return an
-// error. This can only be called on a not yet running dataplane.
+// error. This can only be called on a not yet running dataplane, or within
+// Reconfigure.
 func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 	if conn == nil {
@@ -243,11 +421,11 @@ func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 
 // AddNeighborIA adds the neighboring IA for a given interface ID. If an IA for
 // the given ID is already set, this method will return an error. This can only
-// be called on a yet running dataplane.
+// be called on a not yet running dataplane, or within Reconfigure.
 func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 	if remote.IsZero() {
@@ -265,8 +443,13 @@ func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 
 // AddLinkType adds the link type for a given interface ID. If a link type for
 // the given ID is already set, this method will return an error. This can only
-// be called on a not yet running dataplane.
+// be called on a not yet running dataplane, or within Reconfigure.
 func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.configurable() {
+		return modifyExisting
+	}
 	if _, exists := d.linkTypes[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
@@ -283,7 +466,7 @@ func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 	if conn == nil {
@@ -323,7 +506,7 @@ func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 // returns InterfaceUp if the relevant bfdsession state is up, or if there is no BFD
 // session. Otherwise, it returns InterfaceDown.
 func (d *DataPlane) getInterfaceState(interfaceID uint16) control.InterfaceState {
-	bfdSessions := d.bfdSessions
+	bfdSessions := d.currentState().bfdSessions
 	if bfdSession, ok := bfdSessions[interfaceID]; ok && !bfdSession.IsUp() {
 		return control.InterfaceDown
 	}
@@ -401,11 +584,11 @@ func (d *DataPlane) DelSvc(svc addr.HostSVC, a *net.UDPAddr) error {
 
 // AddNextHop sets the next hop address for the given interface ID. If the
 // interface ID already has an address associated this operation fails. This can
-// only be called on a not yet running dataplane.
+// only be called on a not yet running dataplane, or within Reconfigure.
 func (d *DataPlane) AddNextHop(ifID uint16, a *net.UDPAddr) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 	if a == nil {
@@ -429,7 +612,7 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
+	if !d.configurable() {
 		return modifyExisting
 	}
 
@@ -460,7 +643,7 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 		}
 	}
 	s := &bfdSend{
-		conn:    d.internal,
+		conn:    d.internalConn(dst),
 		srcAddr: src,
 		dstAddr: dst,
 		srcIA:   d.localIA,
@@ -471,108 +654,16 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 	return d.addBFDController(ifID, s, cfg, m)
 }
 
-// Run starts running the dataplane. Note that configuration is not possible
-// after calling this method.
+// Run starts running the dataplane. Afterwards, the configuration can only be
+// changed with Reconfigure.
 func (d *DataPlane) Run(ctx context.Context) error {
 	d.mtx.Lock()
 	d.running = true
 
-	d.initMetrics()
-
-	read := func(ingressID uint16, rd BatchConn) {
-
-		msgs := conn.NewReadMessages(inputBatchCnt)
-		for _, msg := range msgs {
-			msg.Buffers[0] = make([]byte, bufSize)
-		}
-		writeMsgs := make(underlayconn.Messages, 1)
-		writeMsgs[0].Buffers = make([][]byte, 1)
-
-		processor := newPacketProcessor(d, ingressID)
-		var scmpErr scmpError
-		for d.running {
-			pkts, err := rd.ReadBatch(msgs)
-			if err != nil {
-				log.Debug("Failed to read batch", "err", err)
-				// error metric
-				continue
-			}
-			if pkts == 0 {
-				continue
-			}
-			for _, p := range msgs[:pkts] {
-				// input metric
-				inputCounters := d.forwardingMetrics[ingressID]
-				inputCounters.InputPacketsTotal.Inc()
-				inputCounters.InputBytesTotal.Add(float64(p.N))
-
-				srcAddr := p.Addr.(*net.UDPAddr)
-				result, err := processor.processPkt(p.Buffers[0][:p.N], srcAddr)
-
-				switch {
-				case err == nil:
-				case errors.As(err, &scmpErr):
-					if !scmpErr.TypeCode.InfoMsg() {
-						log.Debug("SCMP", "err", scmpErr, "dst_addr", p.Addr)
-					}
-					// SCMP go back the way they came.
-					result.OutAddr = srcAddr
-					result.OutConn = rd
-				default:
-					log.Debug("Error processing packet", "err", err)
-					inputCounters.DroppedPacketsTotal.Inc()
-					continue
-				}
-				if result.OutConn == nil { // e.g. BFD case no message is forwarded
-					continue
-				}
-
-				// Write to OutConn; drop the packet if this would block.
-				// Use WriteBatch because it's the only available function that
-				// supports MSG_DONTWAIT.
-				writeMsgs[0].Buffers[0] = result.OutPkt
-				writeMsgs[0].Addr = nil
-				if result.OutAddr != nil { // don't assign directly to net.Addr, typed nil!
-					writeMsgs[0].Addr = result.OutAddr
-				}
-
-				_, err = result.OutConn.WriteBatch(writeMsgs, syscall.MSG_DONTWAIT)
-				if err != nil {
-					var errno syscall.Errno
-					if !errors.As(err, &errno) ||
-						!(errno == syscall.EAGAIN || errno == syscall.EWOULDBLOCK) {
-						log.Debug("Error writing packet", "err", err)
-						// error metric
-					}
-					inputCounters.DroppedPacketsTotal.Inc()
-					continue
-				}
-				// ok metric
-				outputCounters := d.forwardingMetrics[result.EgressID]
-				outputCounters.OutputPacketsTotal.Inc()
-				outputCounters.OutputBytesTotal.Add(float64(len(result.OutPkt)))
-			}
-		}
-	}
-
-	for k, v := range d.bfdSessions {
-		go func(ifID uint16, c bfdSession) {
-			defer log.HandlePanic()
-			if err := c.Run(); err != nil && err != bfd.AlreadyRunning {
-				log.Error("BFD session failed to start", "ifID", ifID, "err", err)
-			}
-		}(k, v)
-	}
-	for ifID, v := range d.external {
-		go func(i uint16, c BatchConn) {
-			defer log.HandlePanic()
-			read(i, c)
-		}(ifID, v)
-	}
-	go func(c BatchConn) {
-		defer log.HandlePanic()
-		read(0, c)
-	}(d.internal)
+	state := d.newState(nil)
+	d.state.Store(state)
+	d.runPipeline(ctx)
+	d.apply(nil, state)
 
 	d.mtx.Unlock()
 
@@ -580,22 +671,6 @@ func (d *DataPlane) Run(ctx context.Context) error {
 	return nil
 }
 
-// initMetrics initializes the metrics related to packet forwarding. The
-// counters are already instantiated for all the relevant interfaces so this
-// will not have to be repeated during packet forwarding.
-func (d *DataPlane) initMetrics() {
-	d.forwardingMetrics = make(map[uint16]forwardingMetrics)
-	labels := interfaceToMetricLabels(0, d.localIA, d.neighborIAs)
-	d.forwardingMetrics[0] = initForwardingMetrics(d.Metrics, labels)
-	for id := range d.external {
-		if _, notOwned := d.internalNextHops[id]; notOwned {
-			continue
-		}
-		labels = interfaceToMetricLabels(id, d.localIA, d.neighborIAs)
-		d.forwardingMetrics[id] = initForwardingMetrics(d.Metrics, labels)
-	}
-}
-
 type processResult struct {
 	EgressID uint16
 	OutConn  BatchConn
@@ -608,14 +683,28 @@ func newPacketProcessor(d *DataPlane, ingressID uint16) *scionPacketProcessor {
 		d:         d,
 		ingressID: ingressID,
 		buffer:    gopacket.NewSerializeBuffer(),
-		mac:       d.macFactory(),
 		macBuffers: macBuffers{
-			scionInput: make([]byte, path.MACBufferSize),
-			epicInput:  make([]byte, libepic.MACBufferSize),
+			scionInput:          make([]byte, path.MACBufferSize),
+			secondaryScionInput: make([]byte, path.MACBufferSize),
+			epicInput:           make([]byte, libepic.MACBufferSize),
 		},
 	}
 }
 
+// setState sets the forwarding state the next packet is processed with. The
+// MAC hashes are only initialized if the state changed.
+func (p *scionPacketProcessor) setState(state *forwardingState) {
+	if state == p.state {
+		return
+	}
+	p.state = state
+	p.mac = state.macFactory()
+	p.secondaryMac = nil
+	if state.secondaryMacFactory != nil {
+		p.secondaryMac = state.secondaryMacFactory()
+	}
+}
+
 func (p *scionPacketProcessor) reset() error {
 	p.rawPkt = nil
 	//p.scionLayer // cannot easily be reset
@@ -628,12 +717,14 @@ func (p *scionPacketProcessor) reset() error {
 	}
 	p.mac.Reset()
 	p.cachedMac = nil
+	p.scmpTypeCode = 0
 	return nil
 }
 
 func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 	srcAddr *net.UDPAddr) (processResult, error) {
 
+	p.setState(p.d.currentState())
 	p.reset()
 	p.rawPkt = rawPkt
 
@@ -641,10 +732,18 @@ func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 	var err error
 	p.lastLayer, err = decodeLayers(p.rawPkt, &p.scionLayer, &p.hbhLayer, &p.e2eLayer)
 	if err != nil {
-		return processResult{}, err
+		return processResult{}, serrors.Wrap(malformedPacket, err)
 	}
 	pld := p.lastLayer.LayerPayload()
 
+	// BFD messages are never filtered, such that no rule can take down the
+	// links of the router.
+	if p.lastLayer.NextLayerType() != layers.LayerTypeBFD {
+		if err := p.filterPacket(); err != nil {
+			return processResult{}, err
+		}
+	}
+
 	pathType := p.scionLayer.PathType
 	switch pathType {
 	case empty.PathType:
@@ -673,8 +772,20 @@ func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 	}
 }
 
+// filterPacket applies the filter of the dataplane to the packet. It returns an
+// error if the packet is dropped by the filter.
+func (p *scionPacketProcessor) filterPacket() error {
+	f, _ := p.d.filter.Load().(*filter.Filter)
+	last, _ := p.lastLayer.(gopacket.Layer)
+	action, rule := f.Eval(p.ingressID, &p.scionLayer, last)
+	if action == filter.Drop {
+		return serrors.WithCtx(filteredPacket, "rule", rule)
+	}
+	return nil
+}
+
 func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.state.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 
@@ -683,7 +794,7 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) err
 		return err
 	}
 
-	if v, ok := p.d.bfdSessions[p.ingressID]; ok {
+	if v, ok := p.state.bfdSessions[p.ingressID]; ok {
 		v.Messages() <- bfd
 		return nil
 	}
@@ -692,7 +803,7 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) err
 }
 
 func (p *scionPacketProcessor) processIntraBFD(src *net.UDPAddr, data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.state.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 	bfd := &layers.BFD{}
@@ -701,14 +812,14 @@ func (p *scionPacketProcessor) processIntraBFD(src *net.UDPAddr, data []byte) er
 	}
 
 	ifID := uint16(0)
-	for k, v := range p.d.internalNextHops {
-		if bytes.Equal(v.IP, src.IP) && v.Port == src.Port {
+	for k, v := range p.state.internalNextHops {
+		if v.IP.Equal(src.IP) && v.Port == src.Port {
 			ifID = k
 			break
 		}
 	}
 
-	if v, ok := p.d.bfdSessions[ifID]; ok {
+	if v, ok := p.state.bfdSessions[ifID]; ok {
 		v.Messages() <- bfd
 		return nil
 	}
@@ -758,7 +869,7 @@ func (p *scionPacketProcessor) processEPIC() (processResult, error) {
 		err = libepic.VerifyTimestamp(timestamp, epicPath.PktID.Timestamp, time.Now())
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return processResult{}, err
+			return processResult{}, serrors.Wrap(expiredPacket, err)
 		}
 
 		HVF := epicPath.PHVF
@@ -769,7 +880,7 @@ func (p *scionPacketProcessor) processEPIC() (processResult, error) {
 			p.macBuffers.epicInput)
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return processResult{}, err
+			return processResult{}, serrors.Wrap(invalidMAC, err)
 		}
 	}
 
@@ -780,9 +891,11 @@ func (p *scionPacketProcessor) processCOLIBRI() (processResult, error) {
 
 	c := colibriPacketProcessor{
 		d:          p.d,
+		state:      p.state,
 		ingressID:  p.ingressID,
 		rawPkt:     p.rawPkt,
 		scionLayer: p.scionLayer,
+		lastLayer:  p.lastLayer,
 		buffer:     p.buffer,
 	}
 	return c.process()
@@ -793,6 +906,8 @@ func (p *scionPacketProcessor) processCOLIBRI() (processResult, error) {
 type scionPacketProcessor struct {
 	// d is a reference to the dataplane instance that initiated this processor.
 	d *DataPlane
+	// state is the forwarding state the current packet is processed with.
+	state *forwardingState
 	// ingressID is the interface ID this packet came in, determined from the
 	// socket.
 	ingressID uint16
@@ -803,6 +918,9 @@ type scionPacketProcessor struct {
 	buffer gopacket.SerializeBuffer
 	// mac is the hasher for the MAC computation.
 	mac hash.Hash
+	// secondaryMac is the hasher for the MAC computation with the secondary
+	// key, or nil if there is none.
+	secondaryMac hash.Hash
 
 	// scionLayer is the SCION gopacket layer.
 	scionLayer slayers.SCION
@@ -825,17 +943,22 @@ type scionPacketProcessor struct {
 	cachedMac []byte
 	// macBuffers avoid allocating memory during processing.
 	macBuffers macBuffers
+	// scmpTypeCode is the type and code of the SCMP error the packet is
+	// answered with, or 0 if there is none.
+	scmpTypeCode slayers.SCMPTypeCode
 }
 
 // macBuffers are preallocated buffers for the in- and outputs of MAC functions.
 type macBuffers struct {
-	scionInput []byte
-	epicInput  []byte
+	scionInput          []byte
+	secondaryScionInput []byte
+	epicInput           []byte
 }
 
 func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.SerializableLayer,
 	cause error) (processResult, error) {
 
+	p.scmpTypeCode = scmpH.TypeCode
 	// check invoking packet was an SCMP error:
 	if p.lastLayer.NextLayerType() == slayers.LayerTypeSCMP {
 		var scmpLayer slayers.SCMP
@@ -847,6 +970,16 @@ func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.Seri
 			return processResult{}, serrors.WrapStr("SCMP error for SCMP error pkt -> DROP", cause)
 		}
 	}
+	// The message is sent to the source of the invoking packet.
+	if p.d.scmpLimiter != nil && !p.d.scmpLimiter.allow(scmpH.TypeCode.Type(),
+		p.scionLayer.SrcIA, string(p.scionLayer.RawSrcAddr), time.Now()) {
+
+		if cause == nil {
+			return processResult{}, serrors.WithCtx(scmpRateLimited, "type_code", scmpH.TypeCode)
+		}
+		return processResult{}, serrors.Wrap(scmpRateLimited, cause,
+			"type_code", scmpH.TypeCode)
+	}
 
 	rawSCMP, err := p.prepareSCMP(
 		scmpH,
@@ -916,8 +1049,8 @@ func (p *scionPacketProcessor) validateIngressID() (processResult, error) {
 
 func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	pktEgressID := p.egressInterface()
-	_, ih := p.d.internalNextHops[pktEgressID]
-	_, eh := p.d.external[pktEgressID]
+	_, ih := p.state.internalNextHops[pktEgressID]
+	_, eh := p.state.external[pktEgressID]
 	if !ih && !eh {
 		errCode := slayers.SCMPCodeUnknownHopFieldEgress
 		if !p.infoField.ConsDir {
@@ -937,7 +1070,7 @@ func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	}
 	// Check that the interface pair is valid on a segment switch.
 	// Having a segment change received from the internal interface is never valid.
-	ingress, egress := p.d.linkTypes[p.ingressID], p.d.linkTypes[pktEgressID]
+	ingress, egress := p.state.linkTypes[p.ingressID], p.state.linkTypes[pktEgressID]
 	switch {
 	case ingress == topology.Core && egress == topology.Child:
 		return processResult{}, nil
@@ -986,18 +1119,24 @@ func (p *scionPacketProcessor) currentHopPointer() uint16 {
 func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
 	fullMac := path.FullMAC(p.mac, p.infoField, p.hopField, p.macBuffers.scionInput)
 	if subtle.ConstantTimeCompare(p.hopField.Mac[:path.MacLen], fullMac[:path.MacLen]) == 0 {
-		return p.packSCMP(
-			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
-				slayers.SCMPCodeInvalidHopFieldMAC),
-			},
-			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
-			serrors.New("MAC verification failed", "expected", fmt.Sprintf(
-				"%x", fullMac[:path.MacLen]),
-				"actual", fmt.Sprintf("%x", p.hopField.Mac[:path.MacLen]),
-				"cons_dir", p.infoField.ConsDir,
-				"if_id", p.ingressID, "curr_inf", p.path.PathMeta.CurrINF,
-				"curr_hf", p.path.PathMeta.CurrHF, "seg_id", p.infoField.SegID),
-		)
+		// During a key rollover, the hop fields issued with the previous key
+		// are valid as well.
+		secondaryMac := p.secondaryFullMAC(p.infoField, p.hopField)
+		if secondaryMac == nil {
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+					slayers.SCMPCodeInvalidHopFieldMAC),
+				},
+				&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+				serrors.New("MAC verification failed", "expected", fmt.Sprintf(
+					"%x", fullMac[:path.MacLen]),
+					"actual", fmt.Sprintf("%x", p.hopField.Mac[:path.MacLen]),
+					"cons_dir", p.infoField.ConsDir,
+					"if_id", p.ingressID, "curr_inf", p.path.PathMeta.CurrINF,
+					"curr_hf", p.path.PathMeta.CurrHF, "seg_id", p.infoField.SegID),
+			)
+		}
+		fullMac = secondaryMac
 	}
 	// Add the full MAC to the SCION packet processor,
 	// such that EPIC does not need to recalculate it.
@@ -1006,8 +1145,23 @@ func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
 	return processResult{}, nil
 }
 
+// secondaryFullMAC returns the full MAC of the hop field computed with the
+// secondary key if it matches the MAC of the hop field, or nil otherwise.
+func (p *scionPacketProcessor) secondaryFullMAC(info path.InfoField,
+	hf path.HopField) []byte {
+
+	if p.secondaryMac == nil {
+		return nil
+	}
+	fullMac := path.FullMAC(p.secondaryMac, info, hf, p.macBuffers.secondaryScionInput)
+	if subtle.ConstantTimeCompare(hf.Mac[:path.MacLen], fullMac[:path.MacLen]) == 0 {
+		return nil
+	}
+	return fullMac
+}
+
 func (p *scionPacketProcessor) resolveInbound() (*net.UDPAddr, processResult, error) {
-	a, err := p.d.resolveLocalDst(p.scionLayer)
+	a, err := p.d.resolveLocalDst(p.scionLayer, p.lastLayer)
 	switch {
 	case errors.Is(err, noSVCBackend):
 		r, err := p.packSCMP(
@@ -1073,7 +1227,7 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 
 func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
 	egressID := p.egressInterface()
-	if v, ok := p.d.bfdSessions[egressID]; ok {
+	if v, ok := p.state.bfdSessions[egressID]; ok {
 		if !v.IsUp() {
 			scmpH := &slayers.SCMP{
 				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
@@ -1082,7 +1236,7 @@ func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
 				IA:   p.d.localIA,
 				IfID: uint64(egressID),
 			}
-			if _, external := p.d.external[egressID]; !external {
+			if _, external := p.state.external[egressID]; !external {
 				scmpH.TypeCode =
 					slayers.CreateSCMPTypeCode(slayers.SCMPTypeInternalConnectivityDown, 0)
 				scmpP = &slayers.SCMPInternalConnectivityDown{
@@ -1125,7 +1279,7 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() (processResult, error)
 		return processResult{}, nil
 	}
 	egressID := p.egressInterface()
-	if _, ok := p.d.external[egressID]; !ok {
+	if _, ok := p.state.external[egressID]; !ok {
 		return processResult{}, nil
 	}
 	*alert = false
@@ -1216,7 +1370,7 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 		if err != nil {
 			return r, err
 		}
-		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+		return processResult{OutConn: p.d.internalConn(a), OutAddr: a, OutPkt: p.rawPkt}, nil
 	}
 
 	// Outbound: pkts leaving the local IA.
@@ -1241,7 +1395,7 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 	}
 
 	egressID := p.egressInterface()
-	if c, ok := p.d.external[egressID]; ok {
+	if c, ok := p.state.external[egressID]; ok {
 		if err := p.processEgress(); err != nil {
 			return processResult{}, err
 		}
@@ -1249,8 +1403,8 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 	}
 
 	// ASTransit: pkts leaving from another AS BR.
-	if a, ok := p.d.internalNextHops[egressID]; ok {
-		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+	if a, ok := p.state.internalNextHops[egressID]; ok {
+		return processResult{OutConn: p.d.internalConn(a), OutAddr: a, OutPkt: p.rawPkt}, nil
 	}
 	errCode := slayers.SCMPCodeUnknownHopFieldEgress
 	if !p.infoField.ConsDir {
@@ -1287,7 +1441,7 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 				"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
 				"localIA", p.d.localIA, "srcIA", s.SrcIA)
 		}
-		neighborIA, ok := p.d.neighborIAs[ohp.FirstHop.ConsEgress]
+		neighborIA, ok := p.state.neighborIAs[ohp.FirstHop.ConsEgress]
 		if !ok {
 			// TODO parameter problem invalid interface
 			return processResult{}, serrors.WithCtx(cannotRoute,
@@ -1299,9 +1453,11 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 				"neighborIA", neighborIA, "dstIA", s.DstIA)
 		}
 		mac := path.MAC(p.mac, ohp.Info, ohp.FirstHop, p.macBuffers.scionInput)
-		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 {
+		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 &&
+			p.secondaryFullMAC(ohp.Info, ohp.FirstHop) == nil {
+
 			// TODO parameter problem -> invalid MAC
-			return processResult{}, serrors.New("MAC", "expected", fmt.Sprintf("%x", mac),
+			return processResult{}, serrors.WithCtx(invalidMAC, "expected", fmt.Sprintf("%x", mac),
 				"actual", fmt.Sprintf("%x", ohp.FirstHop.Mac), "type", "ohp")
 		}
 		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
@@ -1310,7 +1466,7 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 			return processResult{}, err
 		}
 		// OHP should always be directed to the correct BR.
-		if c, ok := p.d.external[ohp.FirstHop.ConsEgress]; ok {
+		if c, ok := p.state.external[ohp.FirstHop.ConsEgress]; ok {
 			// buffer should already be correct
 			return processResult{EgressID: ohp.FirstHop.ConsEgress, OutConn: c, OutPkt: p.rawPkt},
 				nil
@@ -1326,7 +1482,7 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 			"type", "ohp", "ingress", p.ingressID,
 			"localIA", p.d.localIA, "dstIA", s.DstIA)
 	}
-	neighborIA := p.d.neighborIAs[p.ingressID]
+	neighborIA := p.state.neighborIAs[p.ingressID]
 	if !neighborIA.Equal(s.SrcIA) {
 		return processResult{}, serrors.WrapStr("bad source IA", cannotRoute,
 			"type", "ohp", "ingress", p.ingressID,
@@ -1345,14 +1501,19 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 	if err := updateSCIONLayer(p.rawPkt, s, p.buffer); err != nil {
 		return processResult{}, err
 	}
-	a, err := p.d.resolveLocalDst(s)
+	a, err := p.d.resolveLocalDst(s, nil)
 	if err != nil {
 		return processResult{}, err
 	}
-	return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+	return processResult{OutConn: p.d.internalConn(a), OutAddr: a, OutPkt: p.rawPkt}, nil
 }
 
-func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
+// resolveLocalDst returns the underlay address of the destination of a packet
+// in the local AS. lastLayer is the last decoded layer before the L4 header of
+// the packet, if any.
+func (d *DataPlane) resolveLocalDst(s slayers.SCION,
+	lastLayer gopacket.DecodingLayer) (*net.UDPAddr, error) {
+
 	dst, err := s.DstAddr()
 	if err != nil {
 		// TODO parameter problem.
@@ -1368,14 +1529,26 @@ func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
 		}
 		return a, nil
 	case *net.IPAddr:
-		return addEndhostPort(v), nil
+		return d.addEndhostPort(v, lastLayer), nil
 	default:
 		panic("unexpected address type returned from DstAddr")
 	}
 }
 
-func addEndhostPort(dst *net.IPAddr) *net.UDPAddr {
-	return &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
+// addEndhostPort returns the underlay address of the end host. The packets
+// with a SCION/UDP destination port in the end host port range are delivered
+// to that port, where the application listens directly, and all other packets,
+// e.g., SCMP, to the dispatcher.
+func (d *DataPlane) addEndhostPort(dst *net.IPAddr,
+	lastLayer gopacket.DecodingLayer) *net.UDPAddr {
+
+	port := topology.EndhostPort
+	if lastLayer != nil && nextHdr(lastLayer) == common.L4UDP {
+		if pld := lastLayer.LayerPayload(); len(pld) >= 4 {
+			port = d.endhostPorts.UnderlayPort(binary.BigEndian.Uint16(pld[2:4]))
+		}
+	}
+	return &net.UDPAddr{IP: dst.IP, Port: port}
 }
 
 // TODO(matzf) this function is now only used to update the OneHop-path.
@@ -1488,7 +1661,7 @@ func (p *scionPacketProcessor) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.S
 	}
 	// If the packet is sent to an external router, we need to increment the
 	// path to prepare it for the next hop.
-	_, external := p.d.external[p.ingressID]
+	_, external := p.state.external[p.ingressID]
 	if external {
 		infoField := &revPath.InfoFields[revPath.PathMeta.CurrINF]
 		if infoField.ConsDir {
@@ -1515,7 +1688,11 @@ func (p *scionPacketProcessor) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.S
 	if err := scionL.SetDstAddr(srcA); err != nil {
 		return nil, serrors.Wrap(cannotRoute, err, "details", "setting dest addr")
 	}
-	if err := scionL.SetSrcAddr(&net.IPAddr{IP: p.d.internalIP}); err != nil {
+	srcIP := p.d.internalIP
+	if ipA, ok := srcA.(*net.IPAddr); ok {
+		srcIP = p.d.internalAddr(ipA.IP)
+	}
+	if err := scionL.SetSrcAddr(&net.IPAddr{IP: srcIP}); err != nil {
 		return nil, serrors.Wrap(cannotRoute, err, "details", "setting src addr")
 	}
 	scionL.NextHdr = common.L4SCMP
@@ -1596,26 +1773,33 @@ func nextHdr(layer gopacket.DecodingLayer) common.L4ProtocolType {
 // forwardingMetrics contains the subset of Metrics relevant for forwarding,
 // instantiated with some interface-specific labels.
 type forwardingMetrics struct {
-	InputBytesTotal     prometheus.Counter
-	OutputBytesTotal    prometheus.Counter
-	InputPacketsTotal   prometheus.Counter
-	OutputPacketsTotal  prometheus.Counter
-	DroppedPacketsTotal prometheus.Counter
+	InputBytesTotal    prometheus.Counter
+	OutputBytesTotal   prometheus.Counter
+	InputPacketsTotal  prometheus.Counter
+	OutputPacketsTotal prometheus.Counter
+	// DroppedPacketsTotal counts the dropped packets per dropReason.
+	DroppedPacketsTotal [numDropReasons]prometheus.Counter
 }
 
 func initForwardingMetrics(metrics *Metrics, labels prometheus.Labels) forwardingMetrics {
 	c := forwardingMetrics{
-		InputBytesTotal:     metrics.InputBytesTotal.With(labels),
-		InputPacketsTotal:   metrics.InputPacketsTotal.With(labels),
-		OutputBytesTotal:    metrics.OutputBytesTotal.With(labels),
-		OutputPacketsTotal:  metrics.OutputPacketsTotal.With(labels),
-		DroppedPacketsTotal: metrics.DroppedPacketsTotal.With(labels),
+		InputBytesTotal:    metrics.InputBytesTotal.With(labels),
+		InputPacketsTotal:  metrics.InputPacketsTotal.With(labels),
+		OutputBytesTotal:   metrics.OutputBytesTotal.With(labels),
+		OutputPacketsTotal: metrics.OutputPacketsTotal.With(labels),
 	}
 	c.InputBytesTotal.Add(0)
 	c.InputPacketsTotal.Add(0)
 	c.OutputBytesTotal.Add(0)
 	c.OutputPacketsTotal.Add(0)
-	c.DroppedPacketsTotal.Add(0)
+	for r := dropReason(0); r < numDropReasons; r++ {
+		dropLabels := prometheus.Labels{"reason": r.String()}
+		for k, v := range labels {
+			dropLabels[k] = v
+		}
+		c.DroppedPacketsTotal[r] = metrics.DroppedPacketsTotal.With(dropLabels)
+		c.DroppedPacketsTotal[r].Add(0)
+	}
 	return c
 }
 
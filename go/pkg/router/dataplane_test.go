@@ -21,7 +21,9 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -48,6 +50,7 @@ import (
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	sheader "github.com/scionproto/scion/go/lib/slayers/scion"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 	underlayconn "github.com/scionproto/scion/go/lib/underlay/conn"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -89,6 +92,48 @@ func TestDataPlaneAddInternalInterface(t *testing.T) {
 	})
 }
 
+func TestDataPlaneAddAltInternalInterface(t *testing.T) {
+	ipv4 := net.IP{10, 0, 0, 1}
+	ipv6 := net.ParseIP("fd00::1")
+	t.Run("internal interface not set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.Error(t, d.AddAltInternalInterface(mock_router.NewMockBatchConn(ctrl), ipv6))
+	})
+	t.Run("same IP family", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl), ipv4))
+		assert.Error(t, d.AddAltInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			net.IP{10, 0, 0, 2}))
+	})
+	t.Run("double set fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl), ipv4))
+		assert.NoError(t, d.AddAltInternalInterface(mock_router.NewMockBatchConn(ctrl), ipv6))
+		assert.Error(t, d.AddAltInternalInterface(mock_router.NewMockBatchConn(ctrl), ipv6))
+	})
+	t.Run("sends from the socket of the IP family", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		internal := mock_router.NewMockBatchConn(ctrl)
+		alt := mock_router.NewMockBatchConn(ctrl)
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddInternalInterface(internal, ipv4))
+		require.NoError(t, d.AddAltInternalInterface(alt, ipv6))
+		assert.Equal(t, internal, d.InternalConn(&net.UDPAddr{IP: net.IP{10, 0, 0, 2}}))
+		assert.Equal(t, alt, d.InternalConn(&net.UDPAddr{IP: net.ParseIP("fd00::2")}))
+	})
+}
+
 func TestDataPlaneSetKey(t *testing.T) {
 	t.Run("fails after serve", func(t *testing.T) {
 		d := &router.DataPlane{}
@@ -189,12 +234,16 @@ func TestDataPlaneAddNextHop(t *testing.T) {
 	})
 }
 
+// testMetrics are the metrics of the dataplanes that are run in the tests. They
+// can only be registered once.
+var testMetrics = router.NewMetrics()
+
 func TestDataPlaneRun(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	metrics := router.NewMetrics()
+	metrics := testMetrics
 
 	testCases := map[string]struct {
 		prepareDP func(*gomock.Controller, chan<- struct{}) *router.DataPlane
@@ -268,6 +317,65 @@ func TestDataPlaneRun(t *testing.T) {
 				return ret
 			},
 		},
+		"keep order of flows with multiple processors": {
+			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
+				ret := &router.DataPlane{Metrics: metrics, NumProcessors: 4, BatchSize: 8}
+
+				key := []byte("testkey_xxxxxxxx")
+				local := xtest.MustParseIA("1-ff00:0:110")
+				const flows, pktsPerFlow = 8, 64
+
+				mtx := sync.Mutex{}
+				next := make(map[uint32]uint32, flows)
+				total := 0
+				mInternal := mock_router.NewMockBatchConn(ctrl)
+				mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+				mInternal.EXPECT().WriteBatch(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ms underlayconn.Messages, flags int) (int, error) {
+						mtx.Lock()
+						defer mtx.Unlock()
+						for _, m := range ms {
+							raw := m.Buffers[0]
+							flow := binary.BigEndian.Uint32(raw[0:4]) & 0xfffff
+							seq := binary.BigEndian.Uint32(raw[len(raw)-4:])
+							assert.Equal(t, next[flow], seq, "flow %d", flow)
+							next[flow] = seq + 1
+							total++
+						}
+						if total == flows*pktsPerFlow {
+							done <- struct{}{}
+						}
+						return len(ms), nil
+					}).AnyTimes()
+				_ = ret.AddInternalInterface(mInternal, net.IP{})
+
+				var pkts [][]byte
+				for i := 0; i < pktsPerFlow; i++ {
+					for f := 0; f < flows; f++ {
+						pkts = append(pkts, prepRoutedPkt(t, key, local, uint32(f), uint32(i)))
+					}
+				}
+				mExternal := mock_router.NewMockBatchConn(ctrl)
+				mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+					func(m underlayconn.Messages) (int, error) {
+						n := 0
+						for ; n < len(m) && len(pkts) > 0; n++ {
+							copy(m[n].Buffers[0], pkts[0])
+							m[n].N = len(pkts[0])
+							m[n].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+							pkts = pkts[1:]
+						}
+						return n, nil
+					},
+				).AnyTimes()
+
+				_ = ret.AddExternalInterface(1, mExternal)
+
+				_ = ret.SetIA(local)
+				_ = ret.SetKey(key)
+				return ret
+			},
+		},
 		"bfd bootstrap internal session": {
 			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
 				ret := &router.DataPlane{Metrics: metrics}
@@ -569,6 +677,82 @@ func TestDataPlaneRun(t *testing.T) {
 	}
 }
 
+// BenchmarkDataPlaneRun measures the throughput of packets that are routed from
+// an external interface to the internal interface, for different numbers of
+// processors. The packets belong to 256 flows.
+func BenchmarkDataPlaneRun(b *testing.B) {
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	var pkts [][]byte
+	for f := 0; f < 256; f++ {
+		pkts = append(pkts, prepRoutedPkt(b, key, local, uint32(f), 0))
+	}
+
+	for _, numProcessors := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("processors=%d", numProcessors), func(b *testing.B) {
+			conn := &benchConn{pkts: pkts, total: int64(b.N), done: make(chan struct{})}
+			dp := &router.DataPlane{Metrics: testMetrics, NumProcessors: numProcessors}
+			require.NoError(b, dp.AddInternalInterface(conn, net.IP{}))
+			require.NoError(b, dp.AddExternalInterface(1, conn))
+			require.NoError(b, dp.SetIA(local))
+			require.NoError(b, dp.SetKey(key))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			b.ResetTimer()
+			go func() {
+				_ = dp.Run(ctx)
+			}()
+			<-conn.done
+			b.StopTimer()
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "pkts/s")
+		})
+	}
+}
+
+// benchConn is a BatchConn that reads copies of pkts, in a round robin fashion,
+// until total packets were read, and that counts the written packets. At most
+// benchWindow packets are in flight, such that no packets are dropped in the
+// queues of the router.
+type benchConn struct {
+	pkts  [][]byte
+	total int64
+	done  chan struct{}
+
+	read    int64
+	written int64
+}
+
+const benchWindow = 512
+
+func (c *benchConn) ReadBatch(m underlayconn.Messages) (int, error) {
+	n := 0
+	read := atomic.LoadInt64(&c.read)
+	for ; n < len(m) && read < c.total && read-atomic.LoadInt64(&c.written) < benchWindow; n++ {
+		pkt := c.pkts[read%int64(len(c.pkts))]
+		copy(m[n].Buffers[0], pkt)
+		m[n].N = len(pkt)
+		m[n].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+		read++
+	}
+	atomic.StoreInt64(&c.read, read)
+	if n == 0 {
+		runtime.Gosched()
+	}
+	return n, nil
+}
+
+func (c *benchConn) WriteBatch(m underlayconn.Messages, _ int) (int, error) {
+	if atomic.AddInt64(&c.written, int64(len(m))) == c.total {
+		close(c.done)
+	}
+	return len(m), nil
+}
+
+func (c *benchConn) WriteTo([]byte, *net.UDPAddr) (int, error) { return 0, nil }
+
+func (c *benchConn) Close() error { return nil }
+
 func TestProcessPkt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -611,6 +795,39 @@ func TestProcessPkt(t *testing.T) {
 			srcInterface: 1,
 			assertFunc:   assert.NoError,
 		},
+		"inbound to end host port": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.SetEndhostPorts(underlay.PortRange{
+					Start: 31000,
+					End:   32767,
+				}))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepBaseMsg(now)
+				spkt.DstIA = xtest.MustParseIA("1-ff00:0:110")
+				dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+				_ = spkt.SetDstAddr(dst)
+				_ = spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP("10.0.200.200").To4()})
+				dpath.HopFields = []path.HopField{
+					{ConsIngress: 41, ConsEgress: 40},
+					{ConsIngress: 31, ConsEgress: 30},
+					{ConsIngress: 01, ConsEgress: 0},
+				}
+				dpath.Base.PathMeta.CurrHF = 2
+				dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+				ret := toUDPMsg(t, spkt, dpath, 31042)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: dst.IP, Port: 31042}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
 		"outbound": {
 			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
 				return router.NewDP(
@@ -1851,6 +2068,50 @@ func toMsg(t *testing.T, spkt *slayers.SCION, dpath path.Path) *ipv4.Message {
 	return ret
 }
 
+// toUDPMsg returns a message with a SCION/UDP packet to the given
+// destination port.
+func toUDPMsg(t *testing.T, spkt *slayers.SCION, dpath path.Path,
+	dstPort uint16) *ipv4.Message {
+
+	t.Helper()
+	spkt.Path = dpath
+	udp := &slayers.UDP{SrcPort: 40000, DstPort: dstPort}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(spkt))
+	buffer := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buffer,
+		gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+		spkt, udp, gopacket.Payload([]byte("actualpayloadbytes")))
+	require.NoError(t, err)
+	raw := buffer.Bytes()
+	return &ipv4.Message{Buffers: [][]byte{raw}, N: len(raw)}
+}
+
+// prepRoutedPkt returns a packet that is routed from interface 1 to the
+// internal interface, with the given flow ID and a 4 byte sequence number as
+// payload.
+func prepRoutedPkt(t testing.TB, key []byte, local addr.IA, flowID, seq uint32) []byte {
+	spkt, dpath := prepBaseMsg(time.Now())
+	spkt.DstIA = local
+	spkt.FlowID = flowID
+	dpath.HopFields = []path.HopField{
+		{ConsIngress: 41, ConsEgress: 40},
+		{ConsIngress: 31, ConsEgress: 30},
+		{ConsIngress: 1, ConsEgress: 0},
+	}
+	dpath.Base.PathMeta.CurrHF = 2
+	mac, err := scrypto.InitMac(key)
+	require.NoError(t, err)
+	dpath.HopFields[2].Mac = path.MAC(mac, dpath.InfoFields[0], dpath.HopFields[2], nil)
+	spkt.Path = dpath
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, seq)
+	buffer := gopacket.NewSerializeBuffer()
+	err = gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+		spkt, gopacket.Payload(payload))
+	require.NoError(t, err)
+	return append([]byte(nil), buffer.Bytes()...)
+}
+
 func prepBaseMsg(now time.Time) (*slayers.SCION, *scion.Decoded) {
 	spkt := &slayers.SCION{
 		Header: sheader.Header{
@@ -0,0 +1,234 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	sheader "github.com/scionproto/scion/go/lib/slayers/scion"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+const (
+	// dropTraceLen is the number of dropped packets kept in the drop trace.
+	dropTraceLen = 256
+	// defaultDropTraceSampling is the default sampling of the drop trace.
+	defaultDropTraceSampling = 100
+)
+
+// dropReason is the reason why a packet is dropped.
+type dropReason uint8
+
+const (
+	dropOther dropReason = iota
+	dropMalformed
+	dropUnsupportedPathType
+	dropExpired
+	dropInvalidMAC
+	dropInvalidIngress
+	dropInvalidEgress
+	dropInterfaceDown
+	dropInvalidSize
+	dropNoRoute
+	dropBFD
+	dropQueueFull
+	dropWriteError
+	dropSCMPRateLimited
+	dropFiltered
+	numDropReasons
+)
+
+var dropReasonNames = [numDropReasons]string{
+	dropOther:               "other",
+	dropMalformed:           "malformed",
+	dropUnsupportedPathType: "unsupported_path_type",
+	dropExpired:             "expired",
+	dropInvalidMAC:          "invalid_mac",
+	dropInvalidIngress:      "invalid_ingress",
+	dropInvalidEgress:       "invalid_egress",
+	dropInterfaceDown:       "interface_down",
+	dropInvalidSize:         "invalid_size",
+	dropNoRoute:             "no_route",
+	dropBFD:                 "bfd",
+	dropQueueFull:           "queue_full",
+	dropWriteError:          "write_error",
+	dropSCMPRateLimited:     "scmp_rate_limited",
+	dropFiltered:            "filtered",
+}
+
+func (r dropReason) String() string {
+	return dropReasonNames[r]
+}
+
+// dropReasonErrors maps the errors of the packet processing to the reasons of
+// the drops. The first match wins.
+var dropReasonErrors = []struct {
+	err    error
+	reason dropReason
+}{
+	{malformedPacket, dropMalformed},
+	{malformedPath, dropMalformed},
+	{unsupportedPathType, dropUnsupportedPathType},
+	{unsupportedPathTypeNextHeader, dropUnsupportedPathType},
+	{expiredPacket, dropExpired},
+	{invalidMAC, dropInvalidMAC},
+	{invalidIngress, dropInvalidIngress},
+	{noBFDSessionFound, dropBFD},
+	{noBFDSessionConfigured, dropBFD},
+	{errBFDDisabled, dropBFD},
+	{noSVCBackend, dropNoRoute},
+	{cannotRoute, dropNoRoute},
+	{scmpRateLimited, dropSCMPRateLimited},
+	{filteredPacket, dropFiltered},
+}
+
+// dropReason returns the reason why the packet that failed to be processed with
+// the given error is dropped. Packets that are answered with an SCMP error are
+// dropped too, and the reason is derived from the SCMP type and code.
+func (p *scionPacketProcessor) dropReason(err error) dropReason {
+	var scmpErr scmpError
+	if errors.As(err, &scmpErr) {
+		return scmpDropReason(scmpErr.TypeCode)
+	}
+	// The SCMP error could not be created or was suppressed, e.g., because
+	// the packet was an SCMP error itself.
+	if p.scmpTypeCode != 0 && !p.scmpTypeCode.InfoMsg() {
+		return scmpDropReason(p.scmpTypeCode)
+	}
+	for _, e := range dropReasonErrors {
+		if errors.Is(err, e.err) {
+			return e.reason
+		}
+	}
+	return dropOther
+}
+
+func scmpDropReason(typeCode slayers.SCMPTypeCode) dropReason {
+	switch typeCode.Type() {
+	case slayers.SCMPTypeDestinationUnreachable:
+		return dropNoRoute
+	case slayers.SCMPTypeExternalInterfaceDown, slayers.SCMPTypeInternalConnectivityDown:
+		return dropInterfaceDown
+	case slayers.SCMPTypeParameterProblem:
+		switch typeCode.Code() {
+		case slayers.SCMPCodePathExpired:
+			return dropExpired
+		case slayers.SCMPCodeInvalidHopFieldMAC:
+			return dropInvalidMAC
+		case slayers.SCMPCodeUnknownHopFieldIngress:
+			return dropInvalidIngress
+		case slayers.SCMPCodeUnknownHopFieldEgress:
+			return dropInvalidEgress
+		case slayers.SCMPCodeInvalidPacketSize:
+			return dropInvalidSize
+		case slayers.SCMPCodeInvalidSegmentChange:
+			return dropMalformed
+		}
+	}
+	return dropOther
+}
+
+// drop counts the dropped packet, which was received on the given interface,
+// and records it in the drop trace if it is sampled.
+func (d *DataPlane) drop(ingressID uint16, raw []byte, reason dropReason, err error) {
+	d.currentState().forwardingMetrics[ingressID].DroppedPacketsTotal[reason].Inc()
+	if d.drops.sample(reason, d.DropTraceSampling) {
+		d.drops.add(ingressID, raw, reason, err)
+	}
+}
+
+// dropTrace keeps a sample of the dropped packets in a ring buffer. Of the
+// packets dropped for the same reason, the first one and then every
+// sampling-th one is kept.
+type dropTrace struct {
+	counts [numDropReasons]uint64
+
+	mtx     sync.Mutex
+	entries []control.DroppedPacket
+	next    int
+}
+
+// sample returns whether the drop should be recorded. It is cheap, such that it
+// can be called for every dropped packet.
+func (t *dropTrace) sample(reason dropReason, sampling int) bool {
+	if sampling <= 0 {
+		sampling = defaultDropTraceSampling
+	}
+	return (atomic.AddUint64(&t.counts[reason], 1)-1)%uint64(sampling) == 0
+}
+
+// add records the dropped packet, which was received on the given interface.
+func (t *dropTrace) add(ingressID uint16, raw []byte, reason dropReason, err error) {
+	e := control.DroppedPacket{
+		Time:      time.Now(),
+		Interface: ingressID,
+		Reason:    reason.String(),
+		Length:    len(raw),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	describeDroppedPacket(&e, raw)
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.entries) < dropTraceLen {
+		t.entries = append(t.entries, e)
+		return
+	}
+	t.entries[t.next] = e
+	t.next = (t.next + 1) % dropTraceLen
+}
+
+// list returns the recorded packets, the most recent one first.
+func (t *dropTrace) list() []control.DroppedPacket {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	l := make([]control.DroppedPacket, 0, len(t.entries))
+	for i := len(t.entries) - 1; i >= 0; i-- {
+		l = append(l, t.entries[(t.next+i)%len(t.entries)])
+	}
+	return l
+}
+
+// describeDroppedPacket sets the addresses and the path type of the dropped
+// packet, as far as they can be decoded from the raw packet.
+func describeDroppedPacket(e *control.DroppedPacket, raw []byte) {
+	if len(raw) < slayers.CmnHdrLen {
+		return
+	}
+	var s slayers.SCION
+	s.PathType = path.Type(raw[8])
+	s.DstAddrType = sheader.AddrType(raw[9] >> 6)
+	s.DstAddrLen = sheader.AddrLen(raw[9] >> 4 & 0x3)
+	s.SrcAddrType = sheader.AddrType(raw[9] >> 2 & 0x3)
+	s.SrcAddrLen = sheader.AddrLen(raw[9] & 0x3)
+	e.PathType = s.PathType.String()
+	if err := s.DecodeAddrHdr(raw[slayers.CmnHdrLen:]); err != nil {
+		return
+	}
+	e.SrcIA, e.DstIA = s.SrcIA, s.DstIA
+	if a, err := s.SrcAddr(); err == nil {
+		e.SrcHost = a.String()
+	}
+	if a, err := s.DstAddr(); err == nil {
+		e.DstHost = a.String()
+	}
+}
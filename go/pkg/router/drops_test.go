@@ -0,0 +1,170 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/filter"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestDropReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	now := time.Now()
+
+	inbound := func(mutate func(*path.InfoField, *path.HopField)) *ipv4.Message {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.DstIA = local
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 0, ExpTime: 63},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		mutate(&dpath.InfoFields[0], &dpath.HopFields[2])
+		return toMsg(t, spkt, dpath)
+	}
+	testCases := map[string]struct {
+		msg    *ipv4.Message
+		filter filter.Policy
+		reason string
+	}{
+		"valid": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				hop.Mac = computeMAC(t, key, *info, *hop)
+			}),
+			reason: "",
+		},
+		"malformed": {
+			msg:    &ipv4.Message{Buffers: [][]byte{{0x00, 0x01, 0x02}}},
+			reason: "malformed",
+		},
+		"invalid MAC": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				hop.Mac = [path.MacLen]byte{1, 2, 3, 4, 5, 6}
+			}),
+			reason: "invalid_mac",
+		},
+		"expired": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				info.Timestamp = uint32(now.Add(-24 * time.Hour).Unix())
+				hop.Mac = computeMAC(t, key, *info, *hop)
+			}),
+			reason: "expired",
+		},
+		"invalid ingress": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				hop.ConsIngress = 2
+				hop.Mac = computeMAC(t, key, *info, *hop)
+			}),
+			reason: "invalid_ingress",
+		},
+		"filtered": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				hop.Mac = computeMAC(t, key, *info, *hop)
+			}),
+			filter: filter.Policy{
+				Interfaces: map[uint16][]filter.Rule{
+					1: {{Name: "block", Action: filter.Drop}},
+				},
+			},
+			reason: "filtered",
+		},
+		"filtered on other interface": {
+			msg: inbound(func(info *path.InfoField, hop *path.HopField) {
+				hop.Mac = computeMAC(t, key, *info, *hop)
+			}),
+			filter: filter.Policy{
+				Interfaces: map[uint16][]filter.Rule{
+					2: {{Name: "block", Action: filter.Drop}},
+				},
+			},
+			reason: "",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+				nil, local, nil, key)
+			f, err := filter.New(tc.filter, nil)
+			require.NoError(t, err)
+			dp.SetFilter(f)
+			assert.Equal(t, tc.reason, dp.ProcessDropReason(1, tc.msg))
+		})
+	}
+}
+
+func TestDropTrace(t *testing.T) {
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	raw := prepRoutedPkt(t, key, local, 1, 0)
+
+	c := &router.Connector{DataPlane: router.DataPlane{DropTraceSampling: 2}}
+	for i := 0; i < 4; i++ {
+		c.DataPlane.TraceDrop(1, raw, "invalid_mac", serrors.New(fmt.Sprint(i)))
+	}
+	c.DataPlane.TraceDrop(0, []byte{0x00}, "queue_full", nil)
+
+	drops, err := c.ListDroppedPackets()
+	require.NoError(t, err)
+	require.Len(t, drops, 3)
+
+	// Every second packet is kept per reason, the most recent one first.
+	assert.Equal(t, "queue_full", drops[0].Reason)
+	assert.Equal(t, uint16(0), drops[0].Interface)
+	assert.Empty(t, drops[0].PathType)
+	assert.Equal(t, 1, drops[0].Length)
+
+	for i, errMsg := range []string{"2", "0"} {
+		drop := drops[i+1]
+		assert.Equal(t, "invalid_mac", drop.Reason)
+		assert.Equal(t, errMsg, drop.Error)
+		assert.Equal(t, uint16(1), drop.Interface)
+		assert.Equal(t, xtest.MustParseIA("2-ff00:0:222"), drop.SrcIA)
+		assert.Equal(t, local, drop.DstIA)
+		assert.Equal(t, "SCION (1)", drop.PathType)
+		assert.Equal(t, len(raw), drop.Length)
+	}
+}
+
+func TestDropTraceWrap(t *testing.T) {
+	c := &router.Connector{DataPlane: router.DataPlane{DropTraceSampling: 1}}
+	for i := 0; i < 300; i++ {
+		c.DataPlane.TraceDrop(0, nil, "no_route", serrors.New(fmt.Sprint(i)))
+	}
+	drops, err := c.ListDroppedPackets()
+	require.NoError(t, err)
+	require.Len(t, drops, 256)
+	assert.Equal(t, "299", drops[0].Error)
+	assert.Equal(t, "44", drops[255].Error)
+}
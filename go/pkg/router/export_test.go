@@ -15,16 +15,27 @@
 package router
 
 import (
+	"errors"
 	"net"
+	"time"
 
 	"golang.org/x/net/ipv4"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/topology"
 )
 
 var NewServices = newServices
 
+var NewSCMPRateLimiter = newSCMPRateLimiter
+
+func (l *scmpRateLimiter) Allow(typ slayers.SCMPType, dstIA addr.IA, dstHost string,
+	now time.Time) bool {
+
+	return l.allow(typ, dstIA, dstHost, now)
+}
+
 type ProcessResult struct {
 	processResult
 }
@@ -57,6 +68,10 @@ func (d *DataPlane) FakeStart() {
 	d.running = true
 }
 
+func (d *DataPlane) InternalConn(dst *net.UDPAddr) BatchConn {
+	return d.internalConn(dst)
+}
+
 func (d *DataPlane) ProcessPkt(ifID uint16, m *ipv4.Message) (ProcessResult, error) {
 
 	p := newPacketProcessor(d, ifID)
@@ -73,3 +88,29 @@ func (d *DataPlane) ProcessPkt(ifID uint16, m *ipv4.Message) (ProcessResult, err
 func ExtractServices(s *services) map[addr.HostSVC][]*net.UDPAddr {
 	return s.m
 }
+
+// ProcessDropReason processes the packet and returns the reason why it is
+// dropped, or the empty string if it is not.
+func (d *DataPlane) ProcessDropReason(ifID uint16, m *ipv4.Message) string {
+	p := newPacketProcessor(d, ifID)
+	var srcAddr *net.UDPAddr
+	if m.Addr != nil {
+		srcAddr = m.Addr.(*net.UDPAddr)
+	}
+	_, err := p.processPkt(m.Buffers[0], srcAddr)
+	var scmpErr scmpError
+	if err == nil || (errors.As(err, &scmpErr) && scmpErr.TypeCode.InfoMsg()) {
+		return ""
+	}
+	return p.dropReason(err).String()
+}
+
+// TraceDrop records the packet in the drop trace, as if it was dropped for the
+// given reason, subject to the sampling.
+func (d *DataPlane) TraceDrop(ifID uint16, raw []byte, reason string, err error) {
+	for r := dropReason(0); r < numDropReasons; r++ {
+		if r.String() == reason && d.drops.sample(r, d.DropTraceSampling) {
+			d.drops.add(ifID, raw, r, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filter implements the packet filter of the router. The filter drops
+// the packets received on an interface that match one of its rules, e.g., to
+// block the traffic of an abusive AS during an incident.
+//
+// A rule matches the source and destination ISD-AS of a packet with the hop
+// predicates of the path policies (see pathpol), e.g., "1-ff00:0:110" or
+// "2-0", its path type, and its transport header with a traffic class of the
+// pktcls package, e.g., "dstport=53". The policy is read from a JSON file:
+//
+//	{
+//	  "interfaces": {
+//	    "1": [
+//	      {"name": "block-abuser", "action": "drop", "src_isd_as": "1-ff00:0:666"}
+//	    ]
+//	  },
+//	  "rules": [
+//	    {"name": "no-dns", "action": "drop", "path_types": ["scion"],
+//	     "condition": "dstport=53"}
+//	  ]
+//	}
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/gopacket"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+)
+
+// AnyInterface is the value of the interface label of the hit counters of the
+// rules that apply to the packets received on any interface.
+const AnyInterface = "any"
+
+// Action is the action a rule takes on the matching packets.
+type Action int
+
+// List of available actions.
+const (
+	// Allow forwards the matching packets.
+	Allow Action = iota
+	// Drop drops the matching packets.
+	Drop
+)
+
+func (a Action) String() string {
+	switch a {
+	case Allow:
+		return "allow"
+	case Drop:
+		return "drop"
+	default:
+		return fmt.Sprintf("UNKNOWN (%d)", a)
+	}
+}
+
+func (a Action) MarshalText() ([]byte, error) {
+	switch a {
+	case Allow, Drop:
+		return []byte(a.String()), nil
+	default:
+		return nil, serrors.New("unknown action", "action", int(a))
+	}
+}
+
+func (a *Action) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "allow":
+		*a = Allow
+	case "drop":
+		*a = Drop
+	default:
+		return serrors.New("unknown action", "action", string(b))
+	}
+	return nil
+}
+
+// pathTypes are the names of the path types in the rules.
+var pathTypes = map[string]path.Type{
+	"empty":   empty.PathType,
+	"onehop":  onehop.PathType,
+	"scion":   scion.PathType,
+	"epic":    epic.PathType,
+	"colibri": colibri.PathType,
+}
+
+// Rule is a rule of the packet filter. A packet matches the rule if it
+// matches all the matchers that are set in the rule.
+type Rule struct {
+	// Name identifies the rule in the hit counters. It must be unique among
+	// the rules of an interface.
+	Name string `json:"name"`
+	// Action is the action taken on the matching packets.
+	Action Action `json:"action"`
+	// Src matches the source ISD-AS of the packets. The zero ISD and AS are
+	// wildcards.
+	Src *pathpol.HopPredicate `json:"src_isd_as,omitempty"`
+	// Dst matches the destination ISD-AS of the packets. The zero ISD and AS
+	// are wildcards.
+	Dst *pathpol.HopPredicate `json:"dst_isd_as,omitempty"`
+	// PathTypes match the path type of the packets, i.e., one of "empty",
+	// "onehop", "scion", "epic" or "colibri".
+	PathTypes []string `json:"path_types,omitempty"`
+	// Condition matches the transport header of the packets. It is a traffic
+	// class in the syntax of the pktcls package, e.g., "dstport=1000-2000".
+	Condition string `json:"condition,omitempty"`
+}
+
+// Policy is the configuration of the packet filter. The rules are evaluated
+// in order, and the first rule that matches a packet decides its fate. Packets
+// that match no rule are allowed.
+type Policy struct {
+	// Interfaces are the rules for the packets received on the interface with
+	// the given ID, 0 being the internal interface.
+	Interfaces map[uint16][]Rule `json:"interfaces,omitempty"`
+	// Rules are the rules for the packets received on any interface. They are
+	// evaluated after the rules of the interface.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// LoadPolicy loads the policy from the JSON file at the path.
+func LoadPolicy(file string) (Policy, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return Policy{}, serrors.WrapStr("reading file", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return Policy{}, serrors.WrapStr("parsing file", err, "file", file)
+	}
+	return p, nil
+}
+
+// Filter is the compiled packet filter. It is safe for concurrent use. The nil
+// filter allows all packets.
+type Filter struct {
+	interfaces map[uint16][]rule
+	rules      []rule
+}
+
+// rule is a compiled rule.
+type rule struct {
+	name      string
+	action    Action
+	src, dst  *pathpol.HopPredicate
+	pathTypes []path.Type
+	cond      pktcls.Cond
+	hits      prometheus.Counter
+}
+
+// New compiles the policy into a filter. The hits of the rules are counted
+// by the interface and rule labels of hits, if it is not nil.
+func New(policy Policy, hits *prometheus.CounterVec) (*Filter, error) {
+	f := &Filter{interfaces: make(map[uint16][]rule, len(policy.Interfaces))}
+	for ifID, rules := range policy.Interfaces {
+		compiled, err := compile(rules, strconv.Itoa(int(ifID)), hits)
+		if err != nil {
+			return nil, serrors.WrapStr("compiling rules", err, "interface", ifID)
+		}
+		f.interfaces[ifID] = compiled
+	}
+	compiled, err := compile(policy.Rules, AnyInterface, hits)
+	if err != nil {
+		return nil, serrors.WrapStr("compiling rules", err, "interface", AnyInterface)
+	}
+	f.rules = compiled
+	return f, nil
+}
+
+func compile(rules []Rule, intf string, hits *prometheus.CounterVec) ([]rule, error) {
+	compiled := make([]rule, 0, len(rules))
+	names := make(map[string]struct{}, len(rules))
+	for i, r := range rules {
+		if r.Name == "" {
+			return nil, serrors.New("rule without name", "index", i)
+		}
+		if _, ok := names[r.Name]; ok {
+			return nil, serrors.New("duplicate rule name", "rule", r.Name)
+		}
+		names[r.Name] = struct{}{}
+		c := rule{name: r.Name, action: r.Action, src: r.Src, dst: r.Dst}
+		for _, hp := range []*pathpol.HopPredicate{r.Src, r.Dst} {
+			if hp == nil {
+				continue
+			}
+			for _, ifID := range hp.IfIDs {
+				if ifID != 0 {
+					return nil, serrors.New("interfaces in ISD-AS matcher not supported",
+						"rule", r.Name, "matcher", hp)
+				}
+			}
+		}
+		for _, name := range r.PathTypes {
+			t, ok := pathTypes[strings.ToLower(name)]
+			if !ok {
+				return nil, serrors.New("unknown path type", "rule", r.Name, "type", name)
+			}
+			c.pathTypes = append(c.pathTypes, t)
+		}
+		if r.Condition != "" {
+			cond, err := pktcls.BuildClassTree(r.Condition)
+			if err != nil {
+				return nil, serrors.WrapStr("parsing condition", err, "rule", r.Name)
+			}
+			c.cond = cond
+		}
+		if hits != nil {
+			c.hits = hits.With(prometheus.Labels{"interface": intf, "rule": r.Name})
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// Eval evaluates the filter on the packet received on the given interface and
+// counts the hit of the matching rule. last is the last decoded layer of the
+// packet, i.e., the SCION layer or its last extension, on which the
+// conditions are evaluated. It returns the action and the name of the matching
+// rule, or Allow and the empty string if no rule matches.
+func (f *Filter) Eval(ifID uint16, scn *slayers.SCION, last gopacket.Layer) (Action, string) {
+	if f == nil {
+		return Allow, ""
+	}
+	for _, rules := range [][]rule{f.interfaces[ifID], f.rules} {
+		for i := range rules {
+			r := &rules[i]
+			if !r.match(scn, last) {
+				continue
+			}
+			if r.hits != nil {
+				r.hits.Inc()
+			}
+			return r.action, r.name
+		}
+	}
+	return Allow, ""
+}
+
+func (r *rule) match(scn *slayers.SCION, last gopacket.Layer) bool {
+	if !matchIA(r.src, scn.SrcIA) || !matchIA(r.dst, scn.DstIA) {
+		return false
+	}
+	if len(r.pathTypes) > 0 {
+		found := false
+		for _, t := range r.pathTypes {
+			if t == scn.PathType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return r.cond == nil || r.cond.Eval(last)
+}
+
+// matchIA returns whether the ISD-AS matches the hop predicate. The zero ISD
+// and AS of the predicate are wildcards, and the nil predicate matches all
+// ISD-ASes.
+func matchIA(hp *pathpol.HopPredicate, ia addr.IA) bool {
+	if hp == nil {
+		return true
+	}
+	return (hp.ISD == 0 || hp.ISD == ia.ISD()) && (hp.AS == 0 || hp.AS == ia.AS())
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router/filter"
+)
+
+func TestFilterEval(t *testing.T) {
+	policy, err := filter.LoadPolicy("testdata/policy.json")
+	require.NoError(t, err)
+	hits := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "hits"},
+		[]string{"interface", "rule"})
+	f, err := filter.New(policy, hits)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		ifID     uint16
+		src      string
+		dst      string
+		pathType path.Type
+		dstPort  uint16
+		action   filter.Action
+		rule     string
+	}{
+		"abuser": {
+			ifID: 1, src: "1-ff00:0:666", dst: "1-ff00:0:110", pathType: scion.PathType,
+			dstPort: 80, action: filter.Drop, rule: "block-abuser",
+		},
+		"abuser monitoring": {
+			ifID: 1, src: "1-ff00:0:666", dst: "1-ff00:0:110", pathType: scion.PathType,
+			dstPort: 30041, action: filter.Allow, rule: "allow-monitoring",
+		},
+		"abuser on other interface": {
+			ifID: 2, src: "1-ff00:0:666", dst: "1-ff00:0:110", pathType: scion.PathType,
+			dstPort: 80, action: filter.Allow,
+		},
+		"ISD wildcard": {
+			ifID: 2, src: "2-ff00:0:210", dst: "1-ff00:0:110", pathType: scion.PathType,
+			dstPort: 80, action: filter.Drop, rule: "block-isd",
+		},
+		"ISD wildcard other destination": {
+			ifID: 2, src: "2-ff00:0:210", dst: "1-ff00:0:111", pathType: scion.PathType,
+			dstPort: 80, action: filter.Allow,
+		},
+		"any interface": {
+			ifID: 3, src: "1-ff00:0:111", dst: "1-ff00:0:110", pathType: epic.PathType,
+			dstPort: 53, action: filter.Drop, rule: "no-epic-dns",
+		},
+		"any interface other path type": {
+			ifID: 3, src: "1-ff00:0:111", dst: "1-ff00:0:110", pathType: scion.PathType,
+			dstPort: 53, action: filter.Allow,
+		},
+		"any interface after interface rules": {
+			ifID: 2, src: "1-ff00:0:111", dst: "1-ff00:0:110", pathType: epic.PathType,
+			dstPort: 53, action: filter.Drop, rule: "no-epic-dns",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			scn := newSCION(t, tc.src, tc.dst, tc.pathType, tc.dstPort)
+			action, rule := f.Eval(tc.ifID, scn, scn)
+			assert.Equal(t, tc.action, action)
+			assert.Equal(t, tc.rule, rule)
+		})
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(hits.WithLabelValues("1", "block-abuser")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hits.WithLabelValues("1", "allow-monitoring")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hits.WithLabelValues("2", "block-isd")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(
+		hits.WithLabelValues(filter.AnyInterface, "no-epic-dns")))
+}
+
+func TestFilterNil(t *testing.T) {
+	var f *filter.Filter
+	scn := newSCION(t, "1-ff00:0:111", "1-ff00:0:110", scion.PathType, 53)
+	action, rule := f.Eval(1, scn, scn)
+	assert.Equal(t, filter.Allow, action)
+	assert.Empty(t, rule)
+}
+
+func TestNewErrors(t *testing.T) {
+	testCases := map[string]string{
+		"no name":           `{"rules": [{"action": "drop"}]}`,
+		"duplicate name":    `{"rules": [{"name": "a"}, {"name": "a"}]}`,
+		"unknown path type": `{"rules": [{"name": "a", "path_types": ["foo"]}]}`,
+		"invalid condition": `{"interfaces": {"1": [{"name": "a", "condition": "foo"}]}}`,
+		"interface in IA":   `{"rules": [{"name": "a", "src_isd_as": "1-ff00:0:110#1"}]}`,
+	}
+	for name, raw := range testCases {
+		name, raw := name, raw
+		t.Run(name, func(t *testing.T) {
+			var policy filter.Policy
+			require.NoError(t, json.Unmarshal([]byte(raw), &policy))
+			_, err := filter.New(policy, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	var policy filter.Policy
+	err := json.Unmarshal([]byte(`{"rules": [{"name": "a", "action": "reject"}]}`), &policy)
+	assert.Error(t, err)
+}
+
+// newSCION returns a SCION layer with a SCION/UDP payload to the port.
+func newSCION(t *testing.T, src, dst string, pathType path.Type,
+	dstPort uint16) *slayers.SCION {
+
+	udp := &slayers.UDP{SrcPort: 30041, DstPort: dstPort}
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		udp, gopacket.Payload("payload")))
+
+	scn := &slayers.SCION{PathType: pathType}
+	scn.NextHdr = common.L4UDP
+	scn.SrcIA = xtest.MustParseIA(src)
+	scn.DstIA = xtest.MustParseIA(dst)
+	scn.Payload = buf.Bytes()
+	return scn
+}
@@ -36,6 +36,8 @@ type Metrics struct {
 	SiblingBFDPacketsSent     *prometheus.CounterVec
 	SiblingBFDPacketsReceived *prometheus.CounterVec
 	SiblingBFDStateChanges    *prometheus.CounterVec
+	SCMPSuppressedTotal       *prometheus.CounterVec
+	FilterHitsTotal           *prometheus.CounterVec
 }
 
 // NewMetrics initializes the metrics for the Border Router, and registers them
@@ -74,9 +76,9 @@ func NewMetrics() *Metrics {
 			prometheus.CounterOpts{
 				Name: "router_dropped_pkts_total",
 				Help: "Total number of packets dropped by the router. This metric reports " +
-					"the number of packets that were dropped because of errors.",
+					"the number of packets that were dropped because of errors, by reason.",
 			},
-			[]string{"interface", "isd_as", "neighbor_isd_as"},
+			[]string{"interface", "isd_as", "neighbor_isd_as", "reason"},
 		),
 		InterfaceUp: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -150,5 +152,20 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"sibling", "isd_as"},
 		),
+		SCMPSuppressedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_scmp_suppressed_total",
+				Help: "Total number of SCMP messages that were not sent because they " +
+					"exceeded the rate limit of their type or destination.",
+			},
+			[]string{"type", "limit"},
+		),
+		FilterHitsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_filter_hits_total",
+				Help: "Total number of packets that matched a rule of the packet filter.",
+			},
+			[]string{"interface", "rule"},
+		),
 	}
 }
@@ -0,0 +1,423 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/slayers"
+	underlayconn "github.com/scionproto/scion/go/lib/underlay/conn"
+)
+
+// The packets pass through the following pipeline in Run:
+//
+//  - A reader per interface reads batches of packets from its socket and
+//    dispatches every packet to a processor by the hash of its flow.
+//  - NumProcessors processors parse, validate and update the packets and hand
+//    them to the forwarder of their output socket.
+//  - A forwarder per socket writes the packets that are queued for it in
+//    batches.
+//
+// As the packets of a flow are processed by the same processor, and every
+// queue is FIFO, the packets of a flow leave the router in the order they
+// arrived on an interface. Packets are dropped if a queue is full.
+
+const (
+	// processorQueueLen is the number of packets that can be queued for a
+	// processor.
+	processorQueueLen = 1024
+	// forwarderQueueLen is the number of packets that can be queued for a
+	// forwarder.
+	forwarderQueueLen = 1024
+)
+
+// packet is a packet in the pipeline, together with the information the
+// stages pass along.
+type packet struct {
+	// buf is the buffer the packet is read into. It has a length of bufSize.
+	buf []byte
+	// raw is the received packet, a prefix of buf.
+	raw []byte
+	// srcAddr is the underlay address the packet was received from.
+	srcAddr *net.UDPAddr
+	// ingressID is the interface the packet was received on, 0 for the
+	// internal interface.
+	ingressID uint16
+	// ingressConn is the socket the packet was received on.
+	ingressConn BatchConn
+
+	// out is the packet to send, stored in buf.
+	out []byte
+	// outAddr is the underlay address to send the packet to, nil for the
+	// sockets of the external interfaces.
+	outAddr *net.UDPAddr
+	// egressID is the interface the packet is sent on.
+	egressID uint16
+}
+
+var packetPool = sync.Pool{
+	New: func() interface{} {
+		return &packet{buf: make([]byte, bufSize)}
+	},
+}
+
+func getPacket() *packet {
+	return packetPool.Get().(*packet)
+}
+
+func putPacket(pkt *packet) {
+	*pkt = packet{buf: pkt.buf}
+	packetPool.Put(pkt)
+}
+
+// pipelineConfig returns the number of processors and the batch size, with the
+// defaults applied.
+func (d *DataPlane) pipelineConfig() (int, int) {
+	numProcessors, batchSize := d.NumProcessors, d.BatchSize
+	if numProcessors <= 0 {
+		numProcessors = runtime.GOMAXPROCS(0)
+	}
+	if batchSize <= 0 {
+		batchSize = inputBatchCnt
+	}
+	return numProcessors, batchSize
+}
+
+// pipeline is the packet processing pipeline of the running dataplane. The
+// processors run as long as the dataplane, whereas the readers and the
+// forwarders are started and stopped as the interfaces are reconfigured.
+type pipeline struct {
+	ctx        context.Context
+	batchSize  int
+	processors []chan *packet
+	// busy are held by the processors while they process a packet, such that
+	// a reconfiguration can wait until no packet is processed with the
+	// previous forwarding state anymore.
+	busy []sync.Mutex
+	// readers and forwarders stop the running readers and forwarders.
+	readers    map[readerKey]context.CancelFunc
+	forwarders map[BatchConn]context.CancelFunc
+}
+
+// readerKey identifies the reader of an interface.
+type readerKey struct {
+	ifID uint16
+	conn BatchConn
+}
+
+// runPipeline starts the processors. The readers and the forwarders are started
+// by the pipeline's update. It must be called with the lock held.
+func (d *DataPlane) runPipeline(ctx context.Context) {
+	numProcessors, batchSize := d.pipelineConfig()
+	d.pipeline = &pipeline{
+		ctx:        ctx,
+		batchSize:  batchSize,
+		processors: make([]chan *packet, numProcessors),
+		busy:       make([]sync.Mutex, numProcessors),
+		readers:    make(map[readerKey]context.CancelFunc),
+		forwarders: make(map[BatchConn]context.CancelFunc),
+	}
+	for i := range d.pipeline.processors {
+		d.pipeline.processors[i] = make(chan *packet, processorQueueLen)
+		go func(q <-chan *packet, busy *sync.Mutex) {
+			defer log.HandlePanic()
+			d.runProcessor(ctx, q, busy)
+		}(d.pipeline.processors[i], &d.pipeline.busy[i])
+	}
+}
+
+// update starts the readers and forwarders of the sockets of the forwarding
+// state, and stops the others. The sockets that are no longer used are closed.
+func (p *pipeline) update(d *DataPlane, state *forwardingState) {
+	readers := map[readerKey]struct{}{{0, d.internal}: {}}
+	if d.internalAlt != nil {
+		readers[readerKey{0, d.internalAlt}] = struct{}{}
+	}
+	for ifID, c := range state.external {
+		readers[readerKey{ifID, c}] = struct{}{}
+	}
+	for key, stop := range p.readers {
+		if _, ok := readers[key]; !ok {
+			stop()
+			delete(p.readers, key)
+		}
+	}
+	for c, stop := range p.forwarders {
+		if _, ok := state.forwarders[c]; !ok {
+			stop()
+			delete(p.forwarders, c)
+			// Closing the socket also unblocks its reader.
+			if err := c.Close(); err != nil {
+				log.Info("Failed to close connection", "err", err)
+			}
+		}
+	}
+
+	for c, q := range state.forwarders {
+		if _, ok := p.forwarders[c]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(p.ctx)
+		p.forwarders[c] = cancel
+		go func(c BatchConn, q <-chan *packet) {
+			defer log.HandlePanic()
+			d.runForwarder(ctx, c, q, p.batchSize)
+		}(c, q)
+	}
+	for key := range readers {
+		if _, ok := p.readers[key]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(p.ctx)
+		p.readers[key] = cancel
+		go func(key readerKey) {
+			defer log.HandlePanic()
+			d.runReader(ctx, key.ifID, key.conn, p.batchSize, p.processors)
+		}(key)
+	}
+}
+
+// wait waits until the processors are done with the packets they are
+// processing.
+func (p *pipeline) wait() {
+	for i := range p.busy {
+		p.busy[i].Lock()
+		p.busy[i].Unlock()
+	}
+}
+
+// runReader reads the packets from the socket of an interface and dispatches
+// them to the processors.
+func (d *DataPlane) runReader(ctx context.Context, ingressID uint16, rd BatchConn,
+	batchSize int, processors []chan *packet) {
+
+	msgs := underlayconn.NewReadMessages(batchSize)
+	pkts := make([]*packet, batchSize)
+	for i := range msgs {
+		pkts[i] = getPacket()
+		msgs[i].Buffers[0] = pkts[i].buf
+	}
+	inputCounters := d.currentState().forwardingMetrics[ingressID]
+	for ctx.Err() == nil {
+		n, err := rd.ReadBatch(msgs)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Debug("Failed to read batch", "err", err)
+			// error metric
+			continue
+		}
+		for i, msg := range msgs[:n] {
+			// input metric
+			inputCounters.InputPacketsTotal.Inc()
+			inputCounters.InputBytesTotal.Add(float64(msg.N))
+
+			pkt := pkts[i]
+			pkt.raw = pkt.buf[:msg.N]
+			pkt.srcAddr = msg.Addr.(*net.UDPAddr)
+			pkt.ingressID = ingressID
+			pkt.ingressConn = rd
+			select {
+			case processors[flowHash(pkt.raw)%uint32(len(processors))] <- pkt:
+				pkts[i] = getPacket()
+			default:
+				d.drop(ingressID, pkt.raw, dropQueueFull, nil)
+			}
+			msgs[i].Buffers[0] = pkts[i].buf
+		}
+	}
+}
+
+// runProcessor processes the packets of its queue and hands them to the
+// forwarders of their output sockets. It holds busy while it processes a
+// packet.
+func (d *DataPlane) runProcessor(ctx context.Context, q <-chan *packet, busy *sync.Mutex) {
+	processor := newPacketProcessor(d, 0)
+	for {
+		var pkt *packet
+		select {
+		case pkt = <-q:
+		case <-ctx.Done():
+			return
+		}
+		busy.Lock()
+		d.processPacket(processor, pkt)
+		busy.Unlock()
+	}
+}
+
+// processPacket processes the packet and hands it to the forwarder of its
+// output socket, or drops it.
+func (d *DataPlane) processPacket(processor *scionPacketProcessor, pkt *packet) {
+	processor.ingressID = pkt.ingressID
+	result, err := processor.processPkt(pkt.raw, pkt.srcAddr)
+
+	var scmpErr scmpError
+	switch {
+	case err == nil:
+	case errors.As(err, &scmpErr):
+		if !scmpErr.TypeCode.InfoMsg() {
+			log.Debug("SCMP", "err", scmpErr, "dst_addr", pkt.srcAddr)
+			// The packet that caused the SCMP error is dropped.
+			d.drop(pkt.ingressID, pkt.raw, processor.dropReason(err), err)
+		}
+		// SCMP go back the way they came.
+		result.OutAddr = pkt.srcAddr
+		result.OutConn = pkt.ingressConn
+	default:
+		log.Debug("Error processing packet", "err", err)
+		d.drop(pkt.ingressID, pkt.raw, processor.dropReason(err), err)
+		putPacket(pkt)
+		return
+	}
+	if result.OutConn == nil { // e.g. BFD case no message is forwarded
+		putPacket(pkt)
+		return
+	}
+	fwd, ok := processor.state.forwarders[result.OutConn]
+	if !ok || len(result.OutPkt) == 0 {
+		d.drop(pkt.ingressID, pkt.raw, dropNoRoute, nil)
+		putPacket(pkt)
+		return
+	}
+	// The packet is usually updated in place. Other packets, e.g., SCMP
+	// messages, are serialized into the buffer of the processor, which is
+	// reused for the next packet.
+	if &result.OutPkt[0] != &pkt.buf[0] {
+		n := copy(pkt.buf, result.OutPkt)
+		result.OutPkt = pkt.buf[:n]
+	}
+	pkt.out = result.OutPkt
+	pkt.outAddr = result.OutAddr
+	pkt.egressID = result.EgressID
+	select {
+	case fwd <- pkt:
+	default:
+		d.drop(pkt.ingressID, pkt.out, dropQueueFull, nil)
+		putPacket(pkt)
+	}
+}
+
+// runForwarder writes the packets queued for a socket in batches. A batch
+// consists of the packets that are queued when the previous batch was written,
+// such that the packets are not delayed to fill a batch.
+func (d *DataPlane) runForwarder(ctx context.Context, c BatchConn, q <-chan *packet,
+	batchSize int) {
+
+	msgs := make(underlayconn.Messages, batchSize)
+	for i := range msgs {
+		msgs[i].Buffers = make([][]byte, 1)
+	}
+	pkts := make([]*packet, 0, batchSize)
+	for {
+		select {
+		case pkt := <-q:
+			pkts = append(pkts[:0], pkt)
+		case <-ctx.Done():
+			return
+		}
+	fill:
+		for len(pkts) < batchSize {
+			select {
+			case pkt := <-q:
+				pkts = append(pkts, pkt)
+			default:
+				break fill
+			}
+		}
+		for i, pkt := range pkts {
+			msgs[i].Buffers[0] = pkt.out
+			msgs[i].Addr = nil
+			if pkt.outAddr != nil { // don't assign directly to net.Addr, typed nil!
+				msgs[i].Addr = pkt.outAddr
+			}
+		}
+
+		// Write to the socket; drop the packets if this would block. Use
+		// WriteBatch because it's the only available function that supports
+		// MSG_DONTWAIT.
+		written := 0
+		reason, writeErr := dropQueueFull, error(nil)
+		for written < len(pkts) {
+			n, err := c.WriteBatch(msgs[written:len(pkts)], syscall.MSG_DONTWAIT)
+			if err != nil {
+				var errno syscall.Errno
+				if !errors.As(err, &errno) ||
+					!(errno == syscall.EAGAIN || errno == syscall.EWOULDBLOCK) {
+					log.Debug("Error writing packet", "err", err)
+					reason, writeErr = dropWriteError, err
+				}
+				break
+			}
+			if n <= 0 {
+				break
+			}
+			written += n
+		}
+		forwardingMetrics := d.currentState().forwardingMetrics
+		for i, pkt := range pkts {
+			if i < written {
+				// ok metric
+				outputCounters := forwardingMetrics[pkt.egressID]
+				outputCounters.OutputPacketsTotal.Inc()
+				outputCounters.OutputBytesTotal.Add(float64(len(pkt.out)))
+			} else {
+				d.drop(pkt.ingressID, pkt.out, reason, writeErr)
+			}
+			msgs[i].Buffers[0] = nil
+			pkts[i] = nil
+			putPacket(pkt)
+		}
+	}
+}
+
+// flowHash returns the hash of the flow of the raw SCION packet, i.e., of its
+// flow ID and of its source and destination addresses. Packets that are too
+// short to contain the address header all have the same hash.
+func flowHash(raw []byte) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	if len(raw) < slayers.CmnHdrLen {
+		return 0
+	}
+	// The address header consists of the destination and source ISD-AS,
+	// followed by the destination and source host addresses, the lengths of
+	// which are encoded in byte 9 of the common header.
+	dstLen := 4 * (int(raw[9]>>4&0x3) + 1)
+	srcLen := 4 * (int(raw[9]&0x3) + 1)
+	end := slayers.CmnHdrLen + 16 + dstLen + srcLen
+	if len(raw) < end {
+		return 0
+	}
+	// FNV-1a over the flow ID (the lower 20 bits of the first line) and the
+	// address header.
+	h := uint32(offset32)
+	h = (h ^ uint32(raw[1]&0xf)) * prime32
+	h = (h ^ uint32(raw[2])) * prime32
+	h = (h ^ uint32(raw[3])) * prime32
+	for _, b := range raw[slayers.CmnHdrLen:end] {
+		h = (h ^ uint32(b)) * prime32
+	}
+	return h
+}
@@ -0,0 +1,297 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/cipher"
+	"hash"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/router/bfd"
+)
+
+// forwardingState is the configuration the packets are processed with. Once
+// it is published, it is never modified, such that a reconfiguration can
+// replace it atomically: every packet is processed with a single state.
+type forwardingState struct {
+	external            map[uint16]BatchConn
+	linkTypes           map[uint16]topology.LinkType
+	neighborIAs         map[uint16]addr.IA
+	internalNextHops    map[uint16]*net.UDPAddr
+	bfdSessions         map[uint16]bfdSession
+	macFactory          func() hash.Hash
+	secondaryMacFactory func() hash.Hash
+	colibriKey          cipher.Block
+	secondaryColibriKey cipher.Block
+	// forwardingMetrics are the metrics of the interfaces. The metrics of the
+	// removed interfaces are kept, as the packets that were received on them
+	// before they were removed are still counted.
+	forwardingMetrics map[uint16]forwardingMetrics
+	// forwarders are the queues of the forwarders of the sockets.
+	forwarders map[BatchConn]chan *packet
+}
+
+// currentState returns the forwarding state the packets are processed with.
+// If the dataplane is not running, e.g., in tests, it is the state of the
+// current configuration.
+func (d *DataPlane) currentState() *forwardingState {
+	if state, ok := d.state.Load().(*forwardingState); ok {
+		return state
+	}
+	return d.configState()
+}
+
+// configState returns the forwarding state of the current configuration,
+// without metrics and forwarders. It shares the maps with the configuration.
+func (d *DataPlane) configState() *forwardingState {
+	return &forwardingState{
+		external:            d.external,
+		linkTypes:           d.linkTypes,
+		neighborIAs:         d.neighborIAs,
+		internalNextHops:    d.internalNextHops,
+		bfdSessions:         d.bfdSessions,
+		macFactory:          d.macFactory,
+		secondaryMacFactory: d.secondaryMacFactory,
+		colibriKey:          d.colibriKey,
+		secondaryColibriKey: d.secondaryColibriKey,
+	}
+}
+
+// newState returns the forwarding state of the current configuration. The
+// metrics and the forwarder queues of the interfaces and sockets that are
+// still in use are taken over from prev, which is nil when the dataplane
+// starts. It must be called with the lock held.
+func (d *DataPlane) newState(prev *forwardingState) *forwardingState {
+	state := d.configState()
+	state.forwardingMetrics = make(map[uint16]forwardingMetrics)
+	state.forwarders = make(map[BatchConn]chan *packet)
+	if prev != nil {
+		for id, m := range prev.forwardingMetrics {
+			state.forwardingMetrics[id] = m
+		}
+	}
+	// The metrics are (re-)initialized for all the relevant interfaces, as
+	// their labels include the neighboring IA, which might have changed.
+	if d.Metrics != nil {
+		labels := interfaceToMetricLabels(0, d.localIA, d.neighborIAs)
+		state.forwardingMetrics[0] = initForwardingMetrics(d.Metrics, labels)
+		for id := range d.external {
+			if _, notOwned := d.internalNextHops[id]; notOwned {
+				continue
+			}
+			labels = interfaceToMetricLabels(id, d.localIA, d.neighborIAs)
+			state.forwardingMetrics[id] = initForwardingMetrics(d.Metrics, labels)
+		}
+	}
+
+	conns := []BatchConn{d.internal}
+	if d.internalAlt != nil {
+		conns = append(conns, d.internalAlt)
+	}
+	for _, c := range d.external {
+		conns = append(conns, c)
+	}
+	for _, c := range conns {
+		if _, ok := state.forwarders[c]; ok {
+			continue
+		}
+		if prev != nil {
+			if q, ok := prev.forwarders[c]; ok {
+				state.forwarders[c] = q
+				continue
+			}
+		}
+		state.forwarders[c] = make(chan *packet, forwarderQueueLen)
+	}
+	return state
+}
+
+// Reconfigure changes the configuration of the running dataplane. The changes
+// that fn makes with the Add*, RemoveInterface and Set*Key methods are applied
+// atomically once fn returns, i.e., every packet is processed either with the
+// configuration before or after the changes. If fn fails, none of the changes
+// are applied. The connections of the removed interfaces are closed, unless
+// they are added again.
+//
+// If the dataplane is not running yet, fn is called without further ado.
+// Reconfigure must not be called concurrently with the other configuration
+// methods, except from within fn.
+func (d *DataPlane) Reconfigure(fn func() error) error {
+	d.mtx.Lock()
+	if !d.running {
+		d.mtx.Unlock()
+		return fn()
+	}
+	if d.reconfiguring {
+		d.mtx.Unlock()
+		return serrors.New("reconfiguration in progress")
+	}
+	prev := d.currentState()
+	d.copyConfig()
+	d.reconfiguring = true
+	d.mtx.Unlock()
+
+	err := fn()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.reconfiguring = false
+	if err != nil {
+		// The connections added by fn are owned by the dataplane, which drops
+		// them together with the rest of the changes.
+		prevConns := make(map[BatchConn]struct{}, len(prev.external))
+		for _, c := range prev.external {
+			prevConns[c] = struct{}{}
+		}
+		for _, c := range d.external {
+			if _, ok := prevConns[c]; !ok {
+				if err := c.Close(); err != nil {
+					log.Info("Failed to close connection", "err", err)
+				}
+			}
+		}
+		d.restoreConfig(prev)
+		return err
+	}
+	next := d.newState(prev)
+	d.state.Store(next)
+	d.apply(prev, next)
+	return nil
+}
+
+// RemoveInterface removes the interface with the given ID, i.e., its
+// connection or next hop, neighboring IA, link type and BFD session. This can
+// only be called on a not yet running dataplane, or within Reconfigure.
+func (d *DataPlane) RemoveInterface(ifID uint16) error {
+	_, err := d.removeInterface(ifID)
+	return err
+}
+
+// removeInterface removes the interface and returns its connection, or nil if
+// the interface is owned by another router.
+func (d *DataPlane) removeInterface(ifID uint16) (BatchConn, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.configurable() {
+		return nil, modifyExisting
+	}
+	conn, external := d.external[ifID]
+	_, sibling := d.internalNextHops[ifID]
+	if !external && !sibling {
+		return nil, serrors.New("unknown interface", "ifID", ifID)
+	}
+	delete(d.external, ifID)
+	delete(d.internalNextHops, ifID)
+	delete(d.neighborIAs, ifID)
+	delete(d.linkTypes, ifID)
+	delete(d.bfdSessions, ifID)
+	return conn, nil
+}
+
+// configurable returns whether the configuration can be changed, i.e., whether
+// the dataplane is not running yet, or is being reconfigured.
+func (d *DataPlane) configurable() bool {
+	return !d.running || d.reconfiguring
+}
+
+// copyConfig replaces the maps of the configuration with copies, such that
+// they can be modified without modifying the published forwarding state.
+func (d *DataPlane) copyConfig() {
+	external := make(map[uint16]BatchConn, len(d.external))
+	for k, v := range d.external {
+		external[k] = v
+	}
+	linkTypes := make(map[uint16]topology.LinkType, len(d.linkTypes))
+	for k, v := range d.linkTypes {
+		linkTypes[k] = v
+	}
+	neighborIAs := make(map[uint16]addr.IA, len(d.neighborIAs))
+	for k, v := range d.neighborIAs {
+		neighborIAs[k] = v
+	}
+	internalNextHops := make(map[uint16]*net.UDPAddr, len(d.internalNextHops))
+	for k, v := range d.internalNextHops {
+		internalNextHops[k] = v
+	}
+	bfdSessions := make(map[uint16]bfdSession, len(d.bfdSessions))
+	for k, v := range d.bfdSessions {
+		bfdSessions[k] = v
+	}
+	d.external = external
+	d.linkTypes = linkTypes
+	d.neighborIAs = neighborIAs
+	d.internalNextHops = internalNextHops
+	d.bfdSessions = bfdSessions
+}
+
+// restoreConfig restores the configuration of the forwarding state.
+func (d *DataPlane) restoreConfig(state *forwardingState) {
+	d.external = state.external
+	d.linkTypes = state.linkTypes
+	d.neighborIAs = state.neighborIAs
+	d.internalNextHops = state.internalNextHops
+	d.bfdSessions = state.bfdSessions
+	d.macFactory = state.macFactory
+	d.secondaryMacFactory = state.secondaryMacFactory
+	d.colibriKey = state.colibriKey
+	d.secondaryColibriKey = state.secondaryColibriKey
+}
+
+// apply starts and stops the BFD sessions and the readers and forwarders of
+// the sockets as the forwarding state changes from prev to next, which was
+// just published. prev is nil when the dataplane starts. It must be called
+// with the lock held.
+func (d *DataPlane) apply(prev, next *forwardingState) {
+	if prev != nil && d.pipeline != nil {
+		// Once no packet is processed with the previous state anymore, its
+		// BFD sessions and sockets can be shut down safely.
+		d.pipeline.wait()
+	}
+
+	prevSessions := make(map[bfdSession]struct{})
+	if prev != nil {
+		for _, s := range prev.bfdSessions {
+			prevSessions[s] = struct{}{}
+		}
+	}
+	nextSessions := make(map[bfdSession]struct{})
+	for _, s := range next.bfdSessions {
+		nextSessions[s] = struct{}{}
+	}
+	for s := range prevSessions {
+		if _, ok := nextSessions[s]; !ok {
+			close(s.Messages())
+		}
+	}
+	for k, v := range next.bfdSessions {
+		if _, ok := prevSessions[v]; ok {
+			continue
+		}
+		go func(ifID uint16, c bfdSession) {
+			defer log.HandlePanic()
+			if err := c.Run(); err != nil && err != bfd.AlreadyRunning {
+				log.Error("BFD session failed to start", "ifID", ifID, "err", err)
+			}
+		}(k, v)
+	}
+
+	if d.pipeline != nil {
+		d.pipeline.update(d, next)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestDataPlaneReconfigure(t *testing.T) {
+	oldKey := []byte("testkey_xxxxxxxx")
+	newKey := []byte("testkey_yyyyyyyy")
+	otherKey := []byte("testkey_zzzzzzzz")
+
+	// prepDP returns a running dataplane that forwards from interface 1 to
+	// interface 2, with oldKey.
+	prepDP := func(ctrl *gomock.Controller) *router.DataPlane {
+		d := router.NewDP(
+			map[uint16]router.BatchConn{
+				uint16(2): mock_router.NewMockBatchConn(ctrl),
+			},
+			map[uint16]topology.LinkType{
+				1: topology.Parent,
+				2: topology.Child,
+			},
+			nil, nil, nil, xtest.MustParseIA("1-ff00:0:110"), nil, oldKey)
+		d.FakeStart()
+		return d
+	}
+
+	t.Run("key rollover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		_, err := d.ProcessPkt(1, prepTransitMsg(t, oldKey))
+		require.NoError(t, err)
+
+		err = d.Reconfigure(func() error {
+			if err := d.SetKey(newKey); err != nil {
+				return err
+			}
+			return d.SetSecondaryKey(oldKey)
+		})
+		require.NoError(t, err)
+		_, err = d.ProcessPkt(1, prepTransitMsg(t, newKey))
+		assert.NoError(t, err, "new key")
+		_, err = d.ProcessPkt(1, prepTransitMsg(t, oldKey))
+		assert.NoError(t, err, "old key")
+		_, err = d.ProcessPkt(1, prepTransitMsg(t, otherKey))
+		assert.Error(t, err, "other key")
+	})
+	t.Run("remove interface", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		require.NoError(t, d.Reconfigure(func() error {
+			return d.RemoveInterface(2)
+		}))
+		_, err := d.ProcessPkt(1, prepTransitMsg(t, oldKey))
+		assert.Error(t, err)
+	})
+	t.Run("remove unknown interface fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		assert.Error(t, d.Reconfigure(func() error {
+			return d.RemoveInterface(42)
+		}))
+	})
+	t.Run("add interface", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		require.NoError(t, d.Reconfigure(func() error {
+			if err := d.RemoveInterface(2); err != nil {
+				return err
+			}
+			if err := d.AddExternalInterface(2, mock_router.NewMockBatchConn(ctrl)); err != nil {
+				return err
+			}
+			return d.AddLinkType(2, topology.Child)
+		}))
+		_, err := d.ProcessPkt(1, prepTransitMsg(t, oldKey))
+		assert.NoError(t, err)
+	})
+	t.Run("failure rolls back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		added := mock_router.NewMockBatchConn(ctrl)
+		added.EXPECT().Close()
+		err := d.Reconfigure(func() error {
+			if err := d.RemoveInterface(2); err != nil {
+				return err
+			}
+			if err := d.AddExternalInterface(3, added); err != nil {
+				return err
+			}
+			if err := d.SetKey(newKey); err != nil {
+				return err
+			}
+			return serrors.New("test error")
+		})
+		assert.Error(t, err)
+		_, err = d.ProcessPkt(1, prepTransitMsg(t, oldKey))
+		assert.NoError(t, err)
+		assert.Error(t, d.AddExternalInterface(3, mock_router.NewMockBatchConn(ctrl)))
+	})
+	t.Run("nested reconfiguration fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := prepDP(ctrl)
+		err := d.Reconfigure(func() error {
+			return d.Reconfigure(func() error { return nil })
+		})
+		assert.Error(t, err)
+	})
+}
+
+// prepTransitMsg returns a packet that is forwarded from interface 1 to
+// interface 2, with the hop field MAC computed with key.
+func prepTransitMsg(t *testing.T, key []byte) *ipv4.Message {
+	spkt, dpath := prepBaseMsg(time.Now())
+	dpath.HopFields = []path.HopField{
+		{ConsIngress: 31, ConsEgress: 30},
+		{ConsIngress: 1, ConsEgress: 2},
+		{ConsIngress: 40, ConsEgress: 41},
+	}
+	dpath.Base.PathMeta.CurrHF = 1
+	dpath.HopFields[1].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[1])
+	return toMsg(t, spkt, dpath)
+}
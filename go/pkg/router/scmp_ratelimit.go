@@ -0,0 +1,170 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+// scmpDestinationBuckets is the number of token buckets the SCMP rate limiter
+// keeps for the destinations. It must be a power of two.
+const scmpDestinationBuckets = 1 << 14
+
+// newSCMPBucket creates a full token bucket for the limit. Tokens are
+// messages.
+func newSCMPBucket(limit control.SCMPRateLimit, now time.Time) *tokenbucket.Bucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return tokenbucket.New(limit.Rate, burst, now)
+}
+
+// scmpTypeNames are the names of the SCMP types the router sends, as reported
+// in the type label of the suppressed SCMP messages metric.
+var scmpTypeNames = map[slayers.SCMPType]string{
+	slayers.SCMPTypeDestinationUnreachable:   "destination_unreachable",
+	slayers.SCMPTypePacketTooBig:             "packet_too_big",
+	slayers.SCMPTypeParameterProblem:         "parameter_problem",
+	slayers.SCMPTypeExternalInterfaceDown:    "external_interface_down",
+	slayers.SCMPTypeInternalConnectivityDown: "internal_connectivity_down",
+	slayers.SCMPTypeTracerouteReply:          "traceroute_reply",
+}
+
+func scmpTypeName(typ slayers.SCMPType) string {
+	if name, ok := scmpTypeNames[typ]; ok {
+		return name
+	}
+	return strconv.Itoa(int(typ))
+}
+
+// scmpDestinationIndex returns the index of the token bucket of the
+// destination, i.e., the FNV-1a hash of the destination modulo the number of
+// buckets.
+func scmpDestinationIndex(ia addr.IA, host string) int {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 56; i >= 0; i -= 8 {
+		h = (h ^ uint32(uint64(ia)>>i&0xff)) * prime32
+	}
+	for i := 0; i < len(host); i++ {
+		h = (h ^ uint32(host[i])) * prime32
+	}
+	return int(h & (scmpDestinationBuckets - 1))
+}
+
+// scmpRateLimiter limits the SCMP messages sent by the router per SCMP type
+// and per destination. The destinations are hashed onto a fixed number of
+// token buckets, so the memory and the time per message are bounded no matter
+// how many destinations there are. Destinations whose hashes collide share a
+// bucket. This does not give an attacker more than spoofing the destination
+// itself does. The buckets of the destinations are created when they are first
+// used.
+type scmpRateLimiter struct {
+	mtx   sync.Mutex
+	types map[slayers.SCMPType]*tokenbucket.Bucket
+	// destination is the limit of every destination.
+	destination  control.SCMPRateLimit
+	destinations []*tokenbucket.Bucket
+
+	// suppressed counts the suppressed messages per SCMP type, by the limit
+	// that suppressed them.
+	suppressed       *prometheus.CounterVec
+	suppressedByType map[slayers.SCMPType][2]prometheus.Counter
+}
+
+const (
+	scmpLimitType = iota
+	scmpLimitDestination
+)
+
+func newSCMPRateLimiter(limits control.SCMPRateLimits,
+	suppressed *prometheus.CounterVec) *scmpRateLimiter {
+
+	now := time.Now()
+	l := &scmpRateLimiter{
+		types:            make(map[slayers.SCMPType]*tokenbucket.Bucket, len(limits.Types)),
+		destination:      limits.Destination,
+		suppressed:       suppressed,
+		suppressedByType: make(map[slayers.SCMPType][2]prometheus.Counter),
+	}
+	if limits.Destination.Rate > 0 {
+		l.destinations = make([]*tokenbucket.Bucket, scmpDestinationBuckets)
+	}
+	for t, limit := range limits.Types {
+		if limit.Rate > 0 {
+			l.types[t] = newSCMPBucket(limit, now)
+		}
+	}
+	return l
+}
+
+// allow returns whether an SCMP message of the given type may be sent to the
+// given destination. If not, the message is counted as suppressed.
+func (l *scmpRateLimiter) allow(typ slayers.SCMPType, dstIA addr.IA, dstHost string,
+	now time.Time) bool {
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	var dst *tokenbucket.Bucket
+	if l.destinations != nil {
+		i := scmpDestinationIndex(dstIA, dstHost)
+		if l.destinations[i] == nil {
+			l.destinations[i] = newSCMPBucket(l.destination, now)
+		}
+		dst = l.destinations[i]
+	}
+	// The message is only charged to the buckets if both of them allow it,
+	// such that suppressed messages do not use up the rate of either.
+	if tokenbucket.AllowBoth(dst, l.types[typ], 1, now) {
+		return true
+	}
+	if dst != nil && !dst.Available(1, now) {
+		l.count(typ, scmpLimitDestination)
+	} else {
+		l.count(typ, scmpLimitType)
+	}
+	return false
+}
+
+// count counts a suppressed message. It must be called with the lock held.
+func (l *scmpRateLimiter) count(typ slayers.SCMPType, limit int) {
+	if l.suppressed == nil {
+		return
+	}
+	counters, ok := l.suppressedByType[typ]
+	if !ok {
+		name := scmpTypeName(typ)
+		counters[scmpLimitType] = l.suppressed.With(
+			prometheus.Labels{"type": name, "limit": "type"})
+		counters[scmpLimitDestination] = l.suppressed.With(
+			prometheus.Labels{"type": name, "limit": "destination"})
+		l.suppressedByType[typ] = counters
+	}
+	counters[limit].Inc()
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/control"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestSCMPRateLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	// invalidMAC returns a packet with an invalid MAC from the given source,
+	// which is answered with an SCMP parameter problem.
+	invalidMAC := func(srcIA addr.IA, srcHost string) *ipv4.Message {
+		spkt, dpath := prepBaseMsg(time.Now())
+		spkt.DstIA = local
+		spkt.SrcIA = srcIA
+		require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP(srcHost).To4()}))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 0, Mac: [path.MacLen]byte{1, 2, 3}},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		return toMsg(t, spkt, dpath)
+	}
+	newDP := func(t *testing.T, limits control.SCMPRateLimits) *router.DataPlane {
+		dp := router.NewDP(nil, nil, nil, nil, nil, local, nil, key)
+		require.NoError(t, dp.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			net.IP{10, 0, 200, 200}))
+		require.NoError(t, dp.SetSCMPRateLimits(limits))
+		return dp
+	}
+	srcA := xtest.MustParseIA("1-ff00:0:111")
+	srcB := xtest.MustParseIA("1-ff00:0:112")
+
+	type send struct {
+		srcIA   addr.IA
+		srcHost string
+		sent    bool
+	}
+	testCases := map[string]struct {
+		limits control.SCMPRateLimits
+		sends  []send
+	}{
+		"no limits": {
+			sends: []send{
+				{srcA, "10.0.0.1", true},
+				{srcA, "10.0.0.1", true},
+				{srcA, "10.0.0.1", true},
+			},
+		},
+		"per type": {
+			limits: control.SCMPRateLimits{
+				Types: map[slayers.SCMPType]control.SCMPRateLimit{
+					slayers.SCMPTypeParameterProblem: {Rate: 0.001, Burst: 2},
+				},
+			},
+			sends: []send{
+				{srcA, "10.0.0.1", true},
+				{srcB, "10.0.0.2", true},
+				{srcA, "10.0.0.3", false},
+			},
+		},
+		"other type": {
+			limits: control.SCMPRateLimits{
+				Types: map[slayers.SCMPType]control.SCMPRateLimit{
+					slayers.SCMPTypeTracerouteReply: {Rate: 0.001, Burst: 1},
+				},
+			},
+			sends: []send{
+				{srcA, "10.0.0.1", true},
+				{srcA, "10.0.0.1", true},
+			},
+		},
+		"per destination": {
+			limits: control.SCMPRateLimits{
+				Destination: control.SCMPRateLimit{Rate: 0.001, Burst: 1},
+			},
+			sends: []send{
+				{srcA, "10.0.0.1", true},
+				{srcA, "10.0.0.1", false},
+				{srcA, "10.0.0.2", true},
+				{srcB, "10.0.0.1", true},
+				{srcB, "10.0.0.1", false},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dp := newDP(t, tc.limits)
+			for i, s := range tc.sends {
+				result, err := dp.ProcessPkt(1, invalidMAC(s.srcIA, s.srcHost))
+				assert.Error(t, err, i)
+				assert.Equal(t, s.sent, len(result.OutPkt) > 0, i)
+			}
+		})
+	}
+
+	t.Run("drop reason", func(t *testing.T) {
+		dp := newDP(t, control.SCMPRateLimits{
+			Destination: control.SCMPRateLimit{Rate: 0.001, Burst: 1},
+		})
+		// The packet is dropped for its own reason, whether the SCMP error is
+		// sent or not.
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, "invalid_mac",
+				dp.ProcessDropReason(1, invalidMAC(srcA, "10.0.0.1")), i)
+		}
+	})
+}
+
+func TestSCMPRateLimiterChargesBothLimits(t *testing.T) {
+	now := time.Now()
+	srcA := xtest.MustParseIA("1-ff00:0:111")
+	srcB := xtest.MustParseIA("1-ff00:0:112")
+	srcC := xtest.MustParseIA("1-ff00:0:113")
+	typ := slayers.SCMPTypeParameterProblem
+	limits := func(typeBurst, destinationBurst int) control.SCMPRateLimits {
+		return control.SCMPRateLimits{
+			Types: map[slayers.SCMPType]control.SCMPRateLimit{
+				typ: {Rate: 0.001, Burst: typeBurst},
+			},
+			Destination: control.SCMPRateLimit{Rate: 0.001, Burst: destinationBurst},
+		}
+	}
+	newSuppressed := func() *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: "suppressed"},
+			[]string{"type", "limit"})
+	}
+	suppressed := func(c *prometheus.CounterVec, limit string) float64 {
+		return testutil.ToFloat64(c.With(
+			prometheus.Labels{"type": "parameter_problem", "limit": limit}))
+	}
+
+	t.Run("type limit does not charge destination", func(t *testing.T) {
+		c := newSuppressed()
+		l := router.NewSCMPRateLimiter(limits(1, 1), c)
+		assert.True(t, l.Allow(typ, srcA, "10.0.0.1", now))
+		// The destination still has its token, so the messages are
+		// suppressed by the type limit only.
+		assert.False(t, l.Allow(typ, srcB, "10.0.0.1", now))
+		assert.False(t, l.Allow(typ, srcB, "10.0.0.1", now))
+		assert.Equal(t, float64(2), suppressed(c, "type"))
+		assert.Equal(t, float64(0), suppressed(c, "destination"))
+	})
+
+	t.Run("destination limit does not charge type", func(t *testing.T) {
+		c := newSuppressed()
+		l := router.NewSCMPRateLimiter(limits(2, 1), c)
+		assert.True(t, l.Allow(typ, srcA, "10.0.0.1", now))
+		assert.False(t, l.Allow(typ, srcA, "10.0.0.1", now))
+		assert.True(t, l.Allow(typ, srcB, "10.0.0.1", now))
+		assert.False(t, l.Allow(typ, srcC, "10.0.0.1", now))
+		assert.Equal(t, float64(1), suppressed(c, "type"))
+		assert.Equal(t, float64(1), suppressed(c, "destination"))
+	})
+}
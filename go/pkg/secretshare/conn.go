@@ -0,0 +1,429 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretshare
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/dataplane"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
+)
+
+const (
+	// hdrLen is the length of the share header, in bytes.
+	hdrLen = 12
+	// version is the version of the share header.
+	version = 0
+
+	defaultGroupTimeout        = 2 * time.Second
+	defaultPathRefreshInterval = time.Minute
+	pathQueryTimeout           = 5 * time.Second
+)
+
+// Config is the configuration of a connection.
+type Config struct {
+	// N is the number of shares, and thus paths, per datagram.
+	N int
+	// T is the number of shares needed to reconstruct a datagram. It must be
+	// at least 2 and must not exceed N.
+	T int
+	// AESKey is the hex-encoded AES key, in the same format as in the gateway
+	// configuration. Both ends must use the same key.
+	AESKey string
+	// Router provides the paths to remote ASes. It is required for sending
+	// datagrams, unless paths are set with SetPaths.
+	Router snet.Router
+	// GroupTimeout is the time after which the shares of a datagram that could
+	// not be reconstructed are discarded. If zero, a default of 2 seconds is
+	// used.
+	GroupTimeout time.Duration
+	// PathRefreshInterval is the interval after which the paths to a remote AS
+	// are fetched from the Router again. If zero, a default of 1 minute is used.
+	PathRefreshInterval time.Duration
+}
+
+func (cfg *Config) validate() error {
+	if cfg.T < 2 {
+		return serrors.New("threshold must be at least 2", "T", cfg.T)
+	}
+	if cfg.N < cfg.T || cfg.N > 255 {
+		return serrors.New("number of shares must be between threshold and 255",
+			"N", cfg.N, "T", cfg.T)
+	}
+	if cfg.AESKey == "" {
+		return serrors.New("AES key must be set")
+	}
+	if _, err := dataplane.Encrypt(nil, cfg.AESKey); err != nil {
+		return serrors.WrapStr("invalid AES key", err)
+	}
+	if cfg.GroupTimeout == 0 {
+		cfg.GroupTimeout = defaultGroupTimeout
+	}
+	if cfg.PathRefreshInterval == 0 {
+		cfg.PathRefreshInterval = defaultPathRefreshInterval
+	}
+	return nil
+}
+
+var _ net.PacketConn = (*Conn)(nil)
+
+// Conn is a net.PacketConn that sends every datagram as N secret shares over
+// N different SCION paths, and reconstructs datagrams from the received shares.
+//
+// The addresses passed to WriteTo must be of type *snet.UDPAddr. Their path is
+// ignored, the paths are selected by the connection. The addresses returned
+// by ReadFrom are of type *snet.UDPAddr and carry no path.
+type Conn struct {
+	conn net.PacketConn
+	cfg  Config
+
+	writeMtx sync.Mutex
+	nextID   uint64
+	paths    map[addr.IA]pathSet
+	pinned   map[addr.IA][]snet.Path
+
+	readMtx     sync.Mutex
+	buf         []byte
+	groups      map[groupKey]*group
+	lastCleanup time.Time
+}
+
+// pathSet are the paths selected for a remote AS.
+type pathSet struct {
+	paths   []snet.Path
+	fetched time.Time
+}
+
+// NewConn creates a connection that sends and receives the shares on conn.
+// The connection takes ownership of conn, which is expected to be a SCION
+// connection, e.g., a *snet.Conn.
+func NewConn(conn net.PacketConn, cfg Config) (*Conn, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, serrors.WrapStr("initializing message ID", err)
+	}
+	return &Conn{
+		conn:        conn,
+		cfg:         cfg,
+		nextID:      binary.BigEndian.Uint64(id[:]),
+		paths:       make(map[addr.IA]pathSet),
+		pinned:      make(map[addr.IA][]snet.Path),
+		buf:         make([]byte, common.SupportedMTU),
+		groups:      make(map[groupKey]*group),
+		lastCleanup: time.Now(),
+	}, nil
+}
+
+// Listen opens a SCION connection on the network and wraps it in a Conn.
+func Listen(ctx context.Context, network *snet.SCIONNetwork, listen *net.UDPAddr,
+	cfg Config) (*Conn, error) {
+
+	conn, err := network.Listen(ctx, "udp", listen, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("listening", err)
+	}
+	c, err := NewConn(conn, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Dial opens a SCION connection on the network and returns a net.Conn that
+// exchanges datagrams with remote. Datagrams from other senders are
+// discarded.
+func Dial(ctx context.Context, network *snet.SCIONNetwork, listen *net.UDPAddr,
+	remote *snet.UDPAddr, cfg Config) (net.Conn, error) {
+
+	c, err := Listen(ctx, network, listen, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &connectedConn{Conn: c, remote: remote.Copy()}, nil
+}
+
+// SetPaths pins the paths used for datagrams to the remote AS. The first N
+// paths are used. If paths is empty, the paths are again fetched from the
+// Router.
+func (c *Conn) SetPaths(ia addr.IA, paths []snet.Path) error {
+	if len(paths) != 0 && len(paths) < c.cfg.N {
+		return serrors.New("not enough paths", "required", c.cfg.N, "actual", len(paths))
+	}
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+	if len(paths) == 0 {
+		delete(c.pinned, ia)
+		return nil
+	}
+	c.pinned[ia] = append([]snet.Path(nil), paths[:c.cfg.N]...)
+	return nil
+}
+
+// WriteTo encrypts and splits the datagram, and sends the shares to dst.
+func (c *Conn) WriteTo(b []byte, dst net.Addr) (int, error) {
+	remote, ok := dst.(*snet.UDPAddr)
+	if !ok {
+		return 0, serrors.New("unsupported address type", "type", common.TypeOf(dst))
+	}
+	if len(b) == 0 {
+		return 0, serrors.New("empty datagram")
+	}
+	encrypted, err := dataplane.Encrypt(b, c.cfg.AESKey)
+	if err != nil {
+		return 0, serrors.WrapStr("encrypting datagram", err)
+	}
+	shares, err := dataplane.Split(encrypted, c.cfg.N, c.cfg.T)
+	if err != nil {
+		return 0, serrors.WrapStr("splitting datagram", err)
+	}
+
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+	paths, err := c.pathsTo(remote.IA)
+	if err != nil {
+		return 0, err
+	}
+	id := c.nextID
+	c.nextID++
+	for i, share := range shares {
+		pkt := make([]byte, hdrLen+len(share))
+		pkt[0] = version
+		pkt[1] = uint8(i)
+		pkt[2] = uint8(c.cfg.T)
+		binary.BigEndian.PutUint64(pkt[4:hdrLen], id)
+		copy(pkt[hdrLen:], share)
+		shareDst := &snet.UDPAddr{
+			IA:      remote.IA,
+			Host:    remote.Host,
+			Path:    paths[i].Dataplane(),
+			NextHop: paths[i].UnderlayNextHop(),
+		}
+		if _, err := c.conn.WriteTo(pkt, shareDst); err != nil {
+			return 0, serrors.WrapStr("sending share", err, "index", i)
+		}
+	}
+	return len(b), nil
+}
+
+// pathsTo returns the N paths to the remote AS. It must be called with
+// writeMtx held.
+func (c *Conn) pathsTo(ia addr.IA) ([]snet.Path, error) {
+	if paths, ok := c.pinned[ia]; ok {
+		return paths, nil
+	}
+	if set, ok := c.paths[ia]; ok && time.Since(set.fetched) < c.cfg.PathRefreshInterval {
+		return set.paths, nil
+	}
+	if c.cfg.Router == nil {
+		return nil, serrors.New("no paths set and no router configured", "isd_as", ia)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pathQueryTimeout)
+	defer cancel()
+	paths, err := c.cfg.Router.AllRoutes(ctx, ia)
+	if err != nil {
+		return nil, serrors.WrapStr("fetching paths", err, "isd_as", ia)
+	}
+	selected := selectPaths(paths, c.cfg.N)
+	if len(selected) < c.cfg.N {
+		return nil, serrors.New("not enough paths", "isd_as", ia, "required", c.cfg.N,
+			"actual", len(selected))
+	}
+	c.paths[ia] = pathSet{paths: selected, fetched: time.Now()}
+	return selected, nil
+}
+
+// selectPaths selects n diverse paths. If the paths carry no interface
+// metadata, the first n paths are selected.
+func selectPaths(paths []snet.Path, n int) []snet.Path {
+	if len(paths) < n {
+		return paths
+	}
+	for _, p := range paths {
+		if p.Metadata() == nil || len(p.Metadata().Interfaces) == 0 {
+			return paths[:n]
+		}
+	}
+	return pathhealth.FindPaths(paths, n)
+}
+
+// ReadFrom reads shares until a datagram can be reconstructed, and copies it
+// into b. If b is too small, the datagram is truncated.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	c.readMtx.Lock()
+	defer c.readMtx.Unlock()
+	for {
+		n, src, err := c.conn.ReadFrom(c.buf)
+		if err != nil {
+			return 0, nil, err
+		}
+		if time.Since(c.lastCleanup) >= c.cfg.GroupTimeout/2 {
+			c.cleanup()
+		}
+		remote, ok := src.(*snet.UDPAddr)
+		if !ok || n <= hdrLen || c.buf[0] != version {
+			continue
+		}
+		datagram := c.insert(remote, c.buf[:n])
+		if datagram == nil {
+			continue
+		}
+		return copy(b, datagram), &snet.UDPAddr{IA: remote.IA, Host: remote.Host}, nil
+	}
+}
+
+// insert adds the share to its group. It returns the datagram if it can be
+// reconstructed with this share.
+func (c *Conn) insert(src *snet.UDPAddr, pkt []byte) []byte {
+	threshold := int(pkt[2])
+	if threshold < 2 {
+		return nil
+	}
+	key := groupKey{
+		ia:   src.IA,
+		host: src.Host.String(),
+		id:   binary.BigEndian.Uint64(pkt[4:hdrLen]),
+	}
+	g, ok := c.groups[key]
+	if !ok {
+		g = &group{
+			shares:  make(map[uint8][]byte),
+			created: time.Now(),
+		}
+		c.groups[key] = g
+	}
+	if g.combined {
+		return nil
+	}
+	if _, ok := g.shares[pkt[1]]; ok {
+		return nil
+	}
+	g.shares[pkt[1]] = append([]byte(nil), pkt[hdrLen:]...)
+	if len(g.shares) < threshold {
+		return nil
+	}
+	shares := make([][]byte, 0, threshold)
+	for _, share := range g.shares {
+		shares = append(shares, share)
+		if len(shares) == threshold {
+			break
+		}
+	}
+	encrypted, err := dataplane.Combine(shares)
+	if err != nil {
+		return nil
+	}
+	datagram, err := dataplane.Decrypt(encrypted, c.cfg.AESKey)
+	if err != nil || datagram == nil {
+		return nil
+	}
+	// Keep the group until it expires, so that late shares are discarded.
+	g.combined = true
+	g.shares = nil
+	return datagram
+}
+
+// cleanup discards the groups that are older than the group timeout. It must
+// be called with readMtx held.
+func (c *Conn) cleanup() {
+	now := time.Now()
+	for key, g := range c.groups {
+		if now.Sub(g.created) >= c.cfg.GroupTimeout {
+			delete(c.groups, key)
+		}
+	}
+	c.lastCleanup = now
+}
+
+// Close closes the underlying connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// LocalAddr returns the local address of the underlying connection.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// SetDeadline sets the read and write deadlines of the underlying connection.
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+// SetReadDeadline sets the read deadline of the underlying connection.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the write deadline of the underlying connection.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// groupKey identifies the shares of a datagram.
+type groupKey struct {
+	ia   addr.IA
+	host string
+	id   uint64
+}
+
+// group contains the received shares of a datagram.
+type group struct {
+	shares   map[uint8][]byte
+	created  time.Time
+	combined bool
+}
+
+var _ net.Conn = (*connectedConn)(nil)
+
+// connectedConn is a Conn that exchanges datagrams with a single remote.
+type connectedConn struct {
+	*Conn
+	remote *snet.UDPAddr
+}
+
+func (c *connectedConn) Read(b []byte) (int, error) {
+	for {
+		n, src, err := c.ReadFrom(b)
+		if err != nil {
+			return 0, err
+		}
+		from := src.(*snet.UDPAddr)
+		if from.IA.Equal(c.remote.IA) && from.Host.IP.Equal(c.remote.Host.IP) &&
+			from.Host.Port == c.remote.Host.Port {
+			return n, nil
+		}
+	}
+}
+
+func (c *connectedConn) Write(b []byte) (int, error) {
+	return c.WriteTo(b, c.remote)
+}
+
+func (c *connectedConn) RemoteAddr() net.Addr {
+	return c.remote.Copy()
+}
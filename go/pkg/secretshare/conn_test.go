@@ -0,0 +1,248 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretshare_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/pkg/secretshare"
+)
+
+const testAESKey = "6368616e676520746869732070617373776f726420746f206120736563726574"
+
+func TestConfigValidation(t *testing.T) {
+	testCases := map[string]secretshare.Config{
+		"threshold too small":     {N: 3, T: 1, AESKey: testAESKey},
+		"threshold exceeds count": {N: 2, T: 3, AESKey: testAESKey},
+		"too many shares":         {N: 256, T: 2, AESKey: testAESKey},
+		"no key":                  {N: 3, T: 2},
+		"invalid key":             {N: 3, T: 2, AESKey: "not hex"},
+	}
+	for name, cfg := range testCases {
+		name, cfg := name, cfg
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := secretshare.NewConn(newPacketConn(nil), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConn(t *testing.T) {
+	clientAddr := mustParseAddr(t, "1-ff00:0:110,10.0.0.1:4000")
+	serverAddr := mustParseAddr(t, "1-ff00:0:111,10.0.0.2:5000")
+	client, server := newPacketConnPair(clientAddr, serverAddr)
+	paths := testPaths(serverAddr.IA, 4)
+
+	cfg := secretshare.Config{
+		N:      3,
+		T:      2,
+		AESKey: testAESKey,
+		Router: staticRouter(paths),
+	}
+	clientConn, err := secretshare.NewConn(client, cfg)
+	require.NoError(t, err)
+	cfg.Router = staticRouter(testPaths(clientAddr.IA, 3))
+	serverConn, err := secretshare.NewConn(server, cfg)
+	require.NoError(t, err)
+
+	t.Run("shares are sent on different paths", func(t *testing.T) {
+		n, err := clientConn.WriteTo([]byte("hello"), serverAddr)
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+
+		nextHops := make(map[string]struct{})
+		for i := 0; i < 3; i++ {
+			p := server.peek(t, i)
+			assert.NotContains(t, string(p.data), "hello")
+			nextHops[p.dst.NextHop.String()] = struct{}{}
+		}
+		assert.Len(t, nextHops, 3)
+
+		buf := make([]byte, 100)
+		n, src, err := serverConn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf[:n]))
+		assert.Equal(t, clientAddr.IA, src.(*snet.UDPAddr).IA)
+		assert.Equal(t, clientAddr.Host.String(), src.(*snet.UDPAddr).Host.String())
+	})
+
+	t.Run("threshold shares are enough", func(t *testing.T) {
+		server.drop(1)
+		_, err := clientConn.WriteTo([]byte("lossy"), serverAddr)
+		require.NoError(t, err)
+		_, err = clientConn.WriteTo([]byte("next"), serverAddr)
+		require.NoError(t, err)
+
+		buf := make([]byte, 100)
+		n, _, err := serverConn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "lossy", string(buf[:n]))
+		n, _, err = serverConn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "next", string(buf[:n]))
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		_, err := serverConn.WriteTo([]byte("world"), clientAddr)
+		require.NoError(t, err)
+		buf := make([]byte, 100)
+		n, _, err := clientConn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "world", string(buf[:n]))
+	})
+
+	t.Run("pinned paths", func(t *testing.T) {
+		assert.Error(t, clientConn.SetPaths(serverAddr.IA, paths[:2]))
+		require.NoError(t, clientConn.SetPaths(serverAddr.IA, paths[1:]))
+		// Late shares of the previous datagrams may still be queued.
+		queued := len(server.inbox)
+		_, err := clientConn.WriteTo([]byte("pinned"), serverAddr)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, paths[i+1].UnderlayNextHop().String(),
+				server.peek(t, queued+i).dst.NextHop.String())
+		}
+		buf := make([]byte, 100)
+		n, _, err := serverConn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "pinned", string(buf[:n]))
+	})
+
+	t.Run("not enough paths", func(t *testing.T) {
+		other := mustParseAddr(t, "1-ff00:0:112,10.0.0.3:5000")
+		_, err := clientConn.WriteTo([]byte("hello"), other)
+		assert.Error(t, err)
+	})
+}
+
+func mustParseAddr(t *testing.T, s string) *snet.UDPAddr {
+	a, err := snet.ParseUDPAddr(s)
+	require.NoError(t, err)
+	return a
+}
+
+func testPaths(dst addr.IA, n int) []snet.Path {
+	paths := make([]snet.Path, 0, n)
+	for i := 0; i < n; i++ {
+		paths = append(paths, snetpath.Path{
+			Dst:     dst,
+			NextHop: &net.UDPAddr{IP: net.IP{192, 0, 2, byte(i + 1)}, Port: 30041},
+		})
+	}
+	return paths
+}
+
+// staticRouter returns the paths that lead to the requested IA.
+type staticRouter []snet.Path
+
+func (r staticRouter) Route(ctx context.Context, dst addr.IA) (snet.Path, error) {
+	paths, err := r.AllRoutes(ctx, dst)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return paths[0], nil
+}
+
+func (r staticRouter) AllRoutes(_ context.Context, dst addr.IA) ([]snet.Path, error) {
+	var paths []snet.Path
+	for _, p := range r {
+		if p.Destination().Equal(dst) {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+type packet struct {
+	data []byte
+	src  *snet.UDPAddr
+	dst  *snet.UDPAddr
+}
+
+// packetConn is an in-memory net.PacketConn that delivers the packets to its
+// peer.
+type packetConn struct {
+	local *snet.UDPAddr
+	peer  *packetConn
+	inbox []packet
+	// received is the number of packets written to the connection so far.
+	received int
+	// dropped are the indices of the packets that are dropped.
+	dropped map[int]bool
+}
+
+func newPacketConn(local *snet.UDPAddr) *packetConn {
+	return &packetConn{local: local, dropped: make(map[int]bool)}
+}
+
+func newPacketConnPair(a, b *snet.UDPAddr) (*packetConn, *packetConn) {
+	ca, cb := newPacketConn(a), newPacketConn(b)
+	ca.peer, cb.peer = cb, ca
+	return ca, cb
+}
+
+// drop drops the i-th of the next packets written to the connection.
+func (c *packetConn) drop(i int) {
+	c.dropped[c.received+i] = true
+}
+
+// peek returns the i-th queued packet.
+func (c *packetConn) peek(t *testing.T, i int) packet {
+	require.Greater(t, len(c.inbox), i)
+	return c.inbox[i]
+}
+
+func (c *packetConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	if len(c.inbox) == 0 {
+		return 0, nil, serrors.New("no packet")
+	}
+	p := c.inbox[0]
+	c.inbox = c.inbox[1:]
+	return copy(b, p.data), p.src, nil
+}
+
+func (c *packetConn) WriteTo(b []byte, dst net.Addr) (int, error) {
+	peer := c.peer
+	index := peer.received
+	peer.received++
+	if peer.dropped[index] {
+		return len(b), nil
+	}
+	src := c.local.Copy()
+	src.NextHop = dst.(*snet.UDPAddr).NextHop
+	peer.inbox = append(peer.inbox, packet{
+		data: append([]byte(nil), b...),
+		src:  src,
+		dst:  dst.(*snet.UDPAddr),
+	})
+	return len(b), nil
+}
+
+func (c *packetConn) Close() error                       { return nil }
+func (c *packetConn) LocalAddr() net.Addr                { return c.local }
+func (c *packetConn) SetDeadline(t time.Time) error      { return nil }
+func (c *packetConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *packetConn) SetWriteDeadline(t time.Time) error { return nil }
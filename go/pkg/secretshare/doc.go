@@ -0,0 +1,44 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretshare provides SCION connections that protect datagrams the
+// same way the 4SP gateway protects IP packets, without the need for a TUN
+// device or elevated privileges.
+//
+// Each datagram is encrypted with AES-GCM and the ciphertext is split into N
+// shares with Shamir's secret sharing scheme, T of which are needed to
+// reconstruct it. Every share is sent over a different SCION path. The paths
+// are selected with the same greedy, diversity-maximizing scheme the gateway
+// uses.
+//
+// Each share is carried in its own SCION/UDP packet and is preceded by a
+// header with the following format:
+//
+//  0                   1                   2                   3
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |    Version    |     Index     |   Threshold   |   Reserved    |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                                                               |
+//  +                          Message ID                           +
+//  |                                                               |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// Index is the index of the share, Threshold is the number of shares needed to
+// reconstruct the datagram, and Message ID identifies the shares belonging to
+// the same datagram of a sender.
+//
+// Datagrams are not fragmented. A datagram must fit, together with the
+// encryption and sharing overhead, into a single packet on every path.
+package secretshare
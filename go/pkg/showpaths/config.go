@@ -16,6 +16,8 @@ package showpaths
 
 import (
 	"net"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
 )
 
 // DefaultMaxPaths is the maximum number of paths that are displayed by default.
@@ -38,6 +40,12 @@ type Config struct {
 	// Sequence is a string of space separated Hop Predicates that is used for
 	// filtering.
 	Sequence string
+	// ACL is a list of ACL entries that is used for filtering. The entries may
+	// contain geo predicates that match the static info announced by the ASes.
+	ACL []string
+	// Policy is a path policy that is used for filtering. If it is nil, all
+	// paths are allowed.
+	Policy *pathpol.Policy
 	// Dispatcher is the path to the dispatcher socket. Leaving this empty uses
 	// the default dispatcher socket value.
 	Dispatcher string
@@ -360,6 +360,10 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	if paths, err = path.FilterACL(cfg.ACL, paths); err != nil {
+		return nil, err
+	}
+	paths = cfg.Policy.Filter(paths)
 	if cfg.MaxPaths != 0 && len(paths) > cfg.MaxPaths {
 		paths = paths[:cfg.MaxPaths]
 	}
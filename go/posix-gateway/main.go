@@ -89,8 +89,13 @@ func realMain(ctx context.Context) error {
 		probeAddress.IP = controlAddress.IP
 		probeAddress.Zone = controlAddress.Zone
 	}
+	endhostPorts, err := globalCfg.Gateway.EndhostPorts()
+	if err != nil {
+		return serrors.WrapStr("reading end host port range", err)
+	}
 	var cleanup app.Cleanup
 	g, errCtx := errgroup.WithContext(ctx)
+	capturer := &dataplane.Capturer{}
 	if globalCfg.API.Addr != "" {
 		r := chi.NewRouter()
 		r.Use(cors.Handler(cors.Options{
@@ -102,6 +107,7 @@ func realMain(ctx context.Context) error {
 			Config:   service.NewConfigStatusPage(globalCfg).Handler,
 			Info:     service.NewInfoStatusPage().Handler,
 			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Capturer: capturer,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -140,10 +146,13 @@ func realMain(ctx context.Context) error {
 		DataServerAddr:           dataAddress,
 		DataClientIP:             dataAddress.IP,
 		Dispatcher:               reliable.NewDispatcher(""),
+		EndhostPorts:             endhostPorts,
 		Daemon:                   daemon,
 		RouteSourceIPv4:          globalCfg.Tunnel.SrcIPv4,
 		RouteSourceIPv6:          globalCfg.Tunnel.SrcIPv6,
 		TunnelName:               globalCfg.Tunnel.Name,
+		TunnelDevices:            globalCfg.Tunnel.Devices,
+		TunnelMode:               globalCfg.Tunnel.Mode,
 		RoutingTableReader:       routingTable,
 		RoutingTableSwapper:      routingTable,
 		ConfigReloadTrigger:      app.SIGHUPChannel(ctx),
@@ -153,6 +162,11 @@ func realMain(ctx context.Context) error {
 		NumberOfPathsN:           globalCfg.Tunnel.NumberOfPathsN,
 		NumberOfPathsT:           globalCfg.Tunnel.NumberOfPathsT,
 		AESKey:                   globalCfg.Tunnel.AESKey,
+		DRKey:                    globalCfg.Tunnel.DRKey,
+		FollowReplyPaths:         globalCfg.Tunnel.FollowReplyPaths,
+		PMTUDiscovery:            globalCfg.Tunnel.PMTUDiscovery,
+		Capturer:                 capturer,
+		ColibriBandwidth:         globalCfg.Tunnel.ColibriBandwidth,
 	}
 
 	g.Go(func() error {
@@ -21,11 +21,16 @@ import (
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
@@ -35,6 +40,7 @@ import (
 	"github.com/scionproto/scion/go/pkg/router/api"
 	"github.com/scionproto/scion/go/pkg/router/config"
 	"github.com/scionproto/scion/go/pkg/router/control"
+	"github.com/scionproto/scion/go/pkg/router/filter"
 	"github.com/scionproto/scion/go/pkg/service"
 )
 
@@ -58,7 +64,10 @@ func realMain(ctx context.Context) error {
 	metrics := router.NewMetrics()
 	dp := &router.Connector{
 		DataPlane: router.DataPlane{
-			Metrics: metrics,
+			Metrics:           metrics,
+			NumProcessors:     globalCfg.Router.NumProcessors,
+			BatchSize:         globalCfg.Router.BatchSize,
+			DropTraceSampling: globalCfg.Router.DropTraceSampling,
 		},
 	}
 	iaCtx := &control.IACtx{
@@ -68,11 +77,39 @@ func realMain(ctx context.Context) error {
 	if err := iaCtx.Configure(); err != nil {
 		return serrors.WrapStr("configuring dataplane", err)
 	}
+	reloader := &configReloader{iaCtx: iaCtx}
+	sighup := app.SIGHUPChannel(errCtx)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		reloader.run(errCtx, sighup, globalCfg.Router.ConfigWatchInterval.Duration)
+		return nil
+	})
+	if globalCfg.Router.FilterFile != "" {
+		if err := loadFilter(&dp.DataPlane, metrics); err != nil {
+			return err
+		}
+		reload := app.SIGHUPChannel(errCtx)
+		g.Go(func() error {
+			defer log.HandlePanic()
+			for {
+				select {
+				case <-reload:
+					if err := loadFilter(&dp.DataPlane, metrics); err != nil {
+						log.Error("Failed to reload packet filter", "err", err)
+						continue
+					}
+					log.Info("Reloaded packet filter", "file", globalCfg.Router.FilterFile)
+				case <-errCtx.Done():
+					return nil
+				}
+			}
+		})
+	}
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
-		"topology":  topologyHandler(iaCtx.Config.Topo),
+		"topology":  topologyHandler(reloader.topology),
 	}
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
 		return err
@@ -135,14 +172,114 @@ func loadControlConfig() (*control.Config, error) {
 	if err != nil {
 		return nil, serrors.WrapStr("loading topology", err)
 	}
+	newConf.SCMPRateLimits = globalCfg.Router.SCMP.RateLimits()
 	return newConf, nil
 }
 
-func topologyHandler(topo topology.Topology) service.StatusPage {
+// loadFilter loads the packet filter from the filter file and sets it on the
+// dataplane. If it fails, the dataplane keeps its current filter.
+func loadFilter(dp *router.DataPlane, metrics *router.Metrics) error {
+	policy, err := filter.LoadPolicy(globalCfg.Router.FilterFile)
+	if err != nil {
+		return serrors.WrapStr("loading packet filter", err)
+	}
+	f, err := filter.New(policy, metrics.FilterHitsTotal)
+	if err != nil {
+		return serrors.WrapStr("compiling packet filter", err,
+			"file", globalCfg.Router.FilterFile)
+	}
+	dp.SetFilter(f)
+	return nil
+}
+
+// configReloader reconfigures the running dataplane with the topology and the
+// master keys in the config directory, on SIGHUP or when the files change.
+type configReloader struct {
+	iaCtx *control.IACtx
+	mtx   sync.Mutex
+}
+
+func (r *configReloader) run(ctx context.Context, sighup <-chan struct{},
+	interval time.Duration) {
+
+	modTime, err := configModTime()
+	if err != nil {
+		log.Info("Failed to check topology and keys for changes", "err", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sighup:
+			if t, err := configModTime(); err == nil {
+				modTime = t
+			}
+		case <-ticker.C:
+			t, err := configModTime()
+			if err != nil {
+				log.Info("Failed to check topology and keys for changes", "err", err)
+				continue
+			}
+			if t.Equal(modTime) {
+				continue
+			}
+			modTime = t
+		case <-ctx.Done():
+			return
+		}
+		if err := r.reload(); err != nil {
+			log.Error("Failed to reload topology and keys", "err", err)
+			continue
+		}
+		log.Info("Reloaded topology and keys", "dir", globalCfg.General.ConfigDir)
+	}
+}
+
+// reload loads the topology and the master keys and reconfigures the
+// dataplane. If it fails, the dataplane keeps its current configuration.
+func (r *configReloader) reload() error {
+	cfg, err := loadControlConfig()
+	if err != nil {
+		return err
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.iaCtx.Reconfigure(cfg)
+}
+
+// topology returns the topology the dataplane is configured with.
+func (r *configReloader) topology() topology.Topology {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.iaCtx.Config.Topo
+}
+
+// configModTime returns the latest modification time of the topology and the
+// master keys.
+func configModTime() (time.Time, error) {
+	dir := globalCfg.General.ConfigDir
+	var modTime time.Time
+	for _, file := range []string{
+		filepath.Join(dir, "topology.json"),
+		filepath.Join(dir, "keys", keyconf.MasterKey0),
+		filepath.Join(dir, "keys", keyconf.MasterKey1),
+	} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func topologyHandler(topo func() topology.Topology) service.StatusPage {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
-		bytes, err := json.MarshalIndent(topo, "", "    ")
+		bytes, err := json.MarshalIndent(topo(), "", "    ")
 		if err != nil {
 			http.Error(w, "Unable to marshal topology", http.StatusInternalServerError)
 			return
@@ -48,6 +48,7 @@ func newPing(pather CommandPather) *cobra.Command {
 		logLevel    string
 		maxMTU      bool
 		noColor     bool
+		policy      string
 		refresh     bool
 		healthyOnly bool
 		sequence    string
@@ -102,6 +103,11 @@ On other errors, ping will exit with code 2.
 				"local", localIP,
 			)
 
+			policy, err := path.LoadPolicy(flags.policy)
+			if err != nil {
+				return serrors.WrapStr("loading path policy", err)
+			}
+
 			span, traceCtx := tracing.CtxWith(context.Background(), "run")
 			span.SetTag("dst.isd_as", remote.IA)
 			span.SetTag("dst.host", remote.Host.IP)
@@ -124,6 +130,7 @@ On other errors, ping will exit with code 2.
 				path.WithInteractive(flags.interactive),
 				path.WithRefresh(flags.refresh),
 				path.WithSequence(flags.sequence),
+				path.WithPolicy(policy),
 				path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
 			}
 			if flags.healthyOnly {
@@ -220,6 +227,7 @@ On other errors, ping will exit with code 2.
 	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
 	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second, "timeout per packet")
 	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().StringVar(&flags.policy, "policy", "", app.PolicyUsage)
 	cmd.Flags().BoolVar(&flags.healthyOnly, "healthy-only", false, "only use healthy paths")
 	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
 	cmd.Flags().DurationVar(&flags.interval, "interval", time.Second, "time between packets")
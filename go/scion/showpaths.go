@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/tracing"
 	"github.com/scionproto/scion/go/pkg/app"
 	"github.com/scionproto/scion/go/pkg/app/flag"
+	"github.com/scionproto/scion/go/pkg/app/path"
 	"github.com/scionproto/scion/go/pkg/showpaths"
 )
 
@@ -36,6 +37,7 @@ func newShowpaths(pather CommandPather) *cobra.Command {
 	var flags struct {
 		timeout  time.Duration
 		cfg      showpaths.Config
+		policy   string
 		extended bool
 		json     bool
 		logLevel string
@@ -53,6 +55,9 @@ func newShowpaths(pather CommandPather) *cobra.Command {
   %[1]s showpaths 1-ff00:0:111 --sequence="0-0#2 0*" # outgoing IfID=2
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 0-0#41" # incoming IfID=41 at dstIA
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 1-ff00:0:112 0*" # 1-ff00:0:112 on the path
+  %[1]s showpaths 2-ff00:0:210 --acl="+ 1" --acl="+ 2" --acl="-" # stay in ISDs 1 and 2
+  %[1]s showpaths 1-ff00:0:111 --acl="- addr=Germany" --acl="+" # avoid routers in Germany
+  %[1]s showpaths 1-ff00:0:110 --policy=policy.yml # apply the path policy in policy.yml
   %[1]s showpaths 1-ff00:0:110 --no-probe`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'showpaths' lists available paths between the local and the specified
 SCION ASe a.
@@ -67,7 +72,8 @@ If no alive path is discovered, json output is not enabled, and probing is not
 disabled, showpaths will exit with the code 1.
 On other errors, showpaths will exit with code 2.
 
-%s`, app.SequenceHelp),
+%s
+%s`, app.SequenceHelp, app.ACLHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			dst, err := addr.ParseIA(args[0])
 			if err != nil {
@@ -87,6 +93,9 @@ On other errors, showpaths will exit with code 2.
 			if err := envFlags.LoadExternalVars(); err != nil {
 				return err
 			}
+			if flags.cfg.Policy, err = path.LoadPolicy(flags.policy); err != nil {
+				return serrors.WrapStr("loading path policy", err)
+			}
 
 			flags.cfg.Daemon = envFlags.Daemon()
 			flags.cfg.Dispatcher = envFlags.Dispatcher()
@@ -129,6 +138,8 @@ On other errors, showpaths will exit with code 2.
 	envFlags.Register(cmd.Flags())
 	cmd.Flags().DurationVar(&flags.timeout, "timeout", 5*time.Second, "Timeout")
 	cmd.Flags().StringVar(&flags.cfg.Sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().StringArrayVar(&flags.cfg.ACL, "acl", nil, app.ACLUsage)
+	cmd.Flags().StringVar(&flags.policy, "policy", "", app.PolicyUsage)
 	cmd.Flags().IntVarP(&flags.cfg.MaxPaths, "maxpaths", "m", 10,
 		"Maximum number of paths that are displayed")
 	cmd.Flags().BoolVarP(&flags.extended, "extended", "e", false,
@@ -46,6 +46,7 @@ func newTraceroute(pather CommandPather) *cobra.Command {
 		interactive bool
 		logLevel    string
 		noColor     bool
+		policy      string
 		refresh     bool
 		sequence    string
 		timeout     time.Duration
@@ -93,6 +94,11 @@ On other errors, traceroute will exit with code 2.
 				"local", localIP,
 			)
 
+			policy, err := path.LoadPolicy(flags.policy)
+			if err != nil {
+				return serrors.WrapStr("loading path policy", err)
+			}
+
 			span, traceCtx := tracing.CtxWith(context.Background(), "run")
 			span.SetTag("dst.isd_as", remote.IA)
 			span.SetTag("dst.host", remote.Host.IP)
@@ -113,6 +119,7 @@ On other errors, traceroute will exit with code 2.
 				path.WithInteractive(flags.interactive),
 				path.WithRefresh(flags.refresh),
 				path.WithSequence(flags.sequence),
+				path.WithPolicy(policy),
 				path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
 			)
 			if err != nil {
@@ -176,6 +183,7 @@ On other errors, traceroute will exit with code 2.
 	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
 	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second, "timeout per packet")
 	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().StringVar(&flags.policy, "policy", "", app.PolicyUsage)
 	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
 	cmd.Flags().StringVar(&flags.tracer, "tracing.agent", "", "Tracing agent address")
 	return cmd
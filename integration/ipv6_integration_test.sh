@@ -0,0 +1,93 @@
+#!/bin/bash
+# Copyright 2022 ETH Zurich
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#   http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Runs the go integration tests on a local topology in which all the elements,
+# including the internal and external underlays of the routers, only use IPv6.
+
+set -o pipefail
+
+. integration/common.sh
+
+TOPOLOGY=${TOPOLOGY:-topology/tiny6.topo}
+
+shutdown() {
+    log "Scion status:"
+    ./scion.sh status
+    log "Stopping scion"
+    ./scion.sh stop | grep -v "stopped"
+    log "Scion stopped"
+}
+
+# check_ipv6_only fails if any address in the generated topologies is not an
+# IPv6 address.
+check_ipv6_only() {
+    python3 - <<'PY'
+import glob
+import ipaddress
+import json
+import sys
+
+
+def addrs(v):
+    if isinstance(v, dict):
+        for k, e in v.items():
+            if k in ("addr", "alt_addr", "internal_addr", "alt_internal_addr", "public",
+                     "remote", "bind", "ctrl_addr", "data_addr", "probe_addr"):
+                yield e
+            else:
+                yield from addrs(e)
+
+
+ok = True
+for path in glob.glob("gen/ISD*/AS*/*/topology.json"):
+    with open(path) as f:
+        topo = json.load(f)
+    for a in addrs(topo):
+        if a.startswith("["):
+            host = a[1:a.index("]")]
+        elif a.count(":") == 1:
+            host = a.split(":")[0]
+        else:
+            host = a
+        if ipaddress.ip_address(host.split("%")[0]).version != 6:
+            print("%s: not an IPv6 address: %s" % (path, a))
+            ok = False
+sys.exit(0 if ok else 1)
+PY
+}
+
+log "Generating IPv6-only topology $TOPOLOGY"
+./scion.sh topology -c "$TOPOLOGY" || exit 1
+check_ipv6_only || exit 1
+
+log "Starting scion"
+./scion.sh run | grep -v "started" || exit 1
+
+log "Scion status:"
+./scion.sh status || exit 1
+
+sleep 5
+
+integration/go_integration
+result=$?
+
+shutdown
+
+if [ $result -eq 0 ]; then
+    log "All IPv6 integration tests successful"
+else
+    log "$result IPv6 integration tests failed"
+fi
+exit $result
@@ -0,0 +1,128 @@
+-- Dissector for the frames the 4SP gateways exchange, as captured by the
+-- capture API of the gateway (GET /api/v1/capture). The frames are captured
+-- with the link type LINKTYPE_USER0, see go/pkg/gateway/dataplane/encoder.go
+-- for the frame format.
+fsp_proto = Proto("4sp", "SCION Secure Secret Sharing Proxy Frame")
+
+local frameTypes = {
+    [0] = "IP",
+    [1] = "Ethernet",
+}
+
+local frameModes = {
+    [0] = "Shared",
+    [1] = "Encrypted",
+    [2] = "Plain",
+    [3] = "Probe",
+    [4] = "Probe Reply",
+}
+
+local MODE_SHARED = 0
+local MODE_PLAIN = 2
+local MODE_PROBE = 3
+local MODE_PROBE_REPLY = 4
+local HDR_LEN = 16
+-- Index of frames that contain no start of a packet.
+local NO_INDEX = 0xffff
+
+local fsp_version = ProtoField.uint8("4sp.version", "Version", base.DEC, frameTypes)
+local fsp_session = ProtoField.uint8("4sp.session", "Session", base.DEC)
+local fsp_index = ProtoField.uint16("4sp.index", "Index", base.DEC)
+local fsp_threshold = ProtoField.uint8("4sp.threshold", "Threshold", base.DEC)
+local fsp_padded = ProtoField.uint8("4sp.padded", "Padded", base.DEC, nil, 0x80)
+local fsp_mode = ProtoField.uint8("4sp.mode", "Mode", base.DEC, frameModes, 0x70)
+local fsp_stream = ProtoField.uint32("4sp.stream", "Stream", base.HEX, nil, 0x000fffff)
+local fsp_seq = ProtoField.uint64("4sp.seq", "Sequence Number", base.DEC)
+local fsp_group_seq = ProtoField.uint64("4sp.group_seq", "Group Sequence Number", base.DEC)
+local fsp_share_index = ProtoField.uint8("4sp.share_index", "Share Index", base.DEC)
+local fsp_probe_id = ProtoField.uint64("4sp.probe_id", "Probe ID", base.DEC)
+local fsp_probe_size = ProtoField.uint16("4sp.probe_size", "Probe Size",
+        base.UNIT_STRING, {" bytes"})
+local fsp_payload = ProtoField.bytes("4sp.payload", "Payload")
+
+fsp_proto.fields = {
+    fsp_version,
+    fsp_session,
+    fsp_index,
+    fsp_threshold,
+    fsp_padded,
+    fsp_mode,
+    fsp_stream,
+    fsp_seq,
+    fsp_group_seq,
+    fsp_share_index,
+    fsp_probe_id,
+    fsp_probe_size,
+    fsp_payload,
+}
+
+function fsp_proto.dissector(tvbuf, pktinfo, root)
+    if tvbuf:len() < HDR_LEN then
+        return 0
+    end
+    pktinfo.cols.protocol:set("4SP")
+    local tree = root:add(fsp_proto, tvbuf(0, HDR_LEN))
+
+    local version = tvbuf(0, 1)
+    local session = tvbuf(1, 1)
+    local index = tvbuf(2, 2)
+    local mode = tvbuf(5, 1):bitfield(1, 3)
+    local stream = tvbuf(4, 4)
+    local seq = tvbuf(8, 8)
+
+    tree:add(fsp_version, version)
+    tree:add(fsp_session, session)
+    tree:add(fsp_index, index)
+    tree:add(fsp_threshold, tvbuf(4, 1))
+    tree:add(fsp_padded, tvbuf(5, 1))
+    tree:add(fsp_mode, tvbuf(5, 1))
+    tree:add(fsp_stream, stream)
+
+    local info = string.format("%s, Session: %d, Stream: 0x%05x",
+            frameModes[mode] or "Unknown Mode", session:uint(),
+            bit.band(stream:uint(), 0xfffff))
+    if mode == MODE_PROBE or mode == MODE_PROBE_REPLY then
+        tree:add(fsp_probe_id, seq)
+        info = string.format("%s, Probe ID: %s", info, seq:uint64())
+        if mode == MODE_PROBE_REPLY and tvbuf:len() >= HDR_LEN + 2 then
+            tree:add(fsp_probe_size, tvbuf(HDR_LEN, 2))
+            info = string.format("%s, Size: %d", info, tvbuf(HDR_LEN, 2):uint())
+        else
+            info = string.format("%s, Size: %d", info, tvbuf:len())
+        end
+    elseif mode == MODE_SHARED then
+        -- The shares of a group share the sequence number, except for the last
+        -- byte, which carries the share index.
+        local group = seq:uint64():rshift(8)
+        tree:add(fsp_seq, seq)
+        tree:add(fsp_group_seq, seq, group)
+        tree:add(fsp_share_index, tvbuf(15, 1))
+        info = string.format("%s, Group: %s, Share: %d", info, group, tvbuf(15, 1):uint())
+    else
+        tree:add(fsp_seq, seq)
+        info = string.format("%s, Seq: %s", info, seq:uint64())
+    end
+    pktinfo.cols.info:set(info)
+
+    if tvbuf:len() == HDR_LEN or mode == MODE_PROBE or mode == MODE_PROBE_REPLY then
+        return tvbuf:len()
+    end
+    local payload = tvbuf(HDR_LEN)
+    tree:add(fsp_payload, payload)
+    -- Only plain frames carry the packets in the clear. The first packet that
+    -- starts in an IP frame starts at the index.
+    if mode == MODE_PLAIN and version:uint() == 0 and index:uint() ~= NO_INDEX and
+            index:uint() < payload:len() then
+        local packet = payload(index:uint()):tvb()
+        if packet(0, 1):bitfield(0, 4) == 6 then
+            Dissector.get("ipv6"):call(packet, pktinfo, root)
+        else
+            Dissector.get("ip"):call(packet, pktinfo, root)
+        end
+    end
+    return tvbuf:len()
+end
+
+-- The gateway captures the frames with the link type LINKTYPE_USER0.
+local encap = wtap_encaps and wtap_encaps.USER0 or wtap.USER0
+DissectorTable.get("wtap_encap"):add(encap, fsp_proto)